@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	DebugInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state. Either --input or --elf must be set.",
+		TakesFile: true,
+	}
+	DebugELFFlag = &cli.PathFlag{
+		Name:      "elf",
+		Usage:     "path of 32/64-bit big-endian MIPS ELF file to load instead of --input. Symbols are loaded from the ELF.",
+		TakesFile: true,
+	}
+	DebugVMTypeFlag = &cli.StringFlag{
+		Name:  "type",
+		Usage: "VM type to create state for when loading --elf. Valid options: " + openum.EnumString(stateVersions()),
+	}
+	DebugMetaFlag = &cli.PathFlag{
+		Name:      "meta",
+		Usage:     "path to metadata file for symbol lookup and stack frames when loading --input.",
+		TakesFile: true,
+	}
+	DebugSnapshotIntervalFlag = &cli.Uint64Flag{
+		Name:  "snapshot-interval",
+		Usage: "number of steps between snapshots used to step backwards. Reverse stepping is disabled if 0.",
+		Value: 100_000,
+	}
+	DebugMaxSnapshotsFlag = &cli.IntFlag{
+		Name:  "max-snapshots",
+		Usage: "maximum number of snapshots to keep. The oldest snapshots are removed first.",
+		Value: 100,
+	}
+	DebugSnapshotDirFlag = &cli.PathFlag{
+		Name:  "snapshot-dir",
+		Usage: "directory to store snapshots in. A temporary directory that is removed on exit is used if not set.",
+	}
+)
+
+func Debug(ctx *cli.Context) error {
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
+	guestLogger := Logger(os.Stderr, log.LevelInfo)
+	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
+	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+
+	var state *versions.VersionedState
+	var meta *program.Metadata
+	switch {
+	case ctx.IsSet(DebugInputFlag.Name) && ctx.IsSet(DebugELFFlag.Name):
+		return errors.New("only one of --input and --elf can be set")
+	case ctx.IsSet(DebugELFFlag.Name):
+		ver, err := versions.ParseStateVersion(ctx.String(DebugVMTypeFlag.Name))
+		if err != nil {
+			return fmt.Errorf("invalid --%v: %w", DebugVMTypeFlag.Name, err)
+		}
+		state, meta, err = loadELFState(ctx.Path(DebugELFFlag.Name), ver)
+		if err != nil {
+			return err
+		}
+	case ctx.IsSet(DebugInputFlag.Name):
+		var err error
+		state, err = versions.LoadStateFromFile(ctx.Path(DebugInputFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to load state: %w", err)
+		}
+		if metaPath := ctx.Path(DebugMetaFlag.Name); metaPath != "" {
+			meta, err = jsonutil.LoadJSON[program.Metadata](metaPath)
+			if err != nil {
+				return fmt.Errorf("failed to load metadata: %w", err)
+			}
+		}
+	default:
+		return errors.New("one of --input or --elf must be set")
+	}
+
+	snapshotDir := ctx.Path(DebugSnapshotDirFlag.Name)
+	if snapshotDir == "" {
+		dir, err := os.MkdirTemp("", "cannon-debug")
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot dir: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		snapshotDir = dir
+	} else if err := os.MkdirAll(snapshotDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot dir: %w", err)
+	}
+
+	args := preimageServerArgs(ctx)
+	po, err := NewProcessPreimageOracle(args[0], args[1:], l.With("module", "host"), l.With("module", "host"))
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	cfg := DebuggerConfig{
+		PreimageOracle:   po,
+		GuestStdOut:      outLog,
+		GuestStdErr:      errLog,
+		SnapshotDir:      snapshotDir,
+		SnapshotInterval: ctx.Uint64(DebugSnapshotIntervalFlag.Name),
+		MaxSnapshots:     ctx.Int(DebugMaxSnapshotsFlag.Name),
+	}
+	if meta != nil {
+		cfg.Meta = meta
+		cfg.EnableStack = true
+	}
+	debugger, err := NewDebugger(l, cfg, state, os.Stdout)
+	if err != nil {
+		return err
+	}
+	l.Info("Loaded state", "version", state.Version, "step", state.GetStep())
+	return debugger.Run(ctx.Context, os.Stdin)
+}
+
+func CreateDebugCommand(action cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:  "debug",
+		Usage: "Interactively step through VM execution",
+		Description: "Load a state or ELF and single step or continue to breakpoints, inspecting registers, memory and stack frames. " +
+			"Commands are read from stdin so sessions can be scripted. Run the help command for the available commands. " +
+			"The pre-image server command may be passed after --.",
+		Action: action,
+		Flags: []cli.Flag{
+			DebugInputFlag,
+			DebugELFFlag,
+			DebugVMTypeFlag,
+			DebugMetaFlag,
+			DebugSnapshotIntervalFlag,
+			DebugMaxSnapshotsFlag,
+			DebugSnapshotDirFlag,
+		},
+	}
+}
+
+var DebugCommand = CreateDebugCommand(Debug)
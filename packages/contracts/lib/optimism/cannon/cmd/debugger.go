@@ -0,0 +1,616 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	mipsexec "github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	debuggerPrompt = "(cannon) "
+	// maxMemDump is the maximum number of bytes of memory printed by a single mem command
+	maxMemDump = 4096
+)
+
+var (
+	errQuit            = errors.New("quit")
+	ErrNoSnapshot      = errors.New("no snapshot available")
+	ErrUnknownCommand  = errors.New("unknown command")
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+var regNames = [32]string{
+	"zero", "at", "v0", "v1", "a0", "a1", "a2", "a3",
+	"t0", "t1", "t2", "t3", "t4", "t5", "t6", "t7",
+	"s0", "s1", "s2", "s3", "s4", "s5", "s6", "s7",
+	"t8", "t9", "k0", "k1", "gp", "sp", "fp", "ra",
+}
+
+type breakpoint struct {
+	id   int
+	desc string
+	// beforeStep returns true if execution should stop before the next instruction is executed.
+	// prevPC is the PC of the previously executed instruction.
+	beforeStep func(prevPC arch.Word, state mipsevm.FPVMState) bool
+	// afterPreimage returns true if execution should stop after a step that read from the preimage with the specified key.
+	afterPreimage func(key [32]byte) bool
+}
+
+// debugMaxDeltaSnapshots is the maximum length of a chain of delta snapshots before a full snapshot is written.
+const debugMaxDeltaSnapshots = 10
+
+type debugSnapshot struct {
+	step uint64
+	path string
+	// parent is the path of the parent of a delta snapshot, or empty for a full snapshot
+	parent string
+	// depth is the number of delta snapshots in the chain ending at this snapshot
+	depth int
+}
+
+// DebuggerConfig configures the execution environment of a Debugger.
+type DebuggerConfig struct {
+	// PreimageOracle serves preimages to the program
+	PreimageOracle mipsevm.PreimageOracle
+	// GuestStdOut and GuestStdErr receive the output of the program
+	GuestStdOut io.Writer
+	GuestStdErr io.Writer
+	// Meta is used to look up symbols and may be nil. Stack frames are tracked if EnableStack is set.
+	Meta        mipsevm.Metadata
+	EnableStack bool
+	// SnapshotDir is the directory snapshots for reverse stepping are stored in.
+	SnapshotDir string
+	// SnapshotInterval is the number of steps between snapshots. Reverse stepping is disabled if 0.
+	SnapshotInterval uint64
+	// MaxSnapshots is the maximum number of snapshots to keep. The oldest snapshots are removed first.
+	MaxSnapshots int
+}
+
+// Debugger executes a VM interactively, single stepping or continuing to breakpoints and inspecting the VM state.
+type Debugger struct {
+	logger log.Logger
+	cfg    DebuggerConfig
+	out    io.Writer
+
+	state  *versions.VersionedState
+	vm     mipsevm.FPVM
+	prevPC arch.Word
+
+	breakpoints []*breakpoint
+	nextID      int
+
+	snapshots []debugSnapshot
+	// dirtyBase is the snapshot the dirty memory pages of the state are relative to, if any
+	dirtyBase *debugSnapshot
+
+	lastCmd string
+}
+
+func NewDebugger(logger log.Logger, cfg DebuggerConfig, state *versions.VersionedState, out io.Writer) (*Debugger, error) {
+	d := &Debugger{
+		logger: logger,
+		cfg:    cfg,
+		out:    out,
+		prevPC: ^arch.Word(0),
+		nextID: 1,
+	}
+	if err := d.setState(state); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Debugger) setState(state *versions.VersionedState) error {
+	var meta mipsevm.Metadata = &program.Metadata{}
+	if d.cfg.Meta != nil {
+		meta = d.cfg.Meta
+	}
+	vm := state.CreateVM(d.logger, d.cfg.PreimageOracle, d.cfg.GuestStdOut, d.cfg.GuestStdErr, meta)
+	if d.cfg.EnableStack {
+		if err := vm.InitDebug(); err != nil {
+			return fmt.Errorf("failed to initialize debug mode: %w", err)
+		}
+	}
+	d.state = state
+	d.vm = vm
+	return nil
+}
+
+func (d *Debugger) State() *versions.VersionedState {
+	return d.state
+}
+
+// Run reads commands from in until the input is exhausted or the quit command is read.
+func (d *Debugger) Run(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	d.printf(debuggerPrompt)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			line = d.lastCmd
+		} else {
+			d.lastCmd = line
+		}
+		if line != "" && !strings.HasPrefix(line, "#") {
+			err := d.Exec(ctx, line)
+			if errors.Is(err, errQuit) {
+				return nil
+			} else if ctx.Err() != nil {
+				return ctx.Err()
+			} else if err != nil {
+				d.printf("error: %v\n", err)
+			}
+		}
+		d.printf(debuggerPrompt)
+	}
+	d.printf("\n")
+	return scanner.Err()
+}
+
+// Exec executes a single debugger command.
+func (d *Debugger) Exec(ctx context.Context, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	cmd, args := fields[0], fields[1:]
+	switch cmd {
+	case "help", "h":
+		d.printf("%s", debuggerHelp)
+		return nil
+	case "quit", "q", "exit":
+		return errQuit
+	case "step", "s", "stepi", "si":
+		n, err := optionalCount(args)
+		if err != nil {
+			return err
+		}
+		return d.execute(ctx, n)
+	case "continue", "c":
+		return d.execute(ctx, math.MaxUint64)
+	case "back", "reverse-step", "rs":
+		n, err := optionalCount(args)
+		if err != nil {
+			return err
+		}
+		return d.reverse(ctx, n)
+	case "break", "b":
+		return d.addBreakpoint(args)
+	case "delete", "d":
+		return d.deleteBreakpoint(args)
+	case "breakpoints", "bl":
+		if len(d.breakpoints) == 0 {
+			d.printf("no breakpoints\n")
+		}
+		for _, bp := range d.breakpoints {
+			d.printf("%d: %s\n", bp.id, bp.desc)
+		}
+		return nil
+	case "info", "i":
+		d.printLocation()
+		return nil
+	case "regs", "r":
+		d.printRegisters()
+		return nil
+	case "mem", "x":
+		return d.printMemory(args)
+	case "bt", "backtrace":
+		return d.printStack()
+	case "save":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: save requires a path", ErrInvalidArgument)
+		}
+		if err := serialize.Write(args[0], d.state, OutFilePerm); err != nil {
+			return fmt.Errorf("failed to write state: %w", err)
+		}
+		d.printf("saved state at step %d to %s\n", d.state.GetStep(), args[0])
+		return nil
+	default:
+		return fmt.Errorf("%w: %q (try help)", ErrUnknownCommand, cmd)
+	}
+}
+
+// execute runs up to n steps, stopping early at breakpoints or when the program exits.
+// Breakpoints matching the current location are ignored so execution can continue from a breakpoint.
+func (d *Debugger) execute(ctx context.Context, n uint64) error {
+	reason, err := d.run(ctx, n, true)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		d.printf("%s\n", reason)
+	}
+	d.printLocation()
+	return nil
+}
+
+func (d *Debugger) run(ctx context.Context, n uint64, checkBreakpoints bool) (string, error) {
+	for i := uint64(0); i < n; i++ {
+		if d.state.GetExited() {
+			return fmt.Sprintf("program exited with code %d", d.state.GetExitCode()), nil
+		}
+		if checkBreakpoints && i > 0 {
+			for _, bp := range d.breakpoints {
+				if bp.beforeStep != nil && bp.beforeStep(d.prevPC, d.state) {
+					return fmt.Sprintf("breakpoint %d: %s", bp.id, bp.desc), nil
+				}
+			}
+		}
+		if i%100 == 0 {
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+		}
+		if err := d.snapshot(); err != nil {
+			return "", err
+		}
+		if d.vm.CheckInfiniteLoop() {
+			return "", fmt.Errorf("detected an infinite loop at step %d", d.state.GetStep())
+		}
+		step := d.state.GetStep()
+		pc := d.state.GetPC()
+		if _, err := d.vm.Step(false); err != nil {
+			return "", fmt.Errorf("failed at step %d (PC: %08x): %w", step, pc, err)
+		}
+		d.prevPC = pc
+		if checkBreakpoints {
+			key, _, offset := d.vm.LastPreimage()
+			if offset != ^arch.Word(0) {
+				for _, bp := range d.breakpoints {
+					if bp.afterPreimage != nil && bp.afterPreimage(key) {
+						return fmt.Sprintf("breakpoint %d: %s (read key %s at offset %d)", bp.id, bp.desc, common.Hash(key), offset), nil
+					}
+				}
+			}
+		}
+	}
+	return "", nil
+}
+
+// snapshot stores a snapshot of the current state if one is due.
+func (d *Debugger) snapshot() error {
+	if d.cfg.SnapshotInterval == 0 {
+		return nil
+	}
+	step := d.state.GetStep()
+	if step%d.cfg.SnapshotInterval != 0 {
+		return nil
+	}
+	if len(d.snapshots) > 0 && d.snapshots[len(d.snapshots)-1].step >= step {
+		return nil
+	}
+	snapshot := debugSnapshot{step: step, path: filepath.Join(d.cfg.SnapshotDir, fmt.Sprintf("debug-%d.bin", step))}
+	if base := d.dirtyBase; base != nil && base.depth < debugMaxDeltaSnapshots {
+		if err := versions.WriteDeltaSnapshot(snapshot.path, base.path, base.step, d.state, OutFilePerm); err != nil {
+			return fmt.Errorf("failed to write snapshot at step %d: %w", step, err)
+		}
+		snapshot.parent = base.path
+		snapshot.depth = base.depth + 1
+	} else if err := serialize.Write(snapshot.path, d.state, OutFilePerm); err != nil {
+		return fmt.Errorf("failed to write snapshot at step %d: %w", step, err)
+	}
+	d.state.GetMemory().ResetDirtyPages()
+	d.snapshots = append(d.snapshots, snapshot)
+	d.dirtyBase = &snapshot
+	if d.cfg.MaxSnapshots > 0 && len(d.snapshots) > d.cfg.MaxSnapshots {
+		d.pruneOldestSnapshot()
+	}
+	return nil
+}
+
+// pruneOldestSnapshot removes the oldest snapshot, first compacting any delta snapshots that depend on it.
+func (d *Debugger) pruneOldestSnapshot() {
+	oldest := d.snapshots[0]
+	for i := range d.snapshots[1:] {
+		child := &d.snapshots[i+1]
+		if child.parent != oldest.path {
+			continue
+		}
+		if err := versions.CompactSnapshot(child.path); err != nil {
+			// Keep the parent so the delta snapshot can still be loaded
+			d.logger.Warn("Failed to compact snapshot", "path", child.path, "err", err)
+			return
+		}
+		child.parent = ""
+		child.depth = 0
+	}
+	if err := os.Remove(oldest.path); err != nil {
+		d.logger.Warn("Failed to remove snapshot", "path", oldest.path, "err", err)
+	}
+	if d.dirtyBase != nil && d.dirtyBase.path == oldest.path {
+		d.dirtyBase = nil
+	}
+	d.snapshots = d.snapshots[1:]
+}
+
+// reverse returns to the state n steps earlier by restoring the latest snapshot before it and executing forwards.
+func (d *Debugger) reverse(ctx context.Context, n uint64) error {
+	if d.cfg.SnapshotInterval == 0 {
+		return fmt.Errorf("%w: reverse stepping is disabled", ErrNoSnapshot)
+	}
+	current := d.state.GetStep()
+	if n > current {
+		n = current
+	}
+	target := current - n
+	idx := -1
+	for i, snapshot := range d.snapshots {
+		if snapshot.step <= target {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("%w: no snapshot at or before step %d", ErrNoSnapshot, target)
+	}
+	snapshot := d.snapshots[idx]
+	state, err := versions.LoadStateFromFile(snapshot.path)
+	if err != nil {
+		return fmt.Errorf("failed to load snapshot at step %d: %w", snapshot.step, err)
+	}
+	if err := d.setState(state); err != nil {
+		return err
+	}
+	// Further snapshots are deltas of the restored snapshot
+	d.state.GetMemory().ResetDirtyPages()
+	d.dirtyBase = &snapshot
+	// Snapshots after the restored one are still valid as execution is deterministic
+	d.prevPC = ^arch.Word(0)
+	if _, err := d.run(ctx, target-snapshot.step, false); err != nil {
+		return err
+	}
+	if d.cfg.EnableStack {
+		d.printf("stack frames are only tracked from the snapshot at step %d\n", snapshot.step)
+	}
+	d.printLocation()
+	return nil
+}
+
+func (d *Debugger) addBreakpoint(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: break requires a type: pc, sym, syscall, preimage or step", ErrInvalidArgument)
+	}
+	kind, args := args[0], args[1:]
+	bp := &breakpoint{id: d.nextID}
+	switch kind {
+	case "pc":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: break pc requires an address", ErrInvalidArgument)
+		}
+		addr, err := parseWord(args[0])
+		if err != nil {
+			return err
+		}
+		bp.desc = fmt.Sprintf("pc %#x", addr)
+		bp.beforeStep = func(_ arch.Word, state mipsevm.FPVMState) bool {
+			return state.GetPC() == addr
+		}
+	case "sym":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: break sym requires a symbol name", ErrInvalidArgument)
+		}
+		if d.cfg.Meta == nil {
+			return fmt.Errorf("%w: symbol breakpoints require metadata", ErrInvalidArgument)
+		}
+		name := args[0]
+		matcher := d.cfg.Meta.CreateSymbolMatcher(name)
+		bp.desc = "sym " + name
+		// Only stop when entering the symbol, rather than at every instruction within it
+		bp.beforeStep = func(prevPC arch.Word, state mipsevm.FPVMState) bool {
+			return matcher(state.GetPC()) && !matcher(prevPC)
+		}
+	case "syscall":
+		var num arch.Word
+		anySyscall := len(args) == 0
+		if !anySyscall {
+			var err error
+			num, err = parseWord(args[0])
+			if err != nil {
+				return err
+			}
+			bp.desc = fmt.Sprintf("syscall %d", num)
+		} else {
+			bp.desc = "syscall"
+		}
+		bp.beforeStep = func(_ arch.Word, state mipsevm.FPVMState) bool {
+			if !isSyscall(state) {
+				return false
+			}
+			return anySyscall || state.GetRegistersRef()[mipsexec.RegSyscallNum] == num
+		}
+	case "preimage":
+		var prefix []byte
+		if len(args) > 0 {
+			prefix = common.FromHex(args[0])
+			if len(prefix) > 32 {
+				return fmt.Errorf("%w: preimage key prefix longer than 32 bytes", ErrInvalidArgument)
+			}
+			bp.desc = "preimage " + common.Bytes2Hex(prefix)
+		} else {
+			bp.desc = "preimage"
+		}
+		bp.afterPreimage = func(key [32]byte) bool {
+			return bytes.HasPrefix(key[:], prefix)
+		}
+	case "step":
+		if len(args) != 1 {
+			return fmt.Errorf("%w: break step requires a step number", ErrInvalidArgument)
+		}
+		target, err := strconv.ParseUint(args[0], 0, 64)
+		if err != nil {
+			return fmt.Errorf("%w: invalid step %q", ErrInvalidArgument, args[0])
+		}
+		bp.desc = fmt.Sprintf("step %d", target)
+		bp.beforeStep = func(_ arch.Word, state mipsevm.FPVMState) bool {
+			return state.GetStep() == target
+		}
+	default:
+		return fmt.Errorf("%w: unknown breakpoint type %q", ErrInvalidArgument, kind)
+	}
+	d.nextID++
+	d.breakpoints = append(d.breakpoints, bp)
+	d.printf("breakpoint %d: %s\n", bp.id, bp.desc)
+	return nil
+}
+
+func (d *Debugger) deleteBreakpoint(args []string) error {
+	if len(args) == 0 {
+		d.breakpoints = nil
+		d.printf("deleted all breakpoints\n")
+		return nil
+	}
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("%w: invalid breakpoint id %q", ErrInvalidArgument, arg)
+		}
+		found := false
+		for i, bp := range d.breakpoints {
+			if bp.id == id {
+				d.breakpoints = append(d.breakpoints[:i], d.breakpoints[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: no breakpoint %d", ErrInvalidArgument, id)
+		}
+	}
+	return nil
+}
+
+func (d *Debugger) printLocation() {
+	pc := d.state.GetPC()
+	insn := mipsexec.LoadSubWord(d.state.GetMemory(), pc, 4, false, new(mipsexec.NoopMemoryTracker))
+	d.printf("step=%d pc=%#x insn=%08x", d.state.GetStep(), pc, insn)
+	if d.cfg.Meta != nil {
+		d.printf(" sym=%s", d.cfg.Meta.LookupSymbol(pc))
+	}
+	if d.state.GetExited() {
+		d.printf(" exited=true code=%d", d.state.GetExitCode())
+	}
+	d.printf("\n")
+}
+
+func (d *Debugger) printRegisters() {
+	width := arch.WordSizeBytes * 2
+	regs := d.state.GetRegistersRef()
+	for i, v := range regs {
+		d.printf("%-4s %#0*x", regNames[i], width, v)
+		if i%4 == 3 {
+			d.printf("\n")
+		} else {
+			d.printf("  ")
+		}
+	}
+	cpu := d.state.GetCpu()
+	d.printf("pc   %#0*x  npc  %#0*x  lo   %#0*x  hi   %#0*x\n", width, cpu.PC, width, cpu.NextPC, width, cpu.LO, width, cpu.HI)
+	d.printf("heap %#0*x\n", width, d.state.GetHeap())
+}
+
+func (d *Debugger) printMemory(args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("%w: mem requires an address and optional length", ErrInvalidArgument)
+	}
+	addr, err := parseWord(args[0])
+	if err != nil {
+		return err
+	}
+	length := arch.Word(64)
+	if len(args) == 2 {
+		if length, err = parseWord(args[1]); err != nil {
+			return err
+		}
+	}
+	if length > maxMemDump {
+		return fmt.Errorf("%w: length %d exceeds maximum of %d", ErrInvalidArgument, length, maxMemDump)
+	}
+	data, err := io.ReadAll(d.state.GetMemory().ReadMemoryRange(addr, length))
+	if err != nil {
+		return fmt.Errorf("failed to read memory: %w", err)
+	}
+	for offset := 0; offset < len(data); offset += 16 {
+		end := min(offset+16, len(data))
+		d.printf("%#0*x: % x\n", arch.WordSizeBytes*2, addr+arch.Word(offset), data[offset:end])
+	}
+	return nil
+}
+
+func (d *Debugger) printStack() error {
+	if !d.cfg.EnableStack {
+		return fmt.Errorf("%w: stack frames are only tracked when metadata is available", ErrInvalidArgument)
+	}
+	frames := d.vm.GetStackFrames()
+	if len(frames) == 0 {
+		d.printf("no stack frames\n")
+	}
+	for i, frame := range frames {
+		d.printf("#%d %#x in %s caller=%#x\n", i, frame.Target, frame.Function, frame.Caller)
+	}
+	return nil
+}
+
+func (d *Debugger) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(d.out, format, args...)
+}
+
+// isSyscall returns true if the next instruction to execute is a syscall.
+func isSyscall(state mipsevm.FPVMState) bool {
+	insn := mipsexec.LoadSubWord(state.GetMemory(), state.GetPC(), 4, false, new(mipsexec.NoopMemoryTracker))
+	return insn&0xfc00003f == 0x0c
+}
+
+func parseWord(s string) (arch.Word, error) {
+	v, err := strconv.ParseUint(s, 0, arch.WordSize)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid value %q", ErrInvalidArgument, s)
+	}
+	return arch.Word(v), nil
+}
+
+func optionalCount(args []string) (uint64, error) {
+	if len(args) == 0 {
+		return 1, nil
+	}
+	n, err := strconv.ParseUint(args[0], 0, 64)
+	if err != nil || n == 0 {
+		return 0, fmt.Errorf("%w: invalid count %q", ErrInvalidArgument, args[0])
+	}
+	return n, nil
+}
+
+const debuggerHelp = `Commands:
+  step [n]             execute n instructions (default 1), stopping at breakpoints. Aliases: s, si
+  continue             execute until a breakpoint is hit or the program exits. Alias: c
+  back [n]             step back n instructions (default 1) by replaying from a snapshot. Alias: rs
+  break pc <addr>      stop before the instruction at addr is executed. Alias: b
+  break sym <name>     stop when entering the function with the specified symbol
+  break syscall [num]  stop before executing a syscall, optionally only with the specified number
+  break preimage [key] stop after reading a preimage whose key starts with the hex prefix key
+  break step <n>       stop before executing step n
+  breakpoints          list breakpoints. Alias: bl
+  delete [id...]       delete breakpoints, or all breakpoints if no id is given. Alias: d
+  info                 print the current step, pc, instruction and symbol. Alias: i
+  regs                 print the registers of the current thread. Alias: r
+  mem <addr> [len]     print len bytes of memory from addr (default 64). Alias: x
+  bt                   print the Go stack frames of the current thread
+  save <path>          write the current state to path
+  quit                 exit the debugger. Alias: q
+An empty line repeats the previous command and lines starting with # are ignored.
+`
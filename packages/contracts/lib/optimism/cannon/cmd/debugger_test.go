@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+const (
+	insnIncT0   = 0x25080001 // addiu $t0, $t0, 1
+	insnSyscall = 0x0000000c
+)
+
+// newTestDebugger creates a debugger for a program that increments $t0 three times, calls getpid and then exits.
+func newTestDebugger(t *testing.T, snapshotInterval uint64) (*Debugger, *bytes.Buffer) {
+	state := multithreaded.CreateInitialState(0, 0x10000)
+	insns := []uint32{
+		insnIncT0,
+		insnIncT0,
+		insnIncT0,
+		0x24020000 | uint32(arch.SysGetpid), // addiu $v0, $zero, SysGetpid
+		insnSyscall,
+		0x24020000 | uint32(arch.SysExitGroup), // addiu $v0, $zero, SysExitGroup
+		0x24040003,                             // addiu $a0, $zero, 3
+		insnSyscall,
+	}
+	for i, insn := range insns {
+		testutil.StoreInstruction(state.Memory, arch.Word(i*4), insn)
+	}
+	versionedState, err := versions.NewFromState(state)
+	require.NoError(t, err)
+
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.inc", Start: 0, Size: 12},
+		{Name: "main.exit", Start: 12, Size: 20},
+	}}
+	out := new(bytes.Buffer)
+	cfg := DebuggerConfig{
+		GuestStdOut:      new(bytes.Buffer),
+		GuestStdErr:      new(bytes.Buffer),
+		Meta:             meta,
+		EnableStack:      true,
+		SnapshotDir:      t.TempDir(),
+		SnapshotInterval: snapshotInterval,
+	}
+	d, err := NewDebugger(testlog.Logger(t, log.LevelInfo), cfg, versionedState, out)
+	require.NoError(t, err)
+	return d, out
+}
+
+func TestDebuggerStep(t *testing.T) {
+	d, out := newTestDebugger(t, 0)
+	require.NoError(t, d.Exec(context.Background(), "step"))
+	require.Equal(t, uint64(1), d.State().GetStep())
+	require.Equal(t, arch.Word(4), d.State().GetPC())
+	require.Contains(t, out.String(), "step=1 pc=0x4 insn=25080001 sym=main.inc")
+
+	require.NoError(t, d.Exec(context.Background(), "s 2"))
+	require.Equal(t, uint64(3), d.State().GetStep())
+	require.Equal(t, arch.Word(3), d.State().GetRegistersRef()[8])
+}
+
+func TestDebuggerContinueToExit(t *testing.T) {
+	d, out := newTestDebugger(t, 0)
+	require.NoError(t, d.Exec(context.Background(), "continue"))
+	require.True(t, d.State().GetExited())
+	require.Equal(t, uint8(3), d.State().GetExitCode())
+
+	require.NoError(t, d.Exec(context.Background(), "continue"))
+	require.Contains(t, out.String(), "program exited with code 3")
+}
+
+func TestDebuggerBreakpoints(t *testing.T) {
+	t.Run("PC", func(t *testing.T) {
+		d, out := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), "break pc 0x8"))
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.Equal(t, arch.Word(8), d.State().GetPC())
+		require.Contains(t, out.String(), "breakpoint 1: pc 0x8")
+	})
+
+	t.Run("Symbol", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), "break sym main.exit"))
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.Equal(t, arch.Word(12), d.State().GetPC())
+		// Should not stop again within the same function
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.True(t, d.State().GetExited())
+	})
+
+	t.Run("Syscall", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), "break syscall"))
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.Equal(t, arch.Word(16), d.State().GetPC())
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.Equal(t, arch.Word(28), d.State().GetPC())
+	})
+
+	t.Run("SyscallNumber", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), fmt.Sprintf("break syscall %d", arch.SysExitGroup)))
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.Equal(t, arch.Word(28), d.State().GetPC())
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), "break step 5"))
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.Equal(t, uint64(5), d.State().GetStep())
+	})
+
+	t.Run("StopsStepping", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), "break pc 8"))
+		require.NoError(t, d.Exec(context.Background(), "step 5"))
+		require.Equal(t, uint64(2), d.State().GetStep())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		d, out := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), "break pc 8"))
+		require.NoError(t, d.Exec(context.Background(), "break step 6"))
+		require.NoError(t, d.Exec(context.Background(), "delete 1"))
+		require.ErrorIs(t, d.Exec(context.Background(), "delete 1"), ErrInvalidArgument)
+		require.NoError(t, d.Exec(context.Background(), "bl"))
+		require.Contains(t, out.String(), "2: step 6")
+		require.NoError(t, d.Exec(context.Background(), "c"))
+		require.Equal(t, uint64(6), d.State().GetStep())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 0)
+		require.ErrorIs(t, d.Exec(context.Background(), "break"), ErrInvalidArgument)
+		require.ErrorIs(t, d.Exec(context.Background(), "break foo"), ErrInvalidArgument)
+		require.ErrorIs(t, d.Exec(context.Background(), "break pc xyz"), ErrInvalidArgument)
+	})
+}
+
+func TestDebuggerReverseStep(t *testing.T) {
+	t.Run("FromSnapshot", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 2)
+		require.NoError(t, d.Exec(context.Background(), "step 5"))
+		require.NoError(t, d.Exec(context.Background(), "back 2"))
+		require.Equal(t, uint64(3), d.State().GetStep())
+		require.Equal(t, arch.Word(12), d.State().GetPC())
+		require.Equal(t, arch.Word(3), d.State().GetRegistersRef()[8])
+
+		require.NoError(t, d.Exec(context.Background(), "back 10"))
+		require.Equal(t, uint64(0), d.State().GetStep())
+		require.Equal(t, arch.Word(0), d.State().GetRegistersRef()[8])
+	})
+
+	t.Run("PrunedDeltaSnapshots", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 2)
+		d.cfg.MaxSnapshots = 2
+		require.NoError(t, d.Exec(context.Background(), "step 7"))
+		require.Len(t, d.snapshots, 2)
+		// The oldest remaining snapshot was compacted when its parent was removed
+		for i, expectedParent := range []string{"", d.snapshots[0].path} {
+			parent, err := versions.SnapshotParent(d.snapshots[i].path)
+			require.NoError(t, err)
+			require.Equal(t, expectedParent, parent)
+		}
+		entries, err := os.ReadDir(d.cfg.SnapshotDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		require.NoError(t, d.Exec(context.Background(), "back 2"))
+		require.Equal(t, uint64(5), d.State().GetStep())
+		require.Equal(t, arch.Word(3), d.State().GetRegistersRef()[8])
+		require.ErrorIs(t, d.Exec(context.Background(), "back 10"), ErrNoSnapshot)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		d, _ := newTestDebugger(t, 0)
+		require.NoError(t, d.Exec(context.Background(), "step 2"))
+		require.ErrorIs(t, d.Exec(context.Background(), "back"), ErrNoSnapshot)
+	})
+}
+
+func TestDebuggerInspect(t *testing.T) {
+	d, out := newTestDebugger(t, 0)
+	require.NoError(t, d.Exec(context.Background(), "step 3"))
+
+	out.Reset()
+	require.NoError(t, d.Exec(context.Background(), "regs"))
+	require.Regexp(t, `t0\s+0x0+3`, out.String())
+	require.Regexp(t, `pc\s+0x0+c`, out.String())
+
+	out.Reset()
+	require.NoError(t, d.Exec(context.Background(), "mem 0 8"))
+	require.Regexp(t, `^0x0+: 25 08 00 01 25 08 00 01\n$`, out.String())
+	require.ErrorIs(t, d.Exec(context.Background(), "mem 0 0x10000"), ErrInvalidArgument)
+
+	out.Reset()
+	require.NoError(t, d.Exec(context.Background(), "bt"))
+	require.Equal(t, "no stack frames\n", out.String())
+}
+
+func TestDebuggerRunScript(t *testing.T) {
+	d, out := newTestDebugger(t, 0)
+	script := strings.Join([]string{
+		"# comment lines are ignored",
+		"step",
+		"",
+		"foo",
+		"quit",
+		"step",
+	}, "\n")
+	require.NoError(t, d.Run(context.Background(), strings.NewReader(script)))
+	// The empty line repeats the step and commands after quit are not executed
+	require.Equal(t, uint64(2), d.State().GetStep())
+	require.Contains(t, out.String(), `error: unknown command: "foo"`)
+}
@@ -0,0 +1,471 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	DiffLeftFlag = &cli.PathFlag{
+		Name:      "left",
+		Usage:     "path of the left state, or of an ELF file if --left-type is set.",
+		TakesFile: true,
+	}
+	DiffRightFlag = &cli.PathFlag{
+		Name:      "right",
+		Usage:     "path of the right state, or of an ELF file if --right-type is set.",
+		TakesFile: true,
+	}
+	DiffLeftTypeFlag = &cli.StringFlag{
+		Name:  "left-type",
+		Usage: "VM type to load the left ELF file as. Valid options: " + openum.EnumString(stateVersions()),
+	}
+	DiffRightTypeFlag = &cli.StringFlag{
+		Name:  "right-type",
+		Usage: "VM type to load the right ELF file as. Valid options: " + openum.EnumString(stateVersions()),
+	}
+	DiffLeftMetaFlag = &cli.PathFlag{
+		Name:      "left-meta",
+		Usage:     "path to metadata file for symbol lookup of left PCs. Not required for ELF files.",
+		TakesFile: true,
+	}
+	DiffRightMetaFlag = &cli.PathFlag{
+		Name:      "right-meta",
+		Usage:     "path to metadata file for symbol lookup of right PCs. Not required for ELF files.",
+		TakesFile: true,
+	}
+	DiffLeftSnapshotsFlag = &cli.PathFlag{
+		Name:  "left-snapshots",
+		Usage: "directory of left snapshots written by cannon run --snapshot-at. Binary searches the snapshots instead of executing from --left.",
+	}
+	DiffRightSnapshotsFlag = &cli.PathFlag{
+		Name:  "right-snapshots",
+		Usage: "directory of right snapshots written by cannon run --snapshot-at. Binary searches the snapshots instead of executing from --right.",
+	}
+	DiffSnapshotFmtFlag = &cli.StringFlag{
+		Name:  "snapshot-fmt",
+		Usage: "format of snapshot file names in the snapshot directories.",
+		Value: "state-%d.bin.gz",
+	}
+	DiffMaxStepsFlag = &cli.Uint64Flag{
+		Name:  "max-steps",
+		Usage: "maximum number of steps to execute in lockstep. Unlimited if 0.",
+	}
+	DiffMemoryCheckIntervalFlag = &cli.Uint64Flag{
+		Name:  "memory-check-interval",
+		Usage: "number of steps between memory root comparisons. Larger values are faster but only narrow memory divergence down to the interval.",
+		Value: 1,
+	}
+
+	ErrExecutionsDiverged = errors.New("executions diverged")
+)
+
+// Divergence is the first difference found between two executions.
+type Divergence struct {
+	// Step is the step of the left execution where the difference was found.
+	Step uint64
+	// RightStep is the step of the right execution, which differs from Step when comparing snapshots.
+	RightStep uint64
+	Field     string
+	Left      string
+	Right     string
+	LeftPC    arch.Word
+	RightPC   arch.Word
+	// LastMatch is the last step where the field was compared and matched, if known.
+	LastMatch *uint64
+}
+
+// diffSide is one of the executions being compared.
+type diffSide struct {
+	name  string
+	state *versions.VersionedState
+	vm    mipsevm.FPVM
+	meta  mipsevm.Metadata
+}
+
+func newDiffSide(logger log.Logger, name string, state *versions.VersionedState, meta mipsevm.Metadata, po mipsevm.PreimageOracle) *diffSide {
+	if meta == nil {
+		meta = &program.Metadata{}
+	}
+	l := logger.With("side", name)
+	stdOut := &mipsevm.LoggingWriter{Log: l.With("module", "guest", "stream", "stdout")}
+	stdErr := &mipsevm.LoggingWriter{Log: l.With("module", "guest", "stream", "stderr")}
+	return &diffSide{
+		name:  name,
+		state: state,
+		vm:    state.CreateVM(l, po, stdOut, stdErr, meta),
+		meta:  meta,
+	}
+}
+
+// compareStates returns the first difference between the left and right states, or nil if they match.
+// Memory is only compared if checkMemory is true as computing the memory root is relatively expensive.
+func compareStates(left, right mipsevm.FPVMState, checkMemory bool) *Divergence {
+	diverged := func(field string, l, r any) *Divergence {
+		return &Divergence{
+			Step:      left.GetStep(),
+			RightStep: right.GetStep(),
+			Field:     field,
+			Left:      fmt.Sprintf("%v", l),
+			Right:     fmt.Sprintf("%v", r),
+			LeftPC:    left.GetPC(),
+			RightPC:   right.GetPC(),
+		}
+	}
+	if left.GetExited() != right.GetExited() {
+		return diverged("exited", left.GetExited(), right.GetExited())
+	}
+	if left.GetExitCode() != right.GetExitCode() {
+		return diverged("exit code", left.GetExitCode(), right.GetExitCode())
+	}
+	leftCpu, rightCpu := left.GetCpu(), right.GetCpu()
+	if leftCpu.PC != rightCpu.PC {
+		return diverged("pc", hexWord(leftCpu.PC), hexWord(rightCpu.PC))
+	}
+	if leftCpu.NextPC != rightCpu.NextPC {
+		return diverged("next pc", hexWord(leftCpu.NextPC), hexWord(rightCpu.NextPC))
+	}
+	if leftCpu.LO != rightCpu.LO {
+		return diverged("lo", hexWord(leftCpu.LO), hexWord(rightCpu.LO))
+	}
+	if leftCpu.HI != rightCpu.HI {
+		return diverged("hi", hexWord(leftCpu.HI), hexWord(rightCpu.HI))
+	}
+	leftRegs, rightRegs := left.GetRegistersRef(), right.GetRegistersRef()
+	for i := range leftRegs {
+		if leftRegs[i] != rightRegs[i] {
+			return diverged("register "+regNames[i], hexWord(leftRegs[i]), hexWord(rightRegs[i]))
+		}
+	}
+	if left.GetHeap() != right.GetHeap() {
+		return diverged("heap", hexWord(left.GetHeap()), hexWord(right.GetHeap()))
+	}
+	if left.GetPreimageKey() != right.GetPreimageKey() {
+		return diverged("preimage key", left.GetPreimageKey(), right.GetPreimageKey())
+	}
+	if left.GetPreimageOffset() != right.GetPreimageOffset() {
+		return diverged("preimage offset", left.GetPreimageOffset(), right.GetPreimageOffset())
+	}
+	if checkMemory {
+		leftRoot, rightRoot := left.GetMemory().MerkleRoot(), right.GetMemory().MerkleRoot()
+		if leftRoot != rightRoot {
+			return diverged("memory root", common.Hash(leftRoot), common.Hash(rightRoot))
+		}
+	}
+	return nil
+}
+
+// lockstep executes the left and right VMs one step at a time until they diverge, both exit or maxSteps are executed.
+func lockstep(ctx context.Context, left, right *diffSide, maxSteps uint64, memoryCheckInterval uint64) (*Divergence, error) {
+	if memoryCheckInterval == 0 {
+		memoryCheckInterval = 1
+	}
+	lastMemoryMatch := left.state.GetStep()
+	for i := uint64(0); ; i++ {
+		if i%100 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		done := i >= maxSteps || left.state.GetExited() || right.state.GetExited()
+		checkMemory := done || i%memoryCheckInterval == 0
+		if divergence := compareStates(left.state, right.state, checkMemory); divergence != nil {
+			if divergence.Field == "memory root" && memoryCheckInterval > 1 {
+				divergence.LastMatch = &lastMemoryMatch
+			}
+			return divergence, nil
+		}
+		if checkMemory {
+			lastMemoryMatch = left.state.GetStep()
+		}
+		if done {
+			return nil, nil
+		}
+		for _, side := range []*diffSide{left, right} {
+			if side.vm.CheckInfiniteLoop() {
+				return nil, fmt.Errorf("%v detected an infinite loop at step %d", side.name, side.state.GetStep())
+			}
+			step, pc := side.state.GetStep(), side.state.GetPC()
+			if _, err := side.vm.Step(false); err != nil {
+				return nil, fmt.Errorf("%v failed at step %d (PC: %08x): %w", side.name, step, pc, err)
+			}
+		}
+	}
+}
+
+// snapshotSteps returns the paths of the snapshots in dir with names matching snapshotFmt, keyed by step.
+func snapshotSteps(dir string, snapshotFmt string) (map[uint64]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot dir %v: %w", dir, err)
+	}
+	steps := make(map[uint64]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		var step uint64
+		if _, err := fmt.Sscanf(entry.Name(), snapshotFmt, &step); err != nil {
+			continue
+		}
+		// Sscanf ignores trailing characters so check the name round trips
+		if fmt.Sprintf(snapshotFmt, step) != entry.Name() {
+			continue
+		}
+		steps[step] = filepath.Join(dir, entry.Name())
+	}
+	return steps, nil
+}
+
+// bisectSnapshots binary searches the snapshots at steps common to both directories for the first pair that differs.
+// It returns the last matching pair of snapshots, and the divergence found in the first differing pair,
+// which may be nil if all snapshots match.
+func bisectSnapshots(logger log.Logger, leftSnapshots, rightSnapshots map[uint64]string) (lastMatch *uint64, firstDiff *Divergence, err error) {
+	var steps []uint64
+	for step := range leftSnapshots {
+		if _, ok := rightSnapshots[step]; ok {
+			steps = append(steps, step)
+		}
+	}
+	if len(steps) == 0 {
+		return nil, nil, errors.New("no snapshots at matching steps")
+	}
+	slices.Sort(steps)
+
+	var loadErr error
+	divergences := make(map[int]*Divergence)
+	idx := sort.Search(len(steps), func(i int) bool {
+		if loadErr != nil {
+			return true
+		}
+		left, err := versions.LoadStateFromFile(leftSnapshots[steps[i]])
+		if err != nil {
+			loadErr = fmt.Errorf("failed to load left snapshot at step %d: %w", steps[i], err)
+			return true
+		}
+		right, err := versions.LoadStateFromFile(rightSnapshots[steps[i]])
+		if err != nil {
+			loadErr = fmt.Errorf("failed to load right snapshot at step %d: %w", steps[i], err)
+			return true
+		}
+		divergence := compareStates(left, right, true)
+		logger.Info("Compared snapshots", "step", steps[i], "match", divergence == nil)
+		divergences[i] = divergence
+		return divergence != nil
+	})
+	if loadErr != nil {
+		return nil, nil, loadErr
+	}
+	if idx > 0 {
+		lastMatch = &steps[idx-1]
+	}
+	if idx < len(steps) {
+		firstDiff = divergences[idx]
+	}
+	return lastMatch, firstDiff, nil
+}
+
+func Diff(ctx *cli.Context) error {
+	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
+
+	leftMeta, err := loadDiffMeta(ctx.Path(DiffLeftMetaFlag.Name))
+	if err != nil {
+		return err
+	}
+	rightMeta, err := loadDiffMeta(ctx.Path(DiffRightMetaFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	var left, right *versions.VersionedState
+	var lastSnapshot, nextSnapshot *uint64
+	if ctx.IsSet(DiffLeftSnapshotsFlag.Name) || ctx.IsSet(DiffRightSnapshotsFlag.Name) {
+		if !ctx.IsSet(DiffLeftSnapshotsFlag.Name) || !ctx.IsSet(DiffRightSnapshotsFlag.Name) {
+			return fmt.Errorf("both --%v and --%v must be set", DiffLeftSnapshotsFlag.Name, DiffRightSnapshotsFlag.Name)
+		}
+		snapshotFmt := ctx.String(DiffSnapshotFmtFlag.Name)
+		leftSnapshots, err := snapshotSteps(ctx.Path(DiffLeftSnapshotsFlag.Name), snapshotFmt)
+		if err != nil {
+			return err
+		}
+		rightSnapshots, err := snapshotSteps(ctx.Path(DiffRightSnapshotsFlag.Name), snapshotFmt)
+		if err != nil {
+			return err
+		}
+		lastMatch, firstDiff, err := bisectSnapshots(l, leftSnapshots, rightSnapshots)
+		if err != nil {
+			return err
+		}
+		if lastMatch == nil {
+			printDivergence(firstDiff, leftMeta, rightMeta)
+			fmt.Println("The earliest snapshots differ, so the first divergence may be earlier")
+			return ErrExecutionsDiverged
+		}
+		if firstDiff == nil {
+			fmt.Printf("All snapshots match up to step %d\n", *lastMatch)
+		} else {
+			fmt.Printf("Snapshots match at step %d and differ at step %d\n", *lastMatch, firstDiff.Step)
+			nextSnapshot = &firstDiff.Step
+		}
+		lastSnapshot = lastMatch
+		if left, err = versions.LoadStateFromFile(leftSnapshots[*lastMatch]); err != nil {
+			return fmt.Errorf("failed to load left snapshot: %w", err)
+		}
+		if right, err = versions.LoadStateFromFile(rightSnapshots[*lastMatch]); err != nil {
+			return fmt.Errorf("failed to load right snapshot: %w", err)
+		}
+	} else {
+		if left, leftMeta, err = loadDiffInput(ctx, DiffLeftFlag, DiffLeftTypeFlag, leftMeta); err != nil {
+			return err
+		}
+		if right, rightMeta, err = loadDiffInput(ctx, DiffRightFlag, DiffRightTypeFlag, rightMeta); err != nil {
+			return err
+		}
+	}
+
+	maxSteps := ctx.Uint64(DiffMaxStepsFlag.Name)
+	if maxSteps == 0 {
+		maxSteps = math.MaxUint64
+	}
+	if nextSnapshot != nil {
+		// The divergence must occur before the first differing snapshot
+		maxSteps = min(maxSteps, *nextSnapshot-*lastSnapshot)
+	}
+
+	args := preimageServerArgs(ctx)
+	var sides []*diffSide
+	for _, input := range []struct {
+		name  string
+		state *versions.VersionedState
+		meta  mipsevm.Metadata
+	}{{"left", left, leftMeta}, {"right", right, rightMeta}} {
+		poLogger := l.With("module", "host", "side", input.name)
+		po, err := NewProcessPreimageOracle(args[0], args[1:], poLogger, poLogger)
+		if err != nil {
+			return fmt.Errorf("failed to create %v pre-image oracle process: %w", input.name, err)
+		}
+		if err := po.Start(); err != nil {
+			return fmt.Errorf("failed to start %v pre-image oracle server: %w", input.name, err)
+		}
+		defer func() {
+			if err := po.Close(); err != nil {
+				l.Error("failed to close pre-image server", "side", input.name, "err", err)
+			}
+		}()
+		sides = append(sides, newDiffSide(l, input.name, input.state, input.meta, po))
+	}
+
+	l.Info("Executing in lockstep", "step", left.GetStep())
+	divergence, err := lockstep(ctx.Context, sides[0], sides[1], maxSteps, ctx.Uint64(DiffMemoryCheckIntervalFlag.Name))
+	if err != nil {
+		return err
+	}
+	if divergence == nil {
+		fmt.Printf("No divergence found up to step %d (exited: %v)\n", left.GetStep(), left.GetExited())
+		return nil
+	}
+	printDivergence(divergence, sides[0].meta, sides[1].meta)
+	return ErrExecutionsDiverged
+}
+
+func printDivergence(d *Divergence, leftMeta, rightMeta mipsevm.Metadata) {
+	lookup := func(meta mipsevm.Metadata, pc arch.Word) string {
+		if meta == nil {
+			return ""
+		}
+		return " (" + meta.LookupSymbol(pc) + ")"
+	}
+	if d.Step == d.RightStep {
+		fmt.Printf("First divergence at step %d: %v\n", d.Step, d.Field)
+	} else {
+		fmt.Printf("First divergence at left step %d, right step %d: %v\n", d.Step, d.RightStep, d.Field)
+	}
+	if d.LastMatch != nil {
+		fmt.Printf("  last matched at step %d\n", *d.LastMatch)
+	}
+	fmt.Printf("  left:  %v at pc %v%v\n", d.Left, hexWord(d.LeftPC), lookup(leftMeta, d.LeftPC))
+	fmt.Printf("  right: %v at pc %v%v\n", d.Right, hexWord(d.RightPC), lookup(rightMeta, d.RightPC))
+}
+
+func loadDiffMeta(path string) (mipsevm.Metadata, error) {
+	if path == "" {
+		return nil, nil
+	}
+	meta, err := jsonutil.LoadJSON[program.Metadata](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata %v: %w", path, err)
+	}
+	return meta, nil
+}
+
+// loadDiffInput loads the state or ELF file specified by inputFlag. Metadata loaded from an ELF file replaces meta.
+func loadDiffInput(ctx *cli.Context, inputFlag *cli.PathFlag, typeFlag *cli.StringFlag, meta mipsevm.Metadata) (*versions.VersionedState, mipsevm.Metadata, error) {
+	path := ctx.Path(inputFlag.Name)
+	if path == "" {
+		return nil, nil, fmt.Errorf("flag --%v or snapshot directories must be set", inputFlag.Name)
+	}
+	if !ctx.IsSet(typeFlag.Name) {
+		state, err := versions.LoadStateFromFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load state %v: %w", path, err)
+		}
+		return state, meta, nil
+	}
+	ver, err := versions.ParseStateVersion(ctx.String(typeFlag.Name))
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid --%v: %w", typeFlag.Name, err)
+	}
+	state, elfMeta, err := loadELFState(path, ver)
+	if err != nil {
+		return nil, nil, err
+	}
+	return state, elfMeta, nil
+}
+
+func hexWord(w arch.Word) string {
+	return fmt.Sprintf("%#0*x", arch.WordSizeBytes*2, w)
+}
+
+func CreateDiffCommand(action cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:  "diff",
+		Usage: "Find the first step where two VM executions diverge",
+		Description: "Execute two states or ELF files in lockstep and report the first step where the registers, memory root, " +
+			"preimage key or exit status differ. If snapshot directories are given, the snapshots are binary searched for the " +
+			"first that differ and execution continues in lockstep from the last matching snapshots. " +
+			"The pre-image server command may be passed after -- and is started separately for each execution.",
+		Action: action,
+		Flags: []cli.Flag{
+			DiffLeftFlag,
+			DiffRightFlag,
+			DiffLeftTypeFlag,
+			DiffRightTypeFlag,
+			DiffLeftMetaFlag,
+			DiffRightMetaFlag,
+			DiffLeftSnapshotsFlag,
+			DiffRightSnapshotsFlag,
+			DiffSnapshotFmtFlag,
+			DiffMaxStepsFlag,
+			DiffMemoryCheckIntervalFlag,
+		},
+	}
+}
+
+var DiffCommand = CreateDiffCommand(Diff)
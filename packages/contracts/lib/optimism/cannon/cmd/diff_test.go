@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type staticPreimageOracle []byte
+
+func (o staticPreimageOracle) Hint([]byte) {}
+
+func (o staticPreimageOracle) GetPreimage([32]byte) []byte {
+	return o
+}
+
+// newTestDiffSide creates an execution of a program that reads 4 bytes of preimage data to 0x100 and then exits.
+// The preimage is read at step 5 so executions with different preimage data diverge from then.
+func newTestDiffSide(t *testing.T, name string, preimage []byte) *diffSide {
+	state := multithreaded.CreateInitialState(0, 0x10000)
+	state.PreimageKey = common.Hash{0x02}
+	// Skip the length prefix so the preimage data is read directly
+	state.PreimageOffset = 8
+	insns := []uint32{
+		0x24020000 | uint32(arch.SysRead), // addiu $v0, $zero, SysRead
+		0x24040005,                        // addiu $a0, $zero, 5
+		0x24050100,                        // addiu $a1, $zero, 0x100
+		0x24060004,                        // addiu $a2, $zero, 4
+		insnSyscall,
+		0x24020000 | uint32(arch.SysExitGroup), // addiu $v0, $zero, SysExitGroup
+		0x24040000,                             // addiu $a0, $zero, 0
+		insnSyscall,
+	}
+	for i, insn := range insns {
+		testutil.StoreInstruction(state.Memory, arch.Word(i*4), insn)
+	}
+	versionedState, err := versions.NewFromState(state)
+	require.NoError(t, err)
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.read", Start: 0, Size: 20},
+		{Name: "main.exit", Start: 20, Size: 12},
+	}}
+	return newDiffSide(testlog.Logger(t, log.LevelInfo), name, versionedState, meta, staticPreimageOracle(preimage))
+}
+
+func TestCompareStates(t *testing.T) {
+	newState := func() *multithreaded.State {
+		return multithreaded.CreateInitialState(0, 0x10000)
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		require.Nil(t, compareStates(newState(), newState(), true))
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		right := newState()
+		right.GetRegistersRef()[8] = 3
+		divergence := compareStates(newState(), right, false)
+		require.NotNil(t, divergence)
+		require.Equal(t, "register t0", divergence.Field)
+		require.Equal(t, hexWord(0), divergence.Left)
+		require.Equal(t, hexWord(3), divergence.Right)
+	})
+
+	t.Run("PreimageKey", func(t *testing.T) {
+		right := newState()
+		right.PreimageKey = common.Hash{0x01}
+		divergence := compareStates(newState(), right, false)
+		require.NotNil(t, divergence)
+		require.Equal(t, "preimage key", divergence.Field)
+	})
+
+	t.Run("Exit", func(t *testing.T) {
+		left, right := newState(), newState()
+		left.Exited, right.Exited = true, true
+		right.ExitCode = 1
+		divergence := compareStates(left, right, false)
+		require.NotNil(t, divergence)
+		require.Equal(t, "exit code", divergence.Field)
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		right := newState()
+		testutil.StoreInstruction(right.Memory, 0x100, insnIncT0)
+		require.Nil(t, compareStates(newState(), right, false))
+		divergence := compareStates(newState(), right, true)
+		require.NotNil(t, divergence)
+		require.Equal(t, "memory root", divergence.Field)
+	})
+}
+
+func TestLockstep(t *testing.T) {
+	t.Run("NoDivergence", func(t *testing.T) {
+		left := newTestDiffSide(t, "left", []byte{1, 2, 3, 4})
+		right := newTestDiffSide(t, "right", []byte{1, 2, 3, 4})
+		divergence, err := lockstep(context.Background(), left, right, 100, 1)
+		require.NoError(t, err)
+		require.Nil(t, divergence)
+		require.True(t, left.state.GetExited())
+		require.True(t, right.state.GetExited())
+	})
+
+	t.Run("MaxSteps", func(t *testing.T) {
+		left := newTestDiffSide(t, "left", []byte{1, 2, 3, 4})
+		right := newTestDiffSide(t, "right", []byte{5, 6, 7, 8})
+		divergence, err := lockstep(context.Background(), left, right, 3, 1)
+		require.NoError(t, err)
+		require.Nil(t, divergence)
+		require.Equal(t, uint64(3), left.state.GetStep())
+	})
+
+	t.Run("Diverged", func(t *testing.T) {
+		left := newTestDiffSide(t, "left", []byte{1, 2, 3, 4})
+		right := newTestDiffSide(t, "right", []byte{5, 6, 7, 8})
+		divergence, err := lockstep(context.Background(), left, right, 100, 1)
+		require.NoError(t, err)
+		require.NotNil(t, divergence)
+		require.Equal(t, uint64(5), divergence.Step)
+		require.Equal(t, "memory root", divergence.Field)
+		require.Equal(t, arch.Word(20), divergence.LeftPC)
+		require.Nil(t, divergence.LastMatch)
+		require.Equal(t, "main.exit", left.meta.LookupSymbol(divergence.LeftPC))
+	})
+
+	t.Run("MemoryCheckInterval", func(t *testing.T) {
+		left := newTestDiffSide(t, "left", []byte{1, 2, 3, 4})
+		right := newTestDiffSide(t, "right", []byte{5, 6, 7, 8})
+		divergence, err := lockstep(context.Background(), left, right, 100, 4)
+		require.NoError(t, err)
+		require.NotNil(t, divergence)
+		// Memory is next compared once the programs exit
+		require.Equal(t, uint64(8), divergence.Step)
+		require.Equal(t, "memory root", divergence.Field)
+		require.NotNil(t, divergence.LastMatch)
+		require.Equal(t, uint64(4), *divergence.LastMatch)
+	})
+}
+
+func TestBisectSnapshots(t *testing.T) {
+	writeSnapshots := func(t *testing.T, side *diffSide) map[uint64]string {
+		dir := t.TempDir()
+		for !side.state.GetExited() {
+			path := filepath.Join(dir, fmt.Sprintf("state-%d.bin.gz", side.state.GetStep()))
+			require.NoError(t, serialize.Write(path, side.state, OutFilePerm))
+			_, err := side.vm.Step(false)
+			require.NoError(t, err)
+		}
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "state-1.json.bak"), nil, 0o644))
+		snapshots, err := snapshotSteps(dir, "state-%d.bin.gz")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 8)
+		return snapshots
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	t.Run("Diverged", func(t *testing.T) {
+		left := writeSnapshots(t, newTestDiffSide(t, "left", []byte{1, 2, 3, 4}))
+		right := writeSnapshots(t, newTestDiffSide(t, "right", []byte{5, 6, 7, 8}))
+		// Only steps with snapshots on both sides are compared
+		delete(right, 4)
+		lastMatch, firstDiff, err := bisectSnapshots(logger, left, right)
+		require.NoError(t, err)
+		require.NotNil(t, lastMatch)
+		require.Equal(t, uint64(3), *lastMatch)
+		require.NotNil(t, firstDiff)
+		require.Equal(t, uint64(5), firstDiff.Step)
+	})
+
+	t.Run("NoDivergence", func(t *testing.T) {
+		left := writeSnapshots(t, newTestDiffSide(t, "left", []byte{1, 2, 3, 4}))
+		right := writeSnapshots(t, newTestDiffSide(t, "right", []byte{1, 2, 3, 4}))
+		lastMatch, firstDiff, err := bisectSnapshots(logger, left, right)
+		require.NoError(t, err)
+		require.Equal(t, uint64(7), *lastMatch)
+		require.Nil(t, firstDiff)
+	})
+
+	t.Run("NoCommonSteps", func(t *testing.T) {
+		_, _, err := bisectSnapshots(logger, map[uint64]string{1: "a"}, map[uint64]string{2: "b"})
+		require.ErrorContains(t, err, "no snapshots at matching steps")
+	})
+}
@@ -52,22 +52,33 @@ func stateVersions() []string {
 }
 
 func LoadELF(ctx *cli.Context) error {
-	elfPath := ctx.Path(LoadELFPathFlag.Name)
+	ver, err := versions.ParseStateVersion(ctx.String(LoadELFVMTypeFlag.Name))
+	if err != nil {
+		return err
+	}
+	state, meta, err := loadELFState(ctx.Path(LoadELFPathFlag.Name), ver)
+	if err != nil {
+		return err
+	}
+	if err := jsonutil.WriteJSON[*program.Metadata](meta, ioutil.ToStdOutOrFileOrNoop(ctx.Path(LoadELFMetaFlag.Name), OutFilePerm)); err != nil {
+		return fmt.Errorf("failed to output metadata: %w", err)
+	}
+	return serialize.Write(ctx.Path(LoadELFOutFlag.Name), state, OutFilePerm)
+}
+
+// loadELFState loads the ELF file at elfPath into a new state of the specified version.
+func loadELFState(elfPath string, ver versions.StateVersion) (*versions.VersionedState, *program.Metadata, error) {
 	elfProgram, err := elf.Open(elfPath)
 	if err != nil {
-		return fmt.Errorf("failed to open ELF file %q: %w", elfPath, err)
+		return nil, nil, fmt.Errorf("failed to open ELF file %q: %w", elfPath, err)
 	}
 	if elfProgram.Machine != elf.EM_MIPS {
-		return fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", elfProgram.Machine.String())
+		return nil, nil, fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", elfProgram.Machine.String())
 	}
 
 	var createInitialState func(f *elf.File) (mipsevm.FPVMState, error)
 
 	var patcher = program.PatchStack
-	ver, err := versions.ParseStateVersion(ctx.String(LoadELFVMTypeFlag.Name))
-	if err != nil {
-		return err
-	}
 	switch ver {
 	case versions.VersionSingleThreaded2:
 		createInitialState = func(f *elf.File) (mipsevm.FPVMState, error) {
@@ -85,31 +96,28 @@ func LoadELF(ctx *cli.Context) error {
 			return program.LoadELF(f, multithreaded.CreateInitialState)
 		}
 	default:
-		return fmt.Errorf("unsupported state version: %d (%s)", ver, ver.String())
+		return nil, nil, fmt.Errorf("unsupported state version: %d (%s)", ver, ver.String())
 	}
 
 	state, err := createInitialState(elfProgram)
 	if err != nil {
-		return fmt.Errorf("failed to load ELF data into VM state: %w", err)
+		return nil, nil, fmt.Errorf("failed to load ELF data into VM state: %w", err)
 	}
 	err = patcher(state)
 	if err != nil {
-		return fmt.Errorf("failed to patch state: %w", err)
+		return nil, nil, fmt.Errorf("failed to patch state: %w", err)
 	}
 	meta, err := program.MakeMetadata(elfProgram)
 	if err != nil {
-		return fmt.Errorf("failed to compute program metadata: %w", err)
-	}
-	if err := jsonutil.WriteJSON[*program.Metadata](meta, ioutil.ToStdOutOrFileOrNoop(ctx.Path(LoadELFMetaFlag.Name), OutFilePerm)); err != nil {
-		return fmt.Errorf("failed to output metadata: %w", err)
+		return nil, nil, fmt.Errorf("failed to compute program metadata: %w", err)
 	}
 
 	// Ensure the state is written with appropriate version information
 	versionedState, err := versions.NewFromState(state)
 	if err != nil {
-		return fmt.Errorf("failed to create versioned state: %w", err)
+		return nil, nil, fmt.Errorf("failed to create versioned state: %w", err)
 	}
-	return serialize.Write(ctx.Path(LoadELFOutFlag.Name), versionedState, OutFilePerm)
+	return versionedState, meta, nil
 }
 
 func CreateLoadELFCommand(action cli.ActionFunc) *cli.Command {
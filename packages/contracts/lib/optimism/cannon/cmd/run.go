@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"slices"
@@ -14,6 +15,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	mipsexec "github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/profiler"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
@@ -67,6 +69,11 @@ var (
 		Value:    "state-%d.bin.gz",
 		Required: false,
 	}
+	RunSnapshotMaxDeltaFlag = &cli.UintFlag{
+		Name:     "snapshot-max-delta",
+		Usage:    "maximum number of delta snapshots, containing only the memory pages written since the previous snapshot, to write between full snapshots. Only full snapshots are written if 0.",
+		Required: false,
+	}
 	RunStopAtFlag = &cli.GenericFlag{
 		Name:     "stop-at",
 		Usage:    "step pattern to stop at: " + patternHelp,
@@ -114,6 +121,24 @@ var (
 		TakesFile: true,
 		Required:  false,
 	}
+	RunProfilePprofFlag = &cli.PathFlag{
+		Name:      "profile.pprof",
+		Usage:     "path to write a pprof profile of the steps, syscalls and preimage requests of the guest program to. Requires --meta.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunProfileFoldedFlag = &cli.PathFlag{
+		Name:      "profile.folded",
+		Usage:     "path to write the steps of each guest call stack to in the folded format used by flamegraph tools. Requires --meta.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunProfileSampleIntervalFlag = &cli.Uint64Flag{
+		Name:     "profile.sample-interval",
+		Usage:    "number of steps between samples of the guest call stack. Syscalls and preimage requests are always recorded.",
+		Value:    1,
+		Required: false,
+	}
 
 	OutFilePerm = os.FileMode(0o755)
 )
@@ -276,6 +301,22 @@ func Guard(proc *os.ProcessState, fn StepFn) StepFn {
 
 var _ mipsevm.PreimageOracle = (*ProcessPreimageOracle)(nil)
 
+// preimageServerArgs returns the pre-image server command and arguments passed after the first '--'.
+// The command is empty if no pre-image server is specified.
+func preimageServerArgs(ctx *cli.Context) []string {
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	return args
+}
+
 func Run(ctx *cli.Context) error {
 	if ctx.Bool(RunPProfCPU.Name) {
 		defer profile.Start(profile.NoShutdownHook, profile.ProfilePath("."), profile.CPUProfile).Stop()
@@ -329,18 +370,7 @@ func Run(ctx *cli.Context) error {
 	}
 	stopAtPreimageLargerThan := ctx.Int(RunStopAtPreimageLargerThanFlag.Name)
 
-	// split CLI args after first '--'
-	args := ctx.Args().Slice()
-	for i, arg := range args {
-		if arg == "--" {
-			args = args[i+1:]
-			break
-		}
-	}
-	if len(args) == 0 {
-		args = []string{""}
-	}
-
+	args := preimageServerArgs(ctx)
 	poOut := Logger(os.Stdout, log.LevelInfo).With("module", "host")
 	poErr := Logger(os.Stderr, log.LevelInfo).With("module", "host")
 	po, err := NewProcessPreimageOracle(args[0], args[1:], poOut, poErr)
@@ -389,8 +419,29 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
+	profilePprof := ctx.Path(RunProfilePprofFlag.Name)
+	profileFolded := ctx.Path(RunProfileFoldedFlag.Name)
+	var guestProfiler *profiler.Profiler
+	if profilePprof != "" || profileFolded != "" {
+		if metaPath := ctx.Path(RunMetaFlag.Name); metaPath == "" {
+			return errors.New("cannot enable profiling without a metadata file")
+		}
+		if !debugProgram {
+			// Call stacks are only tracked in debug mode
+			if err := vm.InitDebug(); err != nil {
+				return fmt.Errorf("failed to initialize debug mode: %w", err)
+			}
+		}
+		guestProfiler = profiler.NewProfiler(vm, meta, ctx.Uint64(RunProfileSampleIntervalFlag.Name))
+	}
+
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
+	snapshotMaxDelta := ctx.Uint(RunSnapshotMaxDeltaFlag.Name)
+	// The first snapshot is always a full snapshot so that snapshots don't depend on the input state
+	var lastSnapshot string
+	var lastSnapshotStep uint64
+	var deltaSnapshots uint
 
 	stepFn := vm.Step
 	if po.cmd != nil {
@@ -435,9 +486,24 @@ func Run(ctx *cli.Context) error {
 		}
 
 		if snapshotAt(state) {
-			if err := serialize.Write(fmt.Sprintf(snapshotFmt, step), state, OutFilePerm); err != nil {
-				return fmt.Errorf("failed to write state snapshot: %w", err)
+			snapshotPath := fmt.Sprintf(snapshotFmt, step)
+			if lastSnapshot != "" && deltaSnapshots < snapshotMaxDelta {
+				if err := versions.WriteDeltaSnapshot(snapshotPath, lastSnapshot, lastSnapshotStep, state, OutFilePerm); err != nil {
+					return fmt.Errorf("failed to write delta state snapshot: %w", err)
+				}
+				deltaSnapshots++
+			} else {
+				if err := serialize.Write(snapshotPath, state, OutFilePerm); err != nil {
+					return fmt.Errorf("failed to write state snapshot: %w", err)
+				}
+				deltaSnapshots = 0
 			}
+			state.GetMemory().ResetDirtyPages()
+			lastSnapshot, lastSnapshotStep = snapshotPath, step
+		}
+
+		if guestProfiler != nil {
+			guestProfiler.BeforeStep()
 		}
 
 		if proofAt(state) {
@@ -468,6 +534,10 @@ func Run(ctx *cli.Context) error {
 			}
 		}
 
+		if guestProfiler != nil {
+			guestProfiler.AfterStep()
+		}
+
 		lastPreimageKey, lastPreimageValue, lastPreimageOffset := vm.LastPreimage()
 		if lastPreimageOffset != ^arch.Word(0) {
 			if stopAtAnyPreimage {
@@ -500,9 +570,39 @@ func Run(ctx *cli.Context) error {
 			return fmt.Errorf("failed to write benchmark data: %w", err)
 		}
 	}
+	if guestProfiler != nil {
+		l.Info("Guest profile",
+			"steps", guestProfiler.Total(profiler.Steps),
+			"syscalls", guestProfiler.Total(profiler.Syscalls),
+			"preimages", guestProfiler.Total(profiler.PreimageRequests),
+			"preimageBytes", guestProfiler.Total(profiler.PreimageBytes))
+		if err := writeGuestProfile(profilePprof, guestProfiler.WritePprof); err != nil {
+			return fmt.Errorf("failed to write guest pprof profile: %w", err)
+		}
+		if err := writeGuestProfile(profileFolded, func(w io.Writer) error {
+			return guestProfiler.WriteFolded(w, profiler.Steps)
+		}); err != nil {
+			return fmt.Errorf("failed to write guest folded stacks: %w", err)
+		}
+	}
 	return nil
 }
 
+func writeGuestProfile(path string, write func(w io.Writer) error) error {
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, OutFilePerm)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
 func CreateRunCommand(action cli.ActionFunc) *cli.Command {
 	return &cli.Command{
 		Name:        "run",
@@ -516,6 +616,7 @@ func CreateRunCommand(action cli.ActionFunc) *cli.Command {
 			RunProofFmtFlag,
 			RunSnapshotAtFlag,
 			RunSnapshotFmtFlag,
+			RunSnapshotMaxDeltaFlag,
 			RunStopAtFlag,
 			RunStopAtPreimageFlag,
 			RunStopAtPreimageTypeFlag,
@@ -525,6 +626,9 @@ func CreateRunCommand(action cli.ActionFunc) *cli.Command {
 			RunPProfCPU,
 			RunDebugFlag,
 			RunDebugInfoFlag,
+			RunProfilePprofFlag,
+			RunProfileFoldedFlag,
+			RunProfileSampleIntervalFlag,
 		},
 	}
 }
@@ -21,6 +21,8 @@ func main() {
 		cmd.LoadELFCommand,
 		cmd.WitnessCommand,
 		cmd.RunCommand,
+		cmd.DebugCommand,
+		cmd.DiffCommand,
 	}
 	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
 	err := app.RunContext(ctx, os.Args)
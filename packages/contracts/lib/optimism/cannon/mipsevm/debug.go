@@ -1,10 +1,25 @@
 package mipsevm
 
-import "github.com/ethereum/go-ethereum/common/hexutil"
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
 
 type DebugInfo struct {
+	Steps               hexutil.Uint64 `json:"steps"`
 	Pages               int            `json:"pages"`
 	MemoryUsed          hexutil.Uint64 `json:"memory_used"`
 	NumPreimageRequests int            `json:"num_preimage_requests"`
 	TotalPreimageSize   int            `json:"total_preimage_size"`
 }
+
+// StackFrame is a frame of the call stack tracked in debug mode.
+type StackFrame struct {
+	// Target is the address of the function that was called
+	Target arch.Word
+	// Function is the symbol of the called function
+	Function string
+	// Caller is the address of the call instruction
+	Caller arch.Word
+}
@@ -15,6 +15,7 @@ type StackTracker interface {
 type TraceableStackTracker interface {
 	StackTracker
 	Traceback()
+	Frames() []mipsevm.StackFrame
 }
 
 type NoopStackTracker struct{}
@@ -25,6 +26,10 @@ func (n *NoopStackTracker) PopStack() {}
 
 func (n *NoopStackTracker) Traceback() {}
 
+func (n *NoopStackTracker) Frames() []mipsevm.StackFrame {
+	return nil
+}
+
 type StackTrackerImpl struct {
 	state mipsevm.FPVMState
 
@@ -75,9 +80,20 @@ func (s *StackTrackerImpl) PopStack() {
 
 func (s *StackTrackerImpl) Traceback() {
 	fmt.Printf("traceback at pc=%x. step=%d\n", s.state.GetPC(), s.state.GetStep())
+	for idx, frame := range s.Frames() {
+		fmt.Printf("\t%d %x in %s caller=%08x\n", idx, frame.Target, frame.Function, frame.Caller)
+	}
+}
+
+// Frames returns the tracked call stack, innermost frame first.
+func (s *StackTrackerImpl) Frames() []mipsevm.StackFrame {
+	frames := make([]mipsevm.StackFrame, 0, len(s.stack))
 	for i := len(s.stack) - 1; i >= 0; i-- {
-		jumpAddr := s.stack[i]
-		idx := len(s.stack) - i - 1
-		fmt.Printf("\t%d %x in %s caller=%08x\n", idx, jumpAddr, s.meta.LookupSymbol(jumpAddr), s.caller[i])
+		frames = append(frames, mipsevm.StackFrame{
+			Target:   s.stack[i],
+			Function: s.meta.LookupSymbol(s.stack[i]),
+			Caller:   s.caller[i],
+		})
 	}
+	return frames
 }
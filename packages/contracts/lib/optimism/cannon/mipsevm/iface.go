@@ -84,6 +84,10 @@ type FPVM interface {
 	// Traceback prints a traceback of the program to the console
 	Traceback()
 
+	// GetStackFrames returns the call stack of the current thread, innermost frame first.
+	// The stack is only tracked once debug mode is initialized.
+	GetStackFrames() []StackFrame
+
 	// GetDebugInfo returns debug information about the VM
 	GetDebugInfo() *DebugInfo
 
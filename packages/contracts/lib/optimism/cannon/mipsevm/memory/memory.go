@@ -54,6 +54,9 @@ type Memory struct {
 	// Note: since we don't de-alloc pages, we don't do ref-counting.
 	// Once a page exists, it doesn't leave memory
 
+	// pageIndex set of pages allocated or written since the last ResetDirtyPages
+	dirtyPages map[Word]struct{}
+
 	// two caches: we often read instructions from one page, and do memory things with another page.
 	// this prevents map lookups each instruction
 	lastPageKeys [2]Word
@@ -64,6 +67,7 @@ func NewMemory() *Memory {
 	return &Memory{
 		nodes:        make(map[uint64]*[32]byte),
 		pages:        make(map[Word]*CachedPage),
+		dirtyPages:   make(map[Word]struct{}),
 		lastPageKeys: [2]Word{^Word(0), ^Word(0)}, // default to invalid keys, to not match any pages
 	}
 }
@@ -81,6 +85,40 @@ func (m *Memory) ForEachPage(fn func(pageIndex Word, page *Page) error) error {
 	return nil
 }
 
+// DirtyPages returns the indexes of the pages allocated or written since the last ResetDirtyPages, in ascending order.
+func (m *Memory) DirtyPages() []Word {
+	indexes := maps.Keys(m.dirtyPages)
+	slices.Sort(indexes)
+	return indexes
+}
+
+// ResetDirtyPages clears the set of dirty pages, typically after the memory has been persisted.
+func (m *Memory) ResetDirtyPages() {
+	for k := range m.dirtyPages {
+		m.pages[k].dirty = false
+	}
+	clear(m.dirtyPages)
+}
+
+// markDirty adds the page to the dirty pages. The flag on the page avoids a map write for every store.
+func (m *Memory) markDirty(pageIndex Word, p *CachedPage) {
+	if !p.dirty {
+		p.dirty = true
+		m.dirtyPages[pageIndex] = struct{}{}
+	}
+}
+
+// writablePage marks the page as dirty and, if its data is shared with a copy of this memory, copies the data so
+// that it can be written without modifying the copy.
+func (m *Memory) writablePage(pageIndex Word, p *CachedPage) {
+	m.markDirty(pageIndex, p)
+	if p.shared {
+		data := *p.Data
+		p.Data = &data
+		p.shared = false
+	}
+}
+
 func (m *Memory) invalidate(addr Word) {
 	// addr must be aligned
 	if addr&arch.ExtMask != 0 {
@@ -208,6 +246,7 @@ func (m *Memory) SetWord(addr Word, v Word) {
 		p = m.AllocPage(pageIndex)
 	} else {
 		m.invalidate(addr) // invalidate this branch of memory, now that the value changed
+		m.writablePage(pageIndex, p)
 	}
 	arch.ByteOrderWord.PutWord(p.Data[pageAddr:pageAddr+arch.WordSizeBytes], v)
 }
@@ -230,6 +269,7 @@ func (m *Memory) GetWord(addr Word) Word {
 func (m *Memory) AllocPage(pageIndex Word) *CachedPage {
 	p := &CachedPage{Data: new(Page)}
 	m.pages[pageIndex] = p
+	m.markDirty(pageIndex, p)
 	// make nodes to root
 	k := (1 << PageKeySize) | uint64(pageIndex)
 	for k > 0 {
@@ -265,6 +305,7 @@ func (m *Memory) UnmarshalJSON(data []byte) error {
 	}
 	m.nodes = make(map[uint64]*[32]byte)
 	m.pages = make(map[Word]*CachedPage)
+	m.dirtyPages = make(map[Word]struct{})
 	m.lastPageKeys = [2]Word{^Word(0), ^Word(0)}
 	m.lastPage = [2]*CachedPage{nil, nil}
 	for i, p := range pages {
@@ -293,6 +334,8 @@ func (m *Memory) SetMemoryRange(addr Word, r io.Reader) error {
 		p, ok := m.pageLookup(pageIndex)
 		if !ok {
 			p = m.AllocPage(pageIndex)
+		} else {
+			m.writablePage(pageIndex, p)
 		}
 		p.InvalidateFull()
 		copy(p.Data[pageAddr:], chunk[:n])
@@ -328,6 +371,7 @@ func (m *Memory) Serialize(out io.Writer) error {
 	return nil
 }
 
+// Deserialize reads memory written by Serialize. All the pages read are marked as dirty.
 func (m *Memory) Deserialize(in io.Reader) error {
 	var pageCount Word
 	if err := binary.Read(in, binary.BigEndian, &pageCount); err != nil {
@@ -346,20 +390,49 @@ func (m *Memory) Deserialize(in io.Reader) error {
 	return nil
 }
 
+// Copy returns a copy of the memory. Page data is shared copy-on-write, and the merkle caches are copied, so copying
+// is cheap and the copy does not need to be merkleized again.
 func (m *Memory) Copy() *Memory {
 	out := NewMemory()
-	out.nodes = make(map[uint64]*[32]byte)
-	out.pages = make(map[Word]*CachedPage)
-	out.lastPageKeys = [2]Word{^Word(0), ^Word(0)}
-	out.lastPage = [2]*CachedPage{nil, nil}
 	for k, page := range m.pages {
-		data := new(Page)
-		*data = *page.Data
-		out.AllocPage(k).Data = data
+		out.pages[k] = page.share()
+	}
+	for k, node := range m.nodes {
+		// Nodes are replaced rather than modified when invalidated, so can be shared
+		out.nodes[k] = node
+	}
+	for k := range m.dirtyPages {
+		out.dirtyPages[k] = struct{}{}
+	}
+	return out
+}
+
+// CopyDirtyPages returns a memory containing only the pages allocated or written since the last ResetDirtyPages.
+// Page data is shared copy-on-write.
+func (m *Memory) CopyDirtyPages() *Memory {
+	out := NewMemory()
+	for k := range m.dirtyPages {
+		out.AllocPage(k)
+		out.pages[k] = m.pages[k].share()
 	}
 	return out
 }
 
+// AddMissingPages moves the pages of base that are not in m to m, including their merkle caches.
+// Combined with CopyDirtyPages, this restores a memory from a base memory and the pages written since.
+// The added pages are not marked as dirty. base must not be used afterwards.
+func (m *Memory) AddMissingPages(base *Memory) {
+	for k, page := range base.pages {
+		if _, ok := m.pages[k]; ok {
+			continue
+		}
+		m.AllocPage(k)
+		page.dirty = false
+		m.pages[k] = page
+		delete(m.dirtyPages, k)
+	}
+}
+
 type memReader struct {
 	m     *Memory
 	addr  Word
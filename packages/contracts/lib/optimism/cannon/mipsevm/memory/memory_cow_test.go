@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+func TestMemoryCopyOnWrite(t *testing.T) {
+	m := NewMemory()
+	m.SetWord(0x8000, 123)
+	root := m.MerkleRoot()
+
+	mcpy := m.Copy()
+	mcpy.SetWord(0x8000, 456)
+	require.Equal(t, Word(123), m.GetWord(0x8000))
+	require.Equal(t, Word(456), mcpy.GetWord(0x8000))
+	require.Equal(t, root, m.MerkleRoot())
+	require.NotEqual(t, root, mcpy.MerkleRoot())
+
+	m.SetWord(0x8000, 789)
+	require.Equal(t, Word(789), m.GetWord(0x8000))
+	require.Equal(t, Word(456), mcpy.GetWord(0x8000))
+}
+
+func TestMemoryDirtyPages(t *testing.T) {
+	m := NewMemory()
+	m.SetWord(0x8000, 1)
+	m.SetWord(2*PageSize, 2)
+	require.Equal(t, []Word{2, 0x8000 / PageSize}, m.DirtyPages())
+
+	m.ResetDirtyPages()
+	require.Empty(t, m.DirtyPages())
+
+	m.SetWord(0x8000, 3)
+	m.SetWord(0x8000+arch.WordSizeBytes, 4)
+	require.Equal(t, []Word{0x8000 / PageSize}, m.DirtyPages())
+	require.True(t, m.pages[0x8000/PageSize].dirty)
+	require.False(t, m.pages[2].dirty)
+
+	t.Run("AllDirtyAfterDeserialize", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, m.Serialize(&buf))
+		out := NewMemory()
+		require.NoError(t, out.Deserialize(&buf))
+		require.Equal(t, []Word{2, 0x8000 / PageSize}, out.DirtyPages())
+	})
+}
+
+func TestMemoryRestoreFromDirtyPages(t *testing.T) {
+	base := NewMemory()
+	base.SetWord(0x8000, 1)
+	base.SetWord(2*PageSize, 2)
+	base.ResetDirtyPages()
+
+	m := base.Copy()
+	m.SetWord(0x8000, 3)
+	m.SetWord(5*PageSize, 4)
+	delta := m.CopyDirtyPages()
+	require.Equal(t, 2, delta.PageCount())
+
+	delta.AddMissingPages(base.Copy())
+	require.Equal(t, m.DirtyPages(), delta.DirtyPages())
+	require.Equal(t, m.PageCount(), delta.PageCount())
+	require.Equal(t, m.MerkleRoot(), delta.MerkleRoot())
+	require.Equal(t, Word(3), delta.GetWord(0x8000))
+	require.Equal(t, Word(2), delta.GetWord(2*PageSize))
+
+	// Modifying the restored memory must not modify the memory the base was copied from
+	delta.SetWord(2*PageSize, 5)
+	require.Equal(t, Word(2), base.GetWord(2*PageSize))
+}
+
+func BenchmarkSetWord(b *testing.B) {
+	m := NewMemory()
+	m.SetWord(0x8000, 1)
+	m.ResetDirtyPages()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Write across a page so the page is only marked dirty by the first write
+		m.SetWord(0x8000+Word(i%(PageSize/arch.WordSizeBytes))*arch.WordSizeBytes, Word(i))
+	}
+}
@@ -68,6 +68,17 @@ type CachedPage struct {
 	Cache [PageSize / 32][32]byte
 	// true if the intermediate node is valid
 	Ok [PageSize / 32]bool
+	// true if Data may be referenced by a page of another memory, so must be copied before it is modified
+	shared bool
+	// true if the page is in the dirty pages of its memory, so writes don't need to add it again
+	dirty bool
+}
+
+// share returns a copy of the page which shares its data. Both pages copy the data before it is next modified.
+func (p *CachedPage) share() *CachedPage {
+	p.shared = true
+	out := *p
+	return &out
 }
 
 func (p *CachedPage) invalidate(pageAddr Word) {
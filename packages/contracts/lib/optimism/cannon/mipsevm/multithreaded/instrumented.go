@@ -101,6 +101,7 @@ func (m *InstrumentedState) GetState() mipsevm.FPVMState {
 
 func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 	return &mipsevm.DebugInfo{
+		Steps:               hexutil.Uint64(m.state.GetStep()),
 		Pages:               m.state.Memory.PageCount(),
 		MemoryUsed:          hexutil.Uint64(m.state.Memory.UsageRaw()),
 		NumPreimageRequests: m.preimageOracle.NumPreimageRequests(),
@@ -112,6 +113,10 @@ func (m *InstrumentedState) Traceback() {
 	m.stackTracker.Traceback()
 }
 
+func (m *InstrumentedState) GetStackFrames() []mipsevm.StackFrame {
+	return m.stackTracker.Frames()
+}
+
 func (m *InstrumentedState) LookupSymbol(addr arch.Word) string {
 	if m.meta == nil {
 		return ""
@@ -52,6 +52,10 @@ func (t *ThreadedStackTrackerImpl) Traceback() {
 	t.getCurrentTracker().Traceback()
 }
 
+func (t *ThreadedStackTrackerImpl) Frames() []mipsevm.StackFrame {
+	return t.getCurrentTracker().Frames()
+}
+
 func (t *ThreadedStackTrackerImpl) getCurrentTracker() exec.TraceableStackTracker {
 	thread := t.state.GetCurrentThread()
 	tracker, exists := t.trackersByThreadId[thread.ThreadId]
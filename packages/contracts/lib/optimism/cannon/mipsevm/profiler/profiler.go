@@ -0,0 +1,238 @@
+package profiler
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// ValueType is a value recorded for each call stack of the guest program.
+type ValueType int
+
+const (
+	Steps ValueType = iota
+	Syscalls
+	PreimageRequests
+	PreimageBytes
+	numValueTypes
+)
+
+var valueTypes = [numValueTypes]*profile.ValueType{
+	Steps:            {Type: "steps", Unit: "count"},
+	Syscalls:         {Type: "syscalls", Unit: "count"},
+	PreimageRequests: {Type: "preimages", Unit: "count"},
+	PreimageBytes:    {Type: "preimage_bytes", Unit: "bytes"},
+}
+
+var preimageKeyTypes = map[preimage.KeyType]string{
+	preimage.LocalKeyType:         "local",
+	preimage.Keccak256KeyType:     "keccak",
+	preimage.GlobalGenericKeyType: "generic",
+	preimage.Sha256KeyType:        "sha256",
+	preimage.BlobKeyType:          "blob",
+	preimage.PrecompileKeyType:    "precompile",
+}
+
+const (
+	syscallInsnMask = 0xFC00003F
+	syscallInsn     = 0x0000000C
+	// noSyscall is the syscall label of samples that aren't syscalls
+	noSyscall = -1
+)
+
+type sampleKey struct {
+	// stack is the call stack of the sample joined with ';', outermost function first
+	stack        string
+	syscall      int64
+	preimageType string
+}
+
+// Profiler records the steps, syscalls and preimage requests of the guest program by call stack.
+// Call stacks are only available if debug mode is enabled on the VM, otherwise only the executing function is recorded.
+type Profiler struct {
+	vm             mipsevm.FPVM
+	meta           mipsevm.Metadata
+	sampleInterval uint64
+
+	samples map[sampleKey]*[numValueTypes]int64
+
+	// stack of the step being executed, or empty if not yet looked up
+	stack string
+	// prevStack is the stack of the previous step, if it was looked up
+	prevStack string
+	delaySlot bool
+}
+
+// NewProfiler creates a profiler for the guest program executed by vm.
+// Steps are sampled every sampleInterval steps, while all syscalls and preimage requests are recorded.
+func NewProfiler(vm mipsevm.FPVM, meta mipsevm.Metadata, sampleInterval uint64) *Profiler {
+	return &Profiler{
+		vm:             vm,
+		meta:           meta,
+		sampleInterval: max(sampleInterval, 1),
+		samples:        make(map[sampleKey]*[numValueTypes]int64),
+	}
+}
+
+// BeforeStep records the step about to be executed. It must be called before each step.
+func (p *Profiler) BeforeStep() {
+	p.prevStack, p.stack = p.stack, ""
+	state := p.vm.GetState()
+	cpu := state.GetCpu()
+	p.delaySlot = cpu.NextPC != cpu.PC+4
+	if state.GetStep()%p.sampleInterval == 0 {
+		p.add(sampleKey{stack: p.currentStack(), syscall: noSyscall}, Steps, int64(p.sampleInterval))
+	}
+	insn := exec.LoadSubWord(state.GetMemory(), cpu.PC, 4, false, new(exec.NoopMemoryTracker))
+	if insn&syscallInsnMask == syscallInsn {
+		num := int64(state.GetRegistersRef()[2])
+		p.add(sampleKey{stack: p.currentStack(), syscall: num}, Syscalls, 1)
+	}
+}
+
+// AfterStep records the preimage requested by the step that was executed, if any. It must be called after each step.
+// Preimages are only read by syscalls, so the stack of the step was already looked up by BeforeStep.
+func (p *Profiler) AfterStep() {
+	key, value, offset := p.vm.LastPreimage()
+	// Reads from the start of a preimage are counted as a new request
+	if offset != 0 {
+		return
+	}
+	typ, ok := preimageKeyTypes[preimage.KeyType(key[0])]
+	if !ok {
+		typ = fmt.Sprintf("%d", key[0])
+	}
+	sample := sampleKey{stack: p.currentStack(), syscall: noSyscall, preimageType: typ}
+	p.add(sample, PreimageRequests, 1)
+	// Exclude the length prefix
+	p.add(sample, PreimageBytes, int64(max(len(value)-8, 0)))
+}
+
+func (p *Profiler) add(key sampleKey, valueType ValueType, value int64) {
+	values, ok := p.samples[key]
+	if !ok {
+		values = new([numValueTypes]int64)
+		p.samples[key] = values
+	}
+	values[valueType] += value
+}
+
+// currentStack returns the call stack of the step being executed.
+// It is looked up at most once per step as it must be called before the step changes the PC.
+func (p *Profiler) currentStack() string {
+	if p.stack != "" {
+		return p.stack
+	}
+	// Delay slots execute after a jump or return updates the tracked stack so belong to the stack of the branch
+	if p.delaySlot && p.prevStack != "" {
+		p.stack = p.prevStack
+		return p.stack
+	}
+	frames := p.vm.GetStackFrames()
+	names := make([]string, 0, len(frames)+1)
+	// Frames are ordered innermost first. The function making each call is used rather than the call target so the
+	// entry function is included and functions the target was inlined into are attributed correctly.
+	for i := len(frames) - 1; i >= 0; i-- {
+		names = append(names, p.meta.LookupSymbol(frames[i].Caller))
+	}
+	names = append(names, p.meta.LookupSymbol(p.vm.GetState().GetPC()))
+	p.stack = strings.Join(names, ";")
+	return p.stack
+}
+
+// Total returns the total recorded value of valueType.
+func (p *Profiler) Total(valueType ValueType) int64 {
+	var total int64
+	for _, values := range p.samples {
+		total += values[valueType]
+	}
+	return total
+}
+
+// Profile returns the recorded samples as a pprof profile.
+func (p *Profiler) Profile() *profile.Profile {
+	prof := &profile.Profile{
+		SampleType:        valueTypes[:],
+		DefaultSampleType: valueTypes[Steps].Type,
+		PeriodType:        valueTypes[Steps],
+		Period:            int64(p.sampleInterval),
+	}
+	functions := make(map[string]*profile.Location)
+	location := func(name string) *profile.Location {
+		if loc, ok := functions[name]; ok {
+			return loc
+		}
+		fn := &profile.Function{ID: uint64(len(prof.Function) + 1), Name: name, SystemName: name}
+		loc := &profile.Location{ID: uint64(len(prof.Location) + 1), Line: []profile.Line{{Function: fn}}}
+		prof.Function = append(prof.Function, fn)
+		prof.Location = append(prof.Location, loc)
+		functions[name] = loc
+		return loc
+	}
+	for _, key := range p.sortedKeys() {
+		names := strings.Split(key.stack, ";")
+		sample := &profile.Sample{Value: p.samples[key][:]}
+		// Samples are ordered innermost function first
+		for i := len(names) - 1; i >= 0; i-- {
+			sample.Location = append(sample.Location, location(names[i]))
+		}
+		if key.syscall != noSyscall {
+			sample.NumLabel = map[string][]int64{"syscall": {key.syscall}}
+		}
+		if key.preimageType != "" {
+			sample.Label = map[string][]string{"preimage_type": {key.preimageType}}
+		}
+		prof.Sample = append(prof.Sample, sample)
+	}
+	return prof
+}
+
+// WritePprof writes the recorded samples as a gzipped pprof protobuf.
+func (p *Profiler) WritePprof(w io.Writer) error {
+	return p.Profile().Write(w)
+}
+
+// WriteFolded writes the recorded valueType of each call stack in the folded stack format used by flamegraph tools.
+func (p *Profiler) WriteFolded(w io.Writer, valueType ValueType) error {
+	totals := make(map[string]int64)
+	for key, values := range p.samples {
+		if values[valueType] != 0 {
+			totals[key.stack] += values[valueType]
+		}
+	}
+	stacks := make([]string, 0, len(totals))
+	for stack := range totals {
+		stacks = append(stacks, stack)
+	}
+	slices.Sort(stacks)
+	for _, stack := range stacks {
+		if _, err := fmt.Fprintf(w, "%s %d\n", stack, totals[stack]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Profiler) sortedKeys() []sampleKey {
+	keys := make([]sampleKey, 0, len(p.samples))
+	for key := range p.samples {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b sampleKey) int {
+		if c := strings.Compare(a.stack, b.stack); c != 0 {
+			return c
+		}
+		if a.syscall != b.syscall {
+			return int(a.syscall - b.syscall)
+		}
+		return strings.Compare(a.preimageType, b.preimageType)
+	})
+	return keys
+}
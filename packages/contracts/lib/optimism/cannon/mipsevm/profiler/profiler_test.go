@@ -0,0 +1,135 @@
+package profiler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type staticPreimageOracle []byte
+
+var _ mipsevm.PreimageOracle = staticPreimageOracle(nil)
+
+func (o staticPreimageOracle) Hint([]byte) {}
+
+func (o staticPreimageOracle) GetPreimage([32]byte) []byte {
+	return o
+}
+
+const insnSyscall = 0x0000000c
+
+// runProgram profiles a program where main.main calls main.f, which reads from a preimage, and then exits.
+func runProgram(t *testing.T, debug bool, sampleInterval uint64) *Profiler {
+	state := multithreaded.CreateInitialState(0, 0x10000)
+	state.PreimageKey = preimage.Keccak256Key(common.Hash{0x01}).PreimageKey()
+	insns := []uint32{
+		// main.main
+		0x0c000005,                             // jal main.f
+		0x00000000,                             // nop
+		0x24020000 | uint32(arch.SysExitGroup), // addiu $v0, $zero, SysExitGroup
+		0x24040000,                             // addiu $a0, $zero, 0
+		insnSyscall,
+		// main.f
+		0x24020000 | uint32(arch.SysRead), // addiu $v0, $zero, SysRead
+		0x24040005,                        // addiu $a0, $zero, 5
+		0x24050100,                        // addiu $a1, $zero, 0x100
+		0x24060004,                        // addiu $a2, $zero, 4
+		insnSyscall,
+		0x03e00008, // jr $ra
+		0x00000000, // nop
+	}
+	for i, insn := range insns {
+		testutil.StoreInstruction(state.Memory, arch.Word(i*4), insn)
+	}
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.main", Start: 0, Size: 20},
+		{Name: "main.f", Start: 20, Size: 28},
+	}}
+	vm := state.CreateVM(testlog.Logger(t, log.LevelInfo), staticPreimageOracle("hello"), new(bytes.Buffer), new(bytes.Buffer), meta)
+	if debug {
+		require.NoError(t, vm.InitDebug())
+	}
+
+	p := NewProfiler(vm, meta, sampleInterval)
+	for !state.GetExited() {
+		p.BeforeStep()
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+		p.AfterStep()
+	}
+	return p
+}
+
+func TestProfilerFolded(t *testing.T) {
+	t.Run("WithStacks", func(t *testing.T) {
+		p := runProgram(t, true, 1)
+		require.Equal(t, int64(12), p.Total(Steps))
+
+		var out bytes.Buffer
+		require.NoError(t, p.WriteFolded(&out, Steps))
+		require.Equal(t, "main.main 5\nmain.main;main.f 7\n", out.String())
+
+		out.Reset()
+		require.NoError(t, p.WriteFolded(&out, Syscalls))
+		require.Equal(t, "main.main 1\nmain.main;main.f 1\n", out.String())
+
+		out.Reset()
+		require.NoError(t, p.WriteFolded(&out, PreimageRequests))
+		require.Equal(t, "main.main;main.f 1\n", out.String())
+	})
+
+	t.Run("WithoutStacks", func(t *testing.T) {
+		p := runProgram(t, false, 1)
+		var out bytes.Buffer
+		require.NoError(t, p.WriteFolded(&out, Steps))
+		require.Equal(t, "main.f 7\nmain.main 5\n", out.String())
+	})
+
+	t.Run("Sampled", func(t *testing.T) {
+		p := runProgram(t, true, 4)
+		require.Equal(t, int64(12), p.Total(Steps))
+		// Syscalls and preimages are recorded regardless of the sample interval
+		require.Equal(t, int64(2), p.Total(Syscalls))
+		require.Equal(t, int64(1), p.Total(PreimageRequests))
+	})
+}
+
+func TestProfilerPprof(t *testing.T) {
+	p := runProgram(t, true, 1)
+	var out bytes.Buffer
+	require.NoError(t, p.WritePprof(&out))
+
+	prof, err := profile.Parse(&out)
+	require.NoError(t, err)
+	require.NoError(t, prof.CheckValid())
+	require.Equal(t, "steps", prof.DefaultSampleType)
+	require.Len(t, prof.Function, 2)
+
+	var syscalls []int64
+	for _, sample := range prof.Sample {
+		require.Equal(t, "main.main", sample.Location[len(sample.Location)-1].Line[0].Function.Name)
+		if num, ok := sample.NumLabel["syscall"]; ok {
+			require.Equal(t, int64(1), sample.Value[Syscalls])
+			syscalls = append(syscalls, num[0])
+		}
+		if typ, ok := sample.Label["preimage_type"]; ok {
+			require.Equal(t, []string{"keccak"}, typ)
+			require.Equal(t, "main.f", sample.Location[0].Line[0].Function.Name)
+			require.Equal(t, int64(1), sample.Value[PreimageRequests])
+			require.Equal(t, int64(5), sample.Value[PreimageBytes])
+		}
+	}
+	require.ElementsMatch(t, []int64{int64(arch.SysExitGroup), int64(arch.SysRead)}, syscalls)
+}
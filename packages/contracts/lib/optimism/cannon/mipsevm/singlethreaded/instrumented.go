@@ -98,6 +98,7 @@ func (m *InstrumentedState) GetState() mipsevm.FPVMState {
 
 func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 	return &mipsevm.DebugInfo{
+		Steps:               hexutil.Uint64(m.state.GetStep()),
 		Pages:               m.state.Memory.PageCount(),
 		MemoryUsed:          hexutil.Uint64(m.state.Memory.UsageRaw()),
 		NumPreimageRequests: m.preimageOracle.NumPreimageRequests(),
@@ -109,6 +110,10 @@ func (m *InstrumentedState) Traceback() {
 	m.stackTracker.Traceback()
 }
 
+func (m *InstrumentedState) GetStackFrames() []mipsevm.StackFrame {
+	return m.stackTracker.Frames()
+}
+
 func (m *InstrumentedState) LookupSymbol(addr Word) string {
 	if m.meta == nil {
 		return ""
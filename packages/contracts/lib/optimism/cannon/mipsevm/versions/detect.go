@@ -25,6 +25,12 @@ func DetectVersion(path string) (StateVersion, error) {
 	if err := bin.ReadUInt(&ver); err != nil {
 		return 0, err
 	}
+	if ver == deltaSnapshotMarker {
+		// Delta snapshots are followed by the version of the state
+		if err := bin.ReadUInt(&ver); err != nil {
+			return 0, err
+		}
+	}
 
 	switch ver {
 	case VersionSingleThreaded, VersionMultiThreaded, VersionSingleThreaded2, VersionMultiThreaded64:
@@ -0,0 +1,209 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+// deltaSnapshotMarker is written in place of the state version at the start of delta snapshots.
+const deltaSnapshotMarker StateVersion = 0xfe
+
+var ErrInvalidDeltaSnapshot = errors.New("invalid delta snapshot")
+
+// deltaSnapshot is a state containing only the memory pages written since its parent snapshot.
+// The binary format is:
+//
+// marker      uint8 (0xfe)
+// version     uint8
+// parent step uint64
+// parent      uint32 length prefixed path of the parent snapshot, relative to the directory of the delta if possible
+// state       As per the state of version, with only the pages written since the parent in memory
+type deltaSnapshot struct {
+	parentStep uint64
+	parent     string
+	state      *VersionedState
+}
+
+func (d *deltaSnapshot) Serialize(w io.Writer) error {
+	bout := serialize.NewBinaryWriter(w)
+	if err := bout.WriteUInt(deltaSnapshotMarker); err != nil {
+		return err
+	}
+	if err := bout.WriteUInt(d.state.Version); err != nil {
+		return err
+	}
+	if err := bout.WriteUInt(d.parentStep); err != nil {
+		return err
+	}
+	if err := bout.WriteBytes([]byte(d.parent)); err != nil {
+		return err
+	}
+	delta, err := withMemory(d.state.FPVMState, d.state.GetMemory().CopyDirtyPages())
+	if err != nil {
+		return err
+	}
+	return delta.Serialize(w)
+}
+
+// withMemory returns a shallow copy of state using mem as its memory.
+func withMemory(state mipsevm.FPVMState, mem *memory.Memory) (mipsevm.FPVMState, error) {
+	switch state := state.(type) {
+	case *singlethreaded.State:
+		out := *state
+		out.Memory = mem
+		return &out, nil
+	case *multithreaded.State:
+		out := *state
+		out.Memory = mem
+		return &out, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnknownVersion, state)
+	}
+}
+
+// WriteDeltaSnapshot writes state to path as a delta snapshot of the parent snapshot at parentPath, which must hold
+// the state at parentStep. Only the memory pages written since the dirty pages of the state's memory were last reset
+// are included, so the dirty pages should be reset when the parent snapshot is written.
+// Delta snapshots are loaded transparently by LoadStateFromFile as long as the parent snapshot is kept.
+func WriteDeltaSnapshot(path string, parentPath string, parentStep uint64, state *VersionedState, perm os.FileMode) error {
+	if !serialize.IsBinaryFile(path) {
+		return fmt.Errorf("%w: only binary file formats are supported: %v", ErrInvalidDeltaSnapshot, path)
+	}
+	parent := parentPath
+	if rel, err := filepath.Rel(filepath.Dir(path), parentPath); err == nil && filepath.IsAbs(path) == filepath.IsAbs(parentPath) {
+		parent = rel
+	}
+	delta := &deltaSnapshot{parentStep: parentStep, parent: parent, state: state}
+	return serialize.WriteSerializedBinary(delta, ioutil.ToStdOutOrFileOrNoop(path, perm))
+}
+
+// SnapshotParent returns the path of the parent of the delta snapshot at path,
+// or an empty string if the snapshot is a full snapshot.
+func SnapshotParent(path string) (string, error) {
+	if !serialize.IsBinaryFile(path) {
+		return "", nil
+	}
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer f.Close()
+	bin := serialize.NewBinaryReader(f)
+	var ver StateVersion
+	if err := bin.ReadUInt(&ver); err != nil {
+		return "", err
+	}
+	if ver != deltaSnapshotMarker {
+		return "", nil
+	}
+	_, _, parent, err := readDeltaHeader(path, bin)
+	return parent, err
+}
+
+// CompactSnapshot rewrites the delta snapshot at path as a full snapshot so that it no longer depends on its parent.
+// Full snapshots are left unchanged.
+func CompactSnapshot(path string) error {
+	parent, err := SnapshotParent(path)
+	if err != nil {
+		return err
+	}
+	if parent == "" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	state, err := LoadStateFromFile(path)
+	if err != nil {
+		return err
+	}
+	return serialize.Write(path, state, info.Mode().Perm())
+}
+
+// readDeltaHeader reads the header of the delta snapshot at path, following the marker,
+// and returns the version of the state, the step of the parent and the resolved path of the parent.
+func readDeltaHeader(path string, bin *serialize.BinaryReader) (StateVersion, uint64, string, error) {
+	var ver StateVersion
+	if err := bin.ReadUInt(&ver); err != nil {
+		return 0, 0, "", err
+	}
+	var parentStep uint64
+	if err := bin.ReadUInt(&parentStep); err != nil {
+		return 0, 0, "", err
+	}
+	var parent []byte
+	if err := bin.ReadBytes(&parent); err != nil {
+		return 0, 0, "", err
+	}
+	if len(parent) == 0 {
+		return 0, 0, "", fmt.Errorf("%w: no parent in %v", ErrInvalidDeltaSnapshot, path)
+	}
+	parentPath := string(parent)
+	if !filepath.IsAbs(parentPath) {
+		parentPath = filepath.Join(filepath.Dir(path), parentPath)
+	}
+	return ver, parentStep, parentPath, nil
+}
+
+// loadBinaryState loads the full or delta snapshot at path.
+// visited holds the paths of the delta snapshots being loaded to detect cycles.
+func loadBinaryState(path string, visited map[string]bool) (*VersionedState, error) {
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	bin := serialize.NewBinaryReader(f)
+	state := &VersionedState{}
+	if err := bin.ReadUInt(&state.Version); err != nil {
+		return nil, err
+	}
+	if state.Version != deltaSnapshotMarker {
+		if err := state.deserializeState(f); err != nil {
+			return nil, err
+		}
+		return state, nil
+	}
+
+	ver, parentStep, parentPath, err := readDeltaHeader(path, bin)
+	if err != nil {
+		return nil, err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if visited[absPath] {
+		return nil, fmt.Errorf("%w: cyclic parent reference at %v", ErrInvalidDeltaSnapshot, path)
+	}
+	visited[absPath] = true
+	parent, err := loadBinaryState(parentPath, visited)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load parent of %v: %w", path, err)
+	}
+	if parent.Version != ver {
+		return nil, fmt.Errorf("%w: parent %v has version %v but expected %v", ErrInvalidDeltaSnapshot, parentPath, parent.Version, ver)
+	}
+	if parent.GetStep() != parentStep {
+		return nil, fmt.Errorf("%w: parent %v is at step %d but expected %d", ErrInvalidDeltaSnapshot, parentPath, parent.GetStep(), parentStep)
+	}
+
+	state.Version = ver
+	if err := state.deserializeState(f); err != nil {
+		return nil, err
+	}
+	state.GetMemory().AddMissingPages(parent.GetMemory())
+	return state, nil
+}
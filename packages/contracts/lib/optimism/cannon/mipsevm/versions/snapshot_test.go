@@ -0,0 +1,143 @@
+package versions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+// writeSnapshots writes a full snapshot of a state followed by two delta snapshots, each modifying one page.
+func writeSnapshots(t *testing.T, dir string) (*VersionedState, []string) {
+	return writeSnapshotsWithExt(t, dir, ".bin.gz")
+}
+
+func writeSnapshotsWithExt(t *testing.T, dir string, ext string) (*VersionedState, []string) {
+	state := multithreaded.CreateEmptyState()
+	state.Memory.SetWord(0x1000, 1)
+	state.Memory.SetWord(0x8000, 2)
+	versioned, err := NewFromState(state)
+	require.NoError(t, err)
+
+	paths := []string{filepath.Join(dir, "0"+ext), filepath.Join(dir, "10"+ext), filepath.Join(dir, "20"+ext)}
+	require.NoError(t, serialize.Write(paths[0], versioned, 0o644))
+	state.Memory.ResetDirtyPages()
+
+	state.Step = 10
+	state.Memory.SetWord(0x8000, 3)
+	require.NoError(t, WriteDeltaSnapshot(paths[1], paths[0], 0, versioned, 0o644))
+	state.Memory.ResetDirtyPages()
+
+	state.Step = 20
+	state.Memory.SetWord(0x20000, 4)
+	require.NoError(t, WriteDeltaSnapshot(paths[2], paths[1], 10, versioned, 0o644))
+	return versioned, paths
+}
+
+func TestDeltaSnapshot(t *testing.T) {
+	t.Run("Load", func(t *testing.T) {
+		expected, paths := writeSnapshots(t, t.TempDir())
+		actual, err := LoadStateFromFile(paths[2])
+		require.NoError(t, err)
+		require.Equal(t, expected.Version, actual.Version)
+		require.Equal(t, uint64(20), actual.GetStep())
+		require.Equal(t, expected.GetMemory().PageCount(), actual.GetMemory().PageCount())
+		require.Equal(t, expected.GetMemory().MerkleRoot(), actual.GetMemory().MerkleRoot())
+		require.Equal(t, arch.Word(3), actual.GetMemory().GetWord(0x8000))
+		// Only the pages of the delta snapshot are dirty
+		require.Equal(t, []arch.Word{0x20000 >> memory.PageAddrSize}, actual.GetMemory().DirtyPages())
+
+		expectedWitness, _ := expected.EncodeWitness()
+		actualWitness, _ := actual.EncodeWitness()
+		require.Equal(t, expectedWitness, actualWitness)
+	})
+
+	t.Run("SmallerThanFull", func(t *testing.T) {
+		_, paths := writeSnapshotsWithExt(t, t.TempDir(), ".bin")
+		full, err := os.Stat(paths[0])
+		require.NoError(t, err)
+		delta, err := os.Stat(paths[1])
+		require.NoError(t, err)
+		require.Less(t, delta.Size(), full.Size())
+	})
+
+	t.Run("DetectVersion", func(t *testing.T) {
+		expected, paths := writeSnapshots(t, t.TempDir())
+		ver, err := DetectVersion(paths[2])
+		require.NoError(t, err)
+		require.Equal(t, expected.Version, ver)
+	})
+
+	t.Run("Parent", func(t *testing.T) {
+		_, paths := writeSnapshots(t, t.TempDir())
+		parent, err := SnapshotParent(paths[0])
+		require.NoError(t, err)
+		require.Empty(t, parent)
+
+		parent, err = SnapshotParent(paths[2])
+		require.NoError(t, err)
+		require.Equal(t, paths[1], parent)
+	})
+
+	t.Run("MovedDirectory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "a"), 0o755))
+		expected, _ := writeSnapshots(t, filepath.Join(dir, "a"))
+		require.NoError(t, os.Rename(filepath.Join(dir, "a"), filepath.Join(dir, "b")))
+		actual, err := LoadStateFromFile(filepath.Join(dir, "b", "20.bin.gz"))
+		require.NoError(t, err)
+		require.Equal(t, expected.GetMemory().MerkleRoot(), actual.GetMemory().MerkleRoot())
+	})
+
+	t.Run("Compact", func(t *testing.T) {
+		expected, paths := writeSnapshots(t, t.TempDir())
+		require.NoError(t, CompactSnapshot(paths[1]))
+		parent, err := SnapshotParent(paths[1])
+		require.NoError(t, err)
+		require.Empty(t, parent)
+
+		// The compacted snapshot and its children can be loaded without the removed parent
+		require.NoError(t, os.Remove(paths[0]))
+		actual, err := LoadStateFromFile(paths[2])
+		require.NoError(t, err)
+		require.Equal(t, expected.GetMemory().MerkleRoot(), actual.GetMemory().MerkleRoot())
+	})
+
+	t.Run("MissingParent", func(t *testing.T) {
+		_, paths := writeSnapshots(t, t.TempDir())
+		require.NoError(t, os.Remove(paths[1]))
+		_, err := LoadStateFromFile(paths[2])
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("WrongParentStep", func(t *testing.T) {
+		versioned, paths := writeSnapshots(t, t.TempDir())
+		path := filepath.Join(filepath.Dir(paths[0]), "30.bin.gz")
+		require.NoError(t, WriteDeltaSnapshot(path, paths[0], 5, versioned, 0o644))
+		_, err := LoadStateFromFile(path)
+		require.ErrorIs(t, err, ErrInvalidDeltaSnapshot)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		versioned, err := NewFromState(multithreaded.CreateEmptyState())
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "0.bin.gz")
+		require.NoError(t, WriteDeltaSnapshot(path, path, 0, versioned, 0o644))
+		_, err = LoadStateFromFile(path)
+		require.ErrorIs(t, err, ErrInvalidDeltaSnapshot)
+	})
+
+	t.Run("RejectJSON", func(t *testing.T) {
+		versioned, err := NewFromState(multithreaded.CreateEmptyState())
+		require.NoError(t, err)
+		dir := t.TempDir()
+		err = WriteDeltaSnapshot(filepath.Join(dir, "1.json.gz"), filepath.Join(dir, "0.bin.gz"), 0, versioned, 0o644)
+		require.ErrorIs(t, err, ErrInvalidDeltaSnapshot)
+	})
+}
@@ -42,7 +42,7 @@ func LoadStateFromFile(path string) (*VersionedState, error) {
 		}
 		return NewFromState(state)
 	}
-	return serialize.LoadSerializedBinary[VersionedState](path)
+	return loadBinaryState(path, make(map[string]bool))
 }
 
 func NewFromState(state mipsevm.FPVMState) (*VersionedState, error) {
@@ -92,7 +92,11 @@ func (s *VersionedState) Deserialize(in io.Reader) error {
 	if err := bin.ReadUInt(&s.Version); err != nil {
 		return err
 	}
+	return s.deserializeState(in)
+}
 
+// deserializeState deserializes the FPVMState of s.Version
+func (s *VersionedState) deserializeState(in io.Reader) error {
 	switch s.Version {
 	case VersionSingleThreaded2:
 		if !arch.IsMips32 {
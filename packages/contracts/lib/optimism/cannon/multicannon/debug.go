@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+func Debug(ctx *cli.Context) error {
+	if len(os.Args) == 3 && os.Args[2] == "--help" {
+		if err := list(); err != nil {
+			return err
+		}
+		fmt.Println("use `--input <valid input file> --help` to get more detailed help")
+		return nil
+	}
+
+	var version versions.StateVersion
+	if inputPath, err := parsePathFlag(os.Args[1:], "--input"); err == nil {
+		version, err = versions.DetectVersion(inputPath)
+		if err != nil {
+			return err
+		}
+	} else {
+		// ELF files are loaded into a state of the version specified by --type
+		typ, typeErr := parseFlag(os.Args[1:], "--type")
+		if typeErr != nil {
+			return fmt.Errorf("%w or %w", err, typeErr)
+		}
+		version, err = versions.ParseStateVersion(typ)
+		if err != nil {
+			return err
+		}
+	}
+	return ExecuteCannon(ctx.Context, os.Args[1:], version)
+}
+
+var DebugCommand = &cli.Command{
+	Name:            "debug",
+	Usage:           "Interactively step through VM execution",
+	Description:     "Load a state or ELF and single step or continue to breakpoints, inspecting registers, memory and stack frames.",
+	Action:          Debug,
+	SkipFlagParsing: true,
+}
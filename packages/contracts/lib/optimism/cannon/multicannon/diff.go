@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+func Diff(ctx *cli.Context) error {
+	if len(os.Args) == 3 && os.Args[2] == "--help" {
+		if err := list(); err != nil {
+			return err
+		}
+		fmt.Println("use `--left <valid input file> --help` to get more detailed help")
+		return nil
+	}
+
+	// Both executions must use the same word size, so the cannon implementation is selected by the left execution
+	version, err := detectLeftVersion(os.Args[1:])
+	if err != nil {
+		return err
+	}
+	return ExecuteCannon(ctx.Context, os.Args[1:], version)
+}
+
+func detectLeftVersion(args []string) (versions.StateVersion, error) {
+	if typ, err := parseFlag(args, "--left-type"); err == nil {
+		return versions.ParseStateVersion(typ)
+	}
+	if inputPath, err := parsePathFlag(args, "--left"); err == nil {
+		return versions.DetectVersion(inputPath)
+	}
+	dir, err := parsePathFlag(args, "--left-snapshots")
+	if err != nil {
+		return 0, errors.New("one of --left, --left-type or --left-snapshots must be set")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read snapshot dir %v: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			return versions.DetectVersion(filepath.Join(dir, entry.Name()))
+		}
+	}
+	return 0, fmt.Errorf("no snapshots in %v", dir)
+}
+
+var DiffCommand = &cli.Command{
+	Name:            "diff",
+	Usage:           "Find the first step where two VM executions diverge",
+	Description:     "Execute two states or ELF files in lockstep, or binary search snapshots, and report the first step where they differ.",
+	Action:          Diff,
+	SkipFlagParsing: true,
+}
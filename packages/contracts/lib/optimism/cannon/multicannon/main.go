@@ -30,6 +30,8 @@ func main() {
 		LoadELFCommand,
 		WitnessCommand,
 		RunCommand,
+		DebugCommand,
+		DiffCommand,
 		ListCommand,
 	}
 	ctx := ctxinterrupt.WithCancelOnInterrupt(context.Background())
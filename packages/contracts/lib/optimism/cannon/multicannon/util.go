@@ -11,11 +11,11 @@ import (
 func parseFlag(args []string, flag string) (string, error) {
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
-		if strings.HasPrefix(arg, flag) {
-			toks := strings.Split(arg, "=")
-			if len(toks) == 2 {
-				return toks[1], nil
-			} else if i+1 == len(args) {
+		if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+			return value, nil
+		}
+		if arg == flag {
+			if i+1 == len(args) {
 				return "", fmt.Errorf("flag needs an argument: %s", flag)
 			} else {
 				return args[i+1], nil
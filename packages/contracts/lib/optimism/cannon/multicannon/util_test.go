@@ -39,6 +39,12 @@ func TestParseFlag(t *testing.T) {
 			flag:   "--bar",
 			expect: "one",
 		},
+		{
+			name:   "ignores flags with same prefix",
+			args:   "--bar-baz two --bar one",
+			flag:   "--bar",
+			expect: "one",
+		},
 		{
 			name:      "non-existent flag",
 			args:      "--foo one",
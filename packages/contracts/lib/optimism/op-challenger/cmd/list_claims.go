@@ -3,17 +3,21 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/big"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claimtree"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
@@ -34,8 +38,21 @@ var (
 		Usage:   "Verbose output",
 		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "VERBOSE"),
 	}
+	ClaimsFormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format. Options: table, " + openum.EnumString(claimtree.Formats),
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "FORMAT"),
+		Value:   formatTable,
+	}
+	HonestActorsFlag = &cli.StringSliceFlag{
+		Name:    "honest-actors",
+		Usage:   "List of addresses of honest actors, used to determine honest agreement with claims when exporting the claim tree",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "HONEST_ACTORS"),
+	}
 )
 
+const formatTable = "table"
+
 func ListClaims(ctx *cli.Context) error {
 	logger, err := setupLogging(ctx)
 	if err != nil {
@@ -61,7 +78,63 @@ func ListClaims(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	return listClaims(ctx.Context, contract, ctx.Bool(VerboseFlag.Name))
+	format := ctx.String(ClaimsFormatFlag.Name)
+	if format == formatTable {
+		return listClaims(ctx.Context, contract, ctx.Bool(VerboseFlag.Name))
+	}
+	treeFormat, err := claimtree.ParseFormat(format)
+	if err != nil {
+		return err
+	}
+	var honestActors []common.Address
+	for _, addrStr := range ctx.StringSlice(HonestActorsFlag.Name) {
+		actor, err := opservice.ParseAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("invalid honest actor: %w", err)
+		}
+		honestActors = append(honestActors, actor)
+	}
+	return exportClaims(ctx.Context, gameAddr, contract, treeFormat, honestActors, os.Stdout)
+}
+
+// exportClaims writes the claim tree of the game to out in the specified format.
+func exportClaims(ctx context.Context, gameAddr common.Address, game contracts.FaultDisputeGameContract, format claimtree.Format, honestActors []common.Address, out io.Writer) error {
+	metadata, err := game.GetGameMetadata(ctx, rpcblock.Latest)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve metadata: %w", err)
+	}
+	maxDepth, err := game.GetMaxGameDepth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve max depth: %w", err)
+	}
+	splitDepth, err := game.GetSplitDepth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve split depth: %w", err)
+	}
+	claims, err := game.GetAllClaims(ctx, rpcblock.Latest)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve claims: %w", err)
+	}
+	resolved, err := game.IsResolved(ctx, rpcblock.Latest, claims...)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve claim resolution: %w", err)
+	}
+	tree, err := claimtree.Build(time.Now(), claimtree.Game{
+		Address:                 gameAddr,
+		Status:                  metadata.Status,
+		L2BlockNumber:           metadata.L2BlockNum,
+		RootClaim:               metadata.RootClaim,
+		SplitDepth:              splitDepth,
+		MaxDepth:                maxDepth,
+		MaxClockDuration:        time.Duration(metadata.MaxClockDuration) * time.Second,
+		L2BlockNumberChallenged: metadata.L2BlockNumberChallenged,
+		L2BlockNumberChallenger: metadata.L2BlockNumberChallenger,
+		HonestActors:            honestActors,
+	}, claims, resolved)
+	if err != nil {
+		return fmt.Errorf("failed to build claim tree: %w", err)
+	}
+	return claimtree.Write(out, format, tree)
 }
 
 func listClaims(ctx context.Context, game contracts.FaultDisputeGameContract, verbose bool) error {
@@ -100,10 +173,6 @@ func listClaims(ctx context.Context, game contracts.FaultDisputeGameContract, ve
 		}
 	}
 
-	// The top game runs from depth 0 to split depth *inclusive*.
-	// The - 1 here accounts for the fact that the split depth is included in the top game.
-	bottomDepth := maxDepth - splitDepth - 1
-
 	resolved, err := game.IsResolved(ctx, rpcblock.Latest, claims...)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve claim resolution: %w", err)
@@ -147,17 +216,10 @@ func listClaims(ctx context.Context, game contracts.FaultDisputeGameContract, ve
 		if gameState.DefendsParent(claim) {
 			move = "Defend"
 		}
-		var traceIdx *big.Int
-		if claim.Depth() <= splitDepth {
-			traceIdx = claim.TraceIndex(splitDepth)
-		} else {
-			relativePos, err := claim.Position.RelativeToAncestorAtDepth(splitDepth + 1)
-			if err != nil {
-				fmt.Printf("Error calculating relative position for claim %v: %v", claim.ContractIndex, err)
-				traceIdx = big.NewInt(-1)
-			} else {
-				traceIdx = relativePos.TraceIndex(bottomDepth)
-			}
+		traceIdx, err := claimtree.TraceIndex(claim.Position, splitDepth, maxDepth)
+		if err != nil {
+			fmt.Printf("Error calculating trace index for claim %v: %v", claim.ContractIndex, err)
+			traceIdx = big.NewInt(-1)
 		}
 		value := claim.Value.TerminalString()
 		if verbose {
@@ -189,6 +251,8 @@ func listClaimsFlags() []cli.Flag {
 		flags.L1EthRpcFlag,
 		GameAddressFlag,
 		VerboseFlag,
+		ClaimsFormatFlag,
+		HonestActorsFlag,
 	}
 	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
 	return cliFlags
@@ -197,7 +261,7 @@ func listClaimsFlags() []cli.Flag {
 var ListClaimsCommand = &cli.Command{
 	Name:        "list-claims",
 	Usage:       "List the claims in a dispute game",
-	Description: "Lists the claims in a dispute game",
+	Description: "Lists the claims in a dispute game as a table or exports the claim tree as JSON, Graphviz DOT or Mermaid",
 	Action:      Interruptible(ListClaims),
 	Flags:       listClaimsFlags(),
 }
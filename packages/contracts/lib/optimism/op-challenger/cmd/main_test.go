@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -224,6 +226,58 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestTraceCacheMaxSize(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, uint64(0), cfg.TraceCacheMaxSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--trace-cache-max-size-mb", "512"))
+		require.Equal(t, uint64(512*1024*1024), cfg.TraceCacheMaxSize)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"invalid value \"abc\" for flag -trace-cache-max-size-mb",
+			addRequiredArgs(types.TraceTypeAlphabet, "--trace-cache-max-size-mb", "abc"))
+	})
+}
+
+func TestProofWorkers(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon))
+		require.Empty(t, cfg.Cannon.Remote.Workers)
+		require.Equal(t, vm.DefaultWorkerTimeout, cfg.Cannon.Remote.Timeout)
+		require.Equal(t, uint(vm.DefaultWorkerAttempts), cfg.Cannon.Remote.Attempts)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon,
+			"--proof-workers", "http://worker1:8545,http://worker2:8545",
+			"--proof-worker-auth-token", "secret",
+			"--proof-worker-timeout", "10m",
+			"--proof-worker-attempts", "5"))
+		expected := vm.RemoteConfig{
+			Workers:   []string{"http://worker1:8545", "http://worker2:8545"},
+			AuthToken: "secret",
+			Timeout:   10 * time.Minute,
+			Attempts:  5,
+		}
+		require.Equal(t, expected, cfg.Cannon.Remote)
+		require.Equal(t, expected, cfg.Asterisc.Remote)
+		require.Equal(t, expected, cfg.AsteriscKona.Remote)
+	})
+
+	t.Run("InvalidTimeout", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"invalid value \"abc\" for flag -proof-worker-timeout",
+			addRequiredArgs(types.TraceTypeCannon, "--proof-workers", "http://worker:8545", "--proof-worker-timeout", "abc"))
+	})
+}
+
 func TestMaxPendingTx(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint64(345)
@@ -723,6 +777,18 @@ func TestCannonRequiredArgs(t *testing.T) {
 			})
 		})
 
+		t.Run(fmt.Sprintf("TestCannonSnapshotMaxDelta-%v", traceType), func(t *testing.T) {
+			t.Run("UsesDefault", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType))
+				require.Equal(t, uint(0), cfg.Cannon.SnapshotMaxDelta)
+			})
+
+			t.Run("Valid", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(traceType, "--cannon-snapshot-max-delta=10"))
+				require.Equal(t, uint(10), cfg.Cannon.SnapshotMaxDelta)
+			})
+		})
+
 		t.Run(fmt.Sprintf("TestCannonInfoFreq-%v", traceType), func(t *testing.T) {
 			t.Run("UsesDefault", func(t *testing.T) {
 				cfg := configForArgs(t, addRequiredArgs(traceType))
@@ -937,6 +1003,51 @@ func TestAdditionalBondClaimants(t *testing.T) {
 	})
 }
 
+func TestBondTreasury(t *testing.T) {
+	t.Run("DefaultsToDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, common.Address{}, cfg.BondTreasury)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		treasury := common.Address{0xaa}
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--bond-treasury", treasury.Hex()))
+		require.Equal(t, treasury, cfg.BondTreasury)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid bond-treasury",
+			addRequiredArgs(types.TraceTypeAlphabet, "--bond-treasury", "nope"))
+	})
+}
+
+func TestBondSweepThreshold(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultBondSweepThreshold, cfg.BondSweepThreshold)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--bond-sweep-threshold", "2.5"))
+		require.Equal(t, big.NewInt(2_500_000_000_000_000_000), cfg.BondSweepThreshold)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid bond-sweep-threshold",
+			addRequiredArgs(types.TraceTypeAlphabet, "--bond-sweep-threshold", "lots"))
+	})
+
+	t.Run("TooManyDecimals", func(t *testing.T) {
+		verifyArgsInvalid(t, "more than 18 decimals",
+			addRequiredArgs(types.TraceTypeAlphabet, "--bond-sweep-threshold", "0.0000000000000000001"))
+	})
+
+	t.Run("Negative", func(t *testing.T) {
+		verifyArgsInvalid(t, "must not be negative",
+			addRequiredArgs(types.TraceTypeAlphabet, "--bond-sweep-threshold", "-1"))
+	})
+}
+
 func TestSignerTLS(t *testing.T) {
 	t.Run("EnabledByDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/worker"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ProofWorkerAddrFlag = &cli.StringFlag{
+		Name: "worker-addr",
+		Usage: "Address to serve proof generation jobs on. Requests are authenticated with --proof-worker-auth-token " +
+			"but not encrypted, so only listen on other interfaces when the network is trusted or behind a TLS proxy.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "WORKER_ADDR"),
+		Value:   "127.0.0.1",
+	}
+	ProofWorkerPortFlag = &cli.IntFlag{
+		Name:    "worker-port",
+		Usage:   "Port to serve proof generation jobs on.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "WORKER_PORT"),
+		Value:   8545,
+	}
+	ProofWorkerMaxJobsFlag = &cli.UintFlag{
+		Name:    "max-jobs",
+		Usage:   "Maximum number of proofs to generate concurrently. Additional jobs are rejected so challengers try another worker.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "MAX_JOBS"),
+		Value:   uint(runtime.NumCPU()),
+	}
+	ProofWorkerCacheMaxSizeFlag = &cli.Uint64Flag{
+		Name:    "worker-cache-max-size-mb",
+		Usage:   "Maximum size in MiB of the VM executions kept to speed up later jobs for the same game.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "WORKER_CACHE_MAX_SIZE_MB"),
+		Value:   10 * 1024,
+	}
+	ProofWorkerPrestatesMaxSizeFlag = &cli.Uint64Flag{
+		Name:    "worker-prestates-max-size-mb",
+		Usage:   "Maximum total size in MiB of prestates uploaded by challengers. Further uploads are rejected.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "WORKER_PRESTATES_MAX_SIZE_MB"),
+		Value:   4 * 1024,
+	}
+)
+
+func ProofWorker(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Starting proof worker", "version", VersionWithMeta)
+
+	cfg, err := flags.NewConfigFromCLI(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	authToken := ctx.String(flags.ProofWorkerAuthTokenFlag.Name)
+	if authToken == "" {
+		return nil, fmt.Errorf("%v is required", flags.ProofWorkerAuthTokenFlag.Name)
+	}
+	maxJobs := ctx.Uint(ProofWorkerMaxJobsFlag.Name)
+	if maxJobs == 0 {
+		return nil, fmt.Errorf("%v must not be 0", ProofWorkerMaxJobsFlag.Name)
+	}
+	return worker.NewService(logger, cfg, worker.Config{
+		ListenAddr:       ctx.String(ProofWorkerAddrFlag.Name),
+		ListenPort:       ctx.Int(ProofWorkerPortFlag.Name),
+		AuthToken:        authToken,
+		MaxJobs:          maxJobs,
+		MaxCacheSize:     int64(ctx.Uint64(ProofWorkerCacheMaxSizeFlag.Name)) * 1024 * 1024,
+		MaxPrestatesSize: int64(ctx.Uint64(ProofWorkerPrestatesMaxSizeFlag.Name)) * 1024 * 1024,
+	}, VersionWithMeta), nil
+}
+
+func proofWorkerFlags() []cli.Flag {
+	return slices.Concat(flags.Flags, []cli.Flag{
+		ProofWorkerAddrFlag,
+		ProofWorkerPortFlag,
+		ProofWorkerMaxJobsFlag,
+		ProofWorkerCacheMaxSizeFlag,
+		ProofWorkerPrestatesMaxSizeFlag,
+	})
+}
+
+var ProofWorkerCommand = &cli.Command{
+	Name:  "proof-worker",
+	Usage: "Generates proofs for challengers configured to use remote proof workers",
+	Description: "Serves proof generation jobs over HTTP, executing cannon or asterisc for each enabled trace type. " +
+		"Challengers send jobs to workers listed in --proof-workers instead of running the VM locally.",
+	Action: cliapp.LifecycleCmd(ProofWorker),
+	Flags:  proofWorkerFlags(),
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/prestates"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	simulateProviderAlphabet = "alphabet"
+	simulateProviderOutput   = "output"
+	simulateProviderCannon   = "cannon"
+)
+
+var ErrUnknownProvider = errors.New("unknown trace provider")
+
+var (
+	SimulateGameFileFlag = &cli.StringFlag{
+		Name:    "game-file",
+		Usage:   "Path to a game recorded with --save-game to simulate instead of loading the game from L1.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_GAME_FILE"),
+	}
+	SimulateSaveGameFlag = &cli.StringFlag{
+		Name:    "save-game",
+		Usage:   "Path to record the game loaded from L1 to, so it can be simulated again later with --game-file.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_SAVE_GAME"),
+	}
+	SimulateProviderFlag = &cli.StringFlag{
+		Name: "provider",
+		Usage: "Trace provider the honest challenger uses. Valid options: " +
+			"alphabet (alphabet trace for the full game depth, no RPCs required), " +
+			"output (output roots from the rollup node with alphabet traces below the split depth) or " +
+			"cannon (output roots with cannon traces below the split depth, configured with the usual challenger cannon flags).",
+		Value:   simulateProviderAlphabet,
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_PROVIDER"),
+	}
+	SimulateAdversaryMoveFlag = &cli.StringSliceFlag{
+		Name: "adversary-move",
+		Usage: "Hypothetical adversary move to apply before running the challenger. Format is parentIdx:attack:value, " +
+			"parentIdx:defend:value or parentIdx:step, where the value is the hex encoded claim. " +
+			"Moves are applied in order, each new claim is appended to the end of the claim list.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "SIMULATE_ADVERSARY_MOVE"),
+	}
+)
+
+// recordedGame is a snapshot of a game's claim tree that can be simulated without access to L1.
+type recordedGame struct {
+	L1Head           eth.BlockID     `json:"l1Head"`
+	PrestateBlock    uint64          `json:"prestateBlock"`
+	PoststateBlock   uint64          `json:"poststateBlock"`
+	AbsolutePrestate common.Hash     `json:"absolutePrestate"`
+	SplitDepth       types.Depth     `json:"splitDepth"`
+	MaxDepth         types.Depth     `json:"maxDepth"`
+	Claims           []recordedClaim `json:"claims"`
+}
+
+type recordedClaim struct {
+	ParentIndex int            `json:"parentIndex"`
+	Position    *hexutil.Big   `json:"position"`
+	Value       common.Hash    `json:"value"`
+	Bond        *hexutil.Big   `json:"bond"`
+	Claimant    common.Address `json:"claimant"`
+	CounteredBy common.Address `json:"counteredBy"`
+}
+
+func (g *recordedGame) Game() types.Game {
+	claims := make([]types.Claim, len(g.Claims))
+	for i, claim := range g.Claims {
+		claims[i] = types.Claim{
+			ClaimData: types.ClaimData{
+				Value:    claim.Value,
+				Bond:     claim.Bond.ToInt(),
+				Position: types.NewPositionFromGIndex(claim.Position.ToInt()),
+			},
+			Claimant:            claim.Claimant,
+			CounteredBy:         claim.CounteredBy,
+			ContractIndex:       i,
+			ParentContractIndex: claim.ParentIndex,
+		}
+	}
+	return types.NewGameState(claims, g.MaxDepth)
+}
+
+func Simulate(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	provider := ctx.String(SimulateProviderFlag.Name)
+	if provider != simulateProviderAlphabet && provider != simulateProviderOutput && provider != simulateProviderCannon {
+		return fmt.Errorf("%w: %v", ErrUnknownProvider, provider)
+	}
+	moves, err := parseAdversaryMoves(ctx.StringSlice(SimulateAdversaryMoveFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	var game *recordedGame
+	if ctx.IsSet(SimulateGameFileFlag.Name) {
+		game, err = loadRecordedGame(ctx.String(SimulateGameFileFlag.Name))
+	} else {
+		game, err = loadGameFromL1(ctx, logger)
+	}
+	if err != nil {
+		return err
+	}
+	if ctx.IsSet(SimulateSaveGameFlag.Name) {
+		if err := saveRecordedGame(ctx.String(SimulateSaveGameFlag.Name), game); err != nil {
+			return err
+		}
+	}
+
+	accessor, closeClients, err := newSimulationTraceAccessor(ctx, logger, provider, game)
+	if err != nil {
+		return err
+	}
+	defer closeClients()
+	sim, err := solver.Simulate(ctx.Context, game.Game(), accessor, moves)
+	if err != nil {
+		return err
+	}
+	return printSimulation(os.Stdout, game, moves, sim, ctx.Bool(VerboseFlag.Name))
+}
+
+func loadGameFromL1(ctx *cli.Context, logger log.Logger) (*recordedGame, error) {
+	rpcUrl := ctx.String(flags.L1EthRpcFlag.Name)
+	if rpcUrl == "" {
+		return nil, fmt.Errorf("missing %v or %v", flags.L1EthRpcFlag.Name, SimulateGameFileFlag.Name)
+	}
+	gameAddr, err := opservice.ParseAddress(ctx.String(GameAddressFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+
+	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
+	contract, err := contracts.NewFaultDisputeGameContract(ctx.Context, contractMetrics.NoopContractMetrics, gameAddr, caller)
+	if err != nil {
+		return nil, err
+	}
+	return recordGame(ctx.Context, contract, l1Client)
+}
+
+type l1HeaderSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*ethTypes.Header, error)
+}
+
+func recordGame(ctx context.Context, contract contracts.FaultDisputeGameContract, l1 l1HeaderSource) (*recordedGame, error) {
+	maxDepth, err := contract.GetMaxGameDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve max depth: %w", err)
+	}
+	splitDepth, err := contract.GetSplitDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve split depth: %w", err)
+	}
+	prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve block range: %w", err)
+	}
+	absolutePrestate, err := contract.GetAbsolutePrestateHash(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve absolute prestate: %w", err)
+	}
+	l1HeadHash, err := contract.GetL1Head(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve L1 head: %w", err)
+	}
+	l1Head, err := l1.HeaderByHash(ctx, l1HeadHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve L1 header: %w", err)
+	}
+	claims, err := contract.GetAllClaims(ctx, rpcblock.Latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve claims: %w", err)
+	}
+	game := &recordedGame{
+		L1Head:           eth.HeaderBlockID(l1Head),
+		PrestateBlock:    prestateBlock,
+		PoststateBlock:   poststateBlock,
+		AbsolutePrestate: absolutePrestate,
+		SplitDepth:       splitDepth,
+		MaxDepth:         maxDepth,
+		Claims:           make([]recordedClaim, len(claims)),
+	}
+	for i, claim := range claims {
+		game.Claims[i] = recordedClaim{
+			ParentIndex: claim.ParentContractIndex,
+			Position:    (*hexutil.Big)(claim.Position.ToGIndex()),
+			Value:       claim.Value,
+			Bond:        (*hexutil.Big)(claim.Bond),
+			Claimant:    claim.Claimant,
+			CounteredBy: claim.CounteredBy,
+		}
+	}
+	return game, nil
+}
+
+func loadRecordedGame(path string) (*recordedGame, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read game file: %w", err)
+	}
+	var game recordedGame
+	if err := json.Unmarshal(data, &game); err != nil {
+		return nil, fmt.Errorf("failed to parse game file: %w", err)
+	}
+	if len(game.Claims) == 0 {
+		return nil, fmt.Errorf("game file %v has no claims", path)
+	}
+	for i, claim := range game.Claims {
+		if claim.Position == nil || claim.Bond == nil {
+			return nil, fmt.Errorf("game file %v has incomplete claim %v", path, i)
+		}
+		if i > 0 && (claim.ParentIndex < 0 || claim.ParentIndex >= i) {
+			return nil, fmt.Errorf("game file %v has invalid parent index for claim %v", path, i)
+		}
+	}
+	return &game, nil
+}
+
+func saveRecordedGame(path string, game *recordedGame) error {
+	data, err := json.MarshalIndent(game, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode game: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write game file: %w", err)
+	}
+	return nil
+}
+
+// newSimulationTraceAccessor creates the trace accessor for the provider, and a function to close the RPC clients
+// it uses once the simulation is complete.
+func newSimulationTraceAccessor(ctx *cli.Context, logger log.Logger, provider string, game *recordedGame) (types.TraceAccessor, func(), error) {
+	if provider == simulateProviderAlphabet {
+		return trace.NewSimpleTraceAccessor(alphabet.NewTraceProvider(new(big.Int).SetUint64(game.PrestateBlock), game.MaxDepth)), func() {}, nil
+	}
+
+	rollupRpc := ctx.String(flags.RollupRpcFlag.Name)
+	l2Rpc := ctx.String(flags.L2EthRpcFlag.Name)
+	if rollupRpc == "" || l2Rpc == "" {
+		return nil, nil, fmt.Errorf("flags %v and %v are required for the %v provider", flags.RollupRpcFlag.Name, flags.L2EthRpcFlag.Name, provider)
+	}
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rollupRpc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial rollup client: %w", err)
+	}
+	l2Client, err := ethclient.DialContext(ctx.Context, l2Rpc)
+	if err != nil {
+		rollupClient.Close()
+		return nil, nil, fmt.Errorf("failed to dial l2 client %v: %w", l2Rpc, err)
+	}
+	closeClients := func() {
+		rollupClient.Close()
+		l2Client.Close()
+	}
+	accessor, err := newOutputSimulationTraceAccessor(ctx, logger, provider, game, rollupClient, l2Client)
+	if err != nil {
+		closeClients()
+		return nil, nil, err
+	}
+	return accessor, closeClients, nil
+}
+
+func newOutputSimulationTraceAccessor(ctx *cli.Context, logger log.Logger, provider string, game *recordedGame, rollupClient *sources.RollupClient, l2Client *ethclient.Client) (types.TraceAccessor, error) {
+	prestateProvider := outputs.NewPrestateProvider(rollupClient, game.PrestateBlock)
+	if provider == simulateProviderOutput {
+		return outputs.NewOutputAlphabetTraceAccessor(logger, metrics.NoopMetrics, prestateProvider, rollupClient, l2Client, game.L1Head, game.SplitDepth, game.PrestateBlock, game.PoststateBlock)
+	}
+
+	if err := flags.CheckCannonFlags(ctx); err != nil {
+		return nil, err
+	}
+	cfg, err := flags.NewConfigFromCLI(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(cfg.Datadir, "simulate")
+	stateConverter := cannon.NewStateConverter(cfg.Cannon)
+	prestateSource := prestates.NewPrestateSource(cfg.CannonAbsolutePreStateBaseURL, cfg.CannonAbsolutePreState, filepath.Join(dir, "prestates"), stateConverter)
+	prestate, err := prestateSource.PrestatePath(ctx.Context, game.AbsolutePrestate)
+	if err != nil {
+		return nil, fmt.Errorf("required prestate %v not available: %w", game.AbsolutePrestate, err)
+	}
+	return outputs.NewOutputCannonTraceAccessor(logger, metrics.NoopMetrics, cfg.Cannon, vm.NewOpProgramServerExecutor(logger), l2Client, prestateProvider, prestate, rollupClient, dir, nil, game.L1Head, game.SplitDepth, game.PrestateBlock, game.PoststateBlock)
+}
+
+func parseAdversaryMoves(args []string) ([]solver.AdversaryMove, error) {
+	moves := make([]solver.AdversaryMove, len(args))
+	for i, arg := range args {
+		move, err := parseAdversaryMove(arg)
+		if err != nil {
+			return nil, err
+		}
+		moves[i] = move
+	}
+	return moves, nil
+}
+
+func parseAdversaryMove(arg string) (solver.AdversaryMove, error) {
+	opts := strings.Split(arg, ":")
+	if len(opts) < 2 {
+		return solver.AdversaryMove{}, fmt.Errorf("%w %q: expected parentIdx:type[:value]", solver.ErrInvalidAdversaryMove, arg)
+	}
+	parentIdx, err := strconv.Atoi(opts[0])
+	if err != nil || parentIdx < 0 {
+		return solver.AdversaryMove{}, fmt.Errorf("%w %q: invalid parent index %q", solver.ErrInvalidAdversaryMove, arg, opts[0])
+	}
+	move := solver.AdversaryMove{ParentIdx: parentIdx}
+	switch opts[1] {
+	case "step":
+		if len(opts) != 2 {
+			return solver.AdversaryMove{}, fmt.Errorf("%w %q: step does not take a value", solver.ErrInvalidAdversaryMove, arg)
+		}
+		move.Step = true
+		return move, nil
+	case "attack":
+		move.IsAttack = true
+	case "defend":
+	default:
+		return solver.AdversaryMove{}, fmt.Errorf("%w %q: unknown move type %q", solver.ErrInvalidAdversaryMove, arg, opts[1])
+	}
+	if len(opts) != 3 {
+		return solver.AdversaryMove{}, fmt.Errorf("%w %q: %v requires a value", solver.ErrInvalidAdversaryMove, arg, opts[1])
+	}
+	value, err := hexutil.Decode(opts[2])
+	if err != nil || len(value) != common.HashLength {
+		return solver.AdversaryMove{}, fmt.Errorf("%w %q: invalid value %q", solver.ErrInvalidAdversaryMove, arg, opts[2])
+	}
+	move.Value = common.BytesToHash(value)
+	return move, nil
+}
+
+func printSimulation(out io.Writer, game *recordedGame, moves []solver.AdversaryMove, sim *solver.Simulation, verbose bool) error {
+	valueFormat := "%-14v"
+	if verbose {
+		valueFormat = "%-66v"
+	}
+	formatValue := func(value common.Hash) string {
+		if verbose {
+			return value.Hex()
+		}
+		return value.TerminalString()
+	}
+
+	var info strings.Builder
+	fmt.Fprintf(&info, "Split Depth: %v • Max Depth: %v • Claim Count: %v • Adversary Moves: %v\n\n",
+		game.SplitDepth, game.MaxDepth, len(game.Claims), len(moves))
+
+	actionFormat := "%5v %-26v %6v %7v " + valueFormat + " %v\n"
+	fmt.Fprintf(&info, "Challenger Actions:\n")
+	fmt.Fprintf(&info, actionFormat, "Round", "Type", "Parent", "Attack", "Value", "Rules")
+	for _, simAction := range sim.Actions {
+		action := simAction.Action
+		parent := strconv.Itoa(action.ParentClaim.ContractIndex)
+		value := formatValue(action.Value)
+		if action.Type == types.ActionTypeChallengeL2BlockNumber {
+			parent = "-"
+			value = "-"
+		} else if action.Type == types.ActionTypeStep {
+			value = "-"
+		}
+		rules := "✅"
+		if simAction.RuleViolations != nil {
+			rules = "❌ " + strings.ReplaceAll(simAction.RuleViolations.Error(), "\n", "; ")
+		}
+		fmt.Fprintf(&info, actionFormat, simAction.Round, action.Type, parent, action.IsAttack, value, rules)
+	}
+	if len(sim.Actions) == 0 {
+		fmt.Fprintf(&info, "None\n")
+	}
+
+	subgameFormat := "%3v %-7v %6v %5v " + valueFormat + " %-42v %-9v %v\n"
+	fmt.Fprintf(&info, "\nSubgame Resolution:\n")
+	fmt.Fprintf(&info, subgameFormat, "Idx", "Move", "Parent", "Depth", "Value", "Claimant", "Correct", "Resolution")
+	for i, subgame := range sim.Subgames {
+		claim := subgame.Claim
+		parent := strconv.Itoa(claim.ParentContractIndex)
+		move := "Attack"
+		if claim.IsRoot() {
+			parent = "-"
+			move = "-"
+		} else if sim.Game.DefendsParent(claim) {
+			move = "Defend"
+		}
+		correct := "✅"
+		if !subgame.Correct {
+			correct = "❌"
+		}
+		resolution := "✅ Uncountered"
+		if subgame.Countered() {
+			resolution = "❌ " + describeClaimant(subgame.CounteredBy)
+		}
+		fmt.Fprintf(&info, subgameFormat, i, move, parent, claim.Depth(), formatValue(claim.Value), describeClaimant(claim.Claimant), correct, resolution)
+	}
+
+	result := "Defender Wins"
+	if sim.RootCountered() {
+		result = "Challenger Wins"
+	}
+	fmt.Fprintf(&info, "\nResult: %v\n", result)
+	_, err := io.WriteString(out, info.String())
+	return err
+}
+
+func describeClaimant(addr common.Address) string {
+	switch addr {
+	case solver.ChallengerAddr:
+		return "simulated challenger"
+	case solver.AdversaryAddr:
+		return "simulated adversary"
+	default:
+		return addr.Hex()
+	}
+}
+
+func simulateFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		GameAddressFlag,
+		VerboseFlag,
+		SimulateGameFileFlag,
+		SimulateSaveGameFlag,
+		SimulateProviderFlag,
+		SimulateAdversaryMoveFlag,
+	}
+	// The challenger flags configure the cannon provider and the RPCs used by the output provider.
+	return append(cliFlags, flags.Flags...)
+}
+
+var SimulateCommand = &cli.Command{
+	Name:        "simulate",
+	Usage:       "Simulates the honest challenger's responses to a dispute game without sending transactions",
+	Description: "Loads a game from L1 or a recorded game file, applies any hypothetical adversary moves and prints the actions the challenger would take and how each subgame would resolve",
+	Action:      Interruptible(Simulate),
+	Flags:       simulateFlags(),
+}
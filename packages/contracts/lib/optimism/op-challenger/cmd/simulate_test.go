@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	faulttest "github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAdversaryMove(t *testing.T) {
+	value := common.Hash{0xaa}
+	tests := []struct {
+		arg      string
+		expected solver.AdversaryMove
+		err      error
+	}{
+		{arg: "0:attack:" + value.Hex(), expected: solver.AdversaryMove{ParentIdx: 0, IsAttack: true, Value: value}},
+		{arg: "3:defend:" + value.Hex(), expected: solver.AdversaryMove{ParentIdx: 3, Value: value}},
+		{arg: "5:step", expected: solver.AdversaryMove{ParentIdx: 5, Step: true}},
+		{arg: "5", err: solver.ErrInvalidAdversaryMove},
+		{arg: "-1:attack:" + value.Hex(), err: solver.ErrInvalidAdversaryMove},
+		{arg: "a:attack:" + value.Hex(), err: solver.ErrInvalidAdversaryMove},
+		{arg: "1:bisect:" + value.Hex(), err: solver.ErrInvalidAdversaryMove},
+		{arg: "1:attack", err: solver.ErrInvalidAdversaryMove},
+		{arg: "1:attack:0x1234", err: solver.ErrInvalidAdversaryMove},
+		{arg: "1:step:" + value.Hex(), err: solver.ErrInvalidAdversaryMove},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.arg, func(t *testing.T) {
+			actual, err := parseAdversaryMove(test.arg)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestRecordedGame(t *testing.T) {
+	maxDepth := types.Depth(4)
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(10), maxDepth)
+	builder := claimBuilder.GameBuilder(faulttest.WithInvalidValue(true))
+	builder.Seq().Attack().Defend(faulttest.WithInvalidValue(true))
+
+	game := &recordedGame{
+		PrestateBlock:  10,
+		PoststateBlock: 20,
+		SplitDepth:     2,
+		MaxDepth:       maxDepth,
+	}
+	for _, claim := range builder.Game.Claims() {
+		game.Claims = append(game.Claims, recordedClaim{
+			ParentIndex: claim.ParentContractIndex,
+			Position:    (*hexutil.Big)(claim.Position.ToGIndex()),
+			Value:       claim.Value,
+			Bond:        (*hexutil.Big)(big.NewInt(1)),
+			Claimant:    claim.Claimant,
+			CounteredBy: claim.CounteredBy,
+		})
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "game.json")
+		require.NoError(t, saveRecordedGame(path, game))
+		loaded, err := loadRecordedGame(path)
+		require.NoError(t, err)
+		require.Equal(t, game, loaded)
+		for i, claim := range loaded.Game().Claims() {
+			expected := builder.Game.Claims()[i]
+			require.Equal(t, expected.Position.ToGIndex(), claim.Position.ToGIndex())
+			require.Equal(t, expected.Value, claim.Value)
+			require.Equal(t, expected.ParentContractIndex, claim.ParentContractIndex)
+			require.Equal(t, i, claim.ContractIndex)
+		}
+	})
+
+	t.Run("PrintSimulation", func(t *testing.T) {
+		accessor := trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider())
+		moves := []solver.AdversaryMove{{ParentIdx: 1, IsAttack: true, Value: common.Hash{0xbb}}}
+		sim, err := solver.Simulate(context.Background(), game.Game(), accessor, moves)
+		require.NoError(t, err)
+		var out bytes.Buffer
+		require.NoError(t, printSimulation(&out, game, moves, sim, false))
+		require.Contains(t, out.String(), "Adversary Moves: 1")
+		require.Contains(t, out.String(), "simulated adversary")
+		require.Contains(t, out.String(), "Result: Challenger Wins")
+	})
+
+	t.Run("InvalidParent", func(t *testing.T) {
+		invalid := *game
+		invalid.Claims = append([]recordedClaim{}, game.Claims...)
+		invalid.Claims[1].ParentIndex = 1
+		path := filepath.Join(t.TempDir(), "game.json")
+		require.NoError(t, saveRecordedGame(path, &invalid))
+		_, err := loadRecordedGame(path)
+		require.ErrorContains(t, err, "invalid parent index")
+	})
+}
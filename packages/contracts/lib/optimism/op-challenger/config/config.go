@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"slices"
@@ -15,12 +16,15 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 var (
 	ErrMissingTraceType              = errors.New("no supported trace types specified")
 	ErrMissingDatadir                = errors.New("missing datadir")
 	ErrMaxConcurrencyZero            = errors.New("max concurrency must not be 0")
+	ErrMissingBondSweepThreshold     = errors.New("missing bond sweep threshold")
+	ErrNegativeBondSweepThreshold    = errors.New("bond sweep threshold must not be negative")
 	ErrMissingL2Rpc                  = errors.New("missing L2 rpc url")
 	ErrMissingCannonBin              = errors.New("missing cannon bin")
 	ErrMissingCannonServer           = errors.New("missing cannon server")
@@ -75,6 +79,9 @@ const (
 	DefaultMaxPendingTx = 10
 )
 
+// DefaultBondSweepThreshold is the default balance the challenger keeps before sweeping funds to the bond treasury.
+var DefaultBondSweepThreshold = new(big.Int).Mul(big.NewInt(10), big.NewInt(params.Ether))
+
 // Config is a well typed config that is parsed from the CLI params.
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
@@ -93,6 +100,9 @@ type Config struct {
 
 	SelectiveClaimResolution bool // Whether to only resolve claims for the claimants in AdditionalBondClaimants union [TxSender.From()]
 
+	BondTreasury       common.Address // Address to sweep funds above BondSweepThreshold to (zero address == disabled)
+	BondSweepThreshold *big.Int       // Balance in wei the tx manager sender keeps when sweeping funds to BondTreasury
+
 	TraceTypes []types.TraceType // Type of traces supported
 
 	RollupRpc string // L2 Rollup RPC Url
@@ -114,6 +124,8 @@ type Config struct {
 
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
+	TraceCacheMaxSize uint64 // Maximum size in bytes of the trace cache shared between games (0 == disabled)
+
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -128,6 +140,10 @@ func NewConfig(
 	datadir string,
 	supportedTraceTypes ...types.TraceType,
 ) Config {
+	defaultRemote := vm.RemoteConfig{
+		Timeout:  vm.DefaultWorkerTimeout,
+		Attempts: vm.DefaultWorkerAttempts,
+	}
 	return Config{
 		L1EthRpc:           l1EthRpc,
 		L1Beacon:           l1BeaconApi,
@@ -141,6 +157,8 @@ func NewConfig(
 
 		MaxPendingTx: DefaultMaxPendingTx,
 
+		BondSweepThreshold: new(big.Int).Set(DefaultBondSweepThreshold),
+
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
@@ -156,6 +174,7 @@ func NewConfig(
 			InfoFreq:        DefaultCannonInfoFreq,
 			DebugInfo:       true,
 			BinarySnapshots: true,
+			Remote:          defaultRemote,
 		},
 		Asterisc: vm.Config{
 			VmType:          types.TraceTypeAsterisc,
@@ -165,6 +184,7 @@ func NewConfig(
 			SnapshotFreq:    DefaultAsteriscSnapshotFreq,
 			InfoFreq:        DefaultAsteriscInfoFreq,
 			BinarySnapshots: true,
+			Remote:          defaultRemote,
 		},
 		AsteriscKona: vm.Config{
 			VmType:          types.TraceTypeAsteriscKona,
@@ -174,6 +194,7 @@ func NewConfig(
 			SnapshotFreq:    DefaultAsteriscSnapshotFreq,
 			InfoFreq:        DefaultAsteriscInfoFreq,
 			BinarySnapshots: true,
+			Remote:          defaultRemote,
 		},
 		GameWindow: DefaultGameWindow,
 	}
@@ -208,6 +229,14 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	if c.BondTreasury != (common.Address{}) {
+		if c.BondSweepThreshold == nil {
+			return ErrMissingBondSweepThreshold
+		}
+		if c.BondSweepThreshold.Sign() < 0 {
+			return ErrNegativeBondSweepThreshold
+		}
+	}
 	if c.TraceTypeEnabled(types.TraceTypeCannon) || c.TraceTypeEnabled(types.TraceTypePermissioned) {
 		if c.Cannon.VmBin == "" {
 			return ErrMissingCannonBin
@@ -242,6 +271,9 @@ func (c Config) Check() error {
 		if c.Cannon.InfoFreq == 0 {
 			return ErrMissingCannonInfoFreq
 		}
+		if err := c.Cannon.Remote.Check(); err != nil {
+			return err
+		}
 	}
 	if c.TraceTypeEnabled(types.TraceTypeAsterisc) {
 		if c.Asterisc.VmBin == "" {
@@ -277,6 +309,9 @@ func (c Config) Check() error {
 		if c.Asterisc.InfoFreq == 0 {
 			return ErrMissingAsteriscInfoFreq
 		}
+		if err := c.Asterisc.Remote.Check(); err != nil {
+			return err
+		}
 	}
 	if c.TraceTypeEnabled(types.TraceTypeAsteriscKona) {
 		if c.AsteriscKona.VmBin == "" {
@@ -312,6 +347,9 @@ func (c Config) Check() error {
 		if c.AsteriscKona.InfoFreq == 0 {
 			return ErrMissingAsteriscKonaInfoFreq
 		}
+		if err := c.AsteriscKona.Remote.Check(); err != nil {
+			return err
+		}
 	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
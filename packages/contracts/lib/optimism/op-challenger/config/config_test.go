@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
@@ -482,6 +484,33 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestBondSweepThreshold(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		require.Equal(t, DefaultBondSweepThreshold, config.BondSweepThreshold)
+	})
+
+	t.Run("NotRequiredWithoutTreasury", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.BondSweepThreshold = nil
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("RequiredWithTreasury", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.BondTreasury = common.Address{0xaa}
+		config.BondSweepThreshold = nil
+		require.ErrorIs(t, config.Check(), ErrMissingBondSweepThreshold)
+	})
+
+	t.Run("MustNotBeNegative", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.BondTreasury = common.Address{0xaa}
+		config.BondSweepThreshold = big.NewInt(-1)
+		require.ErrorIs(t, config.Check(), ErrNegativeBondSweepThreshold)
+	})
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(types.TraceTypeAlphabet)
@@ -564,3 +593,41 @@ func TestRequireConfigForMultipleTraceTypesForCannonAndAsterisc(t *testing.T) {
 	// Check final config is valid
 	require.NoError(t, cfg.Check())
 }
+
+func TestProofWorkers(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(types.TraceTypeCannon)
+		cfg.Cannon.Remote.Workers = []string{"http://worker:8545"}
+		cfg.Cannon.Remote.AuthToken = "secret"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("AuthTokenRequired", func(t *testing.T) {
+		cfg := validConfig(types.TraceTypeCannon)
+		cfg.Cannon.Remote.Workers = []string{"http://worker:8545"}
+		require.ErrorIs(t, cfg.Check(), vm.ErrProofWorkerAuthTokenMissing)
+	})
+
+	t.Run("TimeoutRequired", func(t *testing.T) {
+		cfg := validConfig(types.TraceTypeCannon)
+		cfg.Cannon.Remote.Workers = []string{"http://worker:8545"}
+		cfg.Cannon.Remote.AuthToken = "secret"
+		cfg.Cannon.Remote.Timeout = 0
+		require.ErrorIs(t, cfg.Check(), vm.ErrProofWorkerTimeoutZero)
+	})
+
+	t.Run("AttemptsRequired", func(t *testing.T) {
+		cfg := validConfig(types.TraceTypeAsterisc)
+		cfg.Asterisc.Remote.Workers = []string{"http://worker:8545"}
+		cfg.Asterisc.Remote.AuthToken = "secret"
+		cfg.Asterisc.Remote.Attempts = 0
+		require.ErrorIs(t, cfg.Check(), vm.ErrProofWorkerAttemptsZero)
+	})
+
+	t.Run("NotRequiredWithoutWorkers", func(t *testing.T) {
+		cfg := validConfig(types.TraceTypeAsteriscKona)
+		cfg.AsteriscKona.Remote.Timeout = 0
+		cfg.AsteriscKona.Remote.Attempts = 0
+		require.NoError(t, cfg.Check())
+	})
+}
@@ -1,7 +1,9 @@
 package flags
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"slices"
@@ -13,6 +15,7 @@ import (
 	"github.com/ethereum-optimism/superchain-registry/superchain"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
@@ -90,6 +93,35 @@ var (
 		Value:   config.DefaultMaxPendingTx,
 		EnvVars: prefixEnvVars("MAX_PENDING_TX"),
 	}
+	TraceCacheMaxSizeFlag = &cli.Uint64Flag{
+		Name: "trace-cache-max-size-mb",
+		Usage: "Maximum size in MiB of the on-disk cache of VM executions shared between games. " +
+			"Games that dispute the same outputs reuse cached proofs instead of re-executing the VM. 0 to disable.",
+		EnvVars: prefixEnvVars("TRACE_CACHE_MAX_SIZE_MB"),
+	}
+	ProofWorkersFlag = &cli.StringSliceFlag{
+		Name: "proof-workers",
+		Usage: "HTTP URLs of proof workers to generate cannon and asterisc proofs on instead of running the VM locally. " +
+			"Workers are run with the proof-worker subcommand.",
+		EnvVars: prefixEnvVars("PROOF_WORKERS"),
+	}
+	ProofWorkerAuthTokenFlag = &cli.StringFlag{
+		Name:    "proof-worker-auth-token",
+		Usage:   "Token used to authenticate requests to proof workers. Workers must be started with the same token.",
+		EnvVars: prefixEnvVars("PROOF_WORKER_AUTH_TOKEN"),
+	}
+	ProofWorkerTimeoutFlag = &cli.DurationFlag{
+		Name:    "proof-worker-timeout",
+		Usage:   "Maximum time to wait for a proof worker to generate a single proof.",
+		EnvVars: prefixEnvVars("PROOF_WORKER_TIMEOUT"),
+		Value:   vm.DefaultWorkerTimeout,
+	}
+	ProofWorkerAttemptsFlag = &cli.UintFlag{
+		Name:    "proof-worker-attempts",
+		Usage:   "Maximum number of failed attempts to generate a proof on proof workers before giving up.",
+		EnvVars: prefixEnvVars("PROOF_WORKER_ATTEMPTS"),
+		Value:   vm.DefaultWorkerAttempts,
+	}
 	HTTPPollInterval = &cli.DurationFlag{
 		Name:    "http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider.",
@@ -101,6 +133,17 @@ var (
 		Usage:   "List of addresses to claim bonds for, in addition to the configured transaction sender",
 		EnvVars: prefixEnvVars("ADDITIONAL_BOND_CLAIMANTS"),
 	}
+	BondTreasuryFlag = &cli.StringFlag{
+		Name:    "bond-treasury",
+		Usage:   "Address to sweep the transaction sender's balance above the bond sweep threshold to. Sweeping is disabled if not set.",
+		EnvVars: prefixEnvVars("BOND_TREASURY"),
+	}
+	BondSweepThresholdFlag = &cli.StringFlag{
+		Name:    "bond-sweep-threshold",
+		Usage:   "Balance in ETH the transaction sender keeps when sweeping funds to the bond treasury. Everything above it is swept, so it must cover the bonds and gas needed to keep playing games.",
+		EnvVars: prefixEnvVars("BOND_SWEEP_THRESHOLD"),
+		Value:   "10",
+	}
 	PreStatesURLFlag = NewVMFlag("prestates-url", EnvVarPrefix, faultDisputeVMs, func(name string, envVars []string, traceTypeInfo string) cli.Flag {
 		return &cli.StringFlag{
 			Name: name,
@@ -151,6 +194,11 @@ var (
 		EnvVars: prefixEnvVars("CANNON_SNAPSHOT_FREQ"),
 		Value:   config.DefaultCannonSnapshotFreq,
 	}
+	CannonSnapshotMaxDeltaFlag = &cli.UintFlag{
+		Name:    "cannon-snapshot-max-delta",
+		Usage:   "Maximum number of delta cannon snapshots, containing only the memory changed since the previous snapshot, to generate between full snapshots. Only full snapshots are generated if 0 (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_SNAPSHOT_MAX_DELTA"),
+	}
 	CannonInfoFreqFlag = &cli.UintFlag{
 		Name:    "cannon-info-freq",
 		Usage:   "Frequency of cannon info log messages to generate in VM steps (cannon trace type only)",
@@ -245,8 +293,15 @@ var optionalFlags = []cli.Flag{
 	MaxConcurrencyFlag,
 	L2EthRpcFlag,
 	MaxPendingTransactionsFlag,
+	TraceCacheMaxSizeFlag,
+	ProofWorkersFlag,
+	ProofWorkerAuthTokenFlag,
+	ProofWorkerTimeoutFlag,
+	ProofWorkerAttemptsFlag,
 	HTTPPollInterval,
 	AdditionalBondClaimants,
+	BondTreasuryFlag,
+	BondSweepThresholdFlag,
 	GameAllowlistFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
@@ -256,6 +311,7 @@ var optionalFlags = []cli.Flag{
 	CannonPreStateFlag,
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
+	CannonSnapshotMaxDeltaFlag,
 	CannonInfoFreqFlag,
 	AsteriscNetworkFlag,
 	AsteriscRollupConfigFlag,
@@ -395,6 +451,10 @@ func CheckRequired(ctx *cli.Context, traceTypes []types.TraceType) error {
 			}
 		case types.TraceTypeAlphabet, types.TraceTypeFast:
 		default:
+			// Game type extensions check their own configuration when they are registered
+			if types.IsExtensionTraceType(traceType) {
+				continue
+			}
 			return fmt.Errorf("invalid trace type %v. must be one of %v", traceType, types.TraceTypes)
 		}
 	}
@@ -430,6 +490,22 @@ func getL2Rpc(ctx *cli.Context, logger log.Logger) (string, error) {
 	return l2Rpc, nil
 }
 
+// parseEther parses a decimal amount of ETH, returning the amount in wei.
+func parseEther(value string) (*big.Int, error) {
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid ETH amount: %v", value)
+	}
+	amount.Mul(amount, new(big.Rat).SetInt64(params.Ether))
+	if !amount.IsInt() {
+		return nil, fmt.Errorf("ETH amount has more than 18 decimals: %v", value)
+	}
+	if amount.Sign() < 0 {
+		return nil, errors.New("ETH amount must not be negative")
+	}
+	return new(big.Int).Set(amount.Num()), nil
+}
+
 func FactoryAddress(ctx *cli.Context) (common.Address, error) {
 	// Use FactoryAddressFlag in preference to Network. Allows overriding the default dispute game factory.
 	if ctx.IsSet(FactoryAddressFlag.Name) {
@@ -503,6 +579,17 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			claimants = append(claimants, claimant)
 		}
 	}
+	var bondTreasury common.Address
+	if ctx.IsSet(BondTreasuryFlag.Name) {
+		bondTreasury, err = opservice.ParseAddress(ctx.String(BondTreasuryFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", BondTreasuryFlag.Name, err)
+		}
+	}
+	bondSweepThreshold, err := parseEther(ctx.String(BondSweepThresholdFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %w", BondSweepThresholdFlag.Name, err)
+	}
 	var cannonPreStatesURL *url.URL
 	if PreStatesURLFlag.IsSet(ctx, types.TraceTypeCannon) {
 		val := PreStatesURLFlag.String(ctx, types.TraceTypeCannon)
@@ -541,6 +628,12 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	}
 	l1EthRpc := ctx.String(L1EthRpcFlag.Name)
 	l1Beacon := ctx.String(L1BeaconFlag.Name)
+	remote := vm.RemoteConfig{
+		Workers:   ctx.StringSlice(ProofWorkersFlag.Name),
+		AuthToken: ctx.String(ProofWorkerAuthTokenFlag.Name),
+		Timeout:   ctx.Duration(ProofWorkerTimeoutFlag.Name),
+		Attempts:  ctx.Uint(ProofWorkerAttemptsFlag.Name),
+	}
 	return &config.Config{
 		// Required Flags
 		L1EthRpc:                l1EthRpc,
@@ -552,8 +645,11 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		MaxConcurrency:          maxConcurrency,
 		L2Rpc:                   l2Rpc,
 		MaxPendingTx:            ctx.Uint64(MaxPendingTransactionsFlag.Name),
+		TraceCacheMaxSize:       ctx.Uint64(TraceCacheMaxSizeFlag.Name) * 1024 * 1024,
 		PollInterval:            ctx.Duration(HTTPPollInterval.Name),
 		AdditionalBondClaimants: claimants,
+		BondTreasury:            bondTreasury,
+		BondSweepThreshold:      bondSweepThreshold,
 		RollupRpc:               ctx.String(RollupRpcFlag.Name),
 		Cannon: vm.Config{
 			VmType:           types.TraceTypeCannon,
@@ -566,9 +662,11 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			RollupConfigPath: ctx.String(CannonRollupConfigFlag.Name),
 			L2GenesisPath:    ctx.String(CannonL2GenesisFlag.Name),
 			SnapshotFreq:     ctx.Uint(CannonSnapshotFreqFlag.Name),
+			SnapshotMaxDelta: ctx.Uint(CannonSnapshotMaxDeltaFlag.Name),
 			InfoFreq:         ctx.Uint(CannonInfoFreqFlag.Name),
 			DebugInfo:        true,
 			BinarySnapshots:  true,
+			Remote:           remote,
 		},
 		CannonAbsolutePreState:        ctx.String(CannonPreStateFlag.Name),
 		CannonAbsolutePreStateBaseURL: cannonPreStatesURL,
@@ -586,6 +684,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			SnapshotFreq:     ctx.Uint(AsteriscSnapshotFreqFlag.Name),
 			InfoFreq:         ctx.Uint(AsteriscInfoFreqFlag.Name),
 			BinarySnapshots:  true,
+			Remote:           remote,
 		},
 		AsteriscAbsolutePreState:        ctx.String(AsteriscPreStateFlag.Name),
 		AsteriscAbsolutePreStateBaseURL: asteriscPreStatesURL,
@@ -602,6 +701,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 			SnapshotFreq:     ctx.Uint(AsteriscSnapshotFreqFlag.Name),
 			InfoFreq:         ctx.Uint(AsteriscInfoFreqFlag.Name),
 			BinarySnapshots:  true,
+			Remote:           remote,
 		},
 		AsteriscKonaAbsolutePreState:        ctx.String(AsteriscKonaPreStateFlag.Name),
 		AsteriscKonaAbsolutePreStateBaseURL: asteriscKonaPreStatesURL,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"slices"
 	"sync"
 	"time"
@@ -45,6 +46,7 @@ type Agent struct {
 	maxDepth         types.Depth
 	maxClockDuration time.Duration
 	log              log.Logger
+	urgency          gameTypes.Urgency
 }
 
 func NewAgent(
@@ -77,6 +79,7 @@ func NewAgent(
 
 // Act iterates the game & performs all of the next actions.
 func (a *Agent) Act(ctx context.Context) error {
+	a.urgency = gameTypes.Urgency{}
 	if a.tryResolve(ctx) {
 		return nil
 	}
@@ -102,6 +105,7 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		a.log.Error("Failed to calculate all required moves", "err", err)
 	}
+	a.recordUrgency(game, actions)
 
 	var wg sync.WaitGroup
 	wg.Add(len(actions))
@@ -112,6 +116,47 @@ func (a *Agent) Act(ctx context.Context) error {
 	return nil
 }
 
+// Urgency returns the urgency of the actions required the last time Act was called.
+func (a *Agent) Urgency() gameTypes.Urgency {
+	return a.urgency
+}
+
+// recordUrgency records the clock headroom of each action and the resulting urgency of the game.
+// Actions are performed concurrently so the urgency is used to order games rather than actions within a game.
+func (a *Agent) recordUrgency(game types.Game, actions []types.Action) {
+	if len(actions) == 0 {
+		return
+	}
+	now := a.l1Clock.Now()
+	minHeadroom := a.clockHeadroom(game, now, actions[0])
+	bondAtRisk := new(big.Int)
+	for _, action := range actions {
+		headroom := a.clockHeadroom(game, now, action)
+		a.metrics.RecordActionClockHeadroom(action.Type.String(), max(headroom, 0))
+		minHeadroom = min(minHeadroom, headroom)
+		if bond := a.counteredClaim(game, action).Bond; bond != nil {
+			bondAtRisk.Add(bondAtRisk, bond)
+		}
+	}
+	a.urgency = gameTypes.Urgency{
+		Deadline:   now.Add(minHeadroom),
+		BondAtRisk: bondAtRisk,
+	}
+}
+
+// clockHeadroom returns the time remaining on the chess clock to perform the action before the claim it
+// counters can no longer be countered.
+func (a *Agent) clockHeadroom(game types.Game, now time.Time, action types.Action) time.Duration {
+	return a.maxClockDuration - game.ChessClock(now, a.counteredClaim(game, action))
+}
+
+func (a *Agent) counteredClaim(game types.Game, action types.Action) types.Claim {
+	if action.Type == types.ActionTypeChallengeL2BlockNumber {
+		return game.Claims()[0]
+	}
+	return action.ParentClaim
+}
+
 func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action types.Action) {
 	defer wg.Done()
 	actionLog := a.log.New("action", action.Type)
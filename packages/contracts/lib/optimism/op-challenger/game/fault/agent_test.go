@@ -194,6 +194,39 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestAgent_Urgency(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+
+	t.Run("NoActionsRequired", func(t *testing.T) {
+		claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim()}
+		require.NoError(t, agent.Act(context.Background()))
+		require.False(t, agent.Urgency().ActionsRequired())
+	})
+
+	t.Run("EarliestDeadline", func(t *testing.T) {
+		rootTime := l1Time.Add(-2 * time.Minute)
+		root := claimBuilder.CreateRootClaim(test.WithClock(rootTime, 0))
+		laterAttack := claimBuilder.AttackClaim(root, test.WithInvalidValue(true), test.WithClock(rootTime.Add(time.Minute), time.Minute))
+		laterAttack.ContractIndex = 1
+		laterAttack.Bond = big.NewInt(5)
+		earlyAttack := claimBuilder.AttackClaim(root, test.WithInvalidValue(true), test.WithClock(rootTime.Add(10*time.Second), 10*time.Second))
+		earlyAttack.ContractIndex = 2
+		earlyAttack.Bond = big.NewInt(7)
+		claimLoader.claims = []types.Claim{root, laterAttack, earlyAttack}
+
+		require.NoError(t, agent.Act(context.Background()))
+		urgency := agent.Urgency()
+		require.True(t, urgency.ActionsRequired())
+		// Chess clock of the earliest attack has 110s elapsed out of the max 3 minutes
+		require.Equal(t, l1Time.Add(70*time.Second), urgency.Deadline)
+		require.Equal(t, big.NewInt(12), urgency.BondAtRisk)
+	})
+}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LevelInfo)
 	claimLoader := &stubClaimLoader{}
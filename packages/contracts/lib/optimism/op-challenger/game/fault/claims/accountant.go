@@ -0,0 +1,271 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrUnexpectedWithdrawals = errors.New("unexpected number of WETH withdrawal requests")
+
+const (
+	LiquidityAvailable    = "available"
+	LiquidityWithdrawable = "withdrawable"
+	LiquidityLocked       = "locked"
+)
+
+// LiquidityHorizons are the times in the future that projected liquidity is reported for.
+var LiquidityHorizons = map[string]time.Duration{
+	"1h":  time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+}
+
+type AccountantMetrics interface {
+	RecordBondLiquidity(state string, amount *big.Int)
+	RecordProjectedLiquidity(horizon string, amount *big.Int)
+	RecordFundsSwept(amount *big.Int)
+}
+
+type AccountTxSender interface {
+	TxSender
+	From() common.Address
+}
+
+type WETHContract interface {
+	GetBalanceAndDelay(ctx context.Context, block rpcblock.Block) (*big.Int, time.Duration, error)
+	GetWithdrawals(ctx context.Context, block rpcblock.Block, owner common.Address, recipients ...common.Address) ([]*contracts.WithdrawalRequest, error)
+	BalanceOf(ctx context.Context, block rpcblock.Block, addr common.Address) (*big.Int, error)
+	UnlockTx(guy common.Address, amount *big.Int) (txmgr.TxCandidate, error)
+	WithdrawTx(guy common.Address, amount *big.Int) (txmgr.TxCandidate, error)
+}
+
+type WETHContractCreator func(addr common.Address) WETHContract
+
+type BalanceFetcher func(ctx context.Context, addr common.Address) (*big.Int, error)
+
+// Accountant manages the challenger's funds after bonds are claimed. It withdraws WETH held by the challenger in
+// DelayedWETH contracts by unlocking it and withdrawing once the delay has passed, sweeps funds above a threshold to
+// a treasury address and reports the challenger's projected liquidity.
+type Accountant struct {
+	logger      log.Logger
+	metrics     AccountantMetrics
+	clock       RClock
+	ledger      *Ledger
+	claimer     BondClaimer
+	wethCreator WETHContractCreator
+	balance     BalanceFetcher
+	txSender    AccountTxSender
+
+	treasury       common.Address
+	sweepThreshold *big.Int
+}
+
+var _ BondClaimer = (*Accountant)(nil)
+
+// NewAccountant creates an Accountant that runs after bonds are claimed by claimer.
+// Funds are only swept if treasury is not the zero address.
+func NewAccountant(logger log.Logger, metrics AccountantMetrics, clock RClock, ledger *Ledger, claimer BondClaimer,
+	wethCreator WETHContractCreator, balance BalanceFetcher, txSender AccountTxSender, treasury common.Address, sweepThreshold *big.Int,
+) *Accountant {
+	return &Accountant{
+		logger:         logger,
+		metrics:        metrics,
+		clock:          clock,
+		ledger:         ledger,
+		claimer:        claimer,
+		wethCreator:    wethCreator,
+		balance:        balance,
+		txSender:       txSender,
+		treasury:       treasury,
+		sweepThreshold: sweepThreshold,
+	}
+}
+
+func (a *Accountant) ClaimBonds(ctx context.Context, games []types.GameMetadata) error {
+	err := a.claimer.ClaimBonds(ctx, games)
+	for _, weth := range a.ledger.WETHContracts() {
+		err = errors.Join(err, a.withdrawWETH(ctx, weth))
+	}
+	err = errors.Join(err, a.sweep(ctx))
+	err = errors.Join(err, a.recordLiquidity(ctx))
+	return err
+}
+
+// withdrawWETH unlocks any WETH held by the challenger in the DelayedWETH contract and withdraws it once the delay
+// has passed. Existing unlocks are picked up from the contract so they are tracked even if the ledger is lost.
+func (a *Accountant) withdrawWETH(ctx context.Context, addr common.Address) error {
+	sender := a.txSender.From()
+	weth := a.wethCreator(addr)
+	unlock, ok := a.ledger.Unlock(addr, sender)
+	if !ok {
+		withdrawal, err := a.getWithdrawal(ctx, weth)
+		if err != nil {
+			return err
+		}
+		if withdrawal.Amount.Sign() == 0 {
+			return a.unlockWETH(ctx, addr, weth)
+		}
+		unlock, err = a.trackUnlock(ctx, addr, weth, withdrawal)
+		if err != nil {
+			return err
+		}
+	}
+	if withdrawableAt := unlock.WithdrawableAt(); a.clock.Now().Before(withdrawableAt) {
+		a.logger.Debug("WETH withdrawal still locked", "weth", addr, "amount", unlock.Amount, "withdrawableAt", withdrawableAt)
+		return nil
+	}
+	candidate, err := weth.WithdrawTx(sender, unlock.Amount)
+	if err != nil {
+		return fmt.Errorf("failed to create WETH withdraw tx: %w", err)
+	}
+	if err := a.txSender.SendAndWaitSimple("withdraw WETH", candidate); err != nil {
+		return fmt.Errorf("failed to withdraw WETH: %w", err)
+	}
+	a.logger.Info("Withdrew WETH", "weth", addr, "amount", unlock.Amount)
+	return a.ledger.RemoveUnlock(addr, sender)
+}
+
+func (a *Accountant) unlockWETH(ctx context.Context, addr common.Address, weth WETHContract) error {
+	sender := a.txSender.From()
+	balance, err := weth.BalanceOf(ctx, rpcblock.Latest, sender)
+	if err != nil {
+		return err
+	}
+	if balance.Sign() == 0 {
+		return nil
+	}
+	candidate, err := weth.UnlockTx(sender, balance)
+	if err != nil {
+		return fmt.Errorf("failed to create WETH unlock tx: %w", err)
+	}
+	if err := a.txSender.SendAndWaitSimple("unlock WETH", candidate); err != nil {
+		return fmt.Errorf("failed to unlock WETH: %w", err)
+	}
+	// Read back the withdrawal request to get the timestamp of the block the unlock was included in.
+	withdrawal, err := a.getWithdrawal(ctx, weth)
+	if err != nil {
+		return err
+	}
+	_, err = a.trackUnlock(ctx, addr, weth, withdrawal)
+	return err
+}
+
+// getWithdrawal returns the withdrawal request of the WETH the sender unlocked for itself.
+func (a *Accountant) getWithdrawal(ctx context.Context, weth WETHContract) (*contracts.WithdrawalRequest, error) {
+	sender := a.txSender.From()
+	withdrawals, err := weth.GetWithdrawals(ctx, rpcblock.Latest, sender, sender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WETH withdrawal request: %w", err)
+	}
+	if len(withdrawals) != 1 {
+		return nil, fmt.Errorf("%w: expected 1 but got %v", ErrUnexpectedWithdrawals, len(withdrawals))
+	}
+	return withdrawals[0], nil
+}
+
+func (a *Accountant) trackUnlock(ctx context.Context, addr common.Address, weth WETHContract, withdrawal *contracts.WithdrawalRequest) (Unlock, error) {
+	_, delay, err := weth.GetBalanceAndDelay(ctx, rpcblock.Latest)
+	if err != nil {
+		return Unlock{}, fmt.Errorf("failed to get withdrawal delay: %w", err)
+	}
+	unlock := Unlock{
+		WETH:       addr,
+		Claimant:   a.txSender.From(),
+		Amount:     withdrawal.Amount,
+		UnlockedAt: withdrawal.Timestamp.Uint64(),
+		Delay:      uint64(delay.Seconds()),
+	}
+	if err := a.ledger.PutUnlock(unlock); err != nil {
+		return Unlock{}, err
+	}
+	a.logger.Info("Tracking WETH withdrawal", "weth", addr, "amount", unlock.Amount, "withdrawableAt", unlock.WithdrawableAt())
+	return unlock, nil
+}
+
+// sweep sends the challenger's balance above the sweep threshold to the treasury.
+// Everything above the threshold is swept each time, so the threshold must cover the bonds and gas the challenger
+// needs to keep playing games until the next sweep.
+func (a *Accountant) sweep(ctx context.Context) error {
+	if a.treasury == (common.Address{}) {
+		return nil
+	}
+	balance, err := a.balance(ctx, a.txSender.From())
+	if err != nil {
+		return fmt.Errorf("failed to get balance: %w", err)
+	}
+	excess := new(big.Int).Sub(balance, a.sweepThreshold)
+	if excess.Sign() <= 0 {
+		return nil
+	}
+	candidate := txmgr.TxCandidate{
+		To:    &a.treasury,
+		Value: excess,
+	}
+	if err := a.txSender.SendAndWaitSimple("sweep funds", candidate); err != nil {
+		return fmt.Errorf("failed to sweep funds to treasury: %w", err)
+	}
+	a.logger.Info("Swept funds to treasury", "treasury", a.treasury, "amount", excess)
+	a.metrics.RecordFundsSwept(excess)
+	return nil
+}
+
+// recordLiquidity reports the challenger's available balance and the credits it is waiting to withdraw,
+// along with the liquidity projected to be available at each of the LiquidityHorizons.
+func (a *Accountant) recordLiquidity(ctx context.Context) error {
+	sender := a.txSender.From()
+	available, err := a.balance(ctx, sender)
+	if err != nil {
+		return fmt.Errorf("failed to get balance: %w", err)
+	}
+	type pendingFunds struct {
+		amount         *big.Int
+		withdrawableAt time.Time
+	}
+	var pending []pendingFunds
+	for _, credit := range a.ledger.Credits() {
+		if credit.Claimant == sender {
+			pending = append(pending, pendingFunds{credit.Amount, credit.WithdrawableAt()})
+		}
+	}
+	for _, unlock := range a.ledger.Unlocks() {
+		if unlock.Claimant == sender {
+			pending = append(pending, pendingFunds{unlock.Amount, unlock.WithdrawableAt()})
+		}
+	}
+
+	now := a.clock.Now()
+	withdrawable := new(big.Int)
+	locked := new(big.Int)
+	for _, funds := range pending {
+		if now.Before(funds.withdrawableAt) {
+			locked.Add(locked, funds.amount)
+		} else {
+			withdrawable.Add(withdrawable, funds.amount)
+		}
+	}
+	a.metrics.RecordBondLiquidity(LiquidityAvailable, available)
+	a.metrics.RecordBondLiquidity(LiquidityWithdrawable, withdrawable)
+	a.metrics.RecordBondLiquidity(LiquidityLocked, locked)
+
+	for horizon, duration := range LiquidityHorizons {
+		projected := new(big.Int).Set(available)
+		for _, funds := range pending {
+			if !now.Add(duration).Before(funds.withdrawableAt) {
+				projected.Add(projected, funds.amount)
+			}
+		}
+		a.metrics.RecordProjectedLiquidity(horizon, projected)
+	}
+	a.logger.Debug("Bond liquidity", "available", available, "withdrawable", withdrawable, "locked", locked)
+	return nil
+}
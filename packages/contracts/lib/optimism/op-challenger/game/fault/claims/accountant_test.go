@@ -0,0 +1,289 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	treasury       = common.Address{0x7e}
+	sweepThreshold = big.NewInt(1000)
+	unlockTxData   = []byte{0x01}
+	withdrawTxData = []byte{0x02}
+)
+
+func TestAccountant_ClaimBonds(t *testing.T) {
+	t.Run("ClaimsBonds", func(t *testing.T) {
+		a, _, _, claimer, _, _ := newTestAccountant(t, common.Address{})
+		require.NoError(t, a.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: common.Address{0x01}}}))
+		require.EqualValues(t, 1, claimer.claimCalls.Load())
+	})
+
+	t.Run("ContinuesWhenClaimFails", func(t *testing.T) {
+		a, _, _, claimer, _, txSender := newTestAccountant(t, treasury)
+		claimer.claimErr = errors.New("boom")
+		a.balance = staticBalance(big.NewInt(1500))
+		err := a.ClaimBonds(context.Background(), nil)
+		require.ErrorIs(t, err, claimer.claimErr)
+		require.Len(t, txSender.sent, 1, "should still sweep")
+	})
+}
+
+func TestAccountant_WithdrawWETH(t *testing.T) {
+	t.Run("NoWETH", func(t *testing.T) {
+		a, _, _, _, _, txSender := newTestAccountant(t, common.Address{})
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Empty(t, txSender.sent)
+	})
+
+	t.Run("UnlockAndWithdraw", func(t *testing.T) {
+		a, cl, _, _, weth, txSender := newTestAccountant(t, common.Address{})
+		trackWETH(t, a.ledger)
+		weth.balance = big.NewInt(500)
+
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Equal(t, []txmgr.TxCandidate{{To: &wethAddr, TxData: unlockTxData}}, txSender.sent)
+		unlock, ok := a.ledger.Unlock(wethAddr, txSender.From())
+		require.True(t, ok)
+		require.Equal(t, big.NewInt(500), unlock.Amount)
+		require.Equal(t, cl.Now().Add(withdrawDelay), unlock.WithdrawableAt())
+
+		// Not withdrawn until the delay passes
+		cl.AdvanceTime(withdrawDelay - time.Second)
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Len(t, txSender.sent, 1)
+
+		cl.AdvanceTime(time.Second)
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Len(t, txSender.sent, 2)
+		require.Equal(t, txmgr.TxCandidate{To: &wethAddr, TxData: withdrawTxData}, txSender.sent[1])
+		require.Equal(t, big.NewInt(500), weth.withdrawn)
+		_, ok = a.ledger.Unlock(wethAddr, txSender.From())
+		require.False(t, ok)
+	})
+
+	t.Run("AdoptExistingUnlock", func(t *testing.T) {
+		a, cl, _, _, weth, txSender := newTestAccountant(t, common.Address{})
+		trackWETH(t, a.ledger)
+		// Unlock from before a restart where the ledger was lost
+		weth.withdrawal = &contracts.WithdrawalRequest{Amount: big.NewInt(300), Timestamp: big.NewInt(cl.Now().Unix())}
+
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Empty(t, txSender.sent)
+		unlock, ok := a.ledger.Unlock(wethAddr, txSender.From())
+		require.True(t, ok)
+		require.Equal(t, big.NewInt(300), unlock.Amount)
+
+		cl.AdvanceTime(withdrawDelay)
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Equal(t, []txmgr.TxCandidate{{To: &wethAddr, TxData: withdrawTxData}}, txSender.sent)
+	})
+
+	t.Run("UnlockFails", func(t *testing.T) {
+		a, _, _, _, weth, txSender := newTestAccountant(t, common.Address{})
+		trackWETH(t, a.ledger)
+		weth.balance = big.NewInt(500)
+		txSender.sendFails = true
+
+		err := a.ClaimBonds(context.Background(), nil)
+		require.ErrorIs(t, err, mockTxMgrSendError)
+		require.Empty(t, a.ledger.Unlocks())
+	})
+
+	t.Run("MissingWithdrawalRequest", func(t *testing.T) {
+		a, _, _, _, weth, txSender := newTestAccountant(t, common.Address{})
+		trackWETH(t, a.ledger)
+		weth.balance = big.NewInt(500)
+		weth.noWithdrawals = true
+
+		err := a.ClaimBonds(context.Background(), nil)
+		require.ErrorIs(t, err, ErrUnexpectedWithdrawals)
+		require.Empty(t, txSender.sent)
+		require.Empty(t, a.ledger.Unlocks())
+	})
+}
+
+func TestAccountant_Sweep(t *testing.T) {
+	t.Run("DisabledWithoutTreasury", func(t *testing.T) {
+		a, _, _, _, _, txSender := newTestAccountant(t, common.Address{})
+		a.balance = staticBalance(big.NewInt(5000))
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Empty(t, txSender.sent)
+	})
+
+	t.Run("BelowThreshold", func(t *testing.T) {
+		a, _, _, _, _, txSender := newTestAccountant(t, treasury)
+		a.balance = staticBalance(sweepThreshold)
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Empty(t, txSender.sent)
+	})
+
+	t.Run("SweepExcess", func(t *testing.T) {
+		a, _, m, _, _, txSender := newTestAccountant(t, treasury)
+		a.balance = staticBalance(big.NewInt(1500))
+		require.NoError(t, a.ClaimBonds(context.Background(), nil))
+		require.Equal(t, []txmgr.TxCandidate{{To: &treasury, Value: big.NewInt(500)}}, txSender.sent)
+		require.Equal(t, big.NewInt(500), m.swept)
+	})
+
+	t.Run("SweepFails", func(t *testing.T) {
+		a, _, m, _, _, txSender := newTestAccountant(t, treasury)
+		a.balance = staticBalance(big.NewInt(1500))
+		txSender.sendFails = true
+		require.ErrorIs(t, a.ClaimBonds(context.Background(), nil), mockTxMgrSendError)
+		require.Nil(t, m.swept)
+	})
+}
+
+func TestAccountant_RecordLiquidity(t *testing.T) {
+	a, cl, m, _, _, txSender := newTestAccountant(t, common.Address{})
+	a.balance = staticBalance(big.NewInt(100))
+	now := uint64(cl.Now().Unix())
+	credit := func(game byte, claimant common.Address, amount int64, unlockedAt uint64) PendingCredit {
+		return PendingCredit{
+			Game:       types.GameMetadata{Proxy: common.Address{game}},
+			Claimant:   claimant,
+			WETH:       common.Address{0xee},
+			Amount:     big.NewInt(amount),
+			UnlockedAt: unlockedAt,
+			Delay:      uint64(withdrawDelay.Seconds()),
+		}
+	}
+	delay := uint64(withdrawDelay.Seconds())
+	hour := uint64(time.Hour.Seconds())
+	// Withdrawable now
+	require.NoError(t, a.ledger.PutCredit(credit(0x01, txSender.From(), 1, now-delay)))
+	// Withdrawable in 30 minutes
+	require.NoError(t, a.ledger.PutCredit(credit(0x02, txSender.From(), 10, now-delay+hour/2)))
+	// Withdrawable in 2 days
+	require.NoError(t, a.ledger.PutCredit(credit(0x03, txSender.From(), 20, now-delay+48*hour)))
+	// Owed to a different claimant
+	require.NoError(t, a.ledger.PutCredit(credit(0x04, common.Address{0xbb}, 40, now)))
+	// Withdrawable in 7 days
+	require.NoError(t, a.ledger.PutUnlock(Unlock{
+		WETH:       common.Address{0xee},
+		Claimant:   txSender.From(),
+		Amount:     big.NewInt(1000),
+		UnlockedAt: now,
+		Delay:      delay,
+	}))
+
+	require.NoError(t, a.recordLiquidity(context.Background()))
+	require.Equal(t, map[string]*big.Int{
+		LiquidityAvailable:    big.NewInt(100),
+		LiquidityWithdrawable: big.NewInt(1),
+		LiquidityLocked:       big.NewInt(1030),
+	}, m.liquidity)
+	require.Equal(t, map[string]*big.Int{
+		"1h":  big.NewInt(111),
+		"24h": big.NewInt(111),
+		"7d":  big.NewInt(1131),
+	}, m.projected)
+}
+
+func newTestAccountant(t *testing.T, treasury common.Address) (*Accountant, *clock.DeterministicClock, *stubAccountantMetrics, *stubClaimer, *stubWETHContract, *mockTxSender) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	cl := clock.NewDeterministicClock(unlockedAtTime)
+	m := &stubAccountantMetrics{
+		liquidity: make(map[string]*big.Int),
+		projected: make(map[string]*big.Int),
+	}
+	ledger, err := OpenLedger("")
+	require.NoError(t, err)
+	claimer := &stubClaimer{}
+	weth := &stubWETHContract{clock: cl, balance: new(big.Int)}
+	wethCreator := func(addr common.Address) WETHContract {
+		require.Equal(t, wethAddr, addr)
+		return weth
+	}
+	txSender := &mockTxSender{}
+	a := NewAccountant(logger, m, cl, ledger, claimer, wethCreator, staticBalance(new(big.Int)), txSender, treasury, sweepThreshold)
+	return a, cl, m, claimer, weth, txSender
+}
+
+// trackWETH adds the test WETH contract to the ledger as though credit had been tracked from it.
+func trackWETH(t *testing.T, ledger *Ledger) {
+	credit := PendingCredit{Game: types.GameMetadata{Proxy: common.Address{0x01}}, Claimant: common.Address{0xaa}, WETH: wethAddr, Amount: big.NewInt(1)}
+	require.NoError(t, ledger.PutCredit(credit))
+	require.NoError(t, ledger.RemoveCredit(credit.Game.Proxy, credit.Claimant))
+}
+
+func staticBalance(balance *big.Int) BalanceFetcher {
+	return func(_ context.Context, _ common.Address) (*big.Int, error) {
+		return balance, nil
+	}
+}
+
+type stubAccountantMetrics struct {
+	liquidity map[string]*big.Int
+	projected map[string]*big.Int
+	swept     *big.Int
+}
+
+func (s *stubAccountantMetrics) RecordBondLiquidity(state string, amount *big.Int) {
+	s.liquidity[state] = amount
+}
+
+func (s *stubAccountantMetrics) RecordProjectedLiquidity(horizon string, amount *big.Int) {
+	s.projected[horizon] = amount
+}
+
+func (s *stubAccountantMetrics) RecordFundsSwept(amount *big.Int) {
+	s.swept = amount
+}
+
+type stubWETHContract struct {
+	clock      *clock.DeterministicClock
+	balance    *big.Int
+	withdrawal *contracts.WithdrawalRequest
+	withdrawn  *big.Int
+	// noWithdrawals makes GetWithdrawals return no withdrawal requests.
+	noWithdrawals bool
+}
+
+func (s *stubWETHContract) GetBalanceAndDelay(_ context.Context, _ rpcblock.Block) (*big.Int, time.Duration, error) {
+	return big.NewInt(1000), withdrawDelay, nil
+}
+
+func (s *stubWETHContract) GetWithdrawals(_ context.Context, _ rpcblock.Block, _ common.Address, recipients ...common.Address) ([]*contracts.WithdrawalRequest, error) {
+	if s.noWithdrawals {
+		return nil, nil
+	}
+	withdrawals := make([]*contracts.WithdrawalRequest, len(recipients))
+	for i := range recipients {
+		if s.withdrawal != nil {
+			withdrawals[i] = s.withdrawal
+		} else {
+			withdrawals[i] = &contracts.WithdrawalRequest{Amount: new(big.Int), Timestamp: new(big.Int)}
+		}
+	}
+	return withdrawals, nil
+}
+
+func (s *stubWETHContract) BalanceOf(_ context.Context, _ rpcblock.Block, _ common.Address) (*big.Int, error) {
+	return s.balance, nil
+}
+
+// UnlockTx applies the unlock immediately as the tx sender stub does not execute transactions.
+func (s *stubWETHContract) UnlockTx(_ common.Address, amount *big.Int) (txmgr.TxCandidate, error) {
+	s.withdrawal = &contracts.WithdrawalRequest{Amount: amount, Timestamp: big.NewInt(s.clock.Now().Unix())}
+	return txmgr.TxCandidate{To: &wethAddr, TxData: unlockTxData}, nil
+}
+
+func (s *stubWETHContract) WithdrawTx(_ common.Address, amount *big.Int) (txmgr.TxCandidate, error) {
+	s.withdrawn = amount
+	return txmgr.TxCandidate{To: &wethAddr, TxData: withdrawTxData}, nil
+}
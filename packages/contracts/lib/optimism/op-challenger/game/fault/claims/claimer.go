@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -23,14 +26,22 @@ type BondClaimMetrics interface {
 
 type BondContract interface {
 	GetCredit(ctx context.Context, recipient common.Address) (*big.Int, types.GameStatus, error)
+	GetWithdrawals(ctx context.Context, block rpcblock.Block, recipients ...common.Address) ([]*contracts.WithdrawalRequest, error)
+	GetBalanceAndDelay(ctx context.Context, block rpcblock.Block) (*big.Int, time.Duration, common.Address, error)
 	ClaimCreditTx(ctx context.Context, recipient common.Address) (txmgr.TxCandidate, error)
 }
 
 type BondContractCreator func(game types.GameMetadata) (BondContract, error)
 
+type RClock interface {
+	Now() time.Time
+}
+
 type Claimer struct {
 	logger          log.Logger
 	metrics         BondClaimMetrics
+	clock           RClock
+	ledger          *Ledger
 	contractCreator BondContractCreator
 	txSender        TxSender
 	claimants       []common.Address
@@ -38,10 +49,14 @@ type Claimer struct {
 
 var _ BondClaimer = (*Claimer)(nil)
 
-func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender TxSender, claimants ...common.Address) *Claimer {
+// NewBondClaimer creates a Claimer. Credit that is waiting for the DelayedWETH withdrawal delay is recorded in the
+// ledger so it is claimed once the delay passes, even if the game is no longer being monitored.
+func NewBondClaimer(l log.Logger, m BondClaimMetrics, clock RClock, ledger *Ledger, contractCreator BondContractCreator, txSender TxSender, claimants ...common.Address) *Claimer {
 	return &Claimer{
 		logger:          l,
 		metrics:         m,
+		clock:           clock,
+		ledger:          ledger,
 		contractCreator: contractCreator,
 		txSender:        txSender,
 		claimants:       claimants,
@@ -54,6 +69,13 @@ func (c *Claimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) (e
 			err = errors.Join(err, c.claimBond(ctx, game, claimant))
 		}
 	}
+	// Claim credit from games that are no longer monitored, e.g. because they are now outside the game window.
+	for _, pending := range c.ledger.Credits() {
+		if slices.ContainsFunc(games, func(game types.GameMetadata) bool { return game.Proxy == pending.Game.Proxy }) {
+			continue
+		}
+		err = errors.Join(err, c.claimBond(ctx, pending.Game, pending.Claimant))
+	}
 	return err
 }
 
@@ -76,6 +98,18 @@ func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata, addr c
 	}
 	if credit.Cmp(big.NewInt(0)) == 0 {
 		c.logger.Debug("No credit to claim", "game", game.Proxy, "addr", addr)
+		return c.ledger.RemoveCredit(game.Proxy, addr)
+	}
+
+	pending, ok := c.ledger.Credit(game.Proxy, addr)
+	if !ok || pending.Amount.Cmp(credit) != 0 {
+		pending, err = c.trackCredit(ctx, contract, game, addr, credit)
+		if err != nil {
+			return err
+		}
+	}
+	if withdrawableAt := pending.WithdrawableAt(); c.clock.Now().Before(withdrawableAt) {
+		c.logger.Debug("Credit still locked", "game", game.Proxy, "addr", addr, "withdrawableAt", withdrawableAt)
 		return nil
 	}
 
@@ -92,5 +126,30 @@ func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata, addr c
 	}
 
 	c.metrics.RecordBondClaimed(credit.Uint64())
-	return nil
+	return c.ledger.RemoveCredit(game.Proxy, addr)
+}
+
+// trackCredit records the credit in the ledger along with the time it becomes withdrawable from DelayedWETH.
+func (c *Claimer) trackCredit(ctx context.Context, contract BondContract, game types.GameMetadata, addr common.Address, credit *big.Int) (PendingCredit, error) {
+	withdrawals, err := contract.GetWithdrawals(ctx, rpcblock.Latest, addr)
+	if err != nil {
+		return PendingCredit{}, fmt.Errorf("failed to get withdrawal request: %w", err)
+	}
+	_, delay, weth, err := contract.GetBalanceAndDelay(ctx, rpcblock.Latest)
+	if err != nil {
+		return PendingCredit{}, fmt.Errorf("failed to get withdrawal delay: %w", err)
+	}
+	pending := PendingCredit{
+		Game:       game,
+		Claimant:   addr,
+		WETH:       weth,
+		Amount:     credit,
+		UnlockedAt: withdrawals[0].Timestamp.Uint64(),
+		Delay:      uint64(delay.Seconds()),
+	}
+	if err := c.ledger.PutCredit(pending); err != nil {
+		return PendingCredit{}, err
+	}
+	c.logger.Info("Tracking credit", "game", game.Proxy, "addr", addr, "amount", credit, "withdrawableAt", pending.WithdrawableAt())
+	return pending, nil
 }
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -18,6 +22,10 @@ import (
 
 var (
 	mockTxMgrSendError = errors.New("mock tx mgr send error")
+
+	wethAddr       = common.HexToAddress("0x5e7")
+	withdrawDelay  = 7 * 24 * time.Hour
+	unlockedAtTime = time.Unix(1_000_000, 0)
 )
 
 func TestClaimer_ClaimBonds(t *testing.T) {
@@ -89,6 +97,104 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		require.Equal(t, 0, m.RecordBondClaimedCalls)
 	})
 
+	t.Run("BondNotClaimedUntilDelayPasses", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		c, m, contract, txSender := newTestClaimer(t)
+		contract.credit[txSender.From()] = 1
+		cl := c.clock.(*clock.DeterministicClock)
+		cl.AdvanceTime(-time.Second)
+		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}})
+		require.NoError(t, err)
+		require.Equal(t, 0, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+
+		credit, ok := c.ledger.Credit(gameAddr, txSender.From())
+		require.True(t, ok)
+		require.Equal(t, PendingCredit{
+			Game:       types.GameMetadata{Proxy: gameAddr},
+			Claimant:   txSender.From(),
+			WETH:       wethAddr,
+			Amount:     big.NewInt(1),
+			UnlockedAt: uint64(unlockedAtTime.Unix()),
+			Delay:      uint64(withdrawDelay.Seconds()),
+		}, credit)
+
+		cl.AdvanceTime(time.Second)
+		err = c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}})
+		require.NoError(t, err)
+		require.Equal(t, 1, txSender.sends)
+		require.Equal(t, 1, m.RecordBondClaimedCalls)
+		require.Empty(t, c.ledger.Credits())
+	})
+
+	t.Run("CreditRetrackedWhenAmountChanges", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		c, _, contract, txSender := newTestClaimer(t)
+		contract.credit[txSender.From()] = 1
+		c.clock.(*clock.DeterministicClock).AdvanceTime(-time.Second)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+
+		// Another bond was distributed to the claimant, resetting the unlock time
+		contract.credit[txSender.From()] = 3
+		contract.unlockedAt = unlockedAtTime.Add(time.Hour)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		credit, ok := c.ledger.Credit(gameAddr, txSender.From())
+		require.True(t, ok)
+		require.Equal(t, big.NewInt(3), credit.Amount)
+		require.Equal(t, contract.unlockedAt.Add(withdrawDelay), credit.WithdrawableAt())
+		require.Equal(t, 0, txSender.sends)
+	})
+
+	t.Run("ClaimTrackedCreditFromUnmonitoredGame", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		c, m, contract, txSender := newTestClaimer(t)
+		contract.credit[txSender.From()] = 1
+		c.clock.(*clock.DeterministicClock).AdvanceTime(-time.Second)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Len(t, c.ledger.Credits(), 1)
+
+		// Game is no longer in the monitored window but the credit is still claimed once unlocked
+		c.clock.(*clock.DeterministicClock).AdvanceTime(time.Second)
+		require.NoError(t, c.ClaimBonds(context.Background(), nil))
+		require.Equal(t, 1, txSender.sends)
+		require.Equal(t, 1, m.RecordBondClaimedCalls)
+		require.Empty(t, c.ledger.Credits())
+	})
+
+	t.Run("TrackedCreditSurvivesRestart", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		path := filepath.Join(t.TempDir(), LedgerFilename)
+		ledger, err := OpenLedger(path)
+		require.NoError(t, err)
+		c, _, contract, txSender := newTestClaimerWithLedger(t, ledger)
+		contract.credit[txSender.From()] = 1
+		c.clock.(*clock.DeterministicClock).AdvanceTime(-time.Second)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+
+		ledger, err = OpenLedger(path)
+		require.NoError(t, err)
+		c, m, contract, txSender := newTestClaimerWithLedger(t, ledger)
+		contract.credit[txSender.From()] = 1
+		require.NoError(t, c.ClaimBonds(context.Background(), nil))
+		require.Equal(t, 1, txSender.sends)
+		require.Equal(t, 1, m.RecordBondClaimedCalls)
+	})
+
+	t.Run("ZeroCreditRemovesTrackedCredit", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		c, _, contract, txSender := newTestClaimer(t)
+		contract.credit[txSender.From()] = 1
+		c.clock.(*clock.DeterministicClock).AdvanceTime(-time.Second)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Len(t, c.ledger.Credits(), 1)
+
+		// Credit was claimed by someone else
+		contract.credit[txSender.From()] = 0
+		require.NoError(t, c.ClaimBonds(context.Background(), nil))
+		require.Empty(t, c.ledger.Credits())
+		require.Equal(t, 0, txSender.sends)
+	})
+
 	t.Run("ZeroCreditReturnsNil", func(t *testing.T) {
 		gameAddr := common.HexToAddress("0x1234")
 		c, m, contract, txSender := newTestClaimer(t)
@@ -112,17 +218,29 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 }
 
 func newTestClaimer(t *testing.T, claimants ...common.Address) (*Claimer, *mockClaimMetrics, *stubBondContract, *mockTxSender) {
+	ledger, err := OpenLedger("")
+	require.NoError(t, err)
+	return newTestClaimerWithLedger(t, ledger, claimants...)
+}
+
+func newTestClaimerWithLedger(t *testing.T, ledger *Ledger, claimants ...common.Address) (*Claimer, *mockClaimMetrics, *stubBondContract, *mockTxSender) {
 	logger := testlog.Logger(t, log.LvlDebug)
 	m := &mockClaimMetrics{}
 	txSender := &mockTxSender{}
-	bondContract := &stubBondContract{status: types.GameStatusChallengerWon, credit: make(map[common.Address]int64)}
+	// Start with the credit withdrawable
+	cl := clock.NewDeterministicClock(unlockedAtTime.Add(withdrawDelay))
+	bondContract := &stubBondContract{
+		status:     types.GameStatusChallengerWon,
+		credit:     make(map[common.Address]int64),
+		unlockedAt: unlockedAtTime,
+	}
 	contractCreator := func(game types.GameMetadata) (BondContract, error) {
 		return bondContract, nil
 	}
 	if len(claimants) == 0 {
 		claimants = []common.Address{txSender.From()}
 	}
-	c := NewBondClaimer(logger, m, contractCreator, txSender, claimants...)
+	c := NewBondClaimer(logger, m, cl, ledger, contractCreator, txSender, claimants...)
 	return c, m, bondContract, txSender
 }
 
@@ -136,6 +254,7 @@ func (m *mockClaimMetrics) RecordBondClaimed(amount uint64) {
 
 type mockTxSender struct {
 	sends      int
+	sent       []txmgr.TxCandidate
 	sendFails  bool
 	statusFail bool
 }
@@ -144,8 +263,9 @@ func (s *mockTxSender) From() common.Address {
 	return common.HexToAddress("0x33333")
 }
 
-func (s *mockTxSender) SendAndWaitSimple(_ string, _ ...txmgr.TxCandidate) error {
+func (s *mockTxSender) SendAndWaitSimple(_ string, txs ...txmgr.TxCandidate) error {
 	s.sends++
+	s.sent = append(s.sent, txs...)
 	if s.sendFails {
 		return mockTxMgrSendError
 	}
@@ -159,12 +279,28 @@ type stubBondContract struct {
 	credit               map[common.Address]int64
 	status               types.GameStatus
 	claimSimulationFails bool
+	unlockedAt           time.Time
 }
 
 func (s *stubBondContract) GetCredit(_ context.Context, addr common.Address) (*big.Int, types.GameStatus, error) {
 	return big.NewInt(s.credit[addr]), s.status, nil
 }
 
+func (s *stubBondContract) GetWithdrawals(_ context.Context, _ rpcblock.Block, recipients ...common.Address) ([]*contracts.WithdrawalRequest, error) {
+	withdrawals := make([]*contracts.WithdrawalRequest, len(recipients))
+	for i, recipient := range recipients {
+		withdrawals[i] = &contracts.WithdrawalRequest{
+			Amount:    big.NewInt(s.credit[recipient]),
+			Timestamp: big.NewInt(s.unlockedAt.Unix()),
+		}
+	}
+	return withdrawals, nil
+}
+
+func (s *stubBondContract) GetBalanceAndDelay(_ context.Context, _ rpcblock.Block) (*big.Int, time.Duration, common.Address, error) {
+	return big.NewInt(1000), withdrawDelay, wethAddr, nil
+}
+
 func (s *stubBondContract) ClaimCreditTx(_ context.Context, _ common.Address) (txmgr.TxCandidate, error) {
 	if s.claimSimulationFails {
 		return txmgr.TxCandidate{}, fmt.Errorf("failed: %w", contracts.ErrSimulationFailed)
@@ -0,0 +1,235 @@
+package claims
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LedgerFilename is the name of the file in the data directory that the bond ledger is persisted to.
+const LedgerFilename = "bond-ledger.json"
+
+// PendingCredit is credit owed to a claimant by a resolved game that can't be claimed until the DelayedWETH
+// withdrawal delay has passed.
+type PendingCredit struct {
+	Game     types.GameMetadata `json:"game"`
+	Claimant common.Address     `json:"claimant"`
+	WETH     common.Address     `json:"weth"`
+	Amount   *big.Int           `json:"amount"`
+	// UnlockedAt is the timestamp of the most recent DelayedWETH unlock for the claimant in the game.
+	UnlockedAt uint64 `json:"unlockedAt"`
+	// Delay is the DelayedWETH withdrawal delay in seconds.
+	Delay uint64 `json:"delay"`
+}
+
+func (c PendingCredit) WithdrawableAt() time.Time {
+	return time.Unix(int64(c.UnlockedAt+c.Delay), 0)
+}
+
+// Unlock is a withdrawal of WETH held directly by the claimant that has been unlocked in a DelayedWETH contract.
+type Unlock struct {
+	WETH       common.Address `json:"weth"`
+	Claimant   common.Address `json:"claimant"`
+	Amount     *big.Int       `json:"amount"`
+	UnlockedAt uint64         `json:"unlockedAt"`
+	Delay      uint64         `json:"delay"`
+}
+
+func (u Unlock) WithdrawableAt() time.Time {
+	return time.Unix(int64(u.UnlockedAt+u.Delay), 0)
+}
+
+type creditKey struct {
+	game     common.Address
+	claimant common.Address
+}
+
+type unlockKey struct {
+	weth     common.Address
+	claimant common.Address
+}
+
+type ledgerData struct {
+	Credits       []PendingCredit  `json:"credits"`
+	Unlocks       []Unlock         `json:"unlocks"`
+	WETHContracts []common.Address `json:"wethContracts"`
+}
+
+// Ledger tracks the credits and unlocked withdrawals waiting on DelayedWETH withdrawal delays.
+// It is persisted so that funds are still claimed after a restart, even if the game is no longer being monitored.
+type Ledger struct {
+	path string
+
+	m       sync.Mutex
+	credits map[creditKey]PendingCredit
+	unlocks map[unlockKey]Unlock
+	// weth is every DelayedWETH contract credit has been tracked from, retained after the credit is claimed.
+	weth map[common.Address]bool
+}
+
+// OpenLedger loads the ledger from path, creating an empty ledger if the file does not exist.
+// If path is empty the ledger is kept in memory only.
+func OpenLedger(path string) (*Ledger, error) {
+	l := &Ledger{
+		path:    path,
+		credits: make(map[creditKey]PendingCredit),
+		unlocks: make(map[unlockKey]Unlock),
+		weth:    make(map[common.Address]bool),
+	}
+	if path == "" {
+		return l, nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to check bond ledger %v: %w", path, err)
+	}
+	data, err := jsonutil.LoadJSON[ledgerData](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bond ledger: %w", err)
+	}
+	for _, credit := range data.Credits {
+		l.credits[creditKey{credit.Game.Proxy, credit.Claimant}] = credit
+	}
+	for _, unlock := range data.Unlocks {
+		l.unlocks[unlockKey{unlock.WETH, unlock.Claimant}] = unlock
+	}
+	for _, weth := range data.WETHContracts {
+		l.weth[weth] = true
+	}
+	return l, nil
+}
+
+func (l *Ledger) Credit(game common.Address, claimant common.Address) (PendingCredit, bool) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	credit, ok := l.credits[creditKey{game, claimant}]
+	return credit, ok
+}
+
+func (l *Ledger) PutCredit(credit PendingCredit) error {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.credits[creditKey{credit.Game.Proxy, credit.Claimant}] = credit
+	l.weth[credit.WETH] = true
+	return l.save()
+}
+
+func (l *Ledger) RemoveCredit(game common.Address, claimant common.Address) error {
+	l.m.Lock()
+	defer l.m.Unlock()
+	key := creditKey{game, claimant}
+	if _, ok := l.credits[key]; !ok {
+		return nil
+	}
+	delete(l.credits, key)
+	return l.save()
+}
+
+// Credits returns the pending credits, ordered by the time they become withdrawable.
+func (l *Ledger) Credits() []PendingCredit {
+	l.m.Lock()
+	defer l.m.Unlock()
+	credits := make([]PendingCredit, 0, len(l.credits))
+	for _, credit := range l.credits {
+		credits = append(credits, credit)
+	}
+	slices.SortFunc(credits, func(a, b PendingCredit) int {
+		return cmp.Or(
+			cmp.Compare(a.UnlockedAt+a.Delay, b.UnlockedAt+b.Delay),
+			a.Game.Proxy.Cmp(b.Game.Proxy),
+			a.Claimant.Cmp(b.Claimant))
+	})
+	return credits
+}
+
+func (l *Ledger) Unlock(weth common.Address, claimant common.Address) (Unlock, bool) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	unlock, ok := l.unlocks[unlockKey{weth, claimant}]
+	return unlock, ok
+}
+
+func (l *Ledger) PutUnlock(unlock Unlock) error {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.unlocks[unlockKey{unlock.WETH, unlock.Claimant}] = unlock
+	l.weth[unlock.WETH] = true
+	return l.save()
+}
+
+func (l *Ledger) RemoveUnlock(weth common.Address, claimant common.Address) error {
+	l.m.Lock()
+	defer l.m.Unlock()
+	key := unlockKey{weth, claimant}
+	if _, ok := l.unlocks[key]; !ok {
+		return nil
+	}
+	delete(l.unlocks, key)
+	return l.save()
+}
+
+// Unlocks returns the unlocked withdrawals, ordered by the time they become withdrawable.
+func (l *Ledger) Unlocks() []Unlock {
+	l.m.Lock()
+	defer l.m.Unlock()
+	unlocks := make([]Unlock, 0, len(l.unlocks))
+	for _, unlock := range l.unlocks {
+		unlocks = append(unlocks, unlock)
+	}
+	slices.SortFunc(unlocks, func(a, b Unlock) int {
+		return cmp.Or(
+			cmp.Compare(a.UnlockedAt+a.Delay, b.UnlockedAt+b.Delay),
+			a.WETH.Cmp(b.WETH),
+			a.Claimant.Cmp(b.Claimant))
+	})
+	return unlocks
+}
+
+// WETHContracts returns the DelayedWETH contracts that credit or unlocks have been tracked in.
+func (l *Ledger) WETHContracts() []common.Address {
+	l.m.Lock()
+	defer l.m.Unlock()
+	contracts := make([]common.Address, 0, len(l.weth))
+	for weth := range l.weth {
+		contracts = append(contracts, weth)
+	}
+	slices.SortFunc(contracts, func(a, b common.Address) int {
+		return a.Cmp(b)
+	})
+	return contracts
+}
+
+func (l *Ledger) save() error {
+	if l.path == "" {
+		return nil
+	}
+	data := ledgerData{
+		Credits:       make([]PendingCredit, 0, len(l.credits)),
+		Unlocks:       make([]Unlock, 0, len(l.unlocks)),
+		WETHContracts: make([]common.Address, 0, len(l.weth)),
+	}
+	for _, credit := range l.credits {
+		data.Credits = append(data.Credits, credit)
+	}
+	for _, unlock := range l.unlocks {
+		data.Unlocks = append(data.Unlocks, unlock)
+	}
+	for weth := range l.weth {
+		data.WETHContracts = append(data.WETHContracts, weth)
+	}
+	if err := jsonutil.WriteJSON(data, ioutil.ToAtomicFile(l.path, 0o644)); err != nil {
+		return fmt.Errorf("failed to write bond ledger: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package claims
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger(t *testing.T) {
+	credit1 := PendingCredit{
+		Game:       types.GameMetadata{Proxy: common.Address{0x01}},
+		Claimant:   common.Address{0xaa},
+		WETH:       common.Address{0xee},
+		Amount:     big.NewInt(100),
+		UnlockedAt: 500,
+		Delay:      100,
+	}
+	credit2 := PendingCredit{
+		Game:       types.GameMetadata{Proxy: common.Address{0x02}},
+		Claimant:   common.Address{0xaa},
+		WETH:       common.Address{0xef},
+		Amount:     big.NewInt(200),
+		UnlockedAt: 400,
+		Delay:      100,
+	}
+	unlock := Unlock{
+		WETH:       common.Address{0xee},
+		Claimant:   common.Address{0xaa},
+		Amount:     big.NewInt(300),
+		UnlockedAt: 600,
+		Delay:      100,
+	}
+
+	t.Run("CreateWhenMissing", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), LedgerFilename)
+		ledger, err := OpenLedger(path)
+		require.NoError(t, err)
+		require.Empty(t, ledger.Credits())
+		require.Empty(t, ledger.Unlocks())
+		require.Empty(t, ledger.WETHContracts())
+		require.NoFileExists(t, path)
+	})
+
+	t.Run("Credits", func(t *testing.T) {
+		ledger, err := OpenLedger("")
+		require.NoError(t, err)
+		require.NoError(t, ledger.PutCredit(credit1))
+		require.NoError(t, ledger.PutCredit(credit2))
+
+		actual, ok := ledger.Credit(credit1.Game.Proxy, credit1.Claimant)
+		require.True(t, ok)
+		require.Equal(t, credit1, actual)
+		_, ok = ledger.Credit(credit1.Game.Proxy, common.Address{0xbb})
+		require.False(t, ok)
+
+		// Ordered by withdrawable time
+		require.Equal(t, []PendingCredit{credit2, credit1}, ledger.Credits())
+
+		require.NoError(t, ledger.RemoveCredit(credit2.Game.Proxy, credit2.Claimant))
+		require.Equal(t, []PendingCredit{credit1}, ledger.Credits())
+		// Removing a credit that isn't tracked is not an error
+		require.NoError(t, ledger.RemoveCredit(credit2.Game.Proxy, credit2.Claimant))
+	})
+
+	t.Run("Unlocks", func(t *testing.T) {
+		ledger, err := OpenLedger("")
+		require.NoError(t, err)
+		require.NoError(t, ledger.PutUnlock(unlock))
+
+		actual, ok := ledger.Unlock(unlock.WETH, unlock.Claimant)
+		require.True(t, ok)
+		require.Equal(t, unlock, actual)
+		require.Equal(t, []Unlock{unlock}, ledger.Unlocks())
+
+		require.NoError(t, ledger.RemoveUnlock(unlock.WETH, unlock.Claimant))
+		require.Empty(t, ledger.Unlocks())
+	})
+
+	t.Run("WETHContractsRetainedAfterRemoval", func(t *testing.T) {
+		ledger, err := OpenLedger("")
+		require.NoError(t, err)
+		require.NoError(t, ledger.PutCredit(credit2))
+		require.NoError(t, ledger.PutCredit(credit1))
+		require.NoError(t, ledger.PutUnlock(unlock))
+		require.NoError(t, ledger.RemoveCredit(credit2.Game.Proxy, credit2.Claimant))
+		require.Equal(t, []common.Address{{0xee}, {0xef}}, ledger.WETHContracts())
+	})
+
+	t.Run("Persisted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), LedgerFilename)
+		ledger, err := OpenLedger(path)
+		require.NoError(t, err)
+		require.NoError(t, ledger.PutCredit(credit1))
+		require.NoError(t, ledger.PutCredit(credit2))
+		require.NoError(t, ledger.PutUnlock(unlock))
+		require.NoError(t, ledger.RemoveCredit(credit2.Game.Proxy, credit2.Claimant))
+
+		reloaded, err := OpenLedger(path)
+		require.NoError(t, err)
+		require.Equal(t, []PendingCredit{credit1}, reloaded.Credits())
+		require.Equal(t, []Unlock{unlock}, reloaded.Unlocks())
+		require.Equal(t, []common.Address{{0xee}, {0xef}}, reloaded.WETHContracts())
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), LedgerFilename)
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+		_, err := OpenLedger(path)
+		require.ErrorContains(t, err, "failed to load bond ledger")
+	})
+}
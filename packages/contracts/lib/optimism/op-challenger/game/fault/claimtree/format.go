@@ -0,0 +1,166 @@
+package claimtree
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatDOT     Format = "dot"
+	FormatMermaid Format = "mermaid"
+)
+
+var Formats = []Format{FormatJSON, FormatDOT, FormatMermaid}
+
+func ParseFormat(s string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == s {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %v", ErrUnknownFormat, s)
+}
+
+// Write renders the tree to w in the specified format.
+func Write(w io.Writer, format Format, tree *Tree) error {
+	switch format {
+	case FormatJSON:
+		return WriteJSON(w, tree)
+	case FormatDOT:
+		return WriteDOT(w, tree)
+	case FormatMermaid:
+		return WriteMermaid(w, tree)
+	default:
+		return fmt.Errorf("%w: %v", ErrUnknownFormat, format)
+	}
+}
+
+func WriteJSON(w io.Writer, tree *Tree) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(tree)
+}
+
+// WriteDOT renders the tree as a Graphviz digraph. Nodes are coloured by honest actor agreement and countered
+// claims have a dashed outline.
+func WriteDOT(w io.Writer, tree *Tree) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", tree.Game.Hex())
+	fmt.Fprintf(&b, "  label=%q;\n", treeTitle(tree))
+	b.WriteString("  labelloc=t;\n")
+	b.WriteString("  node [shape=box, style=filled, fontname=\"monospace\"];\n")
+	for _, node := range tree.Nodes {
+		style := "filled"
+		if node.Countered {
+			style = "filled,dashed"
+		}
+		label := strings.Join(nodeLabel(node), "\n")
+		fmt.Fprintf(&b, "  c%d [label=%q, style=%q, fillcolor=%q];\n", node.Index, label, style, agreementColor(node))
+	}
+	for _, node := range tree.Nodes {
+		if node.ParentIndex != nil {
+			fmt.Fprintf(&b, "  c%d -> c%d [label=%q];\n", *node.ParentIndex, node.Index, node.Move)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid renders the tree as a Mermaid flowchart. Nodes are coloured by honest actor agreement and countered
+// claims have a dashed outline.
+func WriteMermaid(w io.Writer, tree *Tree) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "---\ntitle: %v\n---\n", treeTitle(tree))
+	b.WriteString("flowchart TD\n")
+	for _, node := range tree.Nodes {
+		lines := nodeLabel(node)
+		for i, line := range lines {
+			lines[i] = strings.ReplaceAll(line, `"`, "#quot;")
+		}
+		fmt.Fprintf(&b, "  c%d[\"%v\"]\n", node.Index, strings.Join(lines, "<br/>"))
+	}
+	for _, node := range tree.Nodes {
+		if node.ParentIndex != nil {
+			fmt.Fprintf(&b, "  c%d -->|%v| c%d\n", *node.ParentIndex, node.Move, node.Index)
+		}
+	}
+	for _, class := range []string{"agree", "disagree", "unknown"} {
+		fmt.Fprintf(&b, "  classDef %v fill:%v\n", class, agreementColors[class])
+	}
+	b.WriteString("  classDef countered stroke-dasharray:5 5\n")
+	for _, node := range tree.Nodes {
+		classes := agreementClass(node)
+		if node.Countered {
+			classes += ",countered"
+		}
+		fmt.Fprintf(&b, "  class c%d %v\n", node.Index, classes)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var agreementColors = map[string]string{
+	"agree":    "#c8e6c9",
+	"disagree": "#ffcdd2",
+	"unknown":  "#eeeeee",
+}
+
+func agreementClass(node Node) string {
+	switch {
+	case node.HonestAgrees == nil:
+		return "unknown"
+	case *node.HonestAgrees:
+		return "agree"
+	default:
+		return "disagree"
+	}
+}
+
+func agreementColor(node Node) string {
+	return agreementColors[agreementClass(node)]
+}
+
+func treeTitle(tree *Tree) string {
+	return fmt.Sprintf("Game %v • %v • L2 Block %v", tree.Game.Hex(), tree.Status, tree.L2BlockNumber)
+}
+
+func nodeLabel(node Node) []string {
+	lines := []string{
+		fmt.Sprintf("Claim %d (%v)", node.Index, node.Move),
+		fmt.Sprintf("Position: %v (depth %v)", node.Position.ToInt(), node.Depth),
+		fmt.Sprintf("Trace Index: %v", node.TraceIndex.ToInt()),
+		fmt.Sprintf("Value: %v", node.Value.TerminalString()),
+		fmt.Sprintf("Claimant: %v", node.Claimant.Hex()),
+		fmt.Sprintf("Bond: %v ETH", eth.WeiToEther(node.Bond.ToInt())),
+	}
+	if node.Resolved {
+		lines = append(lines, "Resolved")
+	} else {
+		lines = append(lines, fmt.Sprintf("Clock: %v remaining", time.Duration(node.ClockRemaining)*time.Second))
+	}
+	if node.CounteredBy != nil && *node.CounteredBy != (common.Address{}) {
+		lines = append(lines, fmt.Sprintf("Countered by %v", node.CounteredBy.Hex()))
+	} else if node.Countered {
+		lines = append(lines, "Countered")
+	}
+	switch agreementClass(node) {
+	case "agree":
+		lines = append(lines, "Honest actor agrees")
+	case "disagree":
+		lines = append(lines, "Honest actor disagrees")
+	}
+	return lines
+}
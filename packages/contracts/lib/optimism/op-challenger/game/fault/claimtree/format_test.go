@@ -0,0 +1,66 @@
+package claimtree
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, format := range Formats {
+		parsed, err := ParseFormat(string(format))
+		require.NoError(t, err)
+		require.Equal(t, format, parsed)
+	}
+	_, err := ParseFormat("svg")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestWriteJSON(t *testing.T) {
+	tree := buildTestTree(t)
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, FormatJSON, tree))
+	var decoded Tree
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Equal(t, *tree, decoded)
+}
+
+func TestWriteDOT(t *testing.T) {
+	tree := buildTestTree(t)
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, FormatDOT, tree))
+	dot := out.String()
+	require.Contains(t, dot, `digraph "0x0100000000000000000000000000000000000000" {`)
+	require.Contains(t, dot, `c0 -> c1 [label="attack"];`)
+	require.Contains(t, dot, `c1 -> c2 [label="defend"];`)
+	require.Contains(t, dot, `c2 -> c3 [label="attack"];`)
+	require.Contains(t, dot, `c0 [label="Claim 0 (root)\nPosition: 1 (depth 0)\nTrace Index: 3\n`)
+	require.Contains(t, dot, `Honest actor disagrees", style="filled,dashed", fillcolor="#ffcdd2"];`)
+	require.Contains(t, dot, `Honest actor agrees", style="filled", fillcolor="#c8e6c9"];`)
+}
+
+func TestWriteMermaid(t *testing.T) {
+	tree := buildTestTree(t)
+	var out bytes.Buffer
+	require.NoError(t, Write(&out, FormatMermaid, tree))
+	mermaid := out.String()
+	require.Contains(t, mermaid, "flowchart TD\n")
+	require.Contains(t, mermaid, `c0["Claim 0 (root)<br/>Position: 1 (depth 0)<br/>Trace Index: 3<br/>`)
+	require.Contains(t, mermaid, "c0 -->|attack| c1\n")
+	require.Contains(t, mermaid, "c1 -->|defend| c2\n")
+	require.Contains(t, mermaid, "class c0 disagree,countered\n")
+	require.Contains(t, mermaid, "class c1 agree\n")
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	require.ErrorIs(t, Write(&bytes.Buffer{}, Format("svg"), buildTestTree(t)), ErrUnknownFormat)
+}
+
+func buildTestTree(t *testing.T) *Tree {
+	game, claims := testGame()
+	tree, err := Build(startTime, game, claims, make([]bool, len(claims)))
+	require.NoError(t, err)
+	return tree
+}
@@ -0,0 +1,210 @@
+package claimtree
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var ErrInvalidPosition = errors.New("invalid position")
+
+type Move string
+
+const (
+	MoveRoot   Move = "root"
+	MoveAttack Move = "attack"
+	MoveDefend Move = "defend"
+)
+
+// Game describes the dispute game the claims belong to.
+type Game struct {
+	Address                 common.Address
+	Status                  gameTypes.GameStatus
+	L2BlockNumber           uint64
+	RootClaim               common.Hash
+	SplitDepth              types.Depth
+	MaxDepth                types.Depth
+	MaxClockDuration        time.Duration
+	L2BlockNumberChallenged bool
+	L2BlockNumberChallenger common.Address
+
+	// HonestActors are the addresses whose claims are assumed to be correct.
+	HonestActors []common.Address
+	// AgreeWithRootClaim is whether the honest actor agrees with the root claim, if known.
+	AgreeWithRootClaim *bool
+}
+
+// Tree is an exportable representation of the claims in a dispute game.
+type Tree struct {
+	Game                    common.Address  `json:"game"`
+	Status                  string          `json:"status"`
+	L2BlockNumber           uint64          `json:"l2BlockNumber"`
+	RootClaim               common.Hash     `json:"rootClaim"`
+	SplitDepth              uint64          `json:"splitDepth"`
+	MaxDepth                uint64          `json:"maxDepth"`
+	L2BlockNumberChallenger *common.Address `json:"l2BlockNumberChallenger,omitempty"`
+	Nodes                   []Node          `json:"nodes"`
+}
+
+type Node struct {
+	Index       int   `json:"index"`
+	ParentIndex *int  `json:"parentIndex,omitempty"`
+	Children    []int `json:"children"`
+	Move        Move  `json:"move"`
+
+	Depth    uint64       `json:"depth"`
+	Position *hexutil.Big `json:"position"`
+	// TraceIndex is the index in the output root trace for claims in the top game
+	// and the index in the execution trace for claims in the bottom game.
+	TraceIndex *hexutil.Big   `json:"traceIndex"`
+	Value      common.Hash    `json:"value"`
+	Claimant   common.Address `json:"claimant"`
+	Bond       *hexutil.Big   `json:"bond"`
+
+	// ClockRemaining is the number of seconds remaining on the chess clock to counter the claim.
+	ClockRemaining uint64 `json:"clockRemaining"`
+	Resolved       bool   `json:"resolved"`
+	// Countered is true if the claim has been countered, either by a resolved counter claim or because
+	// it currently has an uncountered child claim.
+	Countered   bool            `json:"countered"`
+	CounteredBy *common.Address `json:"counteredBy,omitempty"`
+
+	// HonestAgrees is whether the honest actor agrees with the claim. Unset when it can't be determined.
+	HonestAgrees *bool `json:"honestAgrees,omitempty"`
+}
+
+// Build creates a Tree from the game's claims. resolved reports whether each claim has been resolved and must be
+// the same length as claims. Claims must be ordered by contract index, as they are in the contract.
+func Build(now time.Time, game Game, claims []types.Claim, resolved []bool) (*Tree, error) {
+	if len(resolved) != len(claims) {
+		return nil, fmt.Errorf("got resolution status for %v claims but have %v claims", len(resolved), len(claims))
+	}
+	tree := &Tree{
+		Game:          game.Address,
+		Status:        game.Status.String(),
+		L2BlockNumber: game.L2BlockNumber,
+		RootClaim:     game.RootClaim,
+		SplitDepth:    uint64(game.SplitDepth),
+		MaxDepth:      uint64(game.MaxDepth),
+		Nodes:         make([]Node, len(claims)),
+	}
+	if game.L2BlockNumberChallenged {
+		tree.L2BlockNumberChallenger = &game.L2BlockNumberChallenger
+	}
+	gameState := types.NewGameState(claims, game.MaxDepth)
+	for i, claim := range claims {
+		if claim.ContractIndex != i {
+			return nil, fmt.Errorf("claim at index %v has contract index %v", i, claim.ContractIndex)
+		}
+		traceIdx, err := TraceIndex(claim.Position, game.SplitDepth, game.MaxDepth)
+		if err != nil {
+			return nil, fmt.Errorf("invalid position for claim %v: %w", i, err)
+		}
+		node := Node{
+			Index:          i,
+			Children:       []int{},
+			Move:           MoveRoot,
+			Depth:          uint64(claim.Depth()),
+			Position:       (*hexutil.Big)(claim.Position.ToGIndex()),
+			TraceIndex:     (*hexutil.Big)(traceIdx),
+			Value:          claim.Value,
+			Claimant:       claim.Claimant,
+			Bond:           (*hexutil.Big)(bigOrZero(claim.Bond)),
+			ClockRemaining: uint64(max(game.MaxClockDuration-gameState.ChessClock(now, claim), 0).Seconds()),
+			Resolved:       resolved[i],
+		}
+		if claim.CounteredBy != (common.Address{}) {
+			node.CounteredBy = &claim.CounteredBy
+		} else if claim.IsRoot() && tree.L2BlockNumberChallenger != nil {
+			node.CounteredBy = tree.L2BlockNumberChallenger
+		}
+		if !claim.IsRoot() {
+			if claim.ParentContractIndex < 0 || claim.ParentContractIndex >= i {
+				return nil, fmt.Errorf("claim %v has invalid parent index %v", i, claim.ParentContractIndex)
+			}
+			parent := claim.ParentContractIndex
+			node.ParentIndex = &parent
+			tree.Nodes[parent].Children = append(tree.Nodes[parent].Children, i)
+			node.Move = MoveAttack
+			if gameState.DefendsParent(claim) {
+				node.Move = MoveDefend
+			}
+		}
+		tree.Nodes[i] = node
+	}
+	// Children always have a higher index than their parent so walk backwards to determine counter status
+	for i := len(tree.Nodes) - 1; i >= 0; i-- {
+		node := &tree.Nodes[i]
+		if node.Resolved || node.CounteredBy != nil {
+			node.Countered = node.CounteredBy != nil
+			continue
+		}
+		node.Countered = slices.ContainsFunc(node.Children, func(child int) bool {
+			return !tree.Nodes[child].Countered
+		})
+	}
+	for i := range tree.Nodes {
+		tree.Nodes[i].HonestAgrees = honestAgreement(game, tree, i)
+	}
+	return tree, nil
+}
+
+// honestAgreement determines whether the honest actor agrees with a claim based on the honest actor's own moves.
+func honestAgreement(game Game, tree *Tree, idx int) *bool {
+	node := tree.Nodes[idx]
+	agree, disagree := true, false
+	if node.ParentIndex == nil && game.AgreeWithRootClaim != nil {
+		return game.AgreeWithRootClaim
+	}
+	if slices.Contains(game.HonestActors, node.Claimant) {
+		return &agree
+	}
+	if node.CounteredBy != nil && slices.Contains(game.HonestActors, *node.CounteredBy) {
+		return &disagree
+	}
+	for _, childIdx := range node.Children {
+		child := tree.Nodes[childIdx]
+		if !slices.Contains(game.HonestActors, child.Claimant) {
+			continue
+		}
+		// An honest attack disputes the claim while an honest defense agrees with it.
+		if child.Move == MoveDefend {
+			return &agree
+		}
+		return &disagree
+	}
+	return nil
+}
+
+// TraceIndex returns the trace index of a position. Positions in the top game are indexed into the output root
+// trace, while positions in the bottom game are indexed into the execution trace of their disputed output root.
+func TraceIndex(pos types.Position, splitDepth types.Depth, maxDepth types.Depth) (*big.Int, error) {
+	if pos.Depth() > maxDepth {
+		return nil, fmt.Errorf("%w: depth %v exceeds max depth %v", ErrInvalidPosition, pos.Depth(), maxDepth)
+	}
+	if pos.Depth() <= splitDepth {
+		return pos.TraceIndex(splitDepth), nil
+	}
+	relativePos, err := pos.RelativeToAncestorAtDepth(splitDepth + 1)
+	if err != nil {
+		return nil, err
+	}
+	// The top game runs from depth 0 to split depth *inclusive*.
+	// The - 1 here accounts for the fact that the split depth is included in the top game.
+	bottomDepth := maxDepth - splitDepth - 1
+	return relativePos.TraceIndex(bottomDepth), nil
+}
+
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
@@ -0,0 +1,225 @@
+package claimtree
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	proposer   = common.Address{0xaa}
+	honest     = common.Address{0xbb}
+	dishonest  = common.Address{0xcc}
+	challenger = common.Address{0xdd}
+	startTime  = time.Unix(10_000, 0)
+)
+
+func TestBuild(t *testing.T) {
+	game, claims := testGame()
+	now := startTime.Add(30 * time.Minute)
+	tree, err := Build(now, game, claims, make([]bool, len(claims)))
+	require.NoError(t, err)
+	require.Equal(t, game.Address, tree.Game)
+	require.Equal(t, gameTypes.GameStatusInProgress.String(), tree.Status)
+	require.Equal(t, uint64(2), tree.SplitDepth)
+	require.Equal(t, uint64(4), tree.MaxDepth)
+	require.Nil(t, tree.L2BlockNumberChallenger)
+	require.Len(t, tree.Nodes, 4)
+
+	root := tree.Nodes[0]
+	require.Nil(t, root.ParentIndex)
+	require.Equal(t, MoveRoot, root.Move)
+	require.Equal(t, []int{1}, root.Children)
+	require.Equal(t, big.NewInt(1), root.Position.ToInt())
+	require.Equal(t, big.NewInt(3), root.TraceIndex.ToInt())
+	require.Equal(t, uint64(30*60), root.ClockRemaining)
+	require.True(t, root.Countered, "should be countered by uncountered attack")
+	require.Nil(t, root.CounteredBy)
+	require.False(t, *root.HonestAgrees, "honest actor attacked the root")
+
+	attack := tree.Nodes[1]
+	require.Equal(t, 0, *attack.ParentIndex)
+	require.Equal(t, MoveAttack, attack.Move)
+	require.Equal(t, []int{2}, attack.Children)
+	require.Equal(t, uint64(1), attack.Depth)
+	require.Equal(t, big.NewInt(1), attack.TraceIndex.ToInt())
+	require.Equal(t, uint64(40*60), attack.ClockRemaining)
+	require.False(t, attack.Countered, "only child is countered")
+	require.True(t, *attack.HonestAgrees, "honest claimant")
+
+	defend := tree.Nodes[2]
+	require.Equal(t, MoveDefend, defend.Move)
+	require.True(t, defend.Countered)
+	require.False(t, *defend.HonestAgrees, "honest actor attacked the claim")
+
+	bottom := tree.Nodes[3]
+	require.Equal(t, MoveAttack, bottom.Move)
+	require.Equal(t, uint64(3), bottom.Depth)
+	require.Equal(t, big.NewInt(1), bottom.TraceIndex.ToInt(), "should be relative to the bottom game")
+	require.Empty(t, bottom.Children)
+	require.False(t, bottom.Countered)
+	require.True(t, *bottom.HonestAgrees)
+}
+
+func TestBuild_ClockExpired(t *testing.T) {
+	game, claims := testGame()
+	tree, err := Build(startTime.Add(2*time.Hour), game, claims, make([]bool, len(claims)))
+	require.NoError(t, err)
+	for _, node := range tree.Nodes {
+		require.Zero(t, node.ClockRemaining)
+	}
+}
+
+func TestBuild_HonestAgreement(t *testing.T) {
+	t.Run("UnknownWithoutHonestMoves", func(t *testing.T) {
+		game, claims := testGame()
+		game.HonestActors = nil
+		tree, err := Build(startTime, game, claims, make([]bool, len(claims)))
+		require.NoError(t, err)
+		for _, node := range tree.Nodes {
+			require.Nil(t, node.HonestAgrees)
+		}
+	})
+
+	t.Run("RootAgreementKnown", func(t *testing.T) {
+		game, claims := testGame()
+		agree := true
+		game.AgreeWithRootClaim = &agree
+		tree, err := Build(startTime, game, claims, make([]bool, len(claims)))
+		require.NoError(t, err)
+		require.True(t, *tree.Nodes[0].HonestAgrees)
+	})
+
+	t.Run("HonestDefense", func(t *testing.T) {
+		game, claims := testGame()
+		claims[2].Claimant = honest
+		claims[3].Claimant = dishonest
+		tree, err := Build(startTime, game, claims, make([]bool, len(claims)))
+		require.NoError(t, err)
+		require.True(t, *tree.Nodes[1].HonestAgrees, "honest actor defended the claim")
+		require.True(t, *tree.Nodes[2].HonestAgrees)
+		require.Nil(t, tree.Nodes[3].HonestAgrees)
+	})
+
+	t.Run("CounteredByHonestActor", func(t *testing.T) {
+		game, claims := testGame()
+		claims[3].Claimant = dishonest
+		claims[3].CounteredBy = honest
+		tree, err := Build(startTime, game, claims, []bool{false, false, false, true})
+		require.NoError(t, err)
+		require.False(t, *tree.Nodes[3].HonestAgrees)
+	})
+}
+
+func TestBuild_Resolved(t *testing.T) {
+	game, claims := testGame()
+	game.Status = gameTypes.GameStatusChallengerWon
+	claims[0].CounteredBy = honest
+	tree, err := Build(startTime, game, claims, []bool{true, true, true, true})
+	require.NoError(t, err)
+	require.Equal(t, gameTypes.GameStatusChallengerWon.String(), tree.Status)
+	require.True(t, tree.Nodes[0].Countered)
+	require.Equal(t, honest, *tree.Nodes[0].CounteredBy)
+	for _, node := range tree.Nodes[1:] {
+		require.True(t, node.Resolved)
+		require.False(t, node.Countered, "resolved claims use the contract counter status")
+	}
+}
+
+func TestBuild_L2BlockNumberChallenged(t *testing.T) {
+	game, claims := testGame()
+	game.L2BlockNumberChallenged = true
+	game.L2BlockNumberChallenger = challenger
+	tree, err := Build(startTime, game, claims, make([]bool, len(claims)))
+	require.NoError(t, err)
+	require.Equal(t, challenger, *tree.L2BlockNumberChallenger)
+	require.True(t, tree.Nodes[0].Countered)
+	require.Equal(t, challenger, *tree.Nodes[0].CounteredBy)
+}
+
+func TestBuild_Invalid(t *testing.T) {
+	t.Run("ResolvedLengthMismatch", func(t *testing.T) {
+		game, claims := testGame()
+		_, err := Build(startTime, game, claims, []bool{true})
+		require.ErrorContains(t, err, "resolution status")
+	})
+
+	t.Run("UnorderedClaims", func(t *testing.T) {
+		game, claims := testGame()
+		claims[1], claims[2] = claims[2], claims[1]
+		_, err := Build(startTime, game, claims, make([]bool, len(claims)))
+		require.ErrorContains(t, err, "contract index")
+	})
+
+	t.Run("BeyondMaxDepth", func(t *testing.T) {
+		game, claims := testGame()
+		game.MaxDepth = 2
+		_, err := Build(startTime, game, claims, make([]bool, len(claims)))
+		require.ErrorIs(t, err, ErrInvalidPosition)
+	})
+}
+
+func TestTraceIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		pos      types.Position
+		expected int64
+	}{
+		{"Root", types.RootPosition, 3},
+		{"TopGameLeftmost", types.NewPosition(2, big.NewInt(0)), 0},
+		{"TopGameRightmost", types.NewPosition(2, big.NewInt(3)), 3},
+		{"BottomGameRoot", types.NewPosition(3, big.NewInt(3)), 1},
+		{"BottomGameLeaf", types.NewPosition(4, big.NewInt(6)), 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			idx, err := TraceIndex(test.pos, 2, 4)
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(test.expected), idx)
+		})
+	}
+}
+
+func testGame() (Game, []types.Claim) {
+	game := Game{
+		Address:          common.Address{0x01},
+		Status:           gameTypes.GameStatusInProgress,
+		L2BlockNumber:    42,
+		RootClaim:        common.Hash{0xab},
+		SplitDepth:       2,
+		MaxDepth:         4,
+		MaxClockDuration: time.Hour,
+		HonestActors:     []common.Address{honest},
+	}
+	root := types.Claim{
+		ClaimData: types.ClaimData{Value: common.Hash{0xab}, Position: types.RootPosition, Bond: big.NewInt(100)},
+		Claimant:  proposer,
+		Clock:     types.Clock{Timestamp: startTime},
+	}
+	attack := types.Claim{
+		ClaimData:     types.ClaimData{Value: common.Hash{0x02}, Position: root.Position.Attack(), Bond: big.NewInt(200)},
+		Claimant:      honest,
+		Clock:         types.Clock{Timestamp: startTime.Add(10 * time.Minute)},
+		ContractIndex: 1,
+	}
+	defend := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x03}, Position: attack.Position.Defend(), Bond: big.NewInt(300)},
+		Claimant:            dishonest,
+		Clock:               types.Clock{Timestamp: startTime.Add(15 * time.Minute), Duration: 10 * time.Minute},
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+	}
+	bottom := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x04}, Position: defend.Position.Attack(), Bond: big.NewInt(400)},
+		Claimant:            honest,
+		Clock:               types.Clock{Timestamp: startTime.Add(20 * time.Minute), Duration: 5 * time.Minute},
+		ContractIndex:       3,
+		ParentContractIndex: 2,
+	}
+	return game, []types.Claim{root, attack, defend, bottom}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -16,6 +17,10 @@ import (
 var (
 	methodWithdrawals = "withdrawals"
 	methodDelay       = "delay"
+	methodBalanceOf   = "balanceOf"
+	methodUnlock      = "unlock"
+	// methodWithdrawFrom is the overload of withdraw that accepts the sub-account to withdraw from.
+	methodWithdrawFrom = "withdraw0"
 )
 
 type DelayedWETHContract struct {
@@ -80,3 +85,24 @@ func (d *DelayedWETHContract) GetWithdrawals(ctx context.Context, block rpcblock
 	}
 	return withdrawals, nil
 }
+
+// BalanceOf returns the amount of WETH held by addr.
+func (d *DelayedWETHContract) BalanceOf(ctx context.Context, block rpcblock.Block, addr common.Address) (*big.Int, error) {
+	defer d.metrics.StartContractRequest("BalanceOf")()
+	result, err := d.multiCaller.SingleCall(ctx, block, d.contract.Call(methodBalanceOf, addr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve WETH balance of %v: %w", addr, err)
+	}
+	return result.GetBigInt(0), nil
+}
+
+// UnlockTx creates a transaction to start the withdrawal delay for amount of the sender's WETH.
+// The sender can withdraw the WETH from the guy sub-account once the delay has passed.
+func (d *DelayedWETHContract) UnlockTx(guy common.Address, amount *big.Int) (txmgr.TxCandidate, error) {
+	return d.contract.Call(methodUnlock, guy, amount).ToTxCandidate()
+}
+
+// WithdrawTx creates a transaction to withdraw amount of previously unlocked WETH from the guy sub-account as ETH.
+func (d *DelayedWETHContract) WithdrawTx(guy common.Address, amount *big.Int) (txmgr.TxCandidate, error) {
+	return d.contract.Call(methodWithdrawFrom, guy, amount).ToTxCandidate()
+}
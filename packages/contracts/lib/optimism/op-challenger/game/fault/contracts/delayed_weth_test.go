@@ -65,3 +65,37 @@ func setupDelayedWethTest(t *testing.T) (*batchingTest.AbiBasedRpc, *DelayedWETH
 	weth := NewDelayedWETHContract(contractMetrics.NoopContractMetrics, delayedWeth, caller)
 	return stubRpc, weth
 }
+
+func TestDelayedWeth_BalanceOf(t *testing.T) {
+	stubRpc, weth := setupDelayedWethTest(t)
+	block := rpcblock.ByNumber(482)
+	addr := common.Address{0x01}
+	balance := big.NewInt(9827)
+	stubRpc.SetResponse(delayedWeth, methodBalanceOf, block, []interface{}{addr}, []interface{}{balance})
+
+	actual, err := weth.BalanceOf(context.Background(), block, addr)
+	require.NoError(t, err)
+	require.Equal(t, balance, actual)
+}
+
+func TestDelayedWeth_UnlockTx(t *testing.T) {
+	stubRpc, weth := setupDelayedWethTest(t)
+	guy := common.Address{0x01}
+	amount := big.NewInt(1234)
+	stubRpc.SetResponse(delayedWeth, methodUnlock, rpcblock.Latest, []interface{}{guy, amount}, nil)
+
+	tx, err := weth.UnlockTx(guy, amount)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
+
+func TestDelayedWeth_WithdrawTx(t *testing.T) {
+	stubRpc, weth := setupDelayedWethTest(t)
+	guy := common.Address{0x01}
+	amount := big.NewInt(1234)
+	stubRpc.SetResponse(delayedWeth, methodWithdrawFrom, rpcblock.Latest, []interface{}{guy, amount}, nil)
+
+	tx, err := weth.WithdrawTx(guy, amount)
+	require.NoError(t, err)
+	stubRpc.VerifyTxCandidate(tx)
+}
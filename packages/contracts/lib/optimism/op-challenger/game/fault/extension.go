@@ -0,0 +1,136 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/external"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/prestates"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrMissingRegisterTask = errors.New("game type extension must create a register task")
+
+// RegisterTaskCreator creates the task that registers an extension's game type. It is only called when the extension's
+// trace type is enabled. The returned CloseFunc, if not nil, is called when the challenger shuts down.
+type RegisterTaskCreator func(ctx context.Context, logger log.Logger, m metrics.Metricer, cfg *config.Config) (*RegisterTask, CloseFunc, error)
+
+// GameTypeExtension adds support for a game type that isn't built into op-challenger, allowing new proof systems to be
+// added by building a custom binary that registers the extension before starting the challenger.
+type GameTypeExtension struct {
+	// TraceType is the name used to enable the game type with --trace-type.
+	TraceType faultTypes.TraceType
+	// GameType is the game type ID used by the dispute game factory.
+	GameType        faultTypes.GameType
+	NewRegisterTask RegisterTaskCreator
+}
+
+var extensions []GameTypeExtension
+
+// RegisterExtension adds a game type extension. It must be called before flags are parsed, typically from an init
+// function, and fails if the trace type or game type is already supported.
+func RegisterExtension(ext GameTypeExtension) error {
+	if ext.NewRegisterTask == nil {
+		return fmt.Errorf("%w: %v", ErrMissingRegisterTask, ext.TraceType)
+	}
+	if err := faultTypes.RegisterTraceType(ext.TraceType, ext.GameType); err != nil {
+		return err
+	}
+	extensions = append(extensions, ext)
+	return nil
+}
+
+// MustRegisterExtension is like RegisterExtension but panics if the extension can't be registered.
+func MustRegisterExtension(ext GameTypeExtension) {
+	if err := RegisterExtension(ext); err != nil {
+		panic(err)
+	}
+}
+
+// newExtensionRegisterTasks creates the register tasks for the enabled game type extensions.
+func newExtensionRegisterTasks(ctx context.Context, logger log.Logger, m metrics.Metricer, cfg *config.Config) ([]*RegisterTask, []CloseFunc, error) {
+	var tasks []*RegisterTask
+	var closers []CloseFunc
+	closeAll := func() {
+		for _, closeFn := range closers {
+			closeFn()
+		}
+	}
+	for _, ext := range extensions {
+		if !cfg.TraceTypeEnabled(ext.TraceType) {
+			continue
+		}
+		task, closeFn, err := ext.NewRegisterTask(ctx, logger, m, cfg)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to create %v register task: %w", ext.TraceType, err)
+		}
+		if closeFn != nil {
+			closers = append(closers, closeFn)
+		}
+		if task.gameType != ext.GameType {
+			closeAll()
+			return nil, nil, fmt.Errorf("register task for trace type %v has game type %v, expected %v", ext.TraceType, task.gameType, ext.GameType)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, closers, nil
+}
+
+// NewExtensionRegisterTask creates a RegisterTask for an output root game where the bottom half of the game is played
+// with the trace providers created by newTraceProvider.
+// getPrestateProvider returns the absolute prestate provider for the bottom half of games with the specified prestate
+// hash. It is validated against the game's absolute prestate before the game is played.
+func NewExtensionRegisterTask(
+	gameType faultTypes.GameType,
+	getPrestateProvider func(ctx context.Context, prestateHash common.Hash) (faultTypes.PrestateProvider, error),
+	newTraceProvider outputs.BottomTraceProviderCreator,
+) *RegisterTask {
+	return &RegisterTask{
+		gameType:            gameType,
+		getPrestateProvider: getPrestateProvider,
+		newTraceAccessor: func(
+			logger log.Logger,
+			m metrics.Metricer,
+			l2Client utils.L2HeaderSource,
+			prestateProvider faultTypes.PrestateProvider,
+			vmPrestateProvider faultTypes.PrestateProvider,
+			rollupClient outputs.OutputRollupClient,
+			dir string,
+			l1Head eth.BlockID,
+			splitDepth faultTypes.Depth,
+			prestateBlock uint64,
+			poststateBlock uint64) (*trace.Accessor, error) {
+			return outputs.NewOutputTraceAccessor(logger, m, gameType.String(), newTraceProvider, l2Client, prestateProvider, vmPrestateProvider, rollupClient, dir, l1Head, splitDepth, prestateBlock, poststateBlock)
+		},
+	}
+}
+
+// NewExternalProverRegisterTask creates a RegisterTask for an output root game where the bottom half of the game is
+// played using claims and step data from an external prover. See external.ProverNamespace for the protocol.
+// Requests include the game's absolute prestate hash so the prover can support games with different prestates.
+func NewExternalProverRegisterTask(gameType faultTypes.GameType, m caching.Metrics, client external.RPC) *RegisterTask {
+	prestateProviderCache := prestates.NewPrestateProviderCache(m, fmt.Sprintf("prestates-%v", gameType), func(_ context.Context, prestateHash common.Hash) (faultTypes.PrestateProvider, error) {
+		return external.NewPrestateProvider(client, prestateHash), nil
+	})
+	return NewExtensionRegisterTask(
+		gameType,
+		prestateProviderCache.GetOrCreate,
+		func(_ context.Context, logger log.Logger, vmPrestate faultTypes.PrestateProvider, localInputs utils.LocalGameInputs, depth faultTypes.Depth, _ string) (faultTypes.TraceProvider, error) {
+			prestateProvider, ok := vmPrestate.(*external.PrestateProvider)
+			if !ok {
+				return nil, fmt.Errorf("unexpected prestate provider type %T", vmPrestate)
+			}
+			return external.NewTraceProvider(logger, client, prestateProvider, localInputs, depth), nil
+		})
+}
@@ -0,0 +1,108 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterExtension(t *testing.T) {
+	resetExtensions(t)
+	ext := GameTypeExtension{
+		TraceType:       "test-vm",
+		GameType:        42,
+		NewRegisterTask: stubRegisterTaskCreator(42, nil),
+	}
+	require.NoError(t, RegisterExtension(ext))
+	require.True(t, faultTypes.ValidTraceType("test-vm"))
+	require.Equal(t, faultTypes.GameType(42), faultTypes.TraceType("test-vm").GameType())
+
+	require.ErrorIs(t, RegisterExtension(ext), faultTypes.ErrTraceTypeExists)
+	require.ErrorIs(t, RegisterExtension(GameTypeExtension{TraceType: "other-vm", GameType: 43}), ErrMissingRegisterTask)
+	require.Panics(t, func() {
+		MustRegisterExtension(GameTypeExtension{TraceType: "other-vm", GameType: faultTypes.CannonGameType, NewRegisterTask: stubRegisterTaskCreator(faultTypes.CannonGameType, nil)})
+	})
+}
+
+func TestNewExtensionRegisterTasks(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	t.Run("OnlyEnabledExtensions", func(t *testing.T) {
+		resetExtensions(t)
+		closed := 0
+		require.NoError(t, RegisterExtension(GameTypeExtension{TraceType: "vm-a", GameType: 44, NewRegisterTask: stubRegisterTaskCreator(44, func() { closed++ })}))
+		require.NoError(t, RegisterExtension(GameTypeExtension{TraceType: "vm-b", GameType: 45, NewRegisterTask: stubRegisterTaskCreator(45, func() { closed++ })}))
+
+		cfg := &config.Config{TraceTypes: []faultTypes.TraceType{faultTypes.TraceTypeCannon, "vm-b"}}
+		tasks, closers, err := newExtensionRegisterTasks(context.Background(), logger, metrics.NoopMetrics, cfg)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.Equal(t, faultTypes.GameType(45), tasks[0].gameType)
+		require.Len(t, closers, 1)
+		closers[0]()
+		require.Equal(t, 1, closed)
+	})
+
+	t.Run("CreatorError", func(t *testing.T) {
+		resetExtensions(t)
+		closed := 0
+		require.NoError(t, RegisterExtension(GameTypeExtension{TraceType: "vm-c", GameType: 46, NewRegisterTask: stubRegisterTaskCreator(46, func() { closed++ })}))
+		require.NoError(t, RegisterExtension(GameTypeExtension{
+			TraceType: "vm-d",
+			GameType:  47,
+			NewRegisterTask: func(_ context.Context, _ log.Logger, _ metrics.Metricer, _ *config.Config) (*RegisterTask, CloseFunc, error) {
+				return nil, nil, errors.New("boom")
+			},
+		}))
+
+		cfg := &config.Config{TraceTypes: []faultTypes.TraceType{"vm-c", "vm-d"}}
+		_, _, err := newExtensionRegisterTasks(context.Background(), logger, metrics.NoopMetrics, cfg)
+		require.ErrorContains(t, err, "boom")
+		require.Equal(t, 1, closed, "should close previously created extensions")
+	})
+
+	t.Run("MismatchedGameType", func(t *testing.T) {
+		resetExtensions(t)
+		require.NoError(t, RegisterExtension(GameTypeExtension{TraceType: "vm-e", GameType: 48, NewRegisterTask: stubRegisterTaskCreator(49, nil)}))
+
+		cfg := &config.Config{TraceTypes: []faultTypes.TraceType{"vm-e"}}
+		_, _, err := newExtensionRegisterTasks(context.Background(), logger, metrics.NoopMetrics, cfg)
+		require.ErrorContains(t, err, "expected vm-e")
+	})
+}
+
+func TestExternalProverPrestateProviders(t *testing.T) {
+	task := NewExternalProverRegisterTask(50, metrics.NoopMetrics, nil)
+	prestateA, err := task.getPrestateProvider(context.Background(), common.Hash{0xaa})
+	require.NoError(t, err)
+	prestateB, err := task.getPrestateProvider(context.Background(), common.Hash{0xbb})
+	require.NoError(t, err)
+	require.NotSame(t, prestateA, prestateB, "should use a different provider for each prestate")
+	cached, err := task.getPrestateProvider(context.Background(), common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Same(t, prestateA, cached)
+}
+
+func stubRegisterTaskCreator(gameType faultTypes.GameType, closeFn CloseFunc) RegisterTaskCreator {
+	return func(_ context.Context, _ log.Logger, _ metrics.Metricer, _ *config.Config) (*RegisterTask, CloseFunc, error) {
+		return NewExternalProverRegisterTask(gameType, metrics.NoopMetrics, nil), closeFn, nil
+	}
+}
+
+// resetExtensions restores the registered extensions after the test.
+// Trace types can't be unregistered so each test must use unique trace and game types.
+func resetExtensions(t *testing.T) {
+	origExtensions := slices.Clone(extensions)
+	t.Cleanup(func() {
+		extensions = origExtensions
+	})
+}
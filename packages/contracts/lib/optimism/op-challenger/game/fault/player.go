@@ -43,6 +43,7 @@ type TxSender interface {
 
 type GamePlayer struct {
 	act                actor
+	urgency            func() gameTypes.Urgency
 	loader             GameInfo
 	logger             log.Logger
 	syncValidator      SyncValidator
@@ -151,6 +152,7 @@ func NewGamePlayer(
 	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants)
 	return &GamePlayer{
 		act:                agent.Act,
+		urgency:            agent.Urgency,
 		loader:             loader,
 		logger:             logger,
 		status:             status,
@@ -200,10 +202,19 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	if status != gameTypes.GameStatusInProgress {
 		// Release the agent as we will no longer need to act on this game.
 		g.act = actNoop
+		g.urgency = nil
 	}
 	return status
 }
 
+// Urgency returns the urgency of the actions required the last time the game was progressed.
+func (g *GamePlayer) Urgency() gameTypes.Urgency {
+	if g.urgency == nil {
+		return gameTypes.Urgency{}
+	}
+	return g.urgency()
+}
+
 func (g *GamePlayer) logGameStatus(ctx context.Context, status gameTypes.GameStatus) {
 	if status == gameTypes.GameStatusInProgress {
 		claimCount, err := g.loader.GetClaimCount(ctx)
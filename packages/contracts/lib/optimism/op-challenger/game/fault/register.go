@@ -3,6 +3,7 @@ package fault
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
@@ -66,18 +67,26 @@ func RegisterGameTypes(
 	}
 	syncValidator := newSyncStatusValidator(rollupClient)
 
+	var traceCache *vm.TraceCache
+	if cfg.TraceCacheMaxSize > 0 {
+		traceCache = vm.NewTraceCache(logger, m, filepath.Join(cfg.Datadir, "trace-cache"), int64(cfg.TraceCacheMaxSize))
+		if err := traceCache.Evict(); err != nil {
+			logger.Warn("Failed to evict trace cache entries", "err", err)
+		}
+	}
+
 	var registerTasks []*RegisterTask
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeCannon) {
-		registerTasks = append(registerTasks, NewCannonRegisterTask(faultTypes.CannonGameType, cfg, m, vm.NewOpProgramServerExecutor(logger)))
+		registerTasks = append(registerTasks, NewCannonRegisterTask(faultTypes.CannonGameType, cfg, m, vm.NewOpProgramServerExecutor(logger), traceCache))
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypePermissioned) {
-		registerTasks = append(registerTasks, NewCannonRegisterTask(faultTypes.PermissionedGameType, cfg, m, vm.NewOpProgramServerExecutor(logger)))
+		registerTasks = append(registerTasks, NewCannonRegisterTask(faultTypes.PermissionedGameType, cfg, m, vm.NewOpProgramServerExecutor(logger), traceCache))
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAsterisc) {
-		registerTasks = append(registerTasks, NewAsteriscRegisterTask(faultTypes.AsteriscGameType, cfg, m, vm.NewOpProgramServerExecutor(logger)))
+		registerTasks = append(registerTasks, NewAsteriscRegisterTask(faultTypes.AsteriscGameType, cfg, m, vm.NewOpProgramServerExecutor(logger), traceCache))
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAsteriscKona) {
-		registerTasks = append(registerTasks, NewAsteriscKonaRegisterTask(faultTypes.AsteriscKonaGameType, cfg, m, vm.NewKonaExecutor()))
+		registerTasks = append(registerTasks, NewAsteriscKonaRegisterTask(faultTypes.AsteriscKonaGameType, cfg, m, vm.NewKonaExecutor(), traceCache))
 	}
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeFast) {
 		registerTasks = append(registerTasks, NewAlphabetRegisterTask(faultTypes.FastGameType))
@@ -85,10 +94,23 @@ func RegisterGameTypes(
 	if cfg.TraceTypeEnabled(faultTypes.TraceTypeAlphabet) {
 		registerTasks = append(registerTasks, NewAlphabetRegisterTask(faultTypes.AlphabetGameType))
 	}
+	extensionTasks, closers, err := newExtensionRegisterTasks(ctx, logger, m, cfg)
+	if err != nil {
+		l2Client.Close()
+		return nil, err
+	}
+	registerTasks = append(registerTasks, extensionTasks...)
+	closeAll := func() {
+		for _, closeFn := range closers {
+			closeFn()
+		}
+		l2Client.Close()
+	}
 	for _, task := range registerTasks {
 		if err := task.Register(ctx, registry, oracles, systemClock, l1Clock, logger, m, syncValidator, rollupClient, txSender, gameFactory, caller, l2Client, l1HeaderSource, selective, claimants); err != nil {
+			closeAll()
 			return nil, fmt.Errorf("failed to register %v game type: %w", task.gameType, err)
 		}
 	}
-	return l2Client.Close, nil
+	return closeAll, nil
 }
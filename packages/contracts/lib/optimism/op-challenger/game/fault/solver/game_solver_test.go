@@ -223,7 +223,8 @@ func runStep(t *testing.T, solver *GameSolver, game types.Game, correctTraceProv
 	actions, err := solver.CalculateNextActions(context.Background(), game)
 	require.NoError(t, err)
 
-	postState := applyActions(game, challengerAddr, actions)
+	postState, err := ApplyActions(game, challengerAddr, actions)
+	require.NoError(t, err)
 
 	for i, action := range actions {
 		t.Logf("Move %v: Type: %v, ParentIdx: %v, Attack: %v, Value: %v, PreState: %v, ProofData: %v",
@@ -327,34 +328,3 @@ func TestMultipleRounds(t *testing.T) {
 		}
 	}
 }
-
-func applyActions(game types.Game, claimant common.Address, actions []types.Action) types.Game {
-	claims := game.Claims()
-	for _, action := range actions {
-		switch action.Type {
-		case types.ActionTypeMove:
-			newPosition := action.ParentClaim.Position.Attack()
-			if !action.IsAttack {
-				newPosition = action.ParentClaim.Position.Defend()
-			}
-			claim := types.Claim{
-				ClaimData: types.ClaimData{
-					Value:    action.Value,
-					Bond:     big.NewInt(0),
-					Position: newPosition,
-				},
-				Claimant:            claimant,
-				ContractIndex:       len(claims),
-				ParentContractIndex: action.ParentClaim.ContractIndex,
-			}
-			claims = append(claims, claim)
-		case types.ActionTypeStep:
-			counteredClaim := claims[action.ParentClaim.ContractIndex]
-			counteredClaim.CounteredBy = claimant
-			claims[action.ParentClaim.ContractIndex] = counteredClaim
-		default:
-			panic(fmt.Errorf("unknown move type: %v", action.Type))
-		}
-	}
-	return types.NewGameState(claims, game.MaxDepth())
-}
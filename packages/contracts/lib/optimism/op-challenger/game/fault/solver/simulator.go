@@ -0,0 +1,253 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AdversaryAddr is the claimant used for hypothetical adversary moves in a simulation.
+var AdversaryAddr = common.Address(bytes.Repeat([]byte{0xbb}, 20))
+
+// ChallengerAddr is the claimant used for the honest challenger's moves in a simulation.
+var ChallengerAddr = challengerAddr
+
+var ErrInvalidAdversaryMove = errors.New("invalid adversary move")
+
+// validityRules are the rules from [rules] that the contracts enforce, so apply to adversary moves as well.
+var validityRules = []actionRule{
+	onlyStepAtMaxDepth,
+	onlyMoveBeforeMaxDepth,
+	doNotDuplicateExistingMoves,
+	doNotStepAlreadyCounteredClaims,
+	doNotDefendRootClaim,
+}
+
+// AdversaryMove is a hypothetical move against an existing claim in the game.
+type AdversaryMove struct {
+	ParentIdx int
+	// Step counters the parent claim at max depth, assuming the step succeeds.
+	Step     bool
+	IsAttack bool
+	Value    common.Hash
+}
+
+func (m AdversaryMove) String() string {
+	if m.Step {
+		return fmt.Sprintf("step %v", m.ParentIdx)
+	}
+	if m.IsAttack {
+		return fmt.Sprintf("attack %v with %v", m.ParentIdx, m.Value)
+	}
+	return fmt.Sprintf("defend %v with %v", m.ParentIdx, m.Value)
+}
+
+// SimulatedAction is an action the honest challenger would perform during a simulation.
+type SimulatedAction struct {
+	Round  int
+	Action types.Action
+	// RuleViolations is non-nil if the action breaks any of the rules the honest challenger is expected to follow.
+	RuleViolations error
+}
+
+// SubgameResolution describes how the subgame rooted at a claim resolves.
+type SubgameResolution struct {
+	Claim types.Claim
+	// Correct is true if the claim value matches the trace provider used for the simulation.
+	Correct bool
+	// CounteredBy is the claimant that wins the subgame against the claim,
+	// or the zero address if the claim is uncountered.
+	CounteredBy common.Address
+}
+
+// Countered returns true if the subgame resolves against the claim.
+func (r SubgameResolution) Countered() bool {
+	return r.CounteredBy != (common.Address{})
+}
+
+// Simulation is the result of running the honest challenger against a game.
+type Simulation struct {
+	// Game is the game state after all adversary moves and challenger actions are applied.
+	Game     types.Game
+	Actions  []SimulatedAction
+	Subgames []SubgameResolution
+}
+
+// RootCountered returns true if the game resolves in favour of the challengers.
+func (s *Simulation) RootCountered() bool {
+	return len(s.Subgames) > 0 && s.Subgames[0].Countered()
+}
+
+// Simulate applies the adversary moves to the game, then repeatedly runs the honest challenger until it
+// has no further actions to perform. Actions are checked against the rules in rules.go, evaluated using the
+// trace provider selected relative to the action's parent claim. Nothing is sent to L1.
+func Simulate(ctx context.Context, game types.Game, trace types.TraceAccessor, moves []AdversaryMove) (*Simulation, error) {
+	for i, move := range moves {
+		var err error
+		game, err = applyAdversaryMove(game, move)
+		if err != nil {
+			return nil, fmt.Errorf("adversary move %v (%v): %w", i, move, err)
+		}
+	}
+
+	solver := NewGameSolver(game.MaxDepth(), trace)
+	sim := &Simulation{}
+	// Each round can only add claims one level deeper, so the game is settled by max depth rounds.
+	for round := 0; round <= int(game.MaxDepth()); round++ {
+		actions, err := solver.CalculateNextActions(ctx, game)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate actions in round %v: %w", round, err)
+		}
+		if len(actions) == 0 {
+			break
+		}
+		for _, action := range actions {
+			var violations error
+			if action.Type != types.ActionTypeChallengeL2BlockNumber {
+				violations = checkRules(game, action, &accessorTraceProvider{trace, game, action.ParentClaim})
+			}
+			sim.Actions = append(sim.Actions, SimulatedAction{
+				Round:          round,
+				Action:         action,
+				RuleViolations: violations,
+			})
+		}
+		game, err = ApplyActions(game, ChallengerAddr, actions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply actions in round %v: %w", round, err)
+		}
+		if actions[0].Type == types.ActionTypeChallengeL2BlockNumber {
+			// The challenge resolves the whole game so no further actions are needed
+			break
+		}
+	}
+
+	subgames, err := resolveSubgames(ctx, game, trace)
+	if err != nil {
+		return nil, err
+	}
+	sim.Game = game
+	sim.Subgames = subgames
+	return sim, nil
+}
+
+func applyAdversaryMove(game types.Game, move AdversaryMove) (types.Game, error) {
+	if move.ParentIdx < 0 || move.ParentIdx >= len(game.Claims()) {
+		return nil, fmt.Errorf("%w: parent claim %v does not exist in game with %v claims", ErrInvalidAdversaryMove, move.ParentIdx, len(game.Claims()))
+	}
+	action := types.Action{
+		Type:        types.ActionTypeMove,
+		ParentClaim: game.Claims()[move.ParentIdx],
+		IsAttack:    move.IsAttack,
+		Value:       move.Value,
+	}
+	if move.Step {
+		action.Type = types.ActionTypeStep
+	}
+	var errs []error
+	for _, rule := range validityRules {
+		errs = append(errs, rule(game, action, nil))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAdversaryMove, err)
+	}
+	return ApplyActions(game, AdversaryAddr, []types.Action{action})
+}
+
+// ApplyActions returns a new game with the actions applied by claimant, as the contracts would.
+// Steps are assumed to succeed.
+func ApplyActions(game types.Game, claimant common.Address, actions []types.Action) (types.Game, error) {
+	claims := game.Claims()
+	for _, action := range actions {
+		switch action.Type {
+		case types.ActionTypeMove:
+			newPosition := action.ParentClaim.Position.Attack()
+			if !action.IsAttack {
+				newPosition = action.ParentClaim.Position.Defend()
+			}
+			claim := types.Claim{
+				ClaimData: types.ClaimData{
+					Value:    action.Value,
+					Bond:     big.NewInt(0),
+					Position: newPosition,
+				},
+				Claimant:            claimant,
+				ContractIndex:       len(claims),
+				ParentContractIndex: action.ParentClaim.ContractIndex,
+			}
+			claims = append(claims, claim)
+		case types.ActionTypeStep:
+			counteredClaim := claims[action.ParentClaim.ContractIndex]
+			counteredClaim.CounteredBy = claimant
+			claims[action.ParentClaim.ContractIndex] = counteredClaim
+		case types.ActionTypeChallengeL2BlockNumber:
+			// The root claim is countered by the L2 block number challenge
+			root := claims[0]
+			root.CounteredBy = claimant
+			claims[0] = root
+		default:
+			return nil, fmt.Errorf("unknown action type: %v", action.Type)
+		}
+	}
+	return types.NewGameState(claims, game.MaxDepth()), nil
+}
+
+// resolveSubgames resolves every subgame the same way the contracts do: a claim is countered by the
+// leftmost uncountered child, or by whoever successfully stepped against it if there is none.
+func resolveSubgames(ctx context.Context, game types.Game, trace types.TraceAccessor) ([]SubgameResolution, error) {
+	claims := game.Claims()
+	results := make([]SubgameResolution, len(claims))
+	leftmost := make([]*big.Int, len(claims))
+	for i := len(claims) - 1; i >= 0; i-- {
+		claim := claims[i]
+		value, err := trace.Get(ctx, game, claim, claim.Position)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get correct value for claim %v: %w", i, err)
+		}
+		results[i].Claim = claim
+		results[i].Correct = value == claim.Value
+		if results[i].CounteredBy == (common.Address{}) {
+			// No uncountered child was found while resolving the children.
+			results[i].CounteredBy = claim.CounteredBy
+		}
+		if claim.IsRoot() || results[i].Countered() {
+			continue
+		}
+		parent := claim.ParentContractIndex
+		idx := claim.Position.IndexAtDepth()
+		if leftmost[parent] == nil || idx.Cmp(leftmost[parent]) < 0 {
+			leftmost[parent] = idx
+			results[parent].CounteredBy = claim.Claimant
+		}
+	}
+	return results, nil
+}
+
+// accessorTraceProvider adapts a TraceAccessor to the TraceProvider used by the rules,
+// selecting the provider relative to a reference claim.
+type accessorTraceProvider struct {
+	trace types.TraceAccessor
+	game  types.Game
+	ref   types.Claim
+}
+
+func (a *accessorTraceProvider) AbsolutePreStateCommitment(_ context.Context) (common.Hash, error) {
+	return common.Hash{}, errors.New("absolute prestate not available")
+}
+
+func (a *accessorTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	return a.trace.Get(ctx, a.game, a.ref, pos)
+}
+
+func (a *accessorTraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	return a.trace.GetStepData(ctx, a.game, a.ref, pos)
+}
+
+func (a *accessorTraceProvider) GetL2BlockNumberChallenge(ctx context.Context) (*types.InvalidL2BlockNumberChallenge, error) {
+	return a.trace.GetL2BlockNumberChallenge(ctx, a.game)
+}
@@ -0,0 +1,93 @@
+package solver
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	faulttest "github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	maxDepth := types.Depth(4)
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(10), maxDepth)
+	accessor := trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider())
+	incorrect := common.Hash{0xaa}
+
+	t.Run("CounterIncorrectRoot", func(t *testing.T) {
+		game := claimBuilder.GameBuilder(faulttest.WithInvalidValue(true)).Game
+		sim, err := Simulate(context.Background(), game, accessor, nil)
+		require.NoError(t, err)
+		require.Len(t, sim.Actions, 1)
+		require.Equal(t, types.ActionTypeMove, sim.Actions[0].Action.Type)
+		require.True(t, sim.Actions[0].Action.IsAttack)
+		require.NoError(t, sim.Actions[0].RuleViolations)
+		require.True(t, sim.RootCountered())
+		require.Equal(t, ChallengerAddr, sim.Subgames[0].CounteredBy)
+		require.False(t, sim.Subgames[0].Correct)
+		require.True(t, sim.Subgames[1].Correct)
+		require.False(t, sim.Subgames[1].Countered())
+	})
+
+	t.Run("DefendCorrectRootAgainstAdversary", func(t *testing.T) {
+		game := claimBuilder.GameBuilder().Game
+		sim, err := Simulate(context.Background(), game, accessor, []AdversaryMove{
+			{ParentIdx: 0, IsAttack: true, Value: incorrect},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, sim.Actions)
+		for _, action := range sim.Actions {
+			require.NoError(t, action.RuleViolations)
+		}
+		require.False(t, sim.RootCountered())
+		require.Equal(t, AdversaryAddr, sim.Subgames[1].Claim.Claimant)
+		require.Equal(t, ChallengerAddr, sim.Subgames[1].CounteredBy)
+	})
+
+	t.Run("AdversaryStepsOnLeaf", func(t *testing.T) {
+		game := claimBuilder.GameBuilder(faulttest.WithInvalidValue(true)).Game
+		moves := []AdversaryMove{
+			// Build a chain of adversary claims down to max depth, then step against the leaf.
+			{ParentIdx: 0, IsAttack: true, Value: incorrect},
+			{ParentIdx: 1, IsAttack: true, Value: incorrect},
+			{ParentIdx: 2, IsAttack: true, Value: incorrect},
+			{ParentIdx: 3, IsAttack: true, Value: incorrect},
+			{ParentIdx: 4, Step: true},
+		}
+		sim, err := Simulate(context.Background(), game, accessor, moves)
+		require.NoError(t, err)
+		require.True(t, sim.Subgames[4].Countered())
+		require.Equal(t, AdversaryAddr, sim.Subgames[4].CounteredBy)
+		// The honest challenger still counters the incorrect root claim
+		require.True(t, sim.RootCountered())
+	})
+
+	t.Run("InvalidAdversaryMove", func(t *testing.T) {
+		game := claimBuilder.GameBuilder().Game
+		_, err := Simulate(context.Background(), game, accessor, []AdversaryMove{
+			{ParentIdx: 0, IsAttack: false, Value: incorrect},
+		})
+		require.ErrorIs(t, err, ErrInvalidAdversaryMove)
+
+		_, err = Simulate(context.Background(), game, accessor, []AdversaryMove{
+			{ParentIdx: 1, IsAttack: true, Value: incorrect},
+		})
+		require.ErrorIs(t, err, ErrInvalidAdversaryMove)
+
+		_, err = Simulate(context.Background(), game, accessor, []AdversaryMove{
+			{ParentIdx: 0, Step: true},
+		})
+		require.ErrorIs(t, err, ErrInvalidAdversaryMove)
+	})
+}
+
+func TestApplySimulatedActions(t *testing.T) {
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(10), types.Depth(4))
+	game := claimBuilder.GameBuilder().Game
+	_, err := ApplyActions(game, ChallengerAddr, []types.Action{{Type: types.ActionType("unknown"), ParentClaim: game.Claims()[0]}})
+	require.ErrorContains(t, err, "unknown action type")
+}
@@ -33,6 +33,9 @@ type AsteriscTraceProvider struct {
 
 	types.PrestateProvider
 
+	// cache is the shared trace cache entry the trace is stored in, nil if the trace cache is disabled.
+	cache *vm.TraceCacheEntry
+
 	// lastStep stores the last step in the actual trace if known. 0 indicates unknown.
 	// Cached as an optimisation to avoid repeatedly attempting to execute beyond the end of the trace.
 	lastStep uint64
@@ -43,7 +46,7 @@ func NewTraceProvider(logger log.Logger, m vm.Metricer, cfg vm.Config, vmCfg vm.
 		logger:    logger,
 		dir:       dir,
 		prestate:  asteriscPrestate,
-		generator: vm.NewExecutor(logger, m, cfg, vmCfg, asteriscPrestate, localInputs),
+		generator: vm.NewProofGenerator(logger, m, cfg, vmCfg, asteriscPrestate, localInputs),
 		gameDepth: gameDepth,
 		preimageLoader: utils.NewPreimageLoader(func() (utils.PreimageSource, error) {
 			return kvstore.NewDiskKV(logger, vm.PreimageDir(dir), kvtypes.DataFormatFile)
@@ -54,6 +57,14 @@ func NewTraceProvider(logger log.Logger, m vm.Metricer, cfg vm.Config, vmCfg vm.
 	}
 }
 
+// NewCachedTraceProvider creates a provider that stores its trace in a shared trace cache entry instead of a
+// game specific directory, reusing any snapshots and proofs already generated for the same prestate and inputs.
+func NewCachedTraceProvider(logger log.Logger, m vm.Metricer, cfg vm.Config, vmCfg vm.OracleServerExecutor, prestateProvider types.PrestateProvider, asteriscPrestate string, localInputs utils.LocalGameInputs, cache *vm.TraceCacheEntry, gameDepth types.Depth) *AsteriscTraceProvider {
+	provider := NewTraceProvider(logger, m, cfg, vmCfg, prestateProvider, asteriscPrestate, localInputs, cache.Dir(), gameDepth)
+	provider.cache = cache
+	return provider
+}
+
 func (p *AsteriscTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
 	traceIndex := pos.TraceIndex(p.gameDepth)
 	if !traceIndex.IsUint64() {
@@ -76,7 +87,10 @@ func (p *AsteriscTraceProvider) GetStepData(ctx context.Context, pos types.Posit
 	if !traceIndex.IsUint64() {
 		return nil, nil, nil, errors.New("trace index out of bounds")
 	}
-	proof, err := p.loadProof(ctx, traceIndex.Uint64())
+	// Hold the trace cache entry lock until the preimage is loaded so the entry can't be evicted in between
+	unlock := p.cache.Lock()
+	defer unlock()
+	proof, err := p.loadProofLocked(ctx, traceIndex.Uint64())
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -102,6 +116,14 @@ func (p *AsteriscTraceProvider) GetL2BlockNumberChallenge(_ context.Context) (*t
 // loadProof will attempt to load or generate the proof data at the specified index
 // If the requested index is beyond the end of the actual trace it is extended with no-op instructions.
 func (p *AsteriscTraceProvider) loadProof(ctx context.Context, i uint64) (*utils.ProofData, error) {
+	// Prevent other games sharing the trace cache entry from executing the VM at the same time
+	unlock := p.cache.Lock()
+	defer unlock()
+	return p.loadProofLocked(ctx, i)
+}
+
+// loadProofLocked is loadProof for callers that already hold the trace cache entry lock.
+func (p *AsteriscTraceProvider) loadProofLocked(ctx context.Context, i uint64) (*utils.ProofData, error) {
 	// Attempt to read the last step from disk cache
 	if p.lastStep == 0 {
 		step, err := utils.ReadLastStep(p.dir)
@@ -117,7 +139,9 @@ func (p *AsteriscTraceProvider) loadProof(ctx context.Context, i uint64) (*utils
 	}
 	path := filepath.Join(p.dir, utils.ProofsDir, fmt.Sprintf("%d.json.gz", i))
 	file, err := ioutil.OpenDecompressed(path)
+	p.cache.RecordGet(err == nil)
 	if errors.Is(err, os.ErrNotExist) {
+		defer p.cache.Evict()
 		if err := p.generator.GenerateProof(ctx, p.dir, i); err != nil {
 			return nil, fmt.Errorf("generate asterisc trace with proof at %v: %w", i, err)
 		}
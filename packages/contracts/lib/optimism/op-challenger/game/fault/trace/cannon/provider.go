@@ -34,6 +34,9 @@ type CannonTraceProvider struct {
 
 	types.PrestateProvider
 
+	// cache is the shared trace cache entry the trace is stored in, nil if the trace cache is disabled.
+	cache *vm.TraceCacheEntry
+
 	// lastStep stores the last step in the actual trace if known. 0 indicates unknown.
 	// Cached as an optimisation to avoid repeatedly attempting to execute beyond the end of the trace.
 	lastStep uint64
@@ -44,7 +47,7 @@ func NewTraceProvider(logger log.Logger, m vm.Metricer, cfg vm.Config, vmCfg vm.
 		logger:    logger,
 		dir:       dir,
 		prestate:  prestate,
-		generator: vm.NewExecutor(logger, m, cfg, vmCfg, prestate, localInputs),
+		generator: vm.NewProofGenerator(logger, m, cfg, vmCfg, prestate, localInputs),
 		gameDepth: gameDepth,
 		preimageLoader: utils.NewPreimageLoader(func() (utils.PreimageSource, error) {
 			return kvstore.NewDiskKV(logger, vm.PreimageDir(dir), kvtypes.DataFormatFile)
@@ -55,6 +58,14 @@ func NewTraceProvider(logger log.Logger, m vm.Metricer, cfg vm.Config, vmCfg vm.
 	}
 }
 
+// NewCachedTraceProvider creates a provider that stores its trace in a shared trace cache entry instead of a
+// game specific directory, reusing any snapshots and proofs already generated for the same prestate and inputs.
+func NewCachedTraceProvider(logger log.Logger, m vm.Metricer, cfg vm.Config, vmCfg vm.OracleServerExecutor, prestateProvider types.PrestateProvider, prestate string, localInputs utils.LocalGameInputs, cache *vm.TraceCacheEntry, gameDepth types.Depth) *CannonTraceProvider {
+	provider := NewTraceProvider(logger, m, cfg, vmCfg, prestateProvider, prestate, localInputs, cache.Dir(), gameDepth)
+	provider.cache = cache
+	return provider
+}
+
 func (p *CannonTraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
 	traceIndex := pos.TraceIndex(p.gameDepth)
 	if !traceIndex.IsUint64() {
@@ -77,7 +88,10 @@ func (p *CannonTraceProvider) GetStepData(ctx context.Context, pos types.Positio
 	if !traceIndex.IsUint64() {
 		return nil, nil, nil, errors.New("trace index out of bounds")
 	}
-	proof, err := p.loadProof(ctx, traceIndex.Uint64())
+	// Hold the trace cache entry lock until the preimage is loaded so the entry can't be evicted in between
+	unlock := p.cache.Lock()
+	defer unlock()
+	proof, err := p.loadProofLocked(ctx, traceIndex.Uint64())
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -103,6 +117,14 @@ func (p *CannonTraceProvider) GetL2BlockNumberChallenge(_ context.Context) (*typ
 // loadProof will attempt to load or generate the proof data at the specified index
 // If the requested index is beyond the end of the actual trace it is extended with no-op instructions.
 func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*utils.ProofData, error) {
+	// Prevent other games sharing the trace cache entry from executing the VM at the same time
+	unlock := p.cache.Lock()
+	defer unlock()
+	return p.loadProofLocked(ctx, i)
+}
+
+// loadProofLocked is loadProof for callers that already hold the trace cache entry lock.
+func (p *CannonTraceProvider) loadProofLocked(ctx context.Context, i uint64) (*utils.ProofData, error) {
 	// Attempt to read the last step from disk cache
 	if p.lastStep == 0 {
 		step, err := utils.ReadLastStep(p.dir)
@@ -118,7 +140,9 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*utils.P
 	}
 	path := filepath.Join(p.dir, utils.ProofsDir, fmt.Sprintf("%d.json.gz", i))
 	file, err := ioutil.OpenDecompressed(path)
+	p.cache.RecordGet(err == nil)
 	if errors.Is(err, os.ErrNotExist) {
+		defer p.cache.Evict()
 		if err := p.generator.GenerateProof(ctx, p.dir, i); err != nil {
 			return nil, fmt.Errorf("generate cannon trace with proof at %v: %w", i, err)
 		}
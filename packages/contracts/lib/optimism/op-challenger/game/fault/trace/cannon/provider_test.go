@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 
@@ -20,6 +21,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	kvtypes "github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
@@ -219,6 +224,82 @@ func TestGetStepData(t *testing.T) {
 	})
 }
 
+func TestSharedTraceCache(t *testing.T) {
+	dir := t.TempDir()
+	prestate := filepath.Join(dir, "prestate.json")
+	require.NoError(t, os.WriteFile(prestate, []byte("prestate"), 0o644))
+	cache := vm.NewTraceCache(testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, filepath.Join(dir, "cache"), 1_000_000)
+	inputs := utils.LocalGameInputs{L2Claim: common.Hash{0xaa}, L2BlockNumber: big.NewInt(10)}
+	setupCached := func() (*CannonTraceProvider, *stubGenerator) {
+		entry, err := cache.Entry(types.TraceTypeCannon, prestate, inputs)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(entry.Dir(), utils.ProofsDir), 0o777))
+		provider, generator := setupWithTestData(t, entry.Dir(), prestate)
+		provider.cache = entry
+		generator.proof = &utils.ProofData{
+			ClaimValue: common.Hash{0x11},
+			StateData:  []byte{0x22},
+			ProofData:  []byte{0x33},
+		}
+		return provider, generator
+	}
+
+	provider1, generator1 := setupCached()
+	value, err := provider1.Get(context.Background(), PositionFromTraceIndex(provider1, big.NewInt(100)))
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0x11}, value)
+	require.Equal(t, []int{100}, generator1.generated)
+
+	// A second game with the same prestate and inputs reuses the proof generated for the first
+	provider2, generator2 := setupCached()
+	require.Equal(t, provider1.dir, provider2.dir)
+	value, err = provider2.Get(context.Background(), PositionFromTraceIndex(provider2, big.NewInt(100)))
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0x11}, value)
+	require.Empty(t, generator2.generated)
+}
+
+// In this test, the trace cache is evicted while the preimage of a step is being loaded. The entry must stay locked
+// until the preimage is loaded, so the preimage isn't deleted between loading the proof and its preimage.
+func TestSharedTraceCacheLockedWhileLoadingPreimage(t *testing.T) {
+	dir := t.TempDir()
+	prestate := filepath.Join(dir, "prestate.json")
+	require.NoError(t, os.WriteFile(prestate, []byte("prestate"), 0o644))
+	logger := testlog.Logger(t, log.LevelInfo)
+	// A max size of 0 evicts every entry that isn't locked
+	cache := vm.NewTraceCache(logger, metrics.NoopMetrics, filepath.Join(dir, "cache"), 0)
+	entry, err := cache.Entry(types.TraceTypeCannon, prestate, utils.LocalGameInputs{L2Claim: common.Hash{0xaa}, L2BlockNumber: big.NewInt(10)})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(entry.Dir(), utils.ProofsDir), 0o777))
+	require.NoError(t, os.MkdirAll(vm.PreimageDir(entry.Dir()), 0o777))
+
+	input := []byte("precompile input")
+	oracleKey := preimage.PrecompileKey(crypto.Keccak256Hash(input)).PreimageKey()
+	kv, err := kvstore.NewDiskKV(logger, vm.PreimageDir(entry.Dir()), kvtypes.DataFormatFile)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(preimage.Keccak256Key(oracleKey).PreimageKey(), input))
+	require.NoError(t, kv.Close())
+
+	provider, generator := setupWithTestData(t, entry.Dir(), prestate)
+	provider.cache = entry
+	provider.preimageLoader = utils.NewPreimageLoader(func() (utils.PreimageSource, error) {
+		require.NoError(t, cache.Evict())
+		return kvstore.NewDiskKV(logger, vm.PreimageDir(entry.Dir()), kvtypes.DataFormatFile)
+	})
+	generator.proof = &utils.ProofData{
+		ClaimValue:   common.Hash{0x11},
+		StateData:    []byte{0x22},
+		ProofData:    []byte{0x33},
+		OracleKey:    oracleKey[:],
+		OracleOffset: 4,
+	}
+
+	_, _, data, err := provider.GetStepData(context.Background(), PositionFromTraceIndex(provider, big.NewInt(100)))
+	require.NoError(t, err)
+	require.Equal(t, oracleKey[:], data.OracleKey)
+	require.DirExists(t, entry.Dir())
+}
+
 func setupTestData(t *testing.T) (string, string) {
 	srcDir := filepath.Join("test_data", "proofs")
 	entries, err := testData.ReadDir(srcDir)
@@ -0,0 +1,166 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ProverNamespace is the RPC namespace served by external provers.
+//
+// Provers implement three methods:
+//   - prover_absolutePrestate(prestateHash) returns the commitment to the state the trace starts from for games with
+//     the specified absolute prestate hash.
+//   - prover_getClaim(TraceRequest) returns the claim at the requested trace index.
+//   - prover_getStepData(TraceRequest) returns the StepData required to execute the step at the requested trace index.
+const ProverNamespace = "prover"
+
+var ErrInvalidResponse = errors.New("invalid prover response")
+
+// RPC is the client used to send requests to an external prover.
+type RPC interface {
+	CallContext(ctx context.Context, result any, method string, args ...any) error
+}
+
+// TraceRequest identifies a trace index in the execution of the state transition described by Inputs, starting from
+// the game's absolute prestate.
+type TraceRequest struct {
+	PrestateHash common.Hash           `json:"prestateHash"`
+	Inputs       utils.LocalGameInputs `json:"inputs"`
+	Depth        hexutil.Uint64        `json:"depth"`
+	TraceIndex   *hexutil.Big          `json:"traceIndex"`
+}
+
+// StepData is the data required to execute a single step on chain.
+type StepData struct {
+	Prestate hexutil.Bytes `json:"prestate"`
+	Proof    hexutil.Bytes `json:"proof"`
+	Preimage *PreimageData `json:"preimage,omitempty"`
+}
+
+// PreimageData is a preimage that must be loaded into the preimage oracle before the step is executed.
+// The blob fields are only set for preimages loaded from EIP-4844 blobs.
+type PreimageData struct {
+	Key    hexutil.Bytes  `json:"key"`
+	Data   hexutil.Bytes  `json:"data"`
+	Offset hexutil.Uint64 `json:"offset"`
+
+	BlobFieldIndex hexutil.Uint64 `json:"blobFieldIndex,omitempty"`
+	BlobCommitment hexutil.Bytes  `json:"blobCommitment,omitempty"`
+	BlobProof      hexutil.Bytes  `json:"blobProof,omitempty"`
+}
+
+func (p *PreimageData) toOracleData() *types.PreimageOracleData {
+	if len(p.BlobCommitment) > 0 {
+		return types.NewPreimageOracleBlobData(p.Key, p.Data, uint32(p.Offset), uint64(p.BlobFieldIndex), p.BlobCommitment, p.BlobProof)
+	}
+	return types.NewPreimageOracleData(p.Key, p.Data, uint32(p.Offset))
+}
+
+// PrestateProvider loads the absolute prestate commitment for games with the specified prestate hash from an
+// external prover. The commitment is cached after it is first loaded successfully.
+type PrestateProvider struct {
+	client       RPC
+	prestateHash common.Hash
+
+	m        sync.Mutex
+	prestate common.Hash
+}
+
+var _ types.PrestateProvider = (*PrestateProvider)(nil)
+
+func NewPrestateProvider(client RPC, prestateHash common.Hash) *PrestateProvider {
+	return &PrestateProvider{client: client, prestateHash: prestateHash}
+}
+
+func (p *PrestateProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.prestate != (common.Hash{}) {
+		return p.prestate, nil
+	}
+	var prestate common.Hash
+	if err := p.client.CallContext(ctx, &prestate, ProverNamespace+"_absolutePrestate", p.prestateHash); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load absolute prestate from prover: %w", err)
+	}
+	if prestate == (common.Hash{}) {
+		return common.Hash{}, fmt.Errorf("%w: empty absolute prestate", ErrInvalidResponse)
+	}
+	p.prestate = prestate
+	return prestate, nil
+}
+
+// TraceProvider is a [types.TraceProvider] for the bottom half of an output root game that requests claims and
+// step data from an external prover, for example a ZK or TEE prover.
+type TraceProvider struct {
+	*PrestateProvider
+	logger log.Logger
+	client RPC
+	inputs utils.LocalGameInputs
+	depth  types.Depth
+}
+
+var _ types.TraceProvider = (*TraceProvider)(nil)
+
+func NewTraceProvider(logger log.Logger, client RPC, prestateProvider *PrestateProvider, inputs utils.LocalGameInputs, depth types.Depth) *TraceProvider {
+	return &TraceProvider{
+		PrestateProvider: prestateProvider,
+		logger:           logger,
+		client:           client,
+		inputs:           inputs,
+		depth:            depth,
+	}
+}
+
+func (p *TraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	traceIndex := pos.TraceIndex(p.depth)
+	var claim common.Hash
+	if err := p.client.CallContext(ctx, &claim, ProverNamespace+"_getClaim", p.request(pos)); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load claim at trace index %v from prover: %w", traceIndex, err)
+	}
+	if claim == (common.Hash{}) {
+		return common.Hash{}, fmt.Errorf("%w: empty claim at trace index %v", ErrInvalidResponse, traceIndex)
+	}
+	return claim, nil
+}
+
+func (p *TraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	traceIndex := pos.TraceIndex(p.depth)
+	var data StepData
+	if err := p.client.CallContext(ctx, &data, ProverNamespace+"_getStepData", p.request(pos)); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load step data at trace index %v from prover: %w", traceIndex, err)
+	}
+	if len(data.Prestate) == 0 {
+		return nil, nil, nil, fmt.Errorf("%w: empty prestate at trace index %v", ErrInvalidResponse, traceIndex)
+	}
+	var preimage *types.PreimageOracleData
+	if data.Preimage != nil {
+		if len(data.Preimage.Key) == 0 {
+			return nil, nil, nil, fmt.Errorf("%w: empty preimage key at trace index %v", ErrInvalidResponse, traceIndex)
+		}
+		preimage = data.Preimage.toOracleData()
+	}
+	p.logger.Debug("Loaded step data from prover", "traceIndex", traceIndex, "hasPreimage", preimage != nil)
+	return data.Prestate, data.Proof, preimage, nil
+}
+
+// GetL2BlockNumberChallenge returns types.ErrL2BlockNumberValid as the L2 block number is challenged in the top half of the game.
+func (p *TraceProvider) GetL2BlockNumberChallenge(_ context.Context) (*types.InvalidL2BlockNumberChallenge, error) {
+	return nil, types.ErrL2BlockNumberValid
+}
+
+func (p *TraceProvider) request(pos types.Position) TraceRequest {
+	return TraceRequest{
+		PrestateHash: p.prestateHash,
+		Inputs:       p.inputs,
+		Depth:        hexutil.Uint64(p.depth),
+		TraceIndex:   (*hexutil.Big)(pos.TraceIndex(p.depth)),
+	}
+}
@@ -0,0 +1,187 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPrestate = common.Hash{0xaa}
+	testInputs   = utils.LocalGameInputs{
+		L1Head:        common.Hash{0x01},
+		L2Head:        common.Hash{0x02},
+		L2OutputRoot:  common.Hash{0x03},
+		L2Claim:       common.Hash{0x04},
+		L2BlockNumber: big.NewInt(42),
+	}
+)
+
+// stubProver is a prover whose trace is the keccak256 hash of the trace index.
+type stubProver struct {
+	prestate         common.Hash
+	requests         []TraceRequest
+	prestateRequests []common.Hash
+	preimage         *PreimageData
+	err              error
+}
+
+func (s *stubProver) AbsolutePrestate(prestateHash common.Hash) (common.Hash, error) {
+	s.prestateRequests = append(s.prestateRequests, prestateHash)
+	return s.prestate, s.err
+}
+
+func (s *stubProver) GetClaim(req TraceRequest) (common.Hash, error) {
+	s.requests = append(s.requests, req)
+	if s.err != nil {
+		return common.Hash{}, s.err
+	}
+	return crypto.Keccak256Hash(req.TraceIndex.ToInt().Bytes()), nil
+}
+
+func (s *stubProver) GetStepData(req TraceRequest) (*StepData, error) {
+	s.requests = append(s.requests, req)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &StepData{
+		Prestate: req.TraceIndex.ToInt().Bytes(),
+		Proof:    []byte{0xff},
+		Preimage: s.preimage,
+	}, nil
+}
+
+func setupProvider(t *testing.T, depth types.Depth) (*TraceProvider, *stubProver) {
+	prover := &stubProver{prestate: testPrestate}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName(ProverNamespace, prover))
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+	logger := testlog.Logger(t, log.LevelInfo)
+	return NewTraceProvider(logger, client, NewPrestateProvider(client, testPrestate), testInputs, depth), prover
+}
+
+func TestGet(t *testing.T) {
+	t.Run("LeafPosition", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		claim, err := provider.Get(context.Background(), types.NewPosition(4, big.NewInt(6)))
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256Hash([]byte{6}), claim)
+		require.Len(t, prover.requests, 1)
+		require.Equal(t, testPrestate, prover.requests[0].PrestateHash)
+		require.Equal(t, testInputs, prover.requests[0].Inputs)
+		require.EqualValues(t, 4, prover.requests[0].Depth)
+		require.Equal(t, big.NewInt(6), prover.requests[0].TraceIndex.ToInt())
+	})
+
+	t.Run("NonLeafPosition", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		_, err := provider.Get(context.Background(), types.NewPosition(2, big.NewInt(1)))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(7), prover.requests[0].TraceIndex.ToInt())
+	})
+
+	t.Run("ProverError", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		prover.err = errors.New("boom")
+		_, err := provider.Get(context.Background(), types.NewPosition(4, big.NewInt(6)))
+		require.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestGetStepData(t *testing.T) {
+	t.Run("WithoutPreimage", func(t *testing.T) {
+		provider, _ := setupProvider(t, 4)
+		prestate, proof, preimageData, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.NoError(t, err)
+		require.Equal(t, []byte{3}, prestate)
+		require.Equal(t, []byte{0xff}, proof)
+		require.Nil(t, preimageData)
+	})
+
+	t.Run("WithLocalPreimage", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		key := preimage.LocalIndexKey(4).PreimageKey()
+		prover.preimage = &PreimageData{Key: key[:], Data: []byte{1, 2, 3}, Offset: 8}
+		_, _, preimageData, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.NoError(t, err)
+		require.Equal(t, types.NewPreimageOracleData(key[:], []byte{1, 2, 3}, 8), preimageData)
+		require.True(t, preimageData.IsLocal)
+	})
+
+	t.Run("WithBlobPreimage", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		key := preimage.BlobKey(common.Hash{0x01}).PreimageKey()
+		prover.preimage = &PreimageData{
+			Key:            key[:],
+			Data:           []byte{1, 2, 3},
+			BlobFieldIndex: 5,
+			BlobCommitment: []byte{0xcc},
+			BlobProof:      []byte{0xdd},
+		}
+		_, _, preimageData, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.NoError(t, err)
+		require.Equal(t, types.NewPreimageOracleBlobData(key[:], []byte{1, 2, 3}, 0, 5, []byte{0xcc}, []byte{0xdd}), preimageData)
+	})
+
+	t.Run("MissingPreimageKey", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		prover.preimage = &PreimageData{Data: []byte{1, 2, 3}}
+		_, _, _, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(3)))
+		require.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("MissingPrestate", func(t *testing.T) {
+		provider, _ := setupProvider(t, 4)
+		_, _, _, err := provider.GetStepData(context.Background(), types.NewPosition(4, big.NewInt(0)))
+		require.ErrorIs(t, err, ErrInvalidResponse)
+	})
+}
+
+func TestGetL2BlockNumberChallenge(t *testing.T) {
+	provider, _ := setupProvider(t, 4)
+	_, err := provider.GetL2BlockNumberChallenge(context.Background())
+	require.ErrorIs(t, err, types.ErrL2BlockNumberValid)
+}
+
+func TestAbsolutePreStateCommitment(t *testing.T) {
+	t.Run("CachesPrestate", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		for i := 0; i < 2; i++ {
+			prestate, err := provider.AbsolutePreStateCommitment(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, testPrestate, prestate)
+		}
+		require.Equal(t, []common.Hash{testPrestate}, prover.prestateRequests)
+	})
+
+	t.Run("EmptyPrestate", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		prover.prestate = common.Hash{}
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorIs(t, err, ErrInvalidResponse)
+	})
+
+	t.Run("RetriesAfterError", func(t *testing.T) {
+		provider, prover := setupProvider(t, 4)
+		prover.err = errors.New("boom")
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorContains(t, err, "boom")
+		prover.err = nil
+		prestate, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, testPrestate, prestate)
+	})
+}
@@ -28,6 +28,7 @@ func NewOutputAsteriscTraceAccessor(
 	asteriscPrestate string,
 	rollupClient OutputRollupClient,
 	dir string,
+	traceCache *vm.TraceCache,
 	l1Head eth.BlockID,
 	splitDepth types.Depth,
 	prestateBlock uint64,
@@ -41,6 +42,13 @@ func NewOutputAsteriscTraceAccessor(
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch asterisc local inputs: %w", err)
 		}
+		if traceCache != nil {
+			entry, err := traceCache.Entry(cfg.VmType, asteriscPrestate, localInputs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load trace cache entry: %w", err)
+			}
+			return asterisc.NewCachedTraceProvider(logger, m.VmMetrics(cfg.VmType.String()), cfg, vmCfg, prestateProvider, asteriscPrestate, localInputs, entry, depth), nil
+		}
 		provider := asterisc.NewTraceProvider(logger, m.VmMetrics(cfg.VmType.String()), cfg, vmCfg, prestateProvider, asteriscPrestate, localInputs, subdir, depth)
 		return provider, nil
 	}
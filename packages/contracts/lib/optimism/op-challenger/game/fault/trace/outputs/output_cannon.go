@@ -28,6 +28,7 @@ func NewOutputCannonTraceAccessor(
 	cannonPrestate string,
 	rollupClient OutputRollupClient,
 	dir string,
+	traceCache *vm.TraceCache,
 	l1Head eth.BlockID,
 	splitDepth types.Depth,
 	prestateBlock uint64,
@@ -41,6 +42,13 @@ func NewOutputCannonTraceAccessor(
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch cannon local inputs: %w", err)
 		}
+		if traceCache != nil {
+			entry, err := traceCache.Entry(cfg.VmType, cannonPrestate, localInputs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load trace cache entry: %w", err)
+			}
+			return cannon.NewCachedTraceProvider(logger, m.VmMetrics(cfg.VmType.String()), cfg, serverExecutor, prestateProvider, cannonPrestate, localInputs, entry, depth), nil
+		}
 		provider := cannon.NewTraceProvider(logger, m.VmMetrics(cfg.VmType.String()), cfg, serverExecutor, prestateProvider, cannonPrestate, localInputs, subdir, depth)
 		return provider, nil
 	}
@@ -0,0 +1,60 @@
+package outputs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BottomTraceProviderCreator creates the trace provider for the bottom half of an output root game, which executes
+// the state transition from the agreed output root to the claimed output root described by localInputs.
+// vmPrestate is the absolute prestate provider for the bottom half and dir is a directory private to the provider.
+type BottomTraceProviderCreator func(
+	ctx context.Context,
+	logger log.Logger,
+	vmPrestate types.PrestateProvider,
+	localInputs utils.LocalGameInputs,
+	depth types.Depth,
+	dir string,
+) (types.TraceProvider, error)
+
+// NewOutputTraceAccessor creates a trace accessor for an output root game where the bottom half of the game is played
+// using the trace providers created by newTraceProvider. It is intended for game types that are not built in.
+func NewOutputTraceAccessor(
+	logger log.Logger,
+	m metrics.Metricer,
+	name string,
+	newTraceProvider BottomTraceProviderCreator,
+	l2Client utils.L2HeaderSource,
+	prestateProvider types.PrestateProvider,
+	vmPrestateProvider types.PrestateProvider,
+	rollupClient OutputRollupClient,
+	dir string,
+	l1Head eth.BlockID,
+	splitDepth types.Depth,
+	prestateBlock uint64,
+	poststateBlock uint64,
+) (*trace.Accessor, error) {
+	outputProvider := NewTraceProvider(logger, prestateProvider, rollupClient, l2Client, l1Head, splitDepth, prestateBlock, poststateBlock)
+	creator := func(ctx context.Context, localContext common.Hash, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		logger := logger.New("pre", agreed.OutputRoot, "post", claimed.OutputRoot, "localContext", localContext)
+		localInputs, err := utils.FetchLocalInputsFromProposals(ctx, l1Head.Hash, l2Client, agreed, claimed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %v local inputs: %w", name, err)
+		}
+		return newTraceProvider(ctx, logger, vmPrestateProvider, localInputs, depth, filepath.Join(dir, localContext.Hex()))
+	}
+	cache := NewProviderCache(m, fmt.Sprintf("output_%v_provider", name), creator)
+	selector := split.NewSplitProviderSelector(outputProvider, splitDepth, OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
+	return trace.NewAccessor(selector), nil
+}
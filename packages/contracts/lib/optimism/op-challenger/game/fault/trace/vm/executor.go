@@ -34,6 +34,9 @@ type Config struct {
 	InfoFreq        uint   // Frequency of progress log messages (in VM instructions)
 	DebugInfo       bool   // Whether to record debug info from the execution
 	BinarySnapshots bool   // Whether to use binary snapshots instead of JSON
+	// Maximum number of delta snapshots, containing only the memory pages changed since the previous snapshot,
+	// to create between full snapshots. Requires BinarySnapshots and is only supported by cannon.
+	SnapshotMaxDelta uint
 
 	// Host Configuration
 	L1               string
@@ -43,6 +46,9 @@ type Config struct {
 	Network          string
 	RollupConfigPath string
 	L2GenesisPath    string
+
+	// Remote proof generation
+	Remote RemoteConfig
 }
 
 type OracleServerExecutor interface {
@@ -105,6 +111,9 @@ func (e *Executor) DoGenerateProof(ctx context.Context, dir string, begin uint64
 	} else {
 		args = append(args, "--snapshot-fmt", filepath.Join(snapshotDir, "%d.json.gz"))
 	}
+	if e.cfg.BinarySnapshots && e.cfg.SnapshotMaxDelta > 0 {
+		args = append(args, "--snapshot-max-delta", strconv.FormatUint(uint64(e.cfg.SnapshotMaxDelta), 10))
+	}
 	if end < math.MaxUint64 {
 		args = append(args, "--stop-at", "="+strconv.FormatUint(end+1, 10))
 	}
@@ -141,6 +141,23 @@ func TestGenerateProof(t *testing.T) {
 		_, _, args := captureExec(t, cfg, 100)
 		require.Equal(t, filepath.Join(dir, SnapsDir, "%d.json.gz"), args["--snapshot-fmt"])
 	})
+
+	t.Run("DeltaSnapshots", func(t *testing.T) {
+		cfg.Network = "mainnet"
+		cfg.BinarySnapshots = true
+		_, _, args := captureExec(t, cfg, 100)
+		require.NotContains(t, args, "--snapshot-max-delta")
+
+		cfg.SnapshotMaxDelta = 5
+		_, _, args = captureExec(t, cfg, 100)
+		require.Equal(t, "5", args["--snapshot-max-delta"])
+
+		// Delta snapshots are only supported for binary snapshots
+		cfg.BinarySnapshots = false
+		_, _, args = captureExec(t, cfg, 100)
+		require.NotContains(t, args, "--snapshot-max-delta")
+		cfg.SnapshotMaxDelta = 0
+	})
 }
 
 type stubVmMetrics struct {
@@ -0,0 +1,270 @@
+package vm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	DefaultWorkerTimeout  = 1 * time.Hour
+	DefaultWorkerAttempts = 3
+)
+
+var (
+	ErrWorkerBusy            = errors.New("worker busy")
+	ErrUnknownPrestate       = errors.New("unknown prestate")
+	ErrInvalidPrestate       = errors.New("invalid prestate")
+	ErrPrestateQuotaExceeded = errors.New("prestate storage quota exceeded")
+	ErrInvalidJob            = errors.New("invalid proof job")
+	ErrInvalidResult         = errors.New("invalid proof result")
+	ErrUnauthorized          = errors.New("unauthorized")
+
+	ErrProofWorkerTimeoutZero      = errors.New("proof worker timeout must not be 0")
+	ErrProofWorkerAttemptsZero     = errors.New("proof worker attempts must not be 0")
+	ErrProofWorkerAuthTokenMissing = errors.New("proof worker auth token is required")
+
+	// workerRetryDelay is the time to wait after trying every worker before trying them again
+	workerRetryDelay = 5 * time.Second
+)
+
+// RemoteConfig configures generating proofs on remote workers instead of running the VM as a local subprocess.
+type RemoteConfig struct {
+	Workers   []string      // HTTP URLs of the proof generation workers. Proofs are generated locally if empty.
+	AuthToken string        // Token workers require in the Authorization header of each request
+	Timeout   time.Duration // Maximum time to wait for a worker to generate a single proof
+	Attempts  uint          // Maximum number of failed attempts before giving up on generating a proof
+}
+
+func (c RemoteConfig) Check() error {
+	if len(c.Workers) == 0 {
+		return nil
+	}
+	if c.AuthToken == "" {
+		return ErrProofWorkerAuthTokenMissing
+	}
+	if c.Timeout == 0 {
+		return ErrProofWorkerTimeoutZero
+	}
+	if c.Attempts == 0 {
+		return ErrProofWorkerAttemptsZero
+	}
+	return nil
+}
+
+// ProofJob is a request for a worker to generate the proof at a step of the VM execution.
+type ProofJob struct {
+	VmType       types.TraceType       `json:"vmType"`
+	PrestateHash common.Hash           `json:"prestateHash"`
+	Inputs       utils.LocalGameInputs `json:"inputs"`
+	Step         hexutil.Uint64        `json:"step"`
+}
+
+// ProofResult is the output of a ProofJob. Files are relative to Dir, the directory the VM was executed in, and
+// include any snapshots created and then the proof, or the final state if the trace ended before the requested step.
+// Preimages contains the preimages required to load the preimage oracle data for the proof.
+type ProofResult struct {
+	Dir       string
+	Files     []string
+	Preimages []Preimage
+}
+
+type Preimage struct {
+	Key   common.Hash
+	Value []byte
+}
+
+// ProofGenerator generates the proof at a trace index, storing it in dir.
+type ProofGenerator interface {
+	GenerateProof(ctx context.Context, dir string, i uint64) error
+}
+
+// NewProofGenerator creates a ProofGenerator that sends jobs to remote workers if any are configured,
+// or runs the VM as a local subprocess otherwise.
+func NewProofGenerator(logger log.Logger, m Metricer, cfg Config, oracleServer OracleServerExecutor, prestate string, inputs utils.LocalGameInputs) ProofGenerator {
+	if len(cfg.Remote.Workers) > 0 {
+		return NewRemoteExecutor(logger, m, cfg, prestate, inputs)
+	}
+	return NewExecutor(logger, m, cfg, oracleServer, prestate, inputs)
+}
+
+// RemoteExecutor generates proofs by sending jobs to a pool of workers.
+// Workers that are busy are skipped, failed jobs are retried on the next worker up to the configured attempts.
+// If every worker stays busy for longer than the worker timeout, proof generation fails.
+type RemoteExecutor struct {
+	logger   log.Logger
+	metrics  Metricer
+	cfg      Config
+	prestate string
+	inputs   utils.LocalGameInputs
+	client   *http.Client
+}
+
+func NewRemoteExecutor(logger log.Logger, m Metricer, cfg Config, prestate string, inputs utils.LocalGameInputs) *RemoteExecutor {
+	return &RemoteExecutor{
+		logger:   logger,
+		metrics:  m,
+		cfg:      cfg,
+		prestate: prestate,
+		inputs:   inputs,
+		client:   &http.Client{},
+	}
+}
+
+// GenerateProof has a worker execute the VM to generate a proof at the specified trace index.
+// The proof, snapshots and required preimages returned by the worker are stored in the specified directory.
+func (e *RemoteExecutor) GenerateProof(ctx context.Context, dir string, i uint64) error {
+	prestateHash, err := hashFile(e.prestate)
+	if err != nil {
+		return fmt.Errorf("failed to read prestate %v: %w", e.prestate, err)
+	}
+	job := ProofJob{
+		VmType:       e.cfg.VmType,
+		PrestateHash: prestateHash,
+		Inputs:       e.inputs,
+		Step:         hexutil.Uint64(i),
+	}
+	workers := e.cfg.Remote.Workers
+	// Start from a random worker to spread jobs from different games across the pool
+	offset := rand.Intn(len(workers))
+	var errs []error
+	start := time.Now()
+	// busySince is when the workers were last available, to limit how long to wait for a worker that isn't busy
+	busySince := start
+	for n := 0; ; n++ {
+		if n > 0 && n%len(workers) == 0 {
+			if busy := time.Since(busySince); busy >= e.cfg.Remote.Timeout {
+				errs = append(errs, fmt.Errorf("%w: no proof worker available after %v", ErrWorkerBusy, busy))
+				return fmt.Errorf("failed to generate proof at %v: %w", i, errors.Join(errs...))
+			}
+			select {
+			case <-ctx.Done():
+				return errors.Join(append(errs, ctx.Err())...)
+			case <-time.After(workerRetryDelay):
+			}
+		}
+		url := workers[(offset+n)%len(workers)]
+		err := e.generateOnWorker(ctx, url, job, dir)
+		if errors.Is(err, ErrWorkerBusy) {
+			e.logger.Debug("Proof worker busy", "worker", url, "proof", i)
+			continue
+		} else if err != nil {
+			if ctx.Err() != nil {
+				return errors.Join(append(errs, ctx.Err())...)
+			}
+			e.logger.Warn("Failed to generate proof on worker", "worker", url, "proof", i, "err", err)
+			errs = append(errs, fmt.Errorf("worker %v: %w", url, err))
+			busySince = time.Now()
+			if uint(len(errs)) >= e.cfg.Remote.Attempts {
+				return fmt.Errorf("failed to generate proof at %v after %v attempts: %w", i, len(errs), errors.Join(errs...))
+			}
+			continue
+		}
+		execTime := time.Since(start)
+		e.metrics.RecordExecutionTime(execTime)
+		e.logger.Info("Remote VM execution complete", "worker", url, "proof", i, "time", execTime)
+		return nil
+	}
+}
+
+func (e *RemoteExecutor) generateOnWorker(ctx context.Context, url string, job ProofJob, dir string) error {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Remote.Timeout)
+	defer cancel()
+	url = strings.TrimSuffix(url, "/")
+	prestateURL := url + prestatesPath + job.PrestateHash.Hex()
+
+	resp, err := e.request(ctx, http.MethodHead, prestateURL, nil, 0)
+	if errors.Is(err, ErrUnknownPrestate) {
+		if err := e.uploadPrestate(ctx, url, prestateURL); err != nil {
+			return fmt.Errorf("failed to upload prestate: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to check prestate: %w", err)
+	} else {
+		resp.Body.Close()
+	}
+
+	e.logger.Info("Generating trace on proof worker", "worker", url, "proof", uint64(job.Step))
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	resp, err = e.request(ctx, http.MethodPost, url+proofsPath, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return storeProofResult(e.logger, dir, resp.Body)
+}
+
+func (e *RemoteExecutor) uploadPrestate(ctx context.Context, url string, prestateURL string) error {
+	file, err := os.Open(e.prestate)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	e.logger.Info("Uploading prestate to proof worker", "worker", url, "prestate", e.prestate, "size", info.Size())
+	resp, err := e.request(ctx, http.MethodPut, prestateURL+"/"+filepath.Base(e.prestate), file, info.Size())
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// request sends an authenticated request to a worker. Error responses are converted back to the matching sentinel
+// error. The caller must close the body of the returned response.
+func (e *RemoteExecutor) request(ctx context.Context, method string, url string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	req.Header.Set("Authorization", "Bearer "+e.cfg.Remote.AuthToken)
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorSize))
+	err = fmt.Errorf("worker responded %v: %s", resp.Status, bytes.TrimSpace(msg))
+	if sentinel := statusErrors[resp.StatusCode]; sentinel != nil {
+		err = fmt.Errorf("%w: %w", sentinel, err)
+	}
+	return nil, err
+}
+
+func hashFile(path string) (common.Hash, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer file.Close()
+	hasher := crypto.NewKeccakState()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hasher.Sum(nil)), nil
+}
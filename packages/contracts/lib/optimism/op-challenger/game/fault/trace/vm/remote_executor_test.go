@@ -0,0 +1,353 @@
+package vm
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	kvtypes "github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteExecutor(t *testing.T) {
+	workerRetryDelay = time.Millisecond
+	inputs := utils.LocalGameInputs{
+		L1Head:        common.Hash{0x11},
+		L2Claim:       common.Hash{0x22},
+		L2BlockNumber: big.NewInt(30),
+	}
+
+	t.Run("GenerateProof", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		dir := t.TempDir()
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 150))
+
+		proof := loadProof(t, dir, 150)
+		require.Equal(t, common.Hash{150}, proof.ClaimValue)
+		require.FileExists(t, filepath.Join(dir, SnapsDir, "100.bin.gz"))
+		require.Equal(t, []uint64{150}, workers[0].generated)
+		require.Equal(t, inputs, workers[0].inputs)
+
+		// Preimages required to load the oracle data for the proof are sent with the proof
+		loader := utils.NewPreimageLoader(func() (utils.PreimageSource, error) {
+			return kvstore.NewDiskKV(testlog.Logger(t, log.LevelInfo), PreimageDir(dir), kvtypes.DataFormatFile)
+		})
+		data, err := loader.LoadPreimage(proof)
+		require.NoError(t, err)
+		require.Equal(t, []byte("precompile input"), data.GetPreimageWithoutSize())
+	})
+
+	t.Run("UploadPrestateOnce", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		dir := t.TempDir()
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 150))
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 250))
+		require.Equal(t, 1, workers[0].uploads)
+		require.Equal(t, []uint64{150, 250}, workers[0].generated)
+	})
+
+	t.Run("ReuseWorkerExecution", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		require.NoError(t, executor.GenerateProof(context.Background(), t.TempDir(), 150))
+		dir := t.TempDir()
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 150))
+		require.Equal(t, []uint64{150}, workers[0].generated, "should not execute the VM again")
+		require.Equal(t, common.Hash{150}, loadProof(t, dir, 150).ClaimValue)
+	})
+
+	t.Run("TraceEndedBeforeStep", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		workers[0].lastStep = 200
+		dir := t.TempDir()
+		require.NoError(t, executor.GenerateProof(context.Background(), dir, 250))
+		require.FileExists(t, FinalStatePath(dir, true))
+		require.NoFileExists(t, filepath.Join(dir, utils.ProofsDir, "250.json.gz"))
+	})
+
+	t.Run("SkipBusyWorkers", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 2)
+		// Fill the job slot of the first worker
+		workers[0].worker.jobs <- struct{}{}
+		executor.cfg.Remote.Workers = []string{workers[0].url, workers[1].url}
+		require.NoError(t, executor.GenerateProof(context.Background(), t.TempDir(), 150))
+		require.Empty(t, workers[0].generated)
+		require.Equal(t, []uint64{150}, workers[1].generated)
+	})
+
+	t.Run("RetryOnFailure", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 2)
+		workers[0].err = errors.New("boom")
+		workers[1].err = errors.New("boom")
+		executor.cfg.Remote.Attempts = 3
+		err := executor.GenerateProof(context.Background(), t.TempDir(), 150)
+		require.ErrorContains(t, err, "after 3 attempts")
+		require.ErrorContains(t, err, "boom")
+		require.Len(t, append(workers[0].generated, workers[1].generated...), 3)
+
+		workers[0].err = nil
+		workers[1].err = nil
+		require.NoError(t, executor.GenerateProof(context.Background(), t.TempDir(), 150))
+	})
+
+	t.Run("WaitForBusyWorkersUntilCancelled", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		workers[0].worker.jobs <- struct{}{}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := executor.GenerateProof(ctx, t.TempDir(), 150)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("GiveUpWhenWorkersBusyTooLong", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		workers[0].worker.jobs <- struct{}{}
+		executor.cfg.Remote.Timeout = 20 * time.Millisecond
+		err := executor.GenerateProof(context.Background(), t.TempDir(), 150)
+		require.ErrorIs(t, err, ErrWorkerBusy)
+		require.Empty(t, workers[0].generated)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		executor.cfg.Remote.AuthToken = "wrong"
+		err := executor.GenerateProof(context.Background(), t.TempDir(), 150)
+		require.ErrorIs(t, err, ErrUnauthorized)
+		require.Zero(t, workers[0].uploads)
+		require.Empty(t, workers[0].generated)
+	})
+
+	t.Run("PrestateQuotaExceeded", func(t *testing.T) {
+		executor, workers := setupRemoteExecutor(t, inputs, 1)
+		workers[0].worker.maxPrestatesSize = int64(len("prestate")) - 1
+		err := executor.GenerateProof(context.Background(), t.TempDir(), 150)
+		require.ErrorIs(t, err, ErrPrestateQuotaExceeded)
+		require.Empty(t, workers[0].generated)
+	})
+
+	t.Run("RejectFilesOutsideDir", func(t *testing.T) {
+		var buf bytes.Buffer
+		out := tar.NewWriter(&buf)
+		require.NoError(t, out.WriteHeader(&tar.Header{Name: "../escape", Mode: 0o644, Size: 1}))
+		_, err := out.Write([]byte{1})
+		require.NoError(t, err)
+		require.NoError(t, out.Close())
+		err = storeProofResult(testlog.Logger(t, log.LevelInfo), t.TempDir(), &buf)
+		require.ErrorIs(t, err, ErrInvalidResult)
+	})
+
+	t.Run("RejectIncorrectPreimage", func(t *testing.T) {
+		for _, key := range []common.Hash{
+			preimage.Keccak256Key(crypto.Keccak256Hash([]byte("expected"))).PreimageKey(),
+			preimage.Sha256Key(sha256.Sum256([]byte("expected"))).PreimageKey(),
+		} {
+			var buf bytes.Buffer
+			require.NoError(t, writeProofResult(&buf, &ProofResult{Preimages: []Preimage{{Key: key, Value: []byte("incorrect")}}}))
+			dir := t.TempDir()
+			err := storeProofResult(testlog.Logger(t, log.LevelInfo), dir, &buf)
+			require.ErrorIs(t, err, ErrInvalidResult)
+			require.ErrorIs(t, err, preimage.ErrIncorrectData)
+		}
+	})
+
+	t.Run("IncompleteResultNotStored", func(t *testing.T) {
+		srcDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(srcDir, utils.ProofsDir), 0o755))
+		proofPath := filepath.Join(utils.ProofsDir, "150.json.gz")
+		require.NoError(t, os.WriteFile(filepath.Join(srcDir, proofPath), []byte("proof data"), 0o644))
+		var buf bytes.Buffer
+		require.NoError(t, writeProofResult(&buf, &ProofResult{Dir: srcDir, Files: []string{proofPath}}))
+
+		dir := t.TempDir()
+		truncated := io.MultiReader(bytes.NewReader(buf.Bytes()[:520]), iotest.ErrReader(io.ErrUnexpectedEOF))
+		err := storeProofResult(testlog.Logger(t, log.LevelInfo), dir, truncated)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		require.NoFileExists(t, filepath.Join(dir, proofPath))
+		entries, err := os.ReadDir(filepath.Join(dir, utils.ProofsDir))
+		require.NoError(t, err)
+		require.Empty(t, entries, "should not leave temporary files")
+	})
+}
+
+func TestWorkerUploadPrestate(t *testing.T) {
+	data := []byte("prestate")
+	hash := crypto.Keccak256Hash(data)
+	newWorker := func(t *testing.T) *Worker {
+		return NewWorker(testlog.Logger(t, log.LevelInfo), &stubCacheMetrics{}, t.TempDir(), 1, 1_000_000, 1_000, WorkerVM{
+			Config: Config{VmType: types.TraceTypeCannon},
+		})
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		worker := newWorker(t)
+		require.NoError(t, worker.UploadPrestate("prestate.bin.gz", hash, int64(len(data)), bytes.NewReader(data)))
+		known, err := worker.HasPrestate(hash)
+		require.NoError(t, err)
+		require.True(t, known)
+		path, err := worker.prestatePath(hash)
+		require.NoError(t, err)
+		require.Equal(t, "prestate.bin.gz", filepath.Base(path))
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		worker := newWorker(t)
+		err := worker.UploadPrestate("prestate.bin.gz", common.Hash{0xaa}, int64(len(data)), bytes.NewReader(data))
+		require.ErrorIs(t, err, ErrInvalidPrestate)
+		known, err := worker.HasPrestate(common.Hash{0xaa})
+		require.NoError(t, err)
+		require.False(t, known)
+	})
+
+	t.Run("SizeMismatch", func(t *testing.T) {
+		worker := newWorker(t)
+		err := worker.UploadPrestate("prestate.bin.gz", hash, int64(len(data))-1, bytes.NewReader(data))
+		require.ErrorIs(t, err, ErrInvalidPrestate)
+		err = worker.UploadPrestate("prestate.bin.gz", hash, int64(len(data))+1, bytes.NewReader(data))
+		require.ErrorIs(t, err, ErrInvalidPrestate)
+	})
+
+	t.Run("QuotaExceeded", func(t *testing.T) {
+		worker := newWorker(t)
+		worker.maxPrestatesSize = int64(len(data)) + 4
+		require.NoError(t, worker.UploadPrestate("prestate.bin.gz", hash, int64(len(data)), bytes.NewReader(data)))
+		other := []byte("other prestate")
+		err := worker.UploadPrestate("other.bin.gz", crypto.Keccak256Hash(other), int64(len(other)), bytes.NewReader(other))
+		require.ErrorIs(t, err, ErrPrestateQuotaExceeded)
+	})
+}
+
+func TestNewProofGenerator(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	generator := NewProofGenerator(logger, &stubVmMetrics{}, Config{}, nil, "prestate", utils.LocalGameInputs{})
+	require.IsType(t, &Executor{}, generator)
+
+	generator = NewProofGenerator(logger, &stubVmMetrics{}, Config{Remote: RemoteConfig{Workers: []string{"http://worker"}}}, nil, "prestate", utils.LocalGameInputs{})
+	require.IsType(t, &RemoteExecutor{}, generator)
+}
+
+const testAuthToken = "secret"
+
+type stubWorker struct {
+	worker    *Worker
+	url       string
+	generated []uint64
+	inputs    utils.LocalGameInputs
+	uploads   int
+	lastStep  uint64
+	err       error
+}
+
+func setupRemoteExecutor(t *testing.T, inputs utils.LocalGameInputs, workerCount int) (*RemoteExecutor, []*stubWorker) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	prestate := filepath.Join(t.TempDir(), "prestate.bin.gz")
+	require.NoError(t, os.WriteFile(prestate, []byte("prestate"), 0o644))
+
+	workers := make([]*stubWorker, workerCount)
+	var urls []string
+	for i := range workers {
+		stub := &stubWorker{}
+		stub.worker = NewWorker(logger, &stubCacheMetrics{}, t.TempDir(), 1, 1_000_000, 1_000_000, WorkerVM{
+			Config:  Config{VmType: types.TraceTypeCannon, BinarySnapshots: true},
+			Metrics: &stubVmMetrics{},
+		})
+		stub.worker.generate = stub.generate
+		handler := NewWorkerHandler(logger, stub.worker, testAuthToken)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				stub.uploads++
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(server.Close)
+		stub.url = server.URL
+		urls = append(urls, server.URL)
+		workers[i] = stub
+	}
+
+	cfg := Config{
+		VmType:          types.TraceTypeCannon,
+		BinarySnapshots: true,
+		Remote: RemoteConfig{
+			Workers:   urls,
+			AuthToken: testAuthToken,
+			Timeout:   time.Minute,
+			Attempts:  1,
+		},
+	}
+	return NewRemoteExecutor(logger, &stubVmMetrics{}, cfg, prestate, inputs), workers
+}
+
+func (s *stubWorker) generate(_ context.Context, _ WorkerVM, prestate string, inputs utils.LocalGameInputs, dir string, step uint64) error {
+	s.generated = append(s.generated, step)
+	s.inputs = inputs
+	if s.err != nil {
+		return s.err
+	}
+	if _, err := os.Stat(prestate); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, SnapsDir), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, SnapsDir, "100.bin.gz"), []byte("snapshot"), 0o644); err != nil {
+		return err
+	}
+	if s.lastStep != 0 && step > s.lastStep {
+		return os.WriteFile(FinalStatePath(dir, true), []byte("final"), 0o644)
+	}
+
+	input := []byte("precompile input")
+	key := preimage.PrecompileKey(crypto.Keccak256Hash(input)).PreimageKey()
+	if err := os.MkdirAll(PreimageDir(dir), 0o755); err != nil {
+		return err
+	}
+	kv, err := kvstore.NewDiskKV(log.NewLogger(log.DiscardHandler()), PreimageDir(dir), kvtypes.DataFormatFile)
+	if err != nil {
+		return err
+	}
+	defer kv.Close()
+	if err := kv.Put(preimage.Keccak256Key(key).PreimageKey(), input); err != nil {
+		return err
+	}
+	proof := utils.ProofData{
+		ClaimValue: common.Hash{byte(step)},
+		StateData:  []byte{0x01},
+		ProofData:  []byte{0x02},
+		OracleKey:  key[:],
+	}
+	data, err := json.Marshal(proof)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, utils.ProofsDir), 0o755); err != nil {
+		return err
+	}
+	return ioutil.WriteCompressedBytes(filepath.Join(dir, utils.ProofsDir, fmt.Sprintf("%d.json.gz", step)), data, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0o644)
+}
+
+func loadProof(t *testing.T, dir string, step uint64) *utils.ProofData {
+	proof, err := readProof(filepath.Join(dir, utils.ProofsDir, fmt.Sprintf("%d.json.gz", step)))
+	require.NoError(t, err)
+	return proof
+}
@@ -0,0 +1,284 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+)
+
+const (
+	// lastUsedFilename is touched whenever a cache entry is used, its modification time orders entries for eviction.
+	lastUsedFilename = "last-used"
+	metricsLabel     = "trace_cache"
+)
+
+// TraceCache is a content-addressed on-disk cache of VM executions, shared between games and across restarts.
+// Entries are keyed by the VM type, absolute prestate and local inputs, so games that dispute the same outputs
+// reuse the same snapshots, proofs and preimages instead of re-executing the VM.
+// Within an entry, proofs are stored by step.
+type TraceCache struct {
+	logger  log.Logger
+	metrics caching.Metrics
+	dir     string
+	maxSize int64
+
+	mu sync.Mutex
+	// locks tracks the lock and number of holders of each entry currently in use
+	locks map[common.Hash]*entryLock
+	// prestateHashes caches the content hash of prestate files by path
+	prestateHashes map[string]common.Hash
+}
+
+type entryLock struct {
+	sync.Mutex
+	refs int
+}
+
+// NewTraceCache creates a TraceCache storing entries in dir.
+// Least recently used entries are evicted once the total size of the cache exceeds maxSize bytes.
+func NewTraceCache(logger log.Logger, m caching.Metrics, dir string, maxSize int64) *TraceCache {
+	return &TraceCache{
+		logger:         logger,
+		metrics:        m,
+		dir:            dir,
+		maxSize:        maxSize,
+		locks:          make(map[common.Hash]*entryLock),
+		prestateHashes: make(map[string]common.Hash),
+	}
+}
+
+// Entry returns the cache entry for executing the VM from the prestate file with the local inputs.
+func (c *TraceCache) Entry(vmType types.TraceType, prestate string, inputs utils.LocalGameInputs) (*TraceCacheEntry, error) {
+	prestateHash, err := c.prestateHash(prestate)
+	if err != nil {
+		return nil, err
+	}
+	key := TraceCacheKey(vmType, prestateHash, inputs)
+	entry := &TraceCacheEntry{
+		cache: c,
+		key:   key,
+		dir:   filepath.Join(c.dir, key.Hex()),
+	}
+	if err := os.MkdirAll(entry.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create trace cache entry %v: %w", key, err)
+	}
+	entry.touch()
+	return entry, nil
+}
+
+// TraceCacheKey returns the content address of a VM execution from the prestate with the local inputs.
+func TraceCacheKey(vmType types.TraceType, prestateHash common.Hash, inputs utils.LocalGameInputs) common.Hash {
+	blockNum := inputs.L2BlockNumber
+	if blockNum == nil {
+		blockNum = new(big.Int)
+	}
+	return crypto.Keccak256Hash(
+		[]byte(vmType),
+		prestateHash[:],
+		inputs.L1Head[:],
+		inputs.L2Head[:],
+		inputs.L2OutputRoot[:],
+		inputs.L2Claim[:],
+		common.BigToHash(blockNum).Bytes(),
+	)
+}
+
+func (c *TraceCache) prestateHash(prestate string) (common.Hash, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if hash, ok := c.prestateHashes[prestate]; ok {
+		return hash, nil
+	}
+	data, err := os.ReadFile(prestate)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to read prestate %v: %w", prestate, err)
+	}
+	hash := crypto.Keccak256Hash(data)
+	c.prestateHashes[prestate] = hash
+	return hash, nil
+}
+
+func (c *TraceCache) lock(key common.Hash) func() {
+	c.mu.Lock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &entryLock{}
+		c.locks[key] = l
+	}
+	l.refs++
+	c.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(c.locks, key)
+		}
+	}
+}
+
+type cacheEntryInfo struct {
+	key      common.Hash
+	size     int64
+	lastUsed time.Time
+}
+
+// Evict removes the least recently used entries until the cache is no larger than its max size.
+// Entries that are currently locked are never evicted.
+func (c *TraceCache) Evict() error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to list trace cache: %w", err)
+	}
+	var entries []cacheEntryInfo
+	var total int64
+	for _, dirEntry := range dirEntries {
+		var key common.Hash
+		if !dirEntry.IsDir() || key.UnmarshalText([]byte(dirEntry.Name())) != nil {
+			// Ignore anything that isn't a cache entry
+			continue
+		}
+		info, err := entryInfo(filepath.Join(c.dir, dirEntry.Name()))
+		if err != nil {
+			return err
+		}
+		info.key = key
+		entries = append(entries, info)
+		total += info.size
+	}
+	slices.SortFunc(entries, func(a, b cacheEntryInfo) int {
+		return a.lastUsed.Compare(b.lastUsed)
+	})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var errs []error
+	evicted := 0
+	for _, entry := range entries {
+		if total <= c.maxSize {
+			break
+		}
+		if _, inUse := c.locks[entry.key]; inUse {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.dir, entry.key.Hex())); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		c.logger.Debug("Evicted trace cache entry", "key", entry.key, "size", entry.size)
+		total -= entry.size
+		evicted++
+	}
+	if evicted > 0 {
+		c.metrics.CacheAdd(metricsLabel, len(entries)-evicted, true)
+	}
+	return errors.Join(errs...)
+}
+
+func entryInfo(dir string) (cacheEntryInfo, error) {
+	var info cacheEntryInfo
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			// Removed while walking, e.g. a temporary file
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		fileInfo, err := d.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		info.size += fileInfo.Size()
+		if d.Name() == lastUsedFilename {
+			info.lastUsed = fileInfo.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return cacheEntryInfo{}, fmt.Errorf("failed to read trace cache entry %v: %w", dir, err)
+	}
+	return info, nil
+}
+
+// TraceCacheEntry is the cached execution of a VM from one prestate with one set of local inputs.
+// Lock, RecordGet and Evict are safe to call on a nil entry, which disables caching.
+type TraceCacheEntry struct {
+	cache *TraceCache
+	key   common.Hash
+	dir   string
+}
+
+// Dir returns the directory the VM execution is stored in.
+func (e *TraceCacheEntry) Dir() string {
+	return e.dir
+}
+
+// Lock prevents concurrent executions of the VM in the entry and prevents it from being evicted.
+// The returned function releases the lock.
+func (e *TraceCacheEntry) Lock() func() {
+	if e == nil {
+		return func() {}
+	}
+	unlock := e.cache.lock(e.key)
+	// Recreate the entry in case it was evicted while unlocked
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		e.cache.logger.Warn("Failed to create trace cache entry", "dir", e.dir, "err", err)
+	}
+	e.touch()
+	return unlock
+}
+
+// RecordGet records whether the requested proof was already available in the cache.
+func (e *TraceCacheEntry) RecordGet(hit bool) {
+	if e == nil {
+		return
+	}
+	e.cache.metrics.CacheGet(metricsLabel, hit)
+}
+
+// Evict evicts entries from the cache if it has grown beyond its max size.
+func (e *TraceCacheEntry) Evict() {
+	if e == nil {
+		return
+	}
+	if err := e.cache.Evict(); err != nil {
+		e.cache.logger.Warn("Failed to evict trace cache entries", "err", err)
+	}
+}
+
+func (e *TraceCacheEntry) touch() {
+	path := filepath.Join(e.dir, lastUsedFilename)
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); errors.Is(err, os.ErrNotExist) {
+		err = os.WriteFile(path, nil, 0644)
+		if err != nil {
+			e.cache.logger.Warn("Failed to create trace cache last used marker", "dir", e.dir, "err", err)
+		}
+	} else if err != nil {
+		e.cache.logger.Warn("Failed to update trace cache last used marker", "dir", e.dir, "err", err)
+	}
+}
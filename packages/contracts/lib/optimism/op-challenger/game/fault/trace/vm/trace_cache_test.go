@@ -0,0 +1,163 @@
+package vm
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCacheKey(t *testing.T) {
+	inputs := utils.LocalGameInputs{
+		L1Head:        common.Hash{0x11},
+		L2Head:        common.Hash{0x22},
+		L2OutputRoot:  common.Hash{0x33},
+		L2Claim:       common.Hash{0x44},
+		L2BlockNumber: big.NewInt(55),
+	}
+	prestate := common.Hash{0xaa}
+	key := TraceCacheKey(types.TraceTypeCannon, prestate, inputs)
+	require.Equal(t, key, TraceCacheKey(types.TraceTypeCannon, prestate, inputs))
+
+	require.NotEqual(t, key, TraceCacheKey(types.TraceTypeAsterisc, prestate, inputs))
+	require.NotEqual(t, key, TraceCacheKey(types.TraceTypeCannon, common.Hash{0xbb}, inputs))
+	modified := inputs
+	modified.L2Claim = common.Hash{0x45}
+	require.NotEqual(t, key, TraceCacheKey(types.TraceTypeCannon, prestate, modified))
+	modified = inputs
+	modified.L2BlockNumber = big.NewInt(56)
+	require.NotEqual(t, key, TraceCacheKey(types.TraceTypeCannon, prestate, modified))
+}
+
+func TestTraceCache(t *testing.T) {
+	setup := func(t *testing.T, maxSize int64) (*TraceCache, *stubCacheMetrics, string) {
+		dir := t.TempDir()
+		prestate := filepath.Join(dir, "prestate.bin.gz")
+		require.NoError(t, os.WriteFile(prestate, []byte("prestate"), 0644))
+		m := &stubCacheMetrics{}
+		return NewTraceCache(testlog.Logger(t, log.LevelInfo), m, filepath.Join(dir, "cache"), maxSize), m, prestate
+	}
+	inputs := func(blockNum int64) utils.LocalGameInputs {
+		return utils.LocalGameInputs{L2BlockNumber: big.NewInt(blockNum)}
+	}
+	writeData := func(t *testing.T, entry *TraceCacheEntry, size int, lastUsed time.Time) {
+		require.NoError(t, os.WriteFile(filepath.Join(entry.Dir(), "data"), make([]byte, size), 0644))
+		require.NoError(t, os.Chtimes(filepath.Join(entry.Dir(), lastUsedFilename), lastUsed, lastUsed))
+	}
+
+	t.Run("SameInputsShareEntry", func(t *testing.T) {
+		cache, _, prestate := setup(t, 1000)
+		entry1, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(1))
+		require.NoError(t, err)
+		entry2, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(1))
+		require.NoError(t, err)
+		entry3, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(2))
+		require.NoError(t, err)
+		require.Equal(t, entry1.Dir(), entry2.Dir())
+		require.NotEqual(t, entry1.Dir(), entry3.Dir())
+		require.DirExists(t, entry1.Dir())
+		require.FileExists(t, filepath.Join(entry1.Dir(), lastUsedFilename))
+	})
+
+	t.Run("MissingPrestate", func(t *testing.T) {
+		cache, _, prestate := setup(t, 1000)
+		_, err := cache.Entry(types.TraceTypeCannon, prestate+".missing", inputs(1))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("EvictLeastRecentlyUsed", func(t *testing.T) {
+		cache, m, prestate := setup(t, 250)
+		now := time.Now()
+		var entries []*TraceCacheEntry
+		for i := 0; i < 4; i++ {
+			entry, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(int64(i)))
+			require.NoError(t, err)
+			writeData(t, entry, 100, now.Add(time.Duration(i)*time.Minute))
+			entries = append(entries, entry)
+		}
+		require.NoError(t, cache.Evict())
+		require.NoDirExists(t, entries[0].Dir())
+		require.NoDirExists(t, entries[1].Dir())
+		require.DirExists(t, entries[2].Dir())
+		require.DirExists(t, entries[3].Dir())
+		require.Equal(t, 2, m.size)
+		require.True(t, m.evicted)
+	})
+
+	t.Run("DoNotEvictLockedEntries", func(t *testing.T) {
+		cache, _, prestate := setup(t, 150)
+		now := time.Now()
+		oldest, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(1))
+		require.NoError(t, err)
+		newest, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(2))
+		require.NoError(t, err)
+		unlock := oldest.Lock()
+		writeData(t, oldest, 100, now)
+		writeData(t, newest, 100, now.Add(time.Minute))
+
+		require.NoError(t, cache.Evict())
+		require.DirExists(t, oldest.Dir())
+		require.NoDirExists(t, newest.Dir())
+
+		unlock()
+		// Locking again recreates the evicted entry
+		newest.Lock()()
+		require.DirExists(t, newest.Dir())
+	})
+
+	t.Run("UnderMaxSize", func(t *testing.T) {
+		cache, m, prestate := setup(t, 1000)
+		entry, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(1))
+		require.NoError(t, err)
+		writeData(t, entry, 100, time.Now())
+		require.NoError(t, cache.Evict())
+		require.DirExists(t, entry.Dir())
+		require.False(t, m.evicted)
+	})
+
+	t.Run("RecordGet", func(t *testing.T) {
+		cache, m, prestate := setup(t, 1000)
+		entry, err := cache.Entry(types.TraceTypeCannon, prestate, inputs(1))
+		require.NoError(t, err)
+		entry.RecordGet(true)
+		entry.RecordGet(false)
+		entry.RecordGet(true)
+		require.Equal(t, 2, m.hits)
+		require.Equal(t, 1, m.misses)
+	})
+
+	t.Run("NilEntry", func(t *testing.T) {
+		var entry *TraceCacheEntry
+		entry.Lock()()
+		entry.RecordGet(true)
+		entry.Evict()
+	})
+}
+
+type stubCacheMetrics struct {
+	size    int
+	evicted bool
+	hits    int
+	misses  int
+}
+
+func (s *stubCacheMetrics) CacheAdd(_ string, cacheSize int, evicted bool) {
+	s.size = cacheSize
+	s.evicted = s.evicted || evicted
+}
+
+func (s *stubCacheMetrics) CacheGet(_ string, hit bool) {
+	if hit {
+		s.hits++
+	} else {
+		s.misses++
+	}
+}
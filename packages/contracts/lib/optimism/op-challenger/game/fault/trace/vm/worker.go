@@ -0,0 +1,300 @@
+package vm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	kvtypes "github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// WorkerVM is a VM a Worker can execute, along with the oracle server used to supply preimages.
+type WorkerVM struct {
+	Config       Config
+	OracleServer OracleServerExecutor
+	Metrics      Metricer
+}
+
+type generateFn func(ctx context.Context, vm WorkerVM, prestate string, inputs utils.LocalGameInputs, dir string, step uint64) error
+
+// Worker executes proof generation jobs on behalf of challengers.
+// Executions are stored in a TraceCache so later jobs for the same prestate and inputs can start from
+// existing snapshots. Workers are served over HTTP by NewWorkerHandler.
+type Worker struct {
+	logger           log.Logger
+	vms              map[types.TraceType]WorkerVM
+	prestateDir      string
+	maxPrestatesSize int64
+	uploadLock       sync.Mutex
+	cache            *TraceCache
+	jobs             chan struct{}
+	generate         generateFn
+}
+
+// NewWorker creates a Worker storing prestates and executions in dir.
+// At most maxJobs jobs are executed concurrently, additional jobs are rejected with ErrWorkerBusy.
+// Uploaded prestates are limited to a total of maxPrestatesSize bytes.
+func NewWorker(logger log.Logger, cacheMetrics caching.Metrics, dir string, maxJobs uint, maxCacheSize int64, maxPrestatesSize int64, vms ...WorkerVM) *Worker {
+	vmsByType := make(map[types.TraceType]WorkerVM, len(vms))
+	for _, vm := range vms {
+		vmsByType[vm.Config.VmType] = vm
+	}
+	return &Worker{
+		logger:           logger,
+		vms:              vmsByType,
+		prestateDir:      filepath.Join(dir, "prestates"),
+		maxPrestatesSize: maxPrestatesSize,
+		cache:            NewTraceCache(logger, cacheMetrics, filepath.Join(dir, "traces"), maxCacheSize),
+		jobs:             make(chan struct{}, maxJobs),
+		generate: func(ctx context.Context, vm WorkerVM, prestate string, inputs utils.LocalGameInputs, dir string, step uint64) error {
+			return NewExecutor(logger, vm.Metrics, vm.Config, vm.OracleServer, prestate, inputs).GenerateProof(ctx, dir, step)
+		},
+	}
+}
+
+// HasPrestate returns true if the prestate with the specified content hash has been uploaded.
+func (w *Worker) HasPrestate(hash common.Hash) (bool, error) {
+	_, err := w.prestatePath(hash)
+	if errors.Is(err, ErrUnknownPrestate) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UploadPrestate stores a prestate file of size bytes read from data so it can be used by later jobs.
+// The name is preserved because the VM uses the file extension to determine the state format.
+// Returns ErrInvalidPrestate if the data doesn't match the size and content hash, and ErrPrestateQuotaExceeded
+// if storing it would exceed the maximum total size of prestates.
+func (w *Worker) UploadPrestate(name string, hash common.Hash, size int64, data io.Reader) error {
+	name = filepath.Base(name)
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("%w: invalid name %v", ErrInvalidPrestate, name)
+	}
+	if size <= 0 {
+		return fmt.Errorf("%w: invalid size %v", ErrInvalidPrestate, size)
+	}
+	// Uploads are rare so are serialized to keep the quota check simple
+	w.uploadLock.Lock()
+	defer w.uploadLock.Unlock()
+	if known, err := w.HasPrestate(hash); err != nil {
+		return err
+	} else if known {
+		return nil
+	}
+	used, err := dirSize(w.prestateDir)
+	if err != nil {
+		return fmt.Errorf("failed to check prestate storage: %w", err)
+	}
+	if used+size > w.maxPrestatesSize {
+		return fmt.Errorf("%w: %v bytes used, %v bytes uploaded, %v bytes allowed", ErrPrestateQuotaExceeded, used, size, w.maxPrestatesSize)
+	}
+	dir := filepath.Join(w.prestateDir, hash.Hex())
+	if err := os.MkdirAll(w.prestateDir, 0755); err != nil {
+		return fmt.Errorf("failed to create prestate dir: %w", err)
+	}
+	// Write to a temporary file first so a partially uploaded prestate is never used
+	tmp, err := os.CreateTemp(w.prestateDir, "upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create prestate file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	hasher := crypto.NewKeccakState()
+	written, err := io.Copy(io.MultiWriter(tmp, hasher), io.LimitReader(data, size+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write prestate: %w", err)
+	}
+	if written != size {
+		return fmt.Errorf("%w: expected %v bytes but got %v", ErrInvalidPrestate, size, written)
+	}
+	if actual := common.BytesToHash(hasher.Sum(nil)); actual != hash {
+		return fmt.Errorf("%w: expected hash %v but got %v", ErrInvalidPrestate, hash, actual)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create prestate dir: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("failed to store prestate: %w", err)
+	}
+	w.logger.Info("Stored prestate", "hash", hash, "name", name, "size", size)
+	return nil
+}
+
+// GenerateProof executes the VM to generate the proof requested by the job, then calls send with the result.
+// The result's files are not modified or evicted from the cache until send returns.
+// Returns ErrWorkerBusy without executing the job if the worker is already running its max concurrent jobs.
+func (w *Worker) GenerateProof(ctx context.Context, job ProofJob, send func(result *ProofResult) error) error {
+	select {
+	case w.jobs <- struct{}{}:
+		defer func() { <-w.jobs }()
+	default:
+		return ErrWorkerBusy
+	}
+	vm, ok := w.vms[job.VmType]
+	if !ok {
+		return fmt.Errorf("%w: unsupported vm type %v", ErrInvalidJob, job.VmType)
+	}
+	prestate, err := w.prestatePath(job.PrestateHash)
+	if err != nil {
+		return err
+	}
+	entry, err := w.cache.Entry(job.VmType, prestate, job.Inputs)
+	if err != nil {
+		return err
+	}
+	unlock := entry.Lock()
+	defer unlock()
+	defer entry.Evict()
+
+	step := uint64(job.Step)
+	start := time.Now()
+	proofPath := filepath.Join(entry.Dir(), utils.ProofsDir, fmt.Sprintf("%d.json.gz", step))
+	_, err = os.Stat(proofPath)
+	entry.RecordGet(err == nil)
+	if errors.Is(err, os.ErrNotExist) {
+		w.logger.Info("Generating proof", "vm", job.VmType, "prestate", job.PrestateHash, "proof", step)
+		if err := w.generate(ctx, vm, prestate, job.Inputs, entry.Dir(), step); err != nil {
+			return fmt.Errorf("generate %v trace with proof at %v: %w", job.VmType, step, err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("cannot stat proof %v: %w", proofPath, err)
+	}
+	result, err := w.collectResult(entry.Dir(), vm.Config, step, start)
+	if err != nil {
+		return err
+	}
+	return send(result)
+}
+
+func (w *Worker) prestatePath(hash common.Hash) (string, error) {
+	entries, err := os.ReadDir(filepath.Join(w.prestateDir, hash.Hex()))
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(entries) == 0) {
+		return "", fmt.Errorf("%w: %v", ErrUnknownPrestate, hash)
+	} else if err != nil {
+		return "", fmt.Errorf("failed to read prestate dir: %w", err)
+	}
+	return filepath.Join(w.prestateDir, hash.Hex(), entries[0].Name()), nil
+}
+
+// collectResult lists the snapshots created since start and the proof at step, and loads the preimages it requires.
+// If the trace ended before step, the final state is returned in place of the proof.
+func (w *Worker) collectResult(dir string, cfg Config, step uint64, start time.Time) (*ProofResult, error) {
+	result := &ProofResult{Dir: dir}
+	snapshots, err := os.ReadDir(filepath.Join(dir, SnapsDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, snapshot := range snapshots {
+		info, err := snapshot.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot %v: %w", snapshot.Name(), err)
+		}
+		if snapshot.IsDir() || info.ModTime().Before(start) {
+			continue
+		}
+		result.Files = append(result.Files, filepath.Join(SnapsDir, snapshot.Name()))
+	}
+
+	proofPath := filepath.Join(utils.ProofsDir, fmt.Sprintf("%d.json.gz", step))
+	if _, err := os.Stat(filepath.Join(dir, proofPath)); errors.Is(err, os.ErrNotExist) {
+		// The trace ended before the requested step so the final state is used instead
+		finalState, err := filepath.Rel(dir, FinalStatePath(dir, cfg.BinarySnapshots))
+		if err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, finalState)
+		return result, nil
+	}
+	result.Files = append(result.Files, proofPath)
+	proof, err := readProof(filepath.Join(dir, proofPath))
+	if err != nil {
+		return nil, err
+	}
+	// Load the proof's preimage the same way the challenger will, recording every preimage accessed
+	recorder := &recordingPreimageSource{}
+	loader := utils.NewPreimageLoader(func() (utils.PreimageSource, error) {
+		kv, err := kvstore.NewDiskKV(w.logger, PreimageDir(dir), kvtypes.DataFormatFile)
+		if err != nil {
+			return nil, err
+		}
+		recorder.source = kv
+		return recorder, nil
+	})
+	if _, err := loader.LoadPreimage(proof); err != nil {
+		return nil, fmt.Errorf("failed to load preimage for proof: %w", err)
+	}
+	result.Preimages = recorder.preimages
+	return result, nil
+}
+
+// dirSize returns the total size of the files in dir and its subdirectories.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+func readProof(path string) (*utils.ProofData, error) {
+	file, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open proof file (%v): %w", path, err)
+	}
+	defer file.Close()
+	var proof utils.ProofData
+	if err := json.NewDecoder(file).Decode(&proof); err != nil {
+		return nil, fmt.Errorf("failed to read proof (%v): %w", path, err)
+	}
+	return &proof, nil
+}
+
+type recordingPreimageSource struct {
+	source    utils.PreimageSource
+	preimages []Preimage
+}
+
+func (r *recordingPreimageSource) Get(key common.Hash) ([]byte, error) {
+	value, err := r.source.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	r.preimages = append(r.preimages, Preimage{Key: key, Value: value})
+	return value, nil
+}
+
+func (r *recordingPreimageSource) Close() error {
+	return r.source.Close()
+}
@@ -0,0 +1,263 @@
+package vm
+
+import (
+	"archive/tar"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	kvtypes "github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	prestatesPath = "/prestates/"
+	proofsPath    = "/proofs"
+
+	// preimageEntryPrefix is the name prefix of the tar entries containing preimages in a proof result
+	preimageEntryPrefix = "preimage:"
+
+	maxJobSize   = 1024 * 1024
+	maxErrorSize = 1024
+)
+
+// statusErrors maps the HTTP status workers respond with to the matching sentinel error.
+var statusErrors = map[int]error{
+	http.StatusUnauthorized:        ErrUnauthorized,
+	http.StatusServiceUnavailable:  ErrWorkerBusy,
+	http.StatusNotFound:            ErrUnknownPrestate,
+	http.StatusBadRequest:          ErrInvalidPrestate,
+	http.StatusUnprocessableEntity: ErrInvalidJob,
+	http.StatusInsufficientStorage: ErrPrestateQuotaExceeded,
+}
+
+// NewWorkerHandler serves a Worker over HTTP. Every request must include authToken as a bearer token.
+// Prestates and proof results are streamed rather than buffered in memory:
+//
+//	HEAD /prestates/<hash>         200 if the prestate has been uploaded, 404 otherwise
+//	PUT  /prestates/<hash>/<name>  uploads a prestate, which must match the content hash
+//	POST /proofs                   generates the proof for the ProofJob in the body, responding with a tar stream of
+//	                               the result's preimages and then its files
+func NewWorkerHandler(logger log.Logger, worker *Worker, authToken string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("HEAD "+prestatesPath+"{hash}", func(w http.ResponseWriter, r *http.Request) {
+		hash, err := parseHash(r.PathValue("hash"))
+		if err != nil {
+			writeWorkerError(logger, w, err)
+			return
+		}
+		if known, err := worker.HasPrestate(hash); err != nil {
+			writeWorkerError(logger, w, err)
+		} else if !known {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("PUT "+prestatesPath+"{hash}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		hash, err := parseHash(r.PathValue("hash"))
+		if err != nil {
+			writeWorkerError(logger, w, err)
+			return
+		}
+		if err := worker.UploadPrestate(r.PathValue("name"), hash, r.ContentLength, r.Body); err != nil {
+			writeWorkerError(logger, w, err)
+		}
+	})
+	mux.HandleFunc("POST "+proofsPath, func(w http.ResponseWriter, r *http.Request) {
+		var job ProofJob
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxJobSize)).Decode(&job); err != nil {
+			writeWorkerError(logger, w, fmt.Errorf("%w: %w", ErrInvalidJob, err))
+			return
+		}
+		sending := false
+		err := worker.GenerateProof(r.Context(), job, func(result *ProofResult) error {
+			sending = true
+			w.Header().Set("Content-Type", "application/x-tar")
+			w.WriteHeader(http.StatusOK)
+			return writeProofResult(w, result)
+		})
+		if err != nil && sending {
+			// The status has already been sent, so abort the response to make sure the client sees it as incomplete
+			logger.Warn("Failed to send proof result", "err", err)
+			panic(http.ErrAbortHandler)
+		} else if err != nil {
+			writeWorkerError(logger, w, err)
+		}
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(authToken)) != 1 {
+			http.Error(w, ErrUnauthorized.Error(), http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func parseHash(value string) (common.Hash, error) {
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(value)); err != nil {
+		return common.Hash{}, fmt.Errorf("%w: invalid hash %v", ErrInvalidPrestate, value)
+	}
+	return hash, nil
+}
+
+func writeWorkerError(logger log.Logger, w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	for code, sentinel := range statusErrors {
+		if errors.Is(err, sentinel) {
+			status = code
+			break
+		}
+	}
+	if status == http.StatusInternalServerError {
+		logger.Error("Proof worker request failed", "err", err)
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// writeProofResult writes the preimages and then the files of a ProofResult as a tar stream.
+// Files are written in order, so the proof is written after any snapshots.
+func writeProofResult(w io.Writer, result *ProofResult) error {
+	out := tar.NewWriter(w)
+	for _, preimage := range result.Preimages {
+		header := &tar.Header{
+			Name: preimageEntryPrefix + preimage.Key.Hex(),
+			Mode: 0644,
+			Size: int64(len(preimage.Value)),
+		}
+		if err := out.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := out.Write(preimage.Value); err != nil {
+			return err
+		}
+	}
+	for _, path := range result.Files {
+		if err := writeFileEntry(out, result.Dir, path); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+func writeFileEntry(out *tar.Writer, dir string, path string) error {
+	file, err := os.Open(filepath.Join(dir, path))
+	if err != nil {
+		return fmt.Errorf("failed to open %v: %w", path, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %v: %w", path, err)
+	}
+	if err := out.WriteHeader(&tar.Header{Name: filepath.ToSlash(path), Mode: 0644, Size: info.Size()}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, file); err != nil {
+		return fmt.Errorf("failed to send %v: %w", path, err)
+	}
+	return nil
+}
+
+// storeProofResult reads a tar stream written by writeProofResult, storing the preimages and files in dir.
+// Each file is written to a temporary file first so an incomplete response never leaves a partial proof.
+func storeProofResult(logger log.Logger, dir string, r io.Reader) error {
+	in := tar.NewReader(r)
+	var kv kvstore.KV
+	defer func() {
+		if kv != nil {
+			_ = kv.Close()
+		}
+	}()
+	for {
+		header, err := in.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read proof result: %w", err)
+		}
+		if keyHex, ok := strings.CutPrefix(header.Name, preimageEntryPrefix); ok {
+			if kv == nil {
+				if kv, err = openPreimageStore(logger, dir); err != nil {
+					return err
+				}
+			}
+			if err := storePreimage(kv, keyHex, in); err != nil {
+				return err
+			}
+			continue
+		}
+		path := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(path) {
+			return fmt.Errorf("%w: file path %v is not within the trace directory", ErrInvalidResult, header.Name)
+		}
+		if err := storeFile(filepath.Join(dir, path), in); err != nil {
+			return err
+		}
+	}
+}
+
+func openPreimageStore(logger log.Logger, dir string) (kvstore.KV, error) {
+	preimageDir := PreimageDir(dir)
+	if err := os.MkdirAll(preimageDir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create preimage cache directory %v: %w", preimageDir, err)
+	}
+	kv, err := kvstore.NewDiskKV(logger, preimageDir, kvtypes.DataFormatFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open preimage store: %w", err)
+	}
+	return kv, nil
+}
+
+func storePreimage(kv kvstore.KV, keyHex string, r io.Reader) error {
+	var key common.Hash
+	if err := key.UnmarshalText([]byte(keyHex)); err != nil {
+		return fmt.Errorf("%w: invalid preimage key %v", ErrInvalidResult, keyHex)
+	}
+	value, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("failed to read preimage %v: %w", key, err)
+	}
+	// Preimages are used in onchain steps, so don't trust the worker to return the correct data for their keys
+	verified := preimage.WithVerification(func(_ [32]byte) ([]byte, error) {
+		return value, nil
+	})
+	if _, err := verified(key); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidResult, err)
+	}
+	if err := kv.Put(key, value); err != nil {
+		return fmt.Errorf("failed to store preimage %v: %w", key, err)
+	}
+	return nil
+}
+
+func storeFile(path string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %v: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create %v: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %v: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store %v: %w", path, err)
+	}
+	return nil
+}
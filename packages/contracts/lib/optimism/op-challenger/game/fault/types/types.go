@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
 	"time"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
@@ -19,6 +20,9 @@ import (
 var (
 	ErrGameDepthReached   = errors.New("game depth reached")
 	ErrL2BlockNumberValid = errors.New("l2 block number is valid")
+
+	ErrTraceTypeExists = errors.New("trace type already registered")
+	ErrGameTypeExists  = errors.New("game type already registered")
 )
 
 type GameType uint32
@@ -52,6 +56,11 @@ func (t GameType) String() string {
 	case AlphabetGameType:
 		return "alphabet"
 	default:
+		for traceType, gameType := range extensionTraceTypes {
+			if gameType == t {
+				return traceType.String()
+			}
+		}
 		return fmt.Sprintf("<invalid: %d>", t)
 	}
 }
@@ -69,6 +78,29 @@ const (
 
 var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc, TraceTypeAsteriscKona, TraceTypeFast}
 
+// extensionTraceTypes maps trace types added with RegisterTraceType to the game type they play.
+var extensionTraceTypes = make(map[TraceType]GameType)
+
+// RegisterTraceType adds a trace type that is not built into op-challenger, played in games of the specified type.
+// It must be called before flags are parsed, typically from an init function.
+func RegisterTraceType(traceType TraceType, gameType GameType) error {
+	if ValidTraceType(traceType) {
+		return fmt.Errorf("%w: %v", ErrTraceTypeExists, traceType)
+	}
+	if gameType == UnknownGameType || slices.ContainsFunc(TraceTypes, func(t TraceType) bool { return t.GameType() == gameType }) {
+		return fmt.Errorf("%w: %d", ErrGameTypeExists, gameType)
+	}
+	extensionTraceTypes[traceType] = gameType
+	TraceTypes = append(TraceTypes, traceType)
+	return nil
+}
+
+// IsExtensionTraceType returns true if the trace type was added with RegisterTraceType.
+func IsExtensionTraceType(traceType TraceType) bool {
+	_, ok := extensionTraceTypes[traceType]
+	return ok
+}
+
 func (t TraceType) String() string {
 	return string(t)
 }
@@ -111,6 +143,9 @@ func (t TraceType) GameType() GameType {
 	case TraceTypeAlphabet:
 		return AlphabetGameType
 	default:
+		if gameType, ok := extensionTraceTypes[t]; ok {
+			return gameType
+		}
 		return UnknownGameType
 	}
 }
@@ -2,6 +2,7 @@ package types
 
 import (
 	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -60,3 +61,31 @@ func TestIsRootPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterTraceType(t *testing.T) {
+	origTraceTypes := slices.Clone(TraceTypes)
+	t.Cleanup(func() {
+		TraceTypes = origTraceTypes
+		clear(extensionTraceTypes)
+	})
+
+	traceType := TraceType("test-vm")
+	gameType := GameType(42)
+	require.False(t, ValidTraceType(traceType))
+	require.NoError(t, RegisterTraceType(traceType, gameType))
+
+	require.True(t, ValidTraceType(traceType))
+	require.True(t, IsExtensionTraceType(traceType))
+	require.False(t, IsExtensionTraceType(TraceTypeCannon))
+	require.Equal(t, gameType, traceType.GameType())
+	require.Equal(t, "test-vm", gameType.String())
+	var parsed TraceType
+	require.NoError(t, parsed.Set("test-vm"))
+	require.Equal(t, traceType, parsed)
+
+	require.ErrorIs(t, RegisterTraceType(traceType, GameType(43)), ErrTraceTypeExists)
+	require.ErrorIs(t, RegisterTraceType(TraceTypeCannon, GameType(43)), ErrTraceTypeExists)
+	require.ErrorIs(t, RegisterTraceType("other-vm", gameType), ErrGameTypeExists)
+	require.ErrorIs(t, RegisterTraceType("other-vm", CannonGameType), ErrGameTypeExists)
+	require.ErrorIs(t, RegisterTraceType("other-vm", UnknownGameType), ErrGameTypeExists)
+}
@@ -28,6 +28,9 @@ type gameState struct {
 	inflight              bool
 	lastProcessedBlockNum uint64
 	status                types.GameStatus
+	// progressed is true once the game has been progressed at least once, making urgency available.
+	progressed bool
+	urgency    types.Urgency
 }
 
 // coordinator manages the set of current games, queues games to be played (on separate worker threads) and
@@ -105,7 +108,10 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	c.lastScheduledBlockNum = blockNumber
 	c.m.RecordActedL1Block(lowestProcessedBlockNum)
 
-	// Finally, enqueue the jobs
+	// Finally, enqueue the jobs with the most urgent games first
+	slices.SortStableFunc(jobs, func(a, b job) int {
+		return comparePriority(c.states[a.addr], c.states[b.addr])
+	})
 	for _, j := range jobs {
 		if err := c.enqueueJob(ctx, j); err != nil {
 			errs = append(errs, fmt.Errorf("failed to enqueue job for game %v: %w", j.addr, err))
@@ -175,6 +181,8 @@ func (c *coordinator) processResult(j job) error {
 	state.inflight = false
 	state.status = j.status
 	state.lastProcessedBlockNum = j.block
+	state.progressed = true
+	state.urgency = j.urgency
 	c.deleteResolvedGameFiles()
 	c.m.RecordGameUpdateCompleted()
 	return nil
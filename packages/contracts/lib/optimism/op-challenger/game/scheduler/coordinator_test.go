@@ -3,8 +3,10 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -392,6 +394,40 @@ func TestDropOldGameStates(t *testing.T) {
 	require.Contains(t, c.states, gameAddr4, "should create state for game 4")
 }
 
+func TestScheduleMostUrgentGamesFirst(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	ctx := context.Background()
+	gameNoActions := common.Address{0x01}
+	gameUnknown := common.Address{0x02}
+	gameLowBond := common.Address{0x03}
+	gameHighBond := common.Address{0x04}
+	gameLaterDeadline := common.Address{0x05}
+	gameEarliestDeadline := common.Address{0x06}
+	base := time.Unix(3600*1000, 0)
+	urgencies := map[common.Address]types.Urgency{
+		gameEarliestDeadline: {Deadline: base.Add(1 * time.Minute), BondAtRisk: big.NewInt(1)},
+		gameLowBond:          {Deadline: base.Add(10 * time.Minute), BondAtRisk: big.NewInt(1)},
+		gameHighBond:         {Deadline: base.Add(10 * time.Minute), BondAtRisk: big.NewInt(100)},
+		gameLaterDeadline:    {Deadline: base.Add(2 * time.Hour), BondAtRisk: big.NewInt(1000)},
+	}
+	allGames := asGames(gameNoActions, gameLowBond, gameHighBond, gameLaterDeadline, gameEarliestDeadline)
+
+	require.NoError(t, c.schedule(ctx, allGames, 0))
+	for len(workQueue) > 0 {
+		j := <-workQueue
+		j.urgency = urgencies[j.addr]
+		require.NoError(t, c.processResult(j))
+	}
+
+	require.NoError(t, c.schedule(ctx, append(allGames, asGames(gameUnknown)...), 1))
+	var order []common.Address
+	for len(workQueue) > 0 {
+		order = append(order, (<-workQueue).addr)
+	}
+	// A larger bond at risk only takes priority over games with the same deadline
+	require.Equal(t, []common.Address{gameEarliestDeadline, gameHighBond, gameLowBond, gameLaterDeadline, gameUnknown, gameNoActions}, order)
+}
+
 func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan job, chan job, *createdGames, *stubDiskManager, *testlog.CapturingHandler) {
 	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
 	workQueue := make(chan job, bufferSize)
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"math/big"
+)
+
+// comparePriority orders game states so that the most urgent games are progressed first:
+//  1. Games that required actions, earliest deadline first, then largest bond at risk.
+//     Bond at risk only breaks ties so a game close to its deadline is never delayed by games with larger bonds.
+//  2. Games that have not been progressed yet so their urgency is unknown.
+//  3. Games that did not require any actions.
+//
+// Proofs are generated while progressing a game so this also orders proof generation across games.
+func comparePriority(a, b *gameState) int {
+	if rankA, rankB := priorityRank(a), priorityRank(b); rankA != rankB {
+		return rankA - rankB
+	}
+	if !a.urgency.ActionsRequired() {
+		return 0
+	}
+	if c := a.urgency.Deadline.Compare(b.urgency.Deadline); c != 0 {
+		return c
+	}
+	return bondOrZero(b.urgency.BondAtRisk).Cmp(bondOrZero(a.urgency.BondAtRisk))
+}
+
+func priorityRank(state *gameState) int {
+	switch {
+	case !state.progressed:
+		return 1
+	case state.urgency.ActionsRequired():
+		return 0
+	default:
+		return 2
+	}
+}
+
+func bondOrZero(bond *big.Int) *big.Int {
+	if bond == nil {
+		return big.NewInt(0)
+	}
+	return bond
+}
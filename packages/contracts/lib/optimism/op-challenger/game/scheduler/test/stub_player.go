@@ -13,6 +13,7 @@ type StubGamePlayer struct {
 	StatusValue   types.GameStatus
 	Dir           string
 	PrestateErr   error
+	UrgencyValue  types.Urgency
 }
 
 func (g *StubGamePlayer) ValidatePrestate(_ context.Context) error {
@@ -27,3 +28,7 @@ func (g *StubGamePlayer) ProgressGame(_ context.Context) types.GameStatus {
 func (g *StubGamePlayer) Status() types.GameStatus {
 	return g.StatusValue
 }
+
+func (g *StubGamePlayer) Urgency() types.Urgency {
+	return g.UrgencyValue
+}
@@ -12,6 +12,7 @@ type GamePlayer interface {
 	ValidatePrestate(ctx context.Context) error
 	ProgressGame(ctx context.Context) types.GameStatus
 	Status() types.GameStatus
+	Urgency() types.Urgency
 }
 
 type DiskManager interface {
@@ -20,10 +21,11 @@ type DiskManager interface {
 }
 
 type job struct {
-	block  uint64
-	addr   common.Address
-	player GamePlayer
-	status types.GameStatus
+	block   uint64
+	addr    common.Address
+	player  GamePlayer
+	status  types.GameStatus
+	urgency types.Urgency
 }
 
 func newJob(block uint64, addr common.Address, player GamePlayer, status types.GameStatus) *job {
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
@@ -111,7 +114,7 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.registerGameTypes(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to register game types: %w", err)
 	}
-	if err := s.initBondClaims(); err != nil {
+	if err := s.initBondClaims(cfg); err != nil {
 		return fmt.Errorf("failed to init bond claiming: %w", err)
 	}
 	if err := s.initScheduler(cfg); err != nil {
@@ -204,9 +207,25 @@ func (s *Service) initFactoryContract(cfg *config.Config) error {
 	return nil
 }
 
-func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.claimants...)
-	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
+func (s *Service) initBondClaims(cfg *config.Config) error {
+	if err := os.MkdirAll(cfg.Datadir, 0755); err != nil {
+		return fmt.Errorf("failed to create datadir: %w", err)
+	}
+	ledger, err := claims.OpenLedger(filepath.Join(cfg.Datadir, claims.LedgerFilename))
+	if err != nil {
+		return err
+	}
+	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.l1Clock, ledger, s.registry.CreateBondContract, s.txSender, s.claimants...)
+	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+	wethCreator := func(addr common.Address) claims.WETHContract {
+		return contracts.NewDelayedWETHContract(s.metrics, addr, caller)
+	}
+	balance := func(ctx context.Context, addr common.Address) (*big.Int, error) {
+		return s.l1Client.BalanceAt(ctx, addr, nil)
+	}
+	accountant := claims.NewAccountant(s.logger, s.metrics, s.l1Clock, ledger, claimer, wethCreator, balance, s.txSender,
+		cfg.BondTreasury, cfg.BondSweepThreshold)
+	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, accountant)
 	return nil
 }
 
@@ -3,6 +3,8 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -45,3 +47,18 @@ type GameMetadata struct {
 	Timestamp uint64
 	Proxy     common.Address
 }
+
+// Urgency describes how soon a game needs to be progressed, based on the actions it required the last time it
+// was progressed.
+type Urgency struct {
+	// Deadline is the earliest time a chess clock expires for a claim that required a response.
+	// The zero time if no actions were required.
+	Deadline time.Time
+	// BondAtRisk is the total bond of the claims that required a response.
+	BondAtRisk *big.Int
+}
+
+// ActionsRequired returns true if the game required any actions the last time it was progressed.
+func (u Urgency) ActionsRequired() bool {
+	return !u.Deadline.IsZero()
+}
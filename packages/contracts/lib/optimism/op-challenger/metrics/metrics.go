@@ -2,8 +2,10 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
@@ -40,12 +42,16 @@ type Metricer interface {
 	RecordGameL2Challenge()
 	RecordClaimResolutionTime(t float64)
 	RecordGameActTime(t float64)
+	RecordActionClockHeadroom(actionType string, headroom time.Duration)
 
 	RecordPreimageChallenged()
 	RecordPreimageChallengeFailed()
 
 	RecordBondClaimFailed()
 	RecordBondClaimed(amount uint64)
+	RecordBondLiquidity(state string, amount *big.Int)
+	RecordProjectedLiquidity(horizon string, amount *big.Int)
+	RecordFundsSwept(amount *big.Int)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
@@ -81,8 +87,11 @@ type Metrics struct {
 
 	executors prometheus.GaugeVec
 
-	bondClaimFailures prometheus.Counter
-	bondsClaimed      prometheus.Counter
+	bondClaimFailures  prometheus.Counter
+	bondsClaimed       prometheus.Counter
+	bondLiquidity      prometheus.GaugeVec
+	projectedLiquidity prometheus.GaugeVec
+	fundsSwept         prometheus.Counter
 
 	preimageChallenged      prometheus.Counter
 	preimageChallengeFailed prometheus.Counter
@@ -96,6 +105,7 @@ type Metrics struct {
 
 	claimResolutionTime prometheus.Histogram
 	gameActTime         prometheus.Histogram
+	actionClockHeadroom *prometheus.HistogramVec
 	vmExecutionTime     *prometheus.HistogramVec
 	vmMemoryUsed        *prometheus.HistogramVec
 
@@ -172,6 +182,14 @@ func NewMetrics() *Metrics {
 				[]float64{1.0, 2.0, 5.0, 10.0},
 				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
 		}),
+		actionClockHeadroom: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "action_clock_headroom",
+			Help:      "Time (in seconds) remaining on the chess clock when an action is performed",
+			Buckets: append(
+				[]float64{60, 300, 900, 1800},
+				prometheus.ExponentialBuckets(3600, 2, 8)...),
+		}, []string{"action"}),
 		vmExecutionTime: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "vm_execution_time",
@@ -197,6 +215,25 @@ func NewMetrics() *Metrics {
 			Name:      "bonds",
 			Help:      "Number of bonds claimed by the challenge agent",
 		}),
+		bondLiquidity: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bond_liquidity",
+			Help:      "ETH available to the challenger and waiting in DelayedWETH, by whether it is withdrawable yet",
+		}, []string{
+			"state",
+		}),
+		projectedLiquidity: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "projected_liquidity",
+			Help:      "ETH projected to be available to the challenger once DelayedWETH withdrawals unlock, by time horizon",
+		}, []string{
+			"horizon",
+		}),
+		fundsSwept: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "funds_swept",
+			Help:      "Amount of ETH swept to the bond treasury",
+		}),
 		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "preimage_challenged",
@@ -292,6 +329,18 @@ func (m *Metrics) RecordBondClaimed(amount uint64) {
 	m.bondsClaimed.Add(float64(amount))
 }
 
+func (m *Metrics) RecordBondLiquidity(state string, amount *big.Int) {
+	m.bondLiquidity.WithLabelValues(state).Set(eth.WeiToEther(amount))
+}
+
+func (m *Metrics) RecordProjectedLiquidity(horizon string, amount *big.Int) {
+	m.projectedLiquidity.WithLabelValues(horizon).Set(eth.WeiToEther(amount))
+}
+
+func (m *Metrics) RecordFundsSwept(amount *big.Int) {
+	m.fundsSwept.Add(eth.WeiToEther(amount))
+}
+
 func (m *Metrics) RecordVmExecutionTime(vmType string, dur time.Duration) {
 	m.vmExecutionTime.WithLabelValues(vmType).Observe(dur.Seconds())
 }
@@ -308,6 +357,10 @@ func (m *Metrics) RecordGameActTime(t float64) {
 	m.gameActTime.Observe(t)
 }
 
+func (m *Metrics) RecordActionClockHeadroom(actionType string, headroom time.Duration) {
+	m.actionClockHeadroom.WithLabelValues(actionType).Observe(headroom.Seconds())
+}
+
 func (m *Metrics) IncActiveExecutors() {
 	m.executors.WithLabelValues("active").Inc()
 }
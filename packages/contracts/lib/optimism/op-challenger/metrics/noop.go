@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 	"time"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
@@ -36,14 +37,19 @@ func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
 func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
 func (*NoopMetricsImpl) RecordLargePreimageCount(_ int) {}
 
-func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
-func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
+func (*NoopMetricsImpl) RecordBondClaimFailed()                        {}
+func (*NoopMetricsImpl) RecordBondClaimed(uint64)                      {}
+func (*NoopMetricsImpl) RecordBondLiquidity(_ string, _ *big.Int)      {}
+func (*NoopMetricsImpl) RecordProjectedLiquidity(_ string, _ *big.Int) {}
+func (*NoopMetricsImpl) RecordFundsSwept(_ *big.Int)                   {}
 
 func (*NoopMetricsImpl) RecordVmExecutionTime(_ string, _ time.Duration) {}
 func (*NoopMetricsImpl) RecordVmMemoryUsed(_ string, _ uint64)           {}
 func (*NoopMetricsImpl) RecordClaimResolutionTime(t float64)             {}
 func (*NoopMetricsImpl) RecordGameActTime(t float64)                     {}
 
+func (*NoopMetricsImpl) RecordActionClockHeadroom(_ string, _ time.Duration) {}
+
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
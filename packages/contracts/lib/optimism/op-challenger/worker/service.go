@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type Config struct {
+	ListenAddr       string
+	ListenPort       int
+	AuthToken        string // Token challengers must include in the Authorization header of each request
+	MaxJobs          uint   // Maximum number of proofs to generate concurrently
+	MaxCacheSize     int64  // Maximum size in bytes of the executions kept to speed up later jobs
+	MaxPrestatesSize int64  // Maximum total size in bytes of uploaded prestates
+}
+
+// Service serves a vm.Worker over HTTP so challengers can generate proofs remotely.
+type Service struct {
+	logger     log.Logger
+	cfg        *config.Config
+	workerCfg  Config
+	version    string
+	m          *metrics.Metrics
+	httpServer *httputil.HTTPServer
+	metricsSrv *httputil.HTTPServer
+	running    atomic.Bool
+}
+
+func NewService(logger log.Logger, cfg *config.Config, workerCfg Config, version string) *Service {
+	return &Service{
+		logger:    logger,
+		cfg:       cfg,
+		workerCfg: workerCfg,
+		version:   version,
+		m:         metrics.NewMetrics(),
+	}
+}
+
+func (s *Service) Start(_ context.Context) error {
+	if !s.running.CompareAndSwap(false, true) {
+		return errors.New("already started")
+	}
+	if err := s.initMetricsServer(&s.cfg.MetricsConfig); err != nil {
+		return fmt.Errorf("failed to start metrics: %w", err)
+	}
+
+	vms := s.workerVMs()
+	if len(vms) == 0 {
+		return errors.New("no cannon or asterisc trace types enabled")
+	}
+	worker := vm.NewWorker(s.logger, s.m, filepath.Join(s.cfg.Datadir, "worker"), s.workerCfg.MaxJobs, s.workerCfg.MaxCacheSize, s.workerCfg.MaxPrestatesSize, vms...)
+	addr := net.JoinHostPort(s.workerCfg.ListenAddr, strconv.Itoa(s.workerCfg.ListenPort))
+	// Proof generation and artifact transfers are bounded by the challenger's timeout rather than the server's
+	server, err := httputil.StartHTTPServer(addr, vm.NewWorkerHandler(s.logger, worker, s.workerCfg.AuthToken),
+		httputil.WithTimeouts(httputil.HTTPTimeouts{
+			ReadHeaderTimeout: httputil.DefaultTimeouts.ReadHeaderTimeout,
+			IdleTimeout:       httputil.DefaultTimeouts.IdleTimeout,
+		}))
+	if err != nil {
+		return fmt.Errorf("unable to start HTTP server: %w", err)
+	}
+	s.httpServer = server
+	s.m.RecordInfo(s.version)
+	s.m.RecordUp()
+	s.logger.Info("Proof worker started", "addr", server.Addr(), "vms", len(vms), "maxJobs", s.workerCfg.MaxJobs)
+	return nil
+}
+
+func (s *Service) workerVMs() []vm.WorkerVM {
+	var vms []vm.WorkerVM
+	if s.cfg.TraceTypeEnabled(types.TraceTypeCannon) || s.cfg.TraceTypeEnabled(types.TraceTypePermissioned) {
+		vms = append(vms, vm.WorkerVM{
+			Config:       s.cfg.Cannon,
+			OracleServer: vm.NewOpProgramServerExecutor(s.logger),
+			Metrics:      s.m.VmMetrics(s.cfg.Cannon.VmType.String()),
+		})
+	}
+	if s.cfg.TraceTypeEnabled(types.TraceTypeAsterisc) {
+		vms = append(vms, vm.WorkerVM{
+			Config:       s.cfg.Asterisc,
+			OracleServer: vm.NewOpProgramServerExecutor(s.logger),
+			Metrics:      s.m.VmMetrics(s.cfg.Asterisc.VmType.String()),
+		})
+	}
+	if s.cfg.TraceTypeEnabled(types.TraceTypeAsteriscKona) {
+		vms = append(vms, vm.WorkerVM{
+			Config:       s.cfg.AsteriscKona,
+			OracleServer: vm.NewKonaExecutor(),
+			Metrics:      s.m.VmMetrics(s.cfg.AsteriscKona.VmType.String()),
+		})
+	}
+	return vms
+}
+
+func (s *Service) Stop(ctx context.Context) error {
+	s.logger.Info("Stopping proof worker")
+	if !s.running.CompareAndSwap(true, false) {
+		return errors.New("not started")
+	}
+	var result error
+	if s.httpServer != nil {
+		if err := s.httpServer.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop HTTP server: %w", err))
+		}
+	}
+	if s.metricsSrv != nil {
+		if err := s.metricsSrv.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop metrics server: %w", err))
+		}
+	}
+	return result
+}
+
+func (s *Service) Stopped() bool {
+	return !s.running.Load()
+}
+
+func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	s.logger.Debug("Starting metrics server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
+	metricsSrv, err := opmetrics.StartServer(s.m.Registry(), cfg.ListenAddr, cfg.ListenPort)
+	if err != nil {
+		return fmt.Errorf("failed to start metrics server: %w", err)
+	}
+	s.logger.Info("started metrics server", "addr", metricsSrv.Addr())
+	s.metricsSrv = metricsSrv
+	return nil
+}
+
+var _ cliapp.Lifecycle = (*Service)(nil)
@@ -3,8 +3,12 @@ package conductor
 import (
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli/v2"
@@ -32,13 +36,16 @@ type Config struct {
 	// E.g. local tests may use temporary addresses, rather than preset known addresses.
 	ConsensusAdvertisedAddr string
 
-	// RaftServerID is the unique ID for this server used by raft consensus.
+	// ConsensusBackend is the consensus backend used for leader election and unsafe head replication.
+	ConsensusBackend flags.BackendType
+
+	// RaftServerID is the unique ID for this server used by consensus.
 	RaftServerID string
 
 	// RaftStorageDir is the directory to store raft data.
 	RaftStorageDir string
 
-	// RaftBootstrap is true if this node should bootstrap a new raft cluster.
+	// RaftBootstrap is true if this node should bootstrap a new cluster.
 	RaftBootstrap bool
 
 	// RaftSnapshotInterval is the interval to check if a snapshot should be taken.
@@ -50,6 +57,15 @@ type Config struct {
 	// RaftTrailingLogs is the number of logs to keep after a snapshot.
 	RaftTrailingLogs uint64
 
+	// EtcdEndpoints are the endpoints of the etcd v3 compatible store used by the etcd consensus backend.
+	EtcdEndpoints []string
+
+	// EtcdPrefix is the key prefix of the cluster in the etcd store.
+	EtcdPrefix string
+
+	// EtcdLeaseTTL is the TTL of the leader lease in the etcd store.
+	EtcdLeaseTTL time.Duration
+
 	// NodeRPC is the HTTP provider URL for op-node.
 	NodeRPC string
 
@@ -68,6 +84,16 @@ type Config struct {
 	// RPCEnableProxy is true if the sequencer RPC proxy should be enabled.
 	RPCEnableProxy bool
 
+	// DrainPeerRPCs maps the raft server IDs of the other servers in the cluster to their conductor RPC endpoints.
+	DrainPeerRPCs map[string]string
+
+	// DrainTimeout is the maximum time to wait for the handover target to catch up when draining.
+	DrainTimeout time.Duration
+
+	// DrainStateFile is the path of the file that persists the drained state across restarts.
+	// The drained state is not persisted if empty.
+	DrainStateFile string
+
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -85,8 +111,23 @@ func (c *Config) Check() error {
 	if c.RaftServerID == "" {
 		return fmt.Errorf("missing raft server ID")
 	}
-	if c.RaftStorageDir == "" {
-		return fmt.Errorf("missing raft storage directory")
+	switch c.ConsensusBackend {
+	case flags.RaftBackend:
+		if c.RaftStorageDir == "" {
+			return fmt.Errorf("missing raft storage directory")
+		}
+	case flags.EtcdBackend:
+		if len(c.EtcdEndpoints) == 0 {
+			return fmt.Errorf("missing etcd endpoints")
+		}
+		if c.EtcdPrefix == "" {
+			return fmt.Errorf("missing etcd prefix")
+		}
+		if c.EtcdLeaseTTL < time.Second {
+			return fmt.Errorf("etcd lease TTL must be at least 1s")
+		}
+	default:
+		return fmt.Errorf("unknown consensus backend: %q", c.ConsensusBackend)
 	}
 	if c.NodeRPC == "" {
 		return fmt.Errorf("missing node RPC")
@@ -123,18 +164,45 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 		return nil, errors.Wrap(err, "failed to load rollup config")
 	}
 
+	var engineJWTSecret [32]byte
+	if ctx.String(flags.HealthCheckEngineRPC.Name) != "" {
+		engineJWTSecret, err = readJWTSecret(ctx.String(flags.HealthCheckEngineJWTSecret.Name))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load engine jwt secret")
+		}
+	}
+
+	drainPeerRPCs := make(map[string]string)
+	for _, peer := range ctx.StringSlice(flags.DrainPeerRPCs.Name) {
+		id, url, ok := strings.Cut(peer, "=")
+		if !ok || id == "" || url == "" {
+			return nil, fmt.Errorf("invalid drain peer RPC %q, expected <raft server id>=<url>", peer)
+		}
+		drainPeerRPCs[id] = url
+	}
+
+	drainStateFile := ctx.String(flags.DrainStateFile.Name)
+	if drainStateFile == "" && ctx.String(flags.ConsensusBackend.Name) == string(flags.RaftBackend) {
+		drainStateFile = filepath.Join(ctx.String(flags.RaftStorageDir.Name), "drained")
+	}
+
 	return &Config{
 		ConsensusAddr: ctx.String(flags.ConsensusAddr.Name),
 		ConsensusPort: ctx.Int(flags.ConsensusPort.Name),
 		// The consensus server will advertise the address it binds to if this is empty/unspecified.
 		ConsensusAdvertisedAddr: ctx.String(flags.AdvertisedFullAddr.Name),
 
+		ConsensusBackend: flags.BackendType(ctx.String(flags.ConsensusBackend.Name)),
+
 		RaftBootstrap:         ctx.Bool(flags.RaftBootstrap.Name),
 		RaftServerID:          ctx.String(flags.RaftServerID.Name),
 		RaftStorageDir:        ctx.String(flags.RaftStorageDir.Name),
 		RaftSnapshotInterval:  ctx.Duration(flags.RaftSnapshotInterval.Name),
 		RaftSnapshotThreshold: ctx.Uint64(flags.RaftSnapshotThreshold.Name),
 		RaftTrailingLogs:      ctx.Uint64(flags.RaftTrailingLogs.Name),
+		EtcdEndpoints:         ctx.StringSlice(flags.EtcdEndpoints.Name),
+		EtcdPrefix:            ctx.String(flags.EtcdPrefix.Name),
+		EtcdLeaseTTL:          ctx.Duration(flags.EtcdLeaseTTL.Name),
 		NodeRPC:               ctx.String(flags.NodeRPC.Name),
 		ExecutionRPC:          ctx.String(flags.ExecutionRPC.Name),
 		Paused:                ctx.Bool(flags.Paused.Name),
@@ -144,9 +212,24 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 			SafeEnabled:    ctx.Bool(flags.HealthCheckSafeEnabled.Name),
 			SafeInterval:   ctx.Uint64(flags.HealthCheckSafeInterval.Name),
 			MinPeerCount:   ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
+
+			ELEnabled:        ctx.Bool(flags.HealthCheckELEnabled.Name),
+			ELMaxLatency:     ctx.Duration(flags.HealthCheckELMaxLatency.Name),
+			EngineRPC:        ctx.String(flags.HealthCheckEngineRPC.Name),
+			EngineJWTSecret:  engineJWTSecret,
+			EngineMaxLatency: ctx.Duration(flags.HealthCheckEngineMaxLatency.Name),
+			MaxL1OriginLag:   ctx.Uint64(flags.HealthCheckMaxL1OriginLag.Name),
+			MaxSafeLag:       ctx.Uint64(flags.HealthCheckMaxSafeLag.Name),
+			DiskPath:         ctx.String(flags.HealthCheckDiskPath.Name),
+			DiskMinFree:      ctx.Uint64(flags.HealthCheckDiskMinFreeMB.Name) << 20,
+			HTTPProbeURL:     ctx.String(flags.HealthCheckHTTPProbeURL.Name),
+			HTTPProbeTimeout: ctx.Duration(flags.HealthCheckHTTPProbeTimeout.Name),
 		},
 		RollupCfg:      *rollupCfg,
 		RPCEnableProxy: ctx.Bool(flags.RPCEnableProxy.Name),
+		DrainPeerRPCs:  drainPeerRPCs,
+		DrainTimeout:   ctx.Duration(flags.DrainTimeout.Name),
+		DrainStateFile: drainStateFile,
 		LogConfig:      oplog.ReadCLIConfig(ctx),
 		MetricsConfig:  opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:    oppprof.ReadCLIConfig(ctx),
@@ -170,6 +253,39 @@ type HealthCheckConfig struct {
 
 	// MinPeerCount is the minimum number of peers required for the sequencer to be healthy.
 	MinPeerCount uint64
+
+	// ELEnabled is whether to check that the execution client is not syncing.
+	ELEnabled bool
+
+	// ELMaxLatency is the maximum RPC latency of the execution client, 0 disables the latency check.
+	ELMaxLatency time.Duration
+
+	// EngineRPC is the authenticated engine API endpoint, the engine API check is disabled if empty.
+	EngineRPC string
+
+	// EngineJWTSecret is the JWT secret to authenticate with the engine API.
+	EngineJWTSecret [32]byte
+
+	// EngineMaxLatency is the maximum latency of the engine API.
+	EngineMaxLatency time.Duration
+
+	// MaxL1OriginLag is the maximum number of L1 blocks the L1 origin of the unsafe head may lag behind, 0 disables the check.
+	MaxL1OriginLag uint64
+
+	// MaxSafeLag is the maximum number of seconds the safe head may lag behind the unsafe head, 0 disables the check.
+	MaxSafeLag uint64
+
+	// DiskPath is the path to check for free disk space, the disk space check is disabled if empty.
+	DiskPath string
+
+	// DiskMinFree is the minimum free disk space in bytes.
+	DiskMinFree uint64
+
+	// HTTPProbeURL is the URL of a custom HTTP probe, the probe is disabled if empty.
+	HTTPProbeURL string
+
+	// HTTPProbeTimeout is the timeout of the custom HTTP probe.
+	HTTPProbeTimeout time.Duration
 }
 
 func (c *HealthCheckConfig) Check() error {
@@ -182,5 +298,30 @@ func (c *HealthCheckConfig) Check() error {
 	if c.MinPeerCount == 0 {
 		return fmt.Errorf("missing minimum peer count")
 	}
+	if c.EngineRPC != "" && c.EngineMaxLatency == 0 {
+		return fmt.Errorf("missing engine max latency")
+	}
+	if c.HTTPProbeURL != "" && c.HTTPProbeTimeout == 0 {
+		return fmt.Errorf("missing http probe timeout")
+	}
 	return nil
 }
+
+// readJWTSecret reads a 32 byte hex-encoded JWT secret from a file.
+func readJWTSecret(path string) ([32]byte, error) {
+	var secret [32]byte
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return secret, fmt.Errorf("file-name of jwt secret is empty")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return secret, err
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", path)
+	}
+	copy(secret[:], jwtSecret)
+	return secret, nil
+}
@@ -0,0 +1,286 @@
+package conductor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNoHandoverTarget = errors.New("no healthy follower to hand over leadership to")
+	ErrHandoverTimeout  = errors.New("timeout waiting for handover target to catch up")
+)
+
+// handoverPollInterval is the interval to poll the handover target while waiting for it to catch up.
+var handoverPollInterval = 500 * time.Millisecond
+
+// PeerStatusProvider provides the handover status of the other servers in the cluster.
+type PeerStatusProvider interface {
+	HandoverStatus(ctx context.Context, serverID string) (*conductorrpc.HandoverStatus, error)
+}
+
+// rpcPeers fetches the handover status of peers from their conductor RPC endpoints.
+type rpcPeers struct {
+	urls map[string]string
+}
+
+func (p *rpcPeers) HandoverStatus(ctx context.Context, serverID string) (*conductorrpc.HandoverStatus, error) {
+	url, ok := p.urls[serverID]
+	if !ok {
+		return nil, fmt.Errorf("no conductor RPC configured for server %s", serverID)
+	}
+	c, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to dial conductor RPC of server %s", serverID)
+	}
+	defer c.Close()
+	return conductorrpc.NewAPIClient(c).HandoverStatus(ctx)
+}
+
+func (oc *OpConductor) initPeers() {
+	if oc.peers != nil {
+		return
+	}
+	oc.peers = &rpcPeers{urls: oc.cfg.DrainPeerRPCs}
+}
+
+// Drain puts OpConductor into maintenance mode. If it is the leader, it gracefully hands over leadership first:
+//  1. pick the healthiest follower, i.e. the healthy, non-drained voter with the latest unsafe head.
+//  2. pause the control loop and stop the sequencer, so that the unsafe head in consensus no longer moves.
+//  3. wait until the follower has caught up with the latest unsafe payload in consensus.
+//  4. transfer leadership to the follower, so that it starts sequencing on top of the same unsafe head.
+//
+// If the handover fails, OpConductor stays out of maintenance mode and resumes sequencing as the leader.
+func (oc *OpConductor) Drain(ctx context.Context) error {
+	if !oc.cons.Leader() {
+		if err := oc.setDrained(true); err != nil {
+			return err
+		}
+		oc.queueAction()
+		oc.log.Info("OpConductor has been drained", "server", oc.cons.ServerID())
+		return nil
+	}
+
+	target, err := oc.handoverTarget(ctx)
+	if err != nil {
+		return err
+	}
+	oc.log.Info("draining OpConductor", "server", oc.cons.ServerID(), "target", target.ID)
+
+	// pause the control loop, so that it does not restart sequencing while the handover is in progress.
+	wasPaused := oc.Paused()
+	if !wasPaused {
+		if err := oc.Pause(ctx); err != nil {
+			return err
+		}
+	}
+	resume := func() error {
+		if wasPaused {
+			return nil
+		}
+		return oc.Resume(oc.shutdownCtx)
+	}
+
+	err = oc.handover(ctx, target)
+	if err != nil {
+		oc.log.Error("failed to hand over leadership, resuming as leader", "server", oc.cons.ServerID(), "target", target.ID, "err", err)
+		var result *multierror.Error
+		result = multierror.Append(result, err)
+		if e := resume(); e != nil {
+			result = multierror.Append(result, e)
+		}
+		return result.ErrorOrNil()
+	}
+
+	var result *multierror.Error
+	if err := oc.setDrained(true); err != nil {
+		result = multierror.Append(result, err)
+	}
+	oc.log.Info("OpConductor has been drained", "server", oc.cons.ServerID(), "leader", target.ID)
+	if err := resume(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	return result.ErrorOrNil()
+}
+
+func (oc *OpConductor) handover(ctx context.Context, target *consensus.ServerInfo) error {
+	if oc.seqActive.Load() {
+		if err := oc.stopSequencer(); err != nil {
+			return err
+		}
+	}
+
+	if err := oc.waitForHandoverTarget(ctx, target); err != nil {
+		return err
+	}
+
+	err := oc.cons.TransferLeaderTo(target.ID, target.Addr)
+	oc.metrics.RecordLeaderTransfer(err == nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to transfer leadership")
+	}
+	oc.leader.Store(false)
+	return nil
+}
+
+// handoverDrained hands over leadership of a drained leader, e.g. one that was elected while drained, to the
+// healthiest follower in the same way as Drain. The sequencer is stopped even if no follower is fit to take over,
+// and the handover is retried by the control loop.
+func (oc *OpConductor) handoverDrained() error {
+	target, err := oc.handoverTarget(oc.shutdownCtx)
+	if err != nil {
+		var result *multierror.Error
+		result = multierror.Append(result, err)
+		if oc.seqActive.Load() {
+			if e := oc.stopSequencer(); e != nil {
+				result = multierror.Append(result, e)
+			}
+		}
+		return result.ErrorOrNil()
+	}
+	oc.log.Info("handing over leadership of drained server", "server", oc.cons.ServerID(), "target", target.ID)
+	return oc.handover(oc.shutdownCtx, target)
+}
+
+// handoverTarget returns the healthiest follower to hand over leadership to.
+func (oc *OpConductor) handoverTarget(ctx context.Context) (*consensus.ServerInfo, error) {
+	membership, err := oc.cons.ClusterMembership()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster membership")
+	}
+
+	var target *consensus.ServerInfo
+	var targetHead eth.BlockID
+	for _, server := range membership.Servers {
+		if server.ID == oc.cons.ServerID() || server.Suffrage != consensus.Voter {
+			continue
+		}
+		status, err := oc.peers.HandoverStatus(ctx, server.ID)
+		if err != nil {
+			oc.log.Warn("failed to get handover status", "server", server.ID, "err", err)
+			continue
+		}
+		if !status.Healthy || status.Drained {
+			oc.log.Info("skipping handover candidate", "server", server.ID, "healthy", status.Healthy, "drained", status.Drained)
+			continue
+		}
+		if target == nil || status.UnsafeHead.Number > targetHead.Number {
+			server := server
+			target = &server
+			targetHead = status.UnsafeHead
+		}
+	}
+	if target == nil {
+		return nil, ErrNoHandoverTarget
+	}
+	return target, nil
+}
+
+// waitForHandoverTarget waits until the handover target has the latest unsafe payload in consensus as its unsafe head.
+func (oc *OpConductor) waitForHandoverTarget(ctx context.Context, target *consensus.ServerInfo) error {
+	ctx, cancel := context.WithTimeout(ctx, oc.cfg.DrainTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(handoverPollInterval)
+	defer ticker.Stop()
+	for {
+		unsafeInCons, err := oc.cons.LatestUnsafePayload()
+		if err != nil {
+			return errors.Wrap(err, "unable to retrieve unsafe head from consensus")
+		}
+		if unsafeInCons == nil {
+			return ErrNoUnsafeHead
+		}
+
+		status, err := oc.peers.HandoverStatus(ctx, target.ID)
+		if err != nil {
+			oc.log.Warn("failed to get handover status", "server", target.ID, "err", err)
+		} else if !status.Healthy {
+			return fmt.Errorf("handover target %s became unhealthy", target.ID)
+		} else if status.UnsafeHead.Hash == unsafeInCons.ExecutionPayload.BlockHash {
+			return nil
+		} else {
+			oc.log.Info("waiting for handover target to catch up",
+				"server", target.ID,
+				"target_num", status.UnsafeHead.Number,
+				"consensus_num", uint64(unsafeInCons.ExecutionPayload.BlockNumber))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ErrHandoverTimeout
+		case <-ticker.C:
+		}
+	}
+}
+
+// Undrain brings OpConductor out of maintenance mode, so that it can become leader and sequence again.
+func (oc *OpConductor) Undrain(_ context.Context) error {
+	if err := oc.setDrained(false); err != nil {
+		return err
+	}
+	oc.queueAction()
+	oc.log.Info("OpConductor has been undrained", "server", oc.cons.ServerID())
+	return nil
+}
+
+// initDrainState restores the drained state persisted in the drain state file, so that a drained conductor stays
+// drained across restarts until it is explicitly undrained.
+func (oc *OpConductor) initDrainState() error {
+	if oc.cfg.DrainStateFile == "" {
+		return nil
+	}
+	if _, err := os.Stat(oc.cfg.DrainStateFile); os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to read drain state file")
+	}
+	oc.drained.Store(true)
+	oc.log.Info("OpConductor is drained, undrain it to allow it to become leader", "server", oc.cons.ServerID())
+	return nil
+}
+
+// setDrained sets the drained state and persists it in the drain state file.
+// The drained state is set even if it fails to be persisted.
+func (oc *OpConductor) setDrained(drained bool) error {
+	oc.drained.Store(drained)
+	if oc.cfg.DrainStateFile == "" {
+		return nil
+	}
+	if drained {
+		if err := os.WriteFile(oc.cfg.DrainStateFile, nil, 0o644); err != nil {
+			return errors.Wrap(err, "failed to persist drained state")
+		}
+	} else if err := os.Remove(oc.cfg.DrainStateFile); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to persist undrained state")
+	}
+	return nil
+}
+
+// Drained returns true if OpConductor is in maintenance mode.
+func (oc *OpConductor) Drained() bool {
+	return oc.drained.Load()
+}
+
+// HandoverStatus returns whether this server is fit to take over sequencing.
+func (oc *OpConductor) HandoverStatus(ctx context.Context) (*conductorrpc.HandoverStatus, error) {
+	unsafeHead, err := oc.ctrl.LatestUnsafeBlock(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get latest unsafe block")
+	}
+	return &conductorrpc.HandoverStatus{
+		ServerID:   oc.cons.ServerID(),
+		Healthy:    oc.healthy.Load(),
+		Drained:    oc.Drained(),
+		UnsafeHead: eth.ToBlockID(unsafeHead),
+	}, nil
+}
@@ -0,0 +1,166 @@
+package conductor
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type stubPeers map[string]*conductorrpc.HandoverStatus
+
+func (p stubPeers) HandoverStatus(_ context.Context, serverID string) (*conductorrpc.HandoverStatus, error) {
+	status, ok := p[serverID]
+	if !ok {
+		return nil, errors.New("unreachable")
+	}
+	return status, nil
+}
+
+func (s *OpConductorTestSuite) mockCluster() {
+	s.cons.EXPECT().ClusterMembership().Return(&consensus.ClusterMembership{
+		Servers: []consensus.ServerInfo{
+			{ID: "SequencerA", Addr: "127.0.0.1:50050", Suffrage: consensus.Voter},
+			{ID: "SequencerB", Addr: "127.0.0.1:50051", Suffrage: consensus.Voter},
+			{ID: "SequencerC", Addr: "127.0.0.1:50052", Suffrage: consensus.Voter},
+			{ID: "SequencerD", Addr: "127.0.0.1:50053", Suffrage: consensus.Nonvoter},
+		},
+	}, nil)
+}
+
+// In this test, a follower is drained, it stays a follower and is put into maintenance mode.
+func (s *OpConductorTestSuite) TestDrainFollower() {
+	s.cons.EXPECT().Leader().Return(false)
+	s.disableSynchronization()
+
+	s.NoError(s.conductor.Drain(s.ctx))
+	s.True(s.conductor.Drained())
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+
+	s.NoError(s.conductor.Undrain(s.ctx))
+	s.False(s.conductor.Drained())
+}
+
+// In this test, a follower is drained and restarted, it stays drained until it is undrained.
+func (s *OpConductorTestSuite) TestDrainStatePersisted() {
+	cfg := s.cfg
+	cfg.DrainStateFile = filepath.Join(s.T().TempDir(), "drained")
+	s.conductor.cfg = &cfg
+	s.cons.EXPECT().Leader().Return(false)
+	s.disableSynchronization()
+
+	s.NoError(s.conductor.Drain(s.ctx))
+	s.FileExists(cfg.DrainStateFile)
+
+	restarted, err := NewOpConductor(s.ctx, &cfg, s.log, s.metrics, s.version, s.ctrl, s.cons, s.hmon)
+	s.NoError(err)
+	s.True(restarted.Drained())
+	s.hmon.EXPECT().Stop().Return(nil)
+	s.cons.EXPECT().Shutdown().Return(nil)
+	s.NoError(restarted.Stop(s.ctx))
+
+	s.NoError(s.conductor.Undrain(s.ctx))
+	s.False(s.conductor.Drained())
+	s.NoFileExists(cfg.DrainStateFile)
+}
+
+// In this test, the leader is drained. It stops sequencing and hands over leadership to the healthiest follower
+// once that follower has caught up with the latest unsafe head in consensus.
+func (s *OpConductorTestSuite) TestDrainLeader() {
+	s.conductor.leader.Store(true)
+	s.conductor.healthy.Store(true)
+	s.conductor.seqActive.Store(true)
+	s.cons.EXPECT().Leader().Return(true)
+	s.mockCluster()
+
+	unsafeHash := common.Hash{0xaa}
+	s.conductor.peers = stubPeers{
+		"SequencerB": {ServerID: "SequencerB", Healthy: true, UnsafeHead: eth.BlockID{Number: 9}},
+		"SequencerC": {ServerID: "SequencerC", Healthy: true, UnsafeHead: eth.BlockID{Hash: unsafeHash, Number: 10}},
+		"SequencerD": {ServerID: "SequencerD", Healthy: true, UnsafeHead: eth.BlockID{Hash: unsafeHash, Number: 10}},
+	}
+	s.cons.EXPECT().LatestUnsafePayload().Return(&eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{BlockHash: unsafeHash, BlockNumber: hexutil.Uint64(10)},
+	}, nil)
+	s.ctrl.EXPECT().StopSequencer(mock.Anything).Return(unsafeHash, nil).Times(1)
+	s.cons.EXPECT().TransferLeaderTo("SequencerC", "127.0.0.1:50052").Return(nil).Times(1)
+	s.ctrl.EXPECT().SequencerActive(mock.Anything).Return(false, nil)
+	s.disableSynchronization()
+
+	s.NoError(s.conductor.Drain(s.ctx))
+	s.True(s.conductor.Drained())
+	s.False(s.conductor.Paused())
+	s.False(s.conductor.leader.Load())
+	s.False(s.conductor.seqActive.Load())
+}
+
+// In this test, the leader is drained but no follower is fit to take over, so it keeps sequencing.
+func (s *OpConductorTestSuite) TestDrainLeaderNoTarget() {
+	s.conductor.leader.Store(true)
+	s.conductor.healthy.Store(true)
+	s.conductor.seqActive.Store(true)
+	s.cons.EXPECT().Leader().Return(true)
+	s.mockCluster()
+	s.conductor.peers = stubPeers{
+		"SequencerB": {ServerID: "SequencerB", Healthy: false},
+		"SequencerC": {ServerID: "SequencerC", Healthy: true, Drained: true},
+	}
+	s.disableSynchronization()
+
+	s.ErrorIs(s.conductor.Drain(s.ctx), ErrNoHandoverTarget)
+	s.False(s.conductor.Drained())
+	s.True(s.conductor.seqActive.Load())
+	s.ctrl.AssertNotCalled(s.T(), "StopSequencer", mock.Anything)
+}
+
+// In this test, a drained server is elected leader, it hands over leadership to the healthiest follower instead of
+// starting to sequence.
+// [follower, healthy, not sequencing] -- become leader --> [leader, healthy, not sequencing] -- transfer leadership --> [follower, healthy, not sequencing]
+func (s *OpConductorTestSuite) TestDrainedLeaderTransfersLeadership() {
+	s.conductor.drained.Store(true)
+	s.mockCluster()
+	unsafeHash := common.Hash{0xaa}
+	s.conductor.peers = stubPeers{
+		"SequencerB": {ServerID: "SequencerB", Healthy: false, UnsafeHead: eth.BlockID{Hash: unsafeHash, Number: 10}},
+		"SequencerC": {ServerID: "SequencerC", Healthy: true, UnsafeHead: eth.BlockID{Hash: unsafeHash, Number: 10}},
+	}
+	s.cons.EXPECT().LatestUnsafePayload().Return(&eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{BlockHash: unsafeHash, BlockNumber: hexutil.Uint64(10)},
+	}, nil)
+	s.cons.EXPECT().TransferLeaderTo("SequencerC", "127.0.0.1:50052").Return(nil).Times(1)
+	s.enableSynchronization()
+
+	s.updateLeaderStatusAndExecuteAction(true)
+
+	s.False(s.conductor.leader.Load())
+	s.False(s.conductor.seqActive.Load())
+	s.ctrl.AssertNotCalled(s.T(), "StartSequencer", mock.Anything, mock.Anything)
+	s.cons.AssertNotCalled(s.T(), "TransferLeader")
+}
+
+// In this test, a drained server is elected leader while no follower is fit to take over. It doesn't sequence and
+// keeps retrying the handover.
+func (s *OpConductorTestSuite) TestDrainedLeaderNoTarget() {
+	s.conductor.drained.Store(true)
+	s.mockCluster()
+	s.conductor.peers = stubPeers{
+		"SequencerB": {ServerID: "SequencerB", Healthy: false},
+		"SequencerC": {ServerID: "SequencerC", Healthy: true, Drained: true},
+	}
+	s.enableSynchronization()
+
+	s.updateLeaderStatusAndExecuteAction(true)
+
+	s.True(s.conductor.leader.Load())
+	s.False(s.conductor.seqActive.Load())
+	s.ctrl.AssertNotCalled(s.T(), "StartSequencer", mock.Anything, mock.Anything)
+	s.cons.AssertNotCalled(s.T(), "TransferLeader")
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+}
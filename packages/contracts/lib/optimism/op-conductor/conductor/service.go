@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/raft"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-conductor/client"
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/flags"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
 	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
@@ -84,6 +88,7 @@ func NewOpConductor(
 	oc.seqActive.Store(false)      // explicitly set to false by default, the real value will be reported after sequencer control initialization.
 	oc.paused.Store(cfg.Paused)
 	oc.stopped.Store(false)
+	oc.drained.Store(false)
 
 	// do not rely on the default context, use a dedicated context for shutdown.
 	oc.shutdownCtx, oc.shutdownCancel = context.WithCancel(context.Background())
@@ -110,9 +115,13 @@ func (c *OpConductor) init(ctx context.Context) error {
 	if err := c.initConsensus(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize consensus")
 	}
+	if err := c.initDrainState(); err != nil {
+		return errors.Wrap(err, "failed to initialize drain state")
+	}
 	if err := c.initHealthMonitor(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize health monitor")
 	}
+	c.initPeers()
 	if err := c.initRPCServer(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize rpc server")
 	}
@@ -169,22 +178,44 @@ func (c *OpConductor) initConsensus(ctx context.Context) error {
 		return nil
 	}
 
-	raftConsensusConfig := &consensus.RaftConsensusConfig{
-		ServerID: c.cfg.RaftServerID,
-		// AdvertisedAddr may be empty: the server will then default to what it binds to.
-		AdvertisedAddr:    raft.ServerAddress(c.cfg.ConsensusAdvertisedAddr),
-		ListenAddr:        c.cfg.ConsensusAddr,
-		ListenPort:        c.cfg.ConsensusPort,
-		StorageDir:        c.cfg.RaftStorageDir,
-		Bootstrap:         c.cfg.RaftBootstrap,
-		RollupCfg:         &c.cfg.RollupCfg,
-		SnapshotInterval:  c.cfg.RaftSnapshotInterval,
-		SnapshotThreshold: c.cfg.RaftSnapshotThreshold,
-		TrailingLogs:      c.cfg.RaftTrailingLogs,
-	}
-	cons, err := consensus.NewRaftConsensus(c.log, raftConsensusConfig)
-	if err != nil {
-		return errors.Wrap(err, "failed to create raft consensus")
+	var cons consensus.Consensus
+	var err error
+	switch c.cfg.ConsensusBackend {
+	case flags.EtcdBackend:
+		advertisedAddr := c.cfg.ConsensusAdvertisedAddr
+		if advertisedAddr == "" {
+			advertisedAddr = net.JoinHostPort(c.cfg.ConsensusAddr, strconv.Itoa(c.cfg.ConsensusPort))
+		}
+		etcdConsensusConfig := &consensus.EtcdConsensusConfig{
+			ServerID:       c.cfg.RaftServerID,
+			AdvertisedAddr: advertisedAddr,
+			Endpoints:      c.cfg.EtcdEndpoints,
+			Prefix:         c.cfg.EtcdPrefix,
+			LeaseTTL:       c.cfg.EtcdLeaseTTL,
+			Bootstrap:      c.cfg.RaftBootstrap,
+		}
+		cons, err = consensus.NewEtcdConsensus(c.log, etcdConsensusConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to create etcd consensus")
+		}
+	default:
+		raftConsensusConfig := &consensus.RaftConsensusConfig{
+			ServerID: c.cfg.RaftServerID,
+			// AdvertisedAddr may be empty: the server will then default to what it binds to.
+			AdvertisedAddr:    raft.ServerAddress(c.cfg.ConsensusAdvertisedAddr),
+			ListenAddr:        c.cfg.ConsensusAddr,
+			ListenPort:        c.cfg.ConsensusPort,
+			StorageDir:        c.cfg.RaftStorageDir,
+			Bootstrap:         c.cfg.RaftBootstrap,
+			RollupCfg:         &c.cfg.RollupCfg,
+			SnapshotInterval:  c.cfg.RaftSnapshotInterval,
+			SnapshotThreshold: c.cfg.RaftSnapshotThreshold,
+			TrailingLogs:      c.cfg.RaftTrailingLogs,
+		}
+		cons, err = consensus.NewRaftConsensus(c.log, raftConsensusConfig)
+		if err != nil {
+			return errors.Wrap(err, "failed to create raft consensus")
+		}
 	}
 	c.cons = cons
 	c.leaderUpdateCh = c.cons.LeaderCh()
@@ -208,6 +239,11 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 	}
 	p2p := opp2p.NewClient(pc)
 
+	checks, err := c.healthChecks(ctx, node)
+	if err != nil {
+		return err
+	}
+
 	c.hmon = health.NewSequencerHealthMonitor(
 		c.log,
 		c.metrics,
@@ -219,12 +255,49 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 		&c.cfg.RollupCfg,
 		node,
 		p2p,
+		checks...,
 	)
 	c.healthUpdateCh = c.hmon.Subscribe()
 
 	return nil
 }
 
+// healthChecks creates the configured health checks in addition to the built-in ones.
+func (c *OpConductor) healthChecks(ctx context.Context, node dial.RollupClientInterface) ([]health.Check, error) {
+	cfg := c.cfg.HealthCheck
+	// a check must not block the health monitor for longer than its interval.
+	timeout := time.Duration(cfg.Interval) * time.Second
+	var checks []health.Check
+	if cfg.ELEnabled {
+		el, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, c.log, c.cfg.ExecutionRPC)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create execution rpc client")
+		}
+		checks = append(checks, health.NewELSyncCheck(el, cfg.ELMaxLatency, timeout))
+	}
+	if cfg.EngineRPC != "" {
+		auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.EngineJWTSecret))
+		engine, err := opclient.NewRPC(ctx, c.log, cfg.EngineRPC, opclient.WithGethRPCOptions(auth))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create engine rpc client")
+		}
+		checks = append(checks, health.NewEngineCheck(engine, cfg.EngineMaxLatency))
+	}
+	if cfg.MaxL1OriginLag > 0 {
+		checks = append(checks, health.NewL1OriginLagCheck(node, cfg.MaxL1OriginLag, timeout))
+	}
+	if cfg.MaxSafeLag > 0 {
+		checks = append(checks, health.NewSafeLagCheck(node, cfg.MaxSafeLag, timeout))
+	}
+	if cfg.DiskPath != "" {
+		checks = append(checks, health.NewDiskSpaceCheck(cfg.DiskPath, cfg.DiskMinFree))
+	}
+	if cfg.HTTPProbeURL != "" {
+		checks = append(checks, health.NewHTTPProbeCheck(cfg.HTTPProbeURL, cfg.HTTPProbeTimeout))
+	}
+	return checks, nil
+}
+
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
@@ -285,15 +358,18 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 //  1. running: it is running normally, which executes control loop and participates in leader election.
 //  2. paused: control loop (sequencer start/stop) is paused, but it still participates in leader election, and receives health updates.
 //  3. stopped: it is stopped, which means it is not participating in leader election and control loop. OpConductor cannot be started again from stopped mode.
+//
+// Independently, a running OpConductor can be drained into maintenance mode, in which it does not sequence and gives up leadership whenever it is elected.
 type OpConductor struct {
 	log     log.Logger
 	version string
 	cfg     *Config
 	metrics metrics.Metricer
 
-	ctrl client.SequencerControl
-	cons consensus.Consensus
-	hmon health.HealthMonitor
+	ctrl  client.SequencerControl
+	cons  consensus.Consensus
+	hmon  health.HealthMonitor
+	peers PeerStatusProvider
 
 	leader         atomic.Bool
 	leaderOverride atomic.Bool
@@ -314,6 +390,7 @@ type OpConductor struct {
 	actionCh       chan struct{}
 	paused         atomic.Bool
 	stopped        atomic.Bool
+	drained        atomic.Bool
 	shutdownCtx    context.Context
 	shutdownCancel context.CancelFunc
 
@@ -548,6 +625,11 @@ func (oc *OpConductor) SequencerHealthy(_ context.Context) bool {
 	return oc.healthy.Load()
 }
 
+// SequencerHealthDetails returns the per-check results of the latest sequencer health check.
+func (oc *OpConductor) SequencerHealthDetails(_ context.Context) []health.CheckResult {
+	return oc.hmon.Results()
+}
+
 // ClusterMembership returns current cluster's membership information.
 func (oc *OpConductor) ClusterMembership(_ context.Context) (*consensus.ClusterMembership, error) {
 	return oc.cons.ClusterMembership()
@@ -640,8 +722,11 @@ func (oc *OpConductor) action() {
 	status := NewState(oc.leader.Load(), oc.healthy.Load(), oc.seqActive.Load())
 	oc.log.Debug("entering action with status", "status", status)
 
-	// exhaust all cases below for completeness, 3 state, 8 cases.
+	// maintenance mode takes precedence, then exhaust all cases below for completeness, 3 state, 8 cases.
 	switch {
+	case status.leader && oc.Drained():
+		// a drained server must not sequence, stop sequencing and hand over leadership to the healthiest follower.
+		err = oc.handoverDrained()
 	case !status.leader && !status.healthy && !status.active:
 		// if follower is not healthy and not sequencing, just log an error
 		oc.log.Error("server (follower) is not healthy", "server", oc.cons.ServerID())
@@ -17,6 +17,7 @@ import (
 
 	clientmocks "github.com/ethereum-optimism/optimism/op-conductor/client/mocks"
 	consensusmocks "github.com/ethereum-optimism/optimism/op-conductor/consensus/mocks"
+	"github.com/ethereum-optimism/optimism/op-conductor/flags"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	healthmocks "github.com/ethereum-optimism/optimism/op-conductor/health/mocks"
 	"github.com/ethereum-optimism/optimism/op-conductor/metrics"
@@ -29,14 +30,15 @@ import (
 func mockConfig(t *testing.T) Config {
 	now := uint64(time.Now().Unix())
 	return Config{
-		ConsensusAddr:  "127.0.0.1",
-		ConsensusPort:  0,
-		RaftServerID:   "SequencerA",
-		RaftStorageDir: "/tmp/raft",
-		RaftBootstrap:  false,
-		NodeRPC:        "http://node:8545",
-		ExecutionRPC:   "http://geth:8545",
-		Paused:         false,
+		ConsensusAddr:    "127.0.0.1",
+		ConsensusPort:    0,
+		ConsensusBackend: flags.RaftBackend,
+		RaftServerID:     "SequencerA",
+		RaftStorageDir:   "/tmp/raft",
+		RaftBootstrap:    false,
+		NodeRPC:          "http://node:8545",
+		ExecutionRPC:     "http://geth:8545",
+		Paused:           false,
 		HealthCheck: HealthCheckConfig{
 			Interval:       1,
 			UnsafeInterval: 3,
@@ -75,6 +77,7 @@ func mockConfig(t *testing.T) Config {
 			ProtocolVersionsAddress: [20]byte{4, 5},
 		},
 		RPCEnableProxy: false,
+		DrainTimeout:   time.Second,
 	}
 }
 
@@ -0,0 +1,599 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var _ Consensus = (*EtcdConsensus)(nil)
+
+var (
+	ErrNotLeader         = errors.New("not the leader")
+	ErrMembershipChanged = errors.New("cluster membership version mismatch")
+)
+
+// EtcdConsensus implements Consensus using lease based leader election in an external etcd v3 compatible store.
+//
+// The cluster state is stored under a key prefix:
+//   - <prefix>/election/leader holds the ID of the leader, attached to the leader's lease.
+//     It is removed by the store once the leader stops renewing its lease, after which any voter may take it.
+//   - <prefix>/election/transfer holds the ID of the target of an ongoing leadership transfer,
+//     while it exists only the target may become leader.
+//   - <prefix>/members holds the cluster membership, its version is the store revision it was last modified at.
+//   - <prefix>/unsafe holds the latest unsafe payload, it can only be written by the current leader.
+type EtcdConsensus struct {
+	log      log.Logger
+	serverID string
+	addr     string
+
+	client   *clientv3.Client
+	leaseTTL int64
+
+	electionPrefix string
+	leaderKey      string
+	transferKey    string
+	membersKey     string
+	unsafeKey      string
+
+	// lease is the lease of this server in the store, 0 if it currently has none.
+	lease     atomic.Int64
+	leaderMtx sync.Mutex
+	leader    atomic.Bool
+	leaderCh  chan bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type EtcdConsensusConfig struct {
+	ServerID string
+
+	// AdvertisedAddr is the address to advertise in the cluster membership.
+	// Servers do not contact each other directly, so it is informational only.
+	AdvertisedAddr string
+
+	// Endpoints are the endpoints of the etcd v3 compatible store.
+	Endpoints []string
+	// Prefix is the key prefix of the cluster in the store.
+	Prefix string
+	// LeaseTTL is the TTL of the leader lease, i.e. how long it takes
+	// before a new leader is elected after the leader stopped responding.
+	LeaseTTL time.Duration
+
+	Bootstrap bool
+}
+
+// NewEtcdConsensus creates a new EtcdConsensus instance.
+func NewEtcdConsensus(log log.Logger, cfg *EtcdConsensusConfig) (*EtcdConsensus, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		DialTimeout: defaultTimeout,
+		Logger:      zap.NewNop(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create etcd client")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	electionPrefix := cfg.Prefix + "/election/"
+	ec := &EtcdConsensus{
+		log:            log,
+		serverID:       cfg.ServerID,
+		addr:           cfg.AdvertisedAddr,
+		client:         client,
+		leaseTTL:       max(int64(math.Ceil(cfg.LeaseTTL.Seconds())), 1),
+		electionPrefix: electionPrefix,
+		leaderKey:      electionPrefix + "leader",
+		transferKey:    electionPrefix + "transfer",
+		membersKey:     cfg.Prefix + "/members",
+		unsafeKey:      cfg.Prefix + "/unsafe",
+		leaderCh:       make(chan bool, 1),
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+
+	// If bootstrap = true, create the cluster with self as the only voter, unless the cluster already exists.
+	if cfg.Bootstrap {
+		if err := ec.bootstrap(); err != nil {
+			cancel()
+			client.Close()
+			return nil, errors.Wrap(err, "failed to bootstrap etcd cluster")
+		}
+	}
+
+	ec.wg.Add(1)
+	go ec.run()
+
+	return ec, nil
+}
+
+func (ec *EtcdConsensus) bootstrap() error {
+	servers, err := json.Marshal([]ServerInfo{{ID: ec.serverID, Addr: ec.addr, Suffrage: Voter}})
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+	resp, err := ec.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(ec.membersKey), "=", 0)).
+		Then(clientv3.OpPut(ec.membersKey, string(servers))).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if resp.Succeeded {
+		ec.log.Info("Bootstrapped etcd consensus cluster with self", "addr", ec.addr)
+	} else {
+		ec.log.Warn("Etcd consensus cluster already exists, skipping bootstrap")
+	}
+	return nil
+}
+
+// run maintains the lease of this server and tries to become leader whenever there is none.
+func (ec *EtcdConsensus) run() {
+	defer ec.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(ec.leaseTTL) * time.Second / 3)
+	defer ticker.Stop()
+
+	electionCh := ec.client.Watch(ec.ctx, ec.electionPrefix, clientv3.WithPrefix())
+	membersCh := ec.client.Watch(ec.ctx, ec.membersKey)
+	var keepAliveCh <-chan *clientv3.LeaseKeepAliveResponse
+
+	for {
+		if ec.lease.Load() == 0 {
+			ch, err := ec.grantLease()
+			if err != nil {
+				ec.log.Warn("failed to grant etcd lease", "err", err)
+			}
+			keepAliveCh = ch
+		}
+		if err := ec.step(); err != nil {
+			ec.log.Warn("failed to update etcd leadership", "err", err)
+		}
+
+		select {
+		case <-ec.ctx.Done():
+			return
+		case <-ticker.C:
+		case _, ok := <-electionCh:
+			if !ok {
+				electionCh = ec.client.Watch(ec.ctx, ec.electionPrefix, clientv3.WithPrefix())
+			}
+		case _, ok := <-membersCh:
+			if !ok {
+				membersCh = ec.client.Watch(ec.ctx, ec.membersKey)
+			}
+		case _, ok := <-keepAliveCh:
+			if !ok {
+				ec.log.Warn("etcd lease expired", "lease", ec.lease.Load())
+				ec.lease.Store(0)
+				ec.setLeader(false)
+				keepAliveCh = nil
+			}
+		}
+	}
+}
+
+func (ec *EtcdConsensus) grantLease() (<-chan *clientv3.LeaseKeepAliveResponse, error) {
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+	lease, err := ec.client.Grant(ctx, ec.leaseTTL)
+	if err != nil {
+		return nil, err
+	}
+	ch, err := ec.client.KeepAlive(ec.ctx, lease.ID)
+	if err != nil {
+		return nil, err
+	}
+	ec.lease.Store(int64(lease.ID))
+	return ch, nil
+}
+
+// step updates the leadership status of this server from the store,
+// and takes leadership if there is no leader and this server is eligible.
+func (ec *EtcdConsensus) step() error {
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+
+	resp, err := ec.client.Txn(ctx).
+		Then(clientv3.OpGet(ec.leaderKey), clientv3.OpGet(ec.transferKey), clientv3.OpGet(ec.membersKey)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	leaderKV := firstKV(resp, 0)
+	transferKV := firstKV(resp, 1)
+	membership, err := decodeMembership(firstKV(resp, 2))
+	if err != nil {
+		return err
+	}
+	voter := false
+	for _, server := range membership.Servers {
+		if server.ID == ec.serverID {
+			voter = server.Suffrage == Voter
+		}
+	}
+	lease := clientv3.LeaseID(ec.lease.Load())
+
+	if leaderKV != nil {
+		leader := lease != 0 && clientv3.LeaseID(leaderKV.Lease) == lease
+		if leader && !voter {
+			ec.log.Info("no longer a voter, stepping down as leader")
+			if err := ec.resign(ctx, lease); err != nil {
+				return err
+			}
+			leader = false
+		}
+		ec.setLeader(leader)
+		return nil
+	}
+
+	ec.setLeader(false)
+	if !voter || lease == 0 {
+		return nil
+	}
+	cmps := []clientv3.Cmp{clientv3.Compare(clientv3.CreateRevision(ec.leaderKey), "=", 0)}
+	if transferKV != nil {
+		// wait for the target of the leadership transfer to take over, or for the transfer to expire.
+		if string(transferKV.Value) != ec.serverID {
+			return nil
+		}
+		cmps = append(cmps, clientv3.Compare(clientv3.Value(ec.transferKey), "=", ec.serverID))
+	} else {
+		cmps = append(cmps, clientv3.Compare(clientv3.CreateRevision(ec.transferKey), "=", 0))
+	}
+	resp, err = ec.client.Txn(ctx).
+		If(cmps...).
+		Then(clientv3.OpPut(ec.leaderKey, ec.serverID, clientv3.WithLease(lease)), clientv3.OpDelete(ec.transferKey)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if resp.Succeeded {
+		ec.log.Info("elected as etcd consensus leader", "lease", lease)
+		ec.setLeader(true)
+	}
+	return nil
+}
+
+func (ec *EtcdConsensus) resign(ctx context.Context, lease clientv3.LeaseID) error {
+	_, err := ec.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.LeaseValue(ec.leaderKey), "=", lease)).
+		Then(clientv3.OpDelete(ec.leaderKey)).
+		Commit()
+	return err
+}
+
+func (ec *EtcdConsensus) setLeader(leader bool) {
+	ec.leaderMtx.Lock()
+	defer ec.leaderMtx.Unlock()
+	if ec.leader.Swap(leader) == leader {
+		return
+	}
+	// like raft, only the latest leadership status is kept if the channel is not consumed.
+	select {
+	case <-ec.leaderCh:
+	default:
+	}
+	ec.leaderCh <- leader
+}
+
+// Addr implements Consensus, it returns the advertised address of this server.
+func (ec *EtcdConsensus) Addr() string {
+	return ec.addr
+}
+
+// AddVoter implements Consensus, it tries to add a voting member into the cluster.
+func (ec *EtcdConsensus) AddVoter(id, addr string, version uint64) error {
+	return ec.updateMembership(version, func(servers []ServerInfo) []ServerInfo {
+		for i := range servers {
+			if servers[i].ID == id {
+				servers[i].Addr = addr
+				servers[i].Suffrage = Voter
+				return servers
+			}
+		}
+		return append(servers, ServerInfo{ID: id, Addr: addr, Suffrage: Voter})
+	})
+}
+
+// AddNonVoter implements Consensus, it tries to add a non-voting member into the cluster.
+func (ec *EtcdConsensus) AddNonVoter(id, addr string, version uint64) error {
+	return ec.updateMembership(version, func(servers []ServerInfo) []ServerInfo {
+		for i := range servers {
+			if servers[i].ID == id {
+				servers[i].Addr = addr
+				return servers
+			}
+		}
+		return append(servers, ServerInfo{ID: id, Addr: addr, Suffrage: Nonvoter})
+	})
+}
+
+// DemoteVoter implements Consensus, it tries to demote a voting member into a non-voting member in the cluster.
+func (ec *EtcdConsensus) DemoteVoter(id string, version uint64) error {
+	return ec.updateMembership(version, func(servers []ServerInfo) []ServerInfo {
+		for i := range servers {
+			if servers[i].ID == id {
+				servers[i].Suffrage = Nonvoter
+			}
+		}
+		return servers
+	})
+}
+
+// RemoveServer implements Consensus, it tries to remove a member (both voter or non-voter) from the cluster, if leader is being removed, it will cause a new leader election.
+func (ec *EtcdConsensus) RemoveServer(id string, version uint64) error {
+	return ec.updateMembership(version, func(servers []ServerInfo) []ServerInfo {
+		var remaining []ServerInfo
+		for _, server := range servers {
+			if server.ID != id {
+				remaining = append(remaining, server)
+			}
+		}
+		return remaining
+	})
+}
+
+// updateMembership applies the update to the cluster membership, only the leader can update the cluster membership.
+func (ec *EtcdConsensus) updateMembership(version uint64, update func([]ServerInfo) []ServerInfo) error {
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+
+	membership, err := ec.ClusterMembership()
+	if err != nil {
+		return err
+	}
+	if version != 0 && version != membership.Version {
+		return ErrMembershipChanged
+	}
+	servers, err := json.Marshal(update(membership.Servers))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal cluster membership")
+	}
+
+	// a missing leader key compares equal to lease 0, so a server without lease must not attempt any update.
+	lease := clientv3.LeaseID(ec.lease.Load())
+	if lease == 0 {
+		return ErrNotLeader
+	}
+	txn, err := ec.client.Txn(ctx).
+		If(
+			clientv3.Compare(clientv3.LeaseValue(ec.leaderKey), "=", lease),
+			clientv3.Compare(clientv3.ModRevision(ec.membersKey), "=", int64(membership.Version)),
+		).
+		Then(clientv3.OpPut(ec.membersKey, string(servers))).
+		Else(clientv3.OpGet(ec.leaderKey)).
+		Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to update cluster membership")
+	}
+	if !txn.Succeeded {
+		if leaderKV := firstKV(txn, 0); leaderKV == nil || clientv3.LeaseID(leaderKV.Lease) != lease {
+			return ErrNotLeader
+		}
+		return ErrMembershipChanged
+	}
+	return nil
+}
+
+// Leader implements Consensus, it returns true if it is the leader of the cluster.
+func (ec *EtcdConsensus) Leader() bool {
+	return ec.leader.Load()
+}
+
+// LeaderWithID implements Consensus, it returns the leader's server ID and address.
+func (ec *EtcdConsensus) LeaderWithID() *ServerInfo {
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+
+	resp, err := ec.client.Txn(ctx).Then(clientv3.OpGet(ec.leaderKey), clientv3.OpGet(ec.membersKey)).Commit()
+	if err != nil {
+		ec.log.Error("failed to get leader", "err", err)
+		return &ServerInfo{}
+	}
+	leaderKV := firstKV(resp, 0)
+	if leaderKV == nil {
+		return &ServerInfo{}
+	}
+	leader := &ServerInfo{
+		ID:       string(leaderKV.Value),
+		Suffrage: Voter, // leader will always be Voter
+	}
+	membership, err := decodeMembership(firstKV(resp, 1))
+	if err != nil {
+		ec.log.Error("failed to get leader address", "err", err)
+		return leader
+	}
+	for _, server := range membership.Servers {
+		if server.ID == leader.ID {
+			leader.Addr = server.Addr
+		}
+	}
+	return leader
+}
+
+// LeaderCh implements Consensus, it returns a channel that will be notified when leadership status changes (true = leader, false = follower).
+func (ec *EtcdConsensus) LeaderCh() <-chan bool {
+	return ec.leaderCh
+}
+
+// ServerID implements Consensus, it returns the server ID of the current server.
+func (ec *EtcdConsensus) ServerID() string {
+	return ec.serverID
+}
+
+// TransferLeader implements Consensus, it triggers leadership transfer to another voter in the cluster.
+func (ec *EtcdConsensus) TransferLeader() error {
+	if !ec.Leader() {
+		return nil
+	}
+	membership, err := ec.ClusterMembership()
+	if err != nil {
+		return err
+	}
+	for _, server := range membership.Servers {
+		if server.ID != ec.serverID && server.Suffrage == Voter {
+			return ec.TransferLeaderTo(server.ID, server.Addr)
+		}
+	}
+	return errors.New("no other voter to transfer leadership to")
+}
+
+// TransferLeaderTo implements Consensus, it triggers leadership transfer to a specific member in the cluster.
+// If the target does not take over within the lease TTL, any voter may become leader again.
+func (ec *EtcdConsensus) TransferLeaderTo(id string, addr string) error {
+	lease := clientv3.LeaseID(ec.lease.Load())
+	if lease == 0 {
+		return ErrNotLeader
+	}
+
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+
+	transferLease, err := ec.client.Grant(ctx, ec.leaseTTL)
+	if err != nil {
+		return errors.Wrap(err, "failed to grant transfer lease")
+	}
+	resp, err := ec.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.LeaseValue(ec.leaderKey), "=", lease)).
+		Then(clientv3.OpPut(ec.transferKey, id, clientv3.WithLease(transferLease.ID)), clientv3.OpDelete(ec.leaderKey)).
+		Commit()
+	if err != nil {
+		ec.log.Error("failed to transfer leadership to server", "id", id, "addr", addr, "err", err)
+		return err
+	}
+	if !resp.Succeeded {
+		_, _ = ec.client.Revoke(ctx, transferLease.ID)
+		return ErrNotLeader
+	}
+	ec.setLeader(false)
+	return nil
+}
+
+// Shutdown implements Consensus, it gives up leadership and closes the etcd client.
+func (ec *EtcdConsensus) Shutdown() error {
+	ec.cancel()
+	ec.wg.Wait()
+
+	var result error
+	if lease := clientv3.LeaseID(ec.lease.Swap(0)); lease != 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancel()
+		// revoking the lease removes the leader key right away, so that another voter can take over.
+		if _, err := ec.client.Revoke(ctx, lease); err != nil {
+			ec.log.Error("failed to revoke etcd lease", "err", err)
+			result = err
+		}
+	}
+	ec.setLeader(false)
+	if err := ec.client.Close(); err != nil {
+		ec.log.Error("failed to close etcd client", "err", err)
+		result = err
+	}
+	return result
+}
+
+// CommitUnsafePayload implements Consensus, it commits latest unsafe payload to the store, if this server is still the leader.
+func (ec *EtcdConsensus) CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error {
+	ec.log.Debug("committing unsafe payload", "number", uint64(payload.ExecutionPayload.BlockNumber), "hash", payload.ExecutionPayload.BlockHash.Hex())
+
+	var buf bytes.Buffer
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return errors.Wrap(err, "failed to marshal payload envelope")
+	}
+
+	lease := clientv3.LeaseID(ec.lease.Load())
+	if lease == 0 {
+		return ErrNotLeader
+	}
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+	resp, err := ec.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.LeaseValue(ec.leaderKey), "=", lease)).
+		Then(clientv3.OpPut(ec.unsafeKey, buf.String())).
+		Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to put payload envelope")
+	}
+	if !resp.Succeeded {
+		return ErrNotLeader
+	}
+	ec.log.Debug("unsafe payload committed", "number", uint64(payload.ExecutionPayload.BlockNumber), "hash", payload.ExecutionPayload.BlockHash.Hex())
+
+	return nil
+}
+
+// LatestUnsafePayload implements Consensus, it returns the latest unsafe payload from the store in a strongly consistent fashion.
+func (ec *EtcdConsensus) LatestUnsafePayload() (*eth.ExecutionPayloadEnvelope, error) {
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+
+	resp, err := ec.client.Get(ctx, ec.unsafeKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get payload envelope")
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	data := resp.Kvs[0].Value
+	payload := &eth.ExecutionPayloadEnvelope{}
+	if err := payload.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal payload envelope")
+	}
+	return payload, nil
+}
+
+// ClusterMembership implements Consensus, it returns the current cluster membership configuration.
+func (ec *EtcdConsensus) ClusterMembership() (*ClusterMembership, error) {
+	ctx, cancel := context.WithTimeout(ec.ctx, defaultTimeout)
+	defer cancel()
+
+	resp, err := ec.client.Get(ctx, ec.membersKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster membership")
+	}
+	if len(resp.Kvs) == 0 {
+		return decodeMembership(nil)
+	}
+	return decodeMembership(resp.Kvs[0])
+}
+
+func decodeMembership(kv *mvccpb.KeyValue) (*ClusterMembership, error) {
+	if kv == nil {
+		return &ClusterMembership{}, nil
+	}
+	var servers []ServerInfo
+	if err := json.Unmarshal(kv.Value, &servers); err != nil {
+		return nil, fmt.Errorf("failed to decode cluster membership: %w", err)
+	}
+	return &ClusterMembership{
+		Servers: servers,
+		Version: uint64(kv.ModRevision),
+	}, nil
+}
+
+// firstKV returns the first key-value of the i-th range response of the transaction, or nil if there is none.
+func firstKV(resp *clientv3.TxnResponse, i int) *mvccpb.KeyValue {
+	kvs := resp.Responses[i].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		return nil
+	}
+	return kvs[0]
+}
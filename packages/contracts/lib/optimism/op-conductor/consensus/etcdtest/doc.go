@@ -0,0 +1,3 @@
+// Package etcdtest tests the etcd consensus backend against an embedded etcd server.
+// It is a separate module so that the etcd server and its dependencies are not dependencies of op-conductor.
+package etcdtest
@@ -0,0 +1,139 @@
+package etcdtest
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/etcd/server/v3/embed"
+
+	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func freeURL(t *testing.T) url.URL {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return url.URL{Scheme: "http", Host: l.Addr().String()}
+}
+
+// startEtcd starts an embedded single node etcd server and returns its client endpoint.
+func startEtcd(t *testing.T) string {
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	clientURL, peerURL := freeURL(t), freeURL(t)
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = []url.URL{clientURL}, []url.URL{clientURL}
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = []url.URL{peerURL}, []url.URL{peerURL}
+	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
+
+	e, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	t.Cleanup(e.Close)
+	select {
+	case <-e.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("etcd server took too long to start")
+	}
+	return clientURL.String()
+}
+
+func newEtcdConsensus(t *testing.T, endpoint string, id string, bootstrap bool) *consensus.EtcdConsensus {
+	cons, err := consensus.NewEtcdConsensus(testlog.Logger(t, log.LevelInfo), &consensus.EtcdConsensusConfig{
+		ServerID:       id,
+		AdvertisedAddr: fmt.Sprintf("%s:50050", id),
+		Endpoints:      []string{endpoint},
+		Prefix:         "/op-conductor",
+		LeaseTTL:       2 * time.Second,
+		Bootstrap:      bootstrap,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cons.Shutdown() })
+	return cons
+}
+
+func waitForLeadership(t *testing.T, cons *consensus.EtcdConsensus, leader bool) {
+	select {
+	case l := <-cons.LeaderCh():
+		require.Equal(t, leader, l)
+	case <-time.After(10 * time.Second):
+		t.Fatalf("%s did not receive leadership update", cons.ServerID())
+	}
+}
+
+func TestEtcdConsensus(t *testing.T) {
+	endpoint := startEtcd(t)
+
+	a := newEtcdConsensus(t, endpoint, "SequencerA", true)
+	waitForLeadership(t, a, true)
+	require.Equal(t, &consensus.ServerInfo{ID: "SequencerA", Addr: "SequencerA:50050", Suffrage: consensus.Voter}, a.LeaderWithID())
+
+	payload, err := a.LatestUnsafePayload()
+	require.NoError(t, err)
+	require.Nil(t, payload)
+
+	one := hexutil.Uint64(1)
+	hash := common.HexToHash("0x12345")
+	payload = &eth.ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: &hash,
+		ExecutionPayload: &eth.ExecutionPayload{
+			BlockNumber:   2,
+			BlockHash:     hash,
+			Timestamp:     hexutil.Uint64(time.Now().Unix()),
+			Transactions:  []eth.Data{},
+			ExtraData:     []byte{},
+			Withdrawals:   &types.Withdrawals{},
+			ExcessBlobGas: &one,
+			BlobGasUsed:   &one,
+		},
+	}
+	require.NoError(t, a.CommitUnsafePayload(payload))
+
+	// a second server only joins the cluster once it is added by the leader.
+	b := newEtcdConsensus(t, endpoint, "SequencerB", false)
+	require.False(t, b.Leader())
+	require.ErrorIs(t, b.AddVoter("SequencerB", "SequencerB:50050", 0), consensus.ErrNotLeader)
+
+	membership, err := a.ClusterMembership()
+	require.NoError(t, err)
+	require.ErrorIs(t, a.AddVoter("SequencerB", "SequencerB:50050", membership.Version+1), consensus.ErrMembershipChanged)
+	require.NoError(t, a.AddVoter("SequencerB", "SequencerB:50050", membership.Version))
+	membership, err = b.ClusterMembership()
+	require.NoError(t, err)
+	require.Equal(t, []consensus.ServerInfo{
+		{ID: "SequencerA", Addr: "SequencerA:50050", Suffrage: consensus.Voter},
+		{ID: "SequencerB", Addr: "SequencerB:50050", Suffrage: consensus.Voter},
+	}, membership.Servers)
+
+	// followers read the payload committed by the leader, but cannot commit themselves.
+	latest, err := b.LatestUnsafePayload()
+	require.NoError(t, err)
+	require.Equal(t, payload.ExecutionPayload.BlockHash, latest.ExecutionPayload.BlockHash)
+	require.ErrorIs(t, b.CommitUnsafePayload(payload), consensus.ErrNotLeader)
+
+	// leadership is handed over to the transfer target.
+	require.NoError(t, a.TransferLeaderTo("SequencerB", "SequencerB:50050"))
+	waitForLeadership(t, a, false)
+	waitForLeadership(t, b, true)
+	require.Equal(t, "SequencerB", a.LeaderWithID().ID)
+	require.ErrorIs(t, a.CommitUnsafePayload(payload), consensus.ErrNotLeader)
+
+	// demoting the leader makes it step down, and the remaining voter takes over.
+	require.NoError(t, b.DemoteVoter("SequencerB", 0))
+	waitForLeadership(t, b, false)
+	waitForLeadership(t, a, true)
+
+	// shutting down the leader releases its lease right away.
+	require.NoError(t, a.AddVoter("SequencerB", "SequencerB:50050", 0))
+	require.NoError(t, a.Shutdown())
+	waitForLeadership(t, b, true)
+}
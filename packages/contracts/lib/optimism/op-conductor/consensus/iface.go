@@ -38,25 +38,11 @@ type ServerInfo struct {
 	Suffrage ServerSuffrage `json:"suffrage"`
 }
 
-// Consensus defines the consensus interface for leadership election.
-//
-//go:generate mockery --name Consensus --output mocks/ --with-expecter=true
-type Consensus interface {
+// Leadership defines the leader election part of the consensus interface.
+type Leadership interface {
 	// Addr returns the address of this consensus server.
 	// Internally the server may override what is advertised, or fall back to the address it listens to.
 	Addr() string
-	// AddVoter adds a voting member into the cluster, voter is eligible to become leader.
-	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
-	AddVoter(id, addr string, version uint64) error
-	// AddNonVoter adds a non-voting member into the cluster, non-voter is not eligible to become leader.
-	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
-	AddNonVoter(id, addr string, version uint64) error
-	// DemoteVoter demotes a voting member into a non-voting member, if leader is being demoted, it will cause a new leader election.
-	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
-	DemoteVoter(id string, version uint64) error
-	// RemoveServer removes a member (both voter or non-voter) from the cluster, if leader is being removed, it will cause a new leader election.
-	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
-	RemoveServer(id string, version uint64) error
 	// LeaderCh returns a channel that will be notified when leadership status changes (true = leader, false = follower)
 	LeaderCh() <-chan bool
 	// Leader returns if it is the leader of the cluster.
@@ -69,13 +55,42 @@ type Consensus interface {
 	TransferLeader() error
 	// TransferLeaderTo triggers leadership transfer to a specific member in the cluster.
 	TransferLeaderTo(id, addr string) error
+}
+
+// Membership defines the cluster membership part of the consensus interface.
+type Membership interface {
+	// AddVoter adds a voting member into the cluster, voter is eligible to become leader.
+	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
+	AddVoter(id, addr string, version uint64) error
+	// AddNonVoter adds a non-voting member into the cluster, non-voter is not eligible to become leader.
+	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
+	AddNonVoter(id, addr string, version uint64) error
+	// DemoteVoter demotes a voting member into a non-voting member, if leader is being demoted, it will cause a new leader election.
+	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
+	DemoteVoter(id string, version uint64) error
+	// RemoveServer removes a member (both voter or non-voter) from the cluster, if leader is being removed, it will cause a new leader election.
+	// If version is non-zero, this will only be applied if the current cluster version matches the expected version.
+	RemoveServer(id string, version uint64) error
 	// ClusterMembership returns the current cluster membership configuration and associated version.
 	ClusterMembership() (*ClusterMembership, error)
+}
 
+// UnsafeHeadStore defines the replicated unsafe head part of the consensus interface.
+type UnsafeHeadStore interface {
 	// CommitPayload commits latest unsafe payload to the FSM in a strongly consistent fashion.
 	CommitUnsafePayload(payload *eth.ExecutionPayloadEnvelope) error
 	// LatestUnsafeBlock returns the latest unsafe payload from FSM in a strongly consistent fashion.
 	LatestUnsafePayload() (*eth.ExecutionPayloadEnvelope, error)
+}
+
+// Consensus defines the consensus interface for leadership election.
+// It is implemented by RaftConsensus and EtcdConsensus.
+//
+//go:generate mockery --name Consensus --output mocks/ --with-expecter=true
+type Consensus interface {
+	Leadership
+	Membership
+	UnsafeHeadStore
 
 	// Shutdown shuts down the consensus protocol client.
 	Shutdown() error
@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -35,20 +36,30 @@ var (
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_ADVERTISED"),
 		Value:   "",
 	}
+	ConsensusBackend = &cli.GenericFlag{
+		Name: "consensus.backend",
+		Usage: "The consensus backend used for leader election and unsafe head replication. Valid options: " +
+			openum.EnumString(BackendTypes),
+		Value: func() *BackendType {
+			out := RaftBackend
+			return &out
+		}(),
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "CONSENSUS_BACKEND"),
+	}
 	RaftBootstrap = &cli.BoolFlag{
 		Name:    "raft.bootstrap",
-		Usage:   "If this node should bootstrap a new raft cluster",
+		Usage:   "If this node should bootstrap a new cluster, as the only voter",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_BOOTSTRAP"),
 		Value:   false,
 	}
 	RaftServerID = &cli.StringFlag{
 		Name:    "raft.server.id",
-		Usage:   "Unique ID for this server used by raft consensus",
+		Usage:   "Unique ID for this server used by consensus",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_SERVER_ID"),
 	}
 	RaftStorageDir = &cli.StringFlag{
 		Name:    "raft.storage.dir",
-		Usage:   "Directory to store raft data",
+		Usage:   "Directory to store raft data, required with the raft consensus backend",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_STORAGE_DIR"),
 	}
 	RaftSnapshotInterval = &cli.DurationFlag{
@@ -69,6 +80,23 @@ var (
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RAFT_TRAILING_LOGS"),
 		Value:   10240,
 	}
+	EtcdEndpoints = &cli.StringSliceFlag{
+		Name:    "etcd.endpoints",
+		Usage:   "Endpoints of the etcd v3 compatible store, required with the etcd consensus backend",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_ENDPOINTS"),
+	}
+	EtcdPrefix = &cli.StringFlag{
+		Name:    "etcd.prefix",
+		Usage:   "Key prefix of the cluster in the etcd store, allows running several clusters on the same store",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_PREFIX"),
+		Value:   "/op-conductor",
+	}
+	EtcdLeaseTTL = &cli.DurationFlag{
+		Name:    "etcd.lease-ttl",
+		Usage:   "TTL of the leader lease in the etcd store, a new leader is elected at most this long after the leader stops responding",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "ETCD_LEASE_TTL"),
+		Value:   10 * time.Second,
+	}
 	NodeRPC = &cli.StringFlag{
 		Name:    "node.rpc",
 		Usage:   "HTTP provider URL for op-node",
@@ -106,12 +134,89 @@ var (
 		Usage:   "Minimum number of peers required to be considered healthy",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_PEER_COUNT"),
 	}
+	HealthCheckELEnabled = &cli.BoolFlag{
+		Name:    "healthcheck.el-enabled",
+		Usage:   "Whether to check that the execution client is not syncing and responds in time",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_EL_ENABLED"),
+		Value:   false,
+	}
+	HealthCheckELMaxLatency = &cli.DurationFlag{
+		Name:    "healthcheck.el-max-latency",
+		Usage:   "Maximum RPC latency of the execution client, 0 to disable",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_EL_MAX_LATENCY"),
+		Value:   time.Second,
+	}
+	HealthCheckEngineRPC = &cli.StringFlag{
+		Name:    "healthcheck.engine-rpc",
+		Usage:   "Authenticated engine API endpoint of the execution client, enables the engine API responsiveness check if set",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_ENGINE_RPC"),
+	}
+	HealthCheckEngineJWTSecret = &cli.StringFlag{
+		Name:      "healthcheck.engine-jwt-secret",
+		Usage:     "Path to the JWT secret used to authenticate with the engine API",
+		EnvVars:   opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_ENGINE_JWT_SECRET"),
+		TakesFile: true,
+	}
+	HealthCheckEngineMaxLatency = &cli.DurationFlag{
+		Name:    "healthcheck.engine-max-latency",
+		Usage:   "Maximum latency of the engine API",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_ENGINE_MAX_LATENCY"),
+		Value:   time.Second,
+	}
+	HealthCheckMaxL1OriginLag = &cli.Uint64Flag{
+		Name:    "healthcheck.max-l1-origin-lag",
+		Usage:   "Maximum number of L1 blocks the L1 origin of the unsafe head may lag behind the L1 head, 0 to disable",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_L1_ORIGIN_LAG"),
+	}
+	HealthCheckMaxSafeLag = &cli.Uint64Flag{
+		Name:    "healthcheck.max-safe-lag",
+		Usage:   "Maximum number of seconds the safe head may lag behind the unsafe head, i.e. how late the batcher may post, 0 to disable",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MAX_SAFE_LAG"),
+	}
+	HealthCheckDiskPath = &cli.StringFlag{
+		Name:    "healthcheck.disk-path",
+		Usage:   "Path on the filesystem to check for free disk space, enables the disk space check if set",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_DISK_PATH"),
+	}
+	HealthCheckDiskMinFreeMB = &cli.Uint64Flag{
+		Name:    "healthcheck.disk-min-free-mb",
+		Usage:   "Minimum free disk space in MiB",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_DISK_MIN_FREE_MB"),
+		Value:   1024,
+	}
+	HealthCheckHTTPProbeURL = &cli.StringFlag{
+		Name:    "healthcheck.http-probe-url",
+		Usage:   "URL of a custom HTTP probe that must respond with a 2xx status code, enables the probe if set",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_HTTP_PROBE_URL"),
+	}
+	HealthCheckHTTPProbeTimeout = &cli.DurationFlag{
+		Name:    "healthcheck.http-probe-timeout",
+		Usage:   "Timeout of the custom HTTP probe",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_HTTP_PROBE_TIMEOUT"),
+		Value:   2 * time.Second,
+	}
 	Paused = &cli.BoolFlag{
 		Name:    "paused",
 		Usage:   "Whether the conductor is paused",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "PAUSED"),
 		Value:   false,
 	}
+	DrainPeerRPCs = &cli.StringSliceFlag{
+		Name:    "drain.peer-rpcs",
+		Usage:   "Conductor RPC endpoints of the other servers in the cluster, as <raft server id>=<url>, used to pick a handover target when draining",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "DRAIN_PEER_RPCS"),
+	}
+	DrainTimeout = &cli.DurationFlag{
+		Name:    "drain.timeout",
+		Usage:   "Maximum time to wait for the handover target to catch up with the latest unsafe head when draining",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "DRAIN_TIMEOUT"),
+		Value:   time.Minute,
+	}
+	DrainStateFile = &cli.StringFlag{
+		Name:    "drain.state-file",
+		Usage:   "Path of the file that persists the drained state across restarts. Defaults to a file in the raft storage directory with the raft backend",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "DRAIN_STATE_FILE"),
+	}
 	RPCEnableProxy = &cli.BoolFlag{
 		Name:    "rpc.enable-proxy",
 		Usage:   "Enable the RPC proxy to underlying sequencer services",
@@ -124,7 +229,6 @@ var requiredFlags = []cli.Flag{
 	ConsensusAddr,
 	ConsensusPort,
 	RaftServerID,
-	RaftStorageDir,
 	NodeRPC,
 	ExecutionRPC,
 	HealthCheckInterval,
@@ -136,12 +240,31 @@ var optionalFlags = []cli.Flag{
 	AdvertisedFullAddr,
 	Paused,
 	RPCEnableProxy,
+	ConsensusBackend,
 	RaftBootstrap,
+	RaftStorageDir,
 	HealthCheckSafeEnabled,
 	HealthCheckSafeInterval,
+	HealthCheckELEnabled,
+	HealthCheckELMaxLatency,
+	HealthCheckEngineRPC,
+	HealthCheckEngineJWTSecret,
+	HealthCheckEngineMaxLatency,
+	HealthCheckMaxL1OriginLag,
+	HealthCheckMaxSafeLag,
+	HealthCheckDiskPath,
+	HealthCheckDiskMinFreeMB,
+	HealthCheckHTTPProbeURL,
+	HealthCheckHTTPProbeTimeout,
 	RaftSnapshotInterval,
 	RaftSnapshotThreshold,
 	RaftTrailingLogs,
+	EtcdEndpoints,
+	EtcdPrefix,
+	EtcdLeaseTTL,
+	DrainPeerRPCs,
+	DrainTimeout,
+	DrainStateFile,
 }
 
 func init() {
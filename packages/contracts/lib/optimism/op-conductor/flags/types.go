@@ -0,0 +1,42 @@
+package flags
+
+import "fmt"
+
+type BackendType string
+
+const (
+	// consensus backends
+	RaftBackend BackendType = "raft"
+	EtcdBackend BackendType = "etcd"
+)
+
+var BackendTypes = []BackendType{
+	RaftBackend,
+	EtcdBackend,
+}
+
+func (kind BackendType) String() string {
+	return string(kind)
+}
+
+func (kind *BackendType) Set(value string) error {
+	if !ValidBackendType(BackendType(value)) {
+		return fmt.Errorf("unknown consensus backend: %q", value)
+	}
+	*kind = BackendType(value)
+	return nil
+}
+
+func (kind *BackendType) Clone() any {
+	cpy := *kind
+	return &cpy
+}
+
+func ValidBackendType(value BackendType) bool {
+	for _, k := range BackendTypes {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
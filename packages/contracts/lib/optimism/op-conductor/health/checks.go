@@ -0,0 +1,221 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+)
+
+// Check is a single health check of the sequencer, run by the SequencerHealthMonitor
+// in addition to its built-in head progression and peer count checks.
+type Check interface {
+	// Name returns the name of the check, as reported in the check results.
+	Name() string
+	// Check returns an error if the sequencer is not healthy according to this check.
+	Check(ctx context.Context) error
+}
+
+// CheckResult is the result of a single health check.
+type CheckResult struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	// Error describes why the check failed, empty if the check passed.
+	Error string `json:"error,omitempty"`
+	// Latency is how long the check took to run.
+	Latency time.Duration `json:"latency"`
+}
+
+// ELClient is the part of the execution client used by ELSyncCheck.
+type ELClient interface {
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
+}
+
+// ELSyncCheck checks that the execution client is not syncing and responds to RPC requests in time.
+type ELSyncCheck struct {
+	el         ELClient
+	maxLatency time.Duration
+	timeout    time.Duration
+}
+
+// NewELSyncCheck creates an ELSyncCheck, a maxLatency of 0 disables the latency check.
+// The check fails if the execution client does not respond within maxLatency, or timeout if the latency check is disabled.
+func NewELSyncCheck(el ELClient, maxLatency time.Duration, timeout time.Duration) *ELSyncCheck {
+	return &ELSyncCheck{el: el, maxLatency: maxLatency, timeout: timeout}
+}
+
+func (c *ELSyncCheck) Name() string {
+	return "el_sync"
+}
+
+func (c *ELSyncCheck) Check(ctx context.Context) error {
+	timeout := c.timeout
+	if c.maxLatency > 0 {
+		timeout = c.maxLatency
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	progress, err := c.el.SyncProgress(ctx)
+	latency := time.Since(start)
+	if err != nil {
+		return fmt.Errorf("failed to get sync progress within %s: %w", timeout, err)
+	}
+	if progress != nil {
+		return fmt.Errorf("execution client is syncing, current block %d, highest block %d", progress.CurrentBlock, progress.HighestBlock)
+	}
+	if c.maxLatency > 0 && latency > c.maxLatency {
+		return fmt.Errorf("execution client RPC latency %s exceeds %s", latency, c.maxLatency)
+	}
+	return nil
+}
+
+// EngineCheck checks that the engine API of the execution client responds in time.
+// A wedged execution client may still serve its public RPC, while block building through the engine API hangs.
+type EngineCheck struct {
+	engine     client.RPC
+	maxLatency time.Duration
+}
+
+// NewEngineCheck creates an EngineCheck. The engine RPC must be authenticated with the engine JWT secret.
+func NewEngineCheck(engine client.RPC, maxLatency time.Duration) *EngineCheck {
+	return &EngineCheck{engine: engine, maxLatency: maxLatency}
+}
+
+func (c *EngineCheck) Name() string {
+	return "engine"
+}
+
+func (c *EngineCheck) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.maxLatency)
+	defer cancel()
+	var capabilities []string
+	if err := c.engine.CallContext(ctx, &capabilities, "engine_exchangeCapabilities", []string{}); err != nil {
+		return fmt.Errorf("engine API did not respond within %s: %w", c.maxLatency, err)
+	}
+	return nil
+}
+
+// L1OriginLagCheck checks that the L1 origin of the unsafe head does not lag too far behind the L1 head.
+type L1OriginLagCheck struct {
+	node    dial.RollupClientInterface
+	maxLag  uint64
+	timeout time.Duration
+}
+
+// NewL1OriginLagCheck creates an L1OriginLagCheck, maxLag is measured in L1 blocks.
+// The check fails if the rollup node does not respond within timeout.
+func NewL1OriginLagCheck(node dial.RollupClientInterface, maxLag uint64, timeout time.Duration) *L1OriginLagCheck {
+	return &L1OriginLagCheck{node: node, maxLag: maxLag, timeout: timeout}
+}
+
+func (c *L1OriginLagCheck) Name() string {
+	return "l1_origin_lag"
+}
+
+func (c *L1OriginLagCheck) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	status, err := c.node.SyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status within %s: %w", c.timeout, err)
+	}
+	lag := calculateTimeDiff(status.HeadL1.Number, status.UnsafeL2.L1Origin.Number)
+	if lag > c.maxLag {
+		return fmt.Errorf("L1 origin %d lags %d blocks behind L1 head %d, max is %d", status.UnsafeL2.L1Origin.Number, lag, status.HeadL1.Number, c.maxLag)
+	}
+	return nil
+}
+
+// SafeLagCheck checks that the safe head does not lag too far behind the unsafe head,
+// which happens when the batcher stops posting batches.
+type SafeLagCheck struct {
+	node    dial.RollupClientInterface
+	maxLag  uint64
+	timeout time.Duration
+}
+
+// NewSafeLagCheck creates a SafeLagCheck, maxLag is measured in seconds of L2 time.
+// The check fails if the rollup node does not respond within timeout.
+func NewSafeLagCheck(node dial.RollupClientInterface, maxLag uint64, timeout time.Duration) *SafeLagCheck {
+	return &SafeLagCheck{node: node, maxLag: maxLag, timeout: timeout}
+}
+
+func (c *SafeLagCheck) Name() string {
+	return "safe_lag"
+}
+
+func (c *SafeLagCheck) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	status, err := c.node.SyncStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get sync status within %s: %w", c.timeout, err)
+	}
+	lag := calculateTimeDiff(status.UnsafeL2.Time, status.SafeL2.Time)
+	if lag > c.maxLag {
+		return fmt.Errorf("safe head %d lags %ds behind unsafe head %d, max is %ds", status.SafeL2.Number, lag, status.UnsafeL2.Number, c.maxLag)
+	}
+	return nil
+}
+
+// DiskSpaceCheck checks that the filesystem of a path has enough free space left.
+type DiskSpaceCheck struct {
+	path    string
+	minFree uint64
+}
+
+// NewDiskSpaceCheck creates a DiskSpaceCheck, minFree is measured in bytes.
+func NewDiskSpaceCheck(path string, minFree uint64) *DiskSpaceCheck {
+	return &DiskSpaceCheck{path: path, minFree: minFree}
+}
+
+func (c *DiskSpaceCheck) Name() string {
+	return "disk_space"
+}
+
+func (c *DiskSpaceCheck) Check(_ context.Context) error {
+	free, err := freeDiskSpace(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to get free disk space of %s: %w", c.path, err)
+	}
+	if free < c.minFree {
+		return fmt.Errorf("free disk space of %s is %d bytes, min is %d bytes", c.path, free, c.minFree)
+	}
+	return nil
+}
+
+// HTTPProbeCheck checks that a custom HTTP endpoint responds with a 2xx status code in time.
+type HTTPProbeCheck struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPProbeCheck(url string, timeout time.Duration) *HTTPProbeCheck {
+	return &HTTPProbeCheck{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (c *HTTPProbeCheck) Name() string {
+	return "http_probe"
+}
+
+func (c *HTTPProbeCheck) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid probe request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("probe request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("probe responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
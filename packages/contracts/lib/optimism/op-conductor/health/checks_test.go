@@ -0,0 +1,112 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type stubELClient struct {
+	progress *ethereum.SyncProgress
+	err      error
+	delay    time.Duration
+	hang     bool
+}
+
+func (c *stubELClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	if c.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	time.Sleep(c.delay)
+	return c.progress, c.err
+}
+
+// hangingRollupClient is a rollup node that never responds to sync status requests.
+type hangingRollupClient struct {
+	dial.RollupClientInterface
+}
+
+func (c *hangingRollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestELSyncCheck(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, NewELSyncCheck(&stubELClient{}, time.Second, time.Second).Check(ctx))
+	require.ErrorContains(t, NewELSyncCheck(&stubELClient{err: errors.New("boom")}, time.Second, time.Second).Check(ctx), "boom")
+	require.ErrorContains(t, NewELSyncCheck(&stubELClient{progress: &ethereum.SyncProgress{CurrentBlock: 1, HighestBlock: 2}}, time.Second, time.Second).Check(ctx), "syncing")
+	require.ErrorContains(t, NewELSyncCheck(&stubELClient{delay: 10 * time.Millisecond}, time.Millisecond, time.Second).Check(ctx), "latency")
+	require.NoError(t, NewELSyncCheck(&stubELClient{delay: 10 * time.Millisecond}, 0, time.Second).Check(ctx))
+
+	// a wedged execution client fails the check once it times out.
+	require.ErrorIs(t, NewELSyncCheck(&stubELClient{hang: true}, 10*time.Millisecond, time.Hour).Check(ctx), context.DeadlineExceeded)
+	require.ErrorIs(t, NewELSyncCheck(&stubELClient{hang: true}, 0, 10*time.Millisecond).Check(ctx), context.DeadlineExceeded)
+}
+
+func TestRollupNodeChecksTimeout(t *testing.T) {
+	node := &hangingRollupClient{}
+	require.ErrorIs(t, NewL1OriginLagCheck(node, 10, 10*time.Millisecond).Check(context.Background()), context.DeadlineExceeded)
+	require.ErrorIs(t, NewSafeLagCheck(node, 600, 10*time.Millisecond).Check(context.Background()), context.DeadlineExceeded)
+}
+
+func TestL1OriginLagCheck(t *testing.T) {
+	status := func(l1Head, l1Origin uint64) *eth.SyncStatus {
+		return &eth.SyncStatus{
+			HeadL1:   eth.L1BlockRef{Number: l1Head},
+			UnsafeL2: eth.L2BlockRef{L1Origin: eth.BlockID{Number: l1Origin}},
+		}
+	}
+	rc := &testutils.MockRollupClient{}
+	check := NewL1OriginLagCheck(rc, 10, time.Second)
+
+	rc.ExpectSyncStatus(status(100, 90), nil)
+	require.NoError(t, check.Check(context.Background()))
+	rc.ExpectSyncStatus(status(100, 89), nil)
+	require.ErrorContains(t, check.Check(context.Background()), "lags 11 blocks")
+	// the L1 head may briefly be behind the L1 origin when L1 reorgs.
+	rc.ExpectSyncStatus(status(90, 100), nil)
+	require.NoError(t, check.Check(context.Background()))
+}
+
+func TestSafeLagCheck(t *testing.T) {
+	rc := &testutils.MockRollupClient{}
+	check := NewSafeLagCheck(rc, 600, time.Second)
+
+	rc.ExpectSyncStatus(mockSyncStatus(1600, 800, 1000, 500), nil)
+	require.NoError(t, check.Check(context.Background()))
+	rc.ExpectSyncStatus(mockSyncStatus(1601, 801, 1000, 500), nil)
+	require.ErrorContains(t, check.Check(context.Background()), "lags 601s")
+	rc.ExpectSyncStatus(nil, errors.New("boom"))
+	require.ErrorContains(t, check.Check(context.Background()), "boom")
+}
+
+func TestDiskSpaceCheck(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, NewDiskSpaceCheck(dir, 1).Check(context.Background()))
+	require.ErrorContains(t, NewDiskSpaceCheck(dir, 1<<62).Check(context.Background()), "free disk space")
+}
+
+func TestHTTPProbeCheck(t *testing.T) {
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	check := NewHTTPProbeCheck(srv.URL, time.Second)
+	require.NoError(t, check.Check(context.Background()))
+	status = http.StatusServiceUnavailable
+	require.ErrorContains(t, check.Check(context.Background()), "status 503")
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package health
+
+import "golang.org/x/sys/unix"
+
+// freeDiskSpace returns the free disk space, in bytes, available to unprivileged users on the filesystem of path.
+func freeDiskSpace(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
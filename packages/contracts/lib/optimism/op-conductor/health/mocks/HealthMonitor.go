@@ -5,6 +5,8 @@ package mocks
 import (
 	context "context"
 
+	health "github.com/ethereum-optimism/optimism/op-conductor/health"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -21,6 +23,53 @@ func (_m *HealthMonitor) EXPECT() *HealthMonitor_Expecter {
 	return &HealthMonitor_Expecter{mock: &_m.Mock}
 }
 
+// Results provides a mock function with given fields:
+func (_m *HealthMonitor) Results() []health.CheckResult {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Results")
+	}
+
+	var r0 []health.CheckResult
+	if rf, ok := ret.Get(0).(func() []health.CheckResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]health.CheckResult)
+		}
+	}
+
+	return r0
+}
+
+// HealthMonitor_Results_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Results'
+type HealthMonitor_Results_Call struct {
+	*mock.Call
+}
+
+// Results is a helper method to define mock.On call
+func (_e *HealthMonitor_Expecter) Results() *HealthMonitor_Results_Call {
+	return &HealthMonitor_Results_Call{Call: _e.mock.On("Results")}
+}
+
+func (_c *HealthMonitor_Results_Call) Run(run func()) *HealthMonitor_Results_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *HealthMonitor_Results_Call) Return(_a0 []health.CheckResult) *HealthMonitor_Results_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *HealthMonitor_Results_Call) RunAndReturn(run func() []health.CheckResult) *HealthMonitor_Results_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Start provides a mock function with given fields: ctx
 func (_m *HealthMonitor) Start(ctx context.Context) error {
 	ret := _m.Called(ctx)
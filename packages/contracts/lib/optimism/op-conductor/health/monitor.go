@@ -3,6 +3,7 @@ package health
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -29,13 +30,16 @@ type HealthMonitor interface {
 	Start(ctx context.Context) error
 	// Stop stops the health check.
 	Stop() error
+	// Results returns the per-check results of the latest health check.
+	Results() []CheckResult
 }
 
 // NewSequencerHealthMonitor creates a new sequencer health monitor.
 // interval is the interval between health checks measured in seconds.
 // safeInterval is the interval between safe head progress measured in seconds.
 // minPeerCount is the minimum number of peers required for the sequencer to be healthy.
-func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount uint64, safeEnabled bool, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API) HealthMonitor {
+// checks are additional health checks, run after the built-in checks.
+func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interval, unsafeInterval, safeInterval, minPeerCount uint64, safeEnabled bool, rollupCfg *rollup.Config, node dial.RollupClientInterface, p2p p2p.API, checks ...Check) HealthMonitor {
 	return &SequencerHealthMonitor{
 		log:            log,
 		metrics:        metrics,
@@ -49,6 +53,7 @@ func NewSequencerHealthMonitor(log log.Logger, metrics metrics.Metricer, interva
 		timeProviderFn: currentTimeProvicer,
 		node:           node,
 		p2p:            p2p,
+		checks:         checks,
 	}
 }
 
@@ -73,6 +78,10 @@ type SequencerHealthMonitor struct {
 
 	node dial.RollupClientInterface
 	p2p  p2p.API
+
+	checks      []Check
+	resultsLock sync.RWMutex
+	results     []CheckResult
 }
 
 var _ HealthMonitor = (*SequencerHealthMonitor)(nil)
@@ -105,6 +114,13 @@ func (hm *SequencerHealthMonitor) Subscribe() <-chan error {
 	return hm.healthUpdateCh
 }
 
+// Results implements HealthMonitor.
+func (hm *SequencerHealthMonitor) Results() []CheckResult {
+	hm.resultsLock.RLock()
+	defer hm.resultsLock.RUnlock()
+	return append([]CheckResult(nil), hm.results...)
+}
+
 func (hm *SequencerHealthMonitor) loop(ctx context.Context) {
 	defer hm.wg.Done()
 
@@ -117,7 +133,7 @@ func (hm *SequencerHealthMonitor) loop(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			err := hm.healthCheck(ctx)
+			err := hm.runChecks(ctx)
 			hm.metrics.RecordHealthCheck(err == nil, err)
 			// Ensure that we exit cleanly if told to shutdown while still waiting to publish the health update
 			select {
@@ -130,6 +146,62 @@ func (hm *SequencerHealthMonitor) loop(ctx context.Context) {
 	}
 }
 
+// runChecks runs the built-in health check and the additional checks concurrently, and records the result of each.
+// All checks must complete within the health check interval, so that slow checks can't delay the next health update.
+// It returns the error of the first failing check, in the order the checks are configured.
+func (hm *SequencerHealthMonitor) runChecks(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(hm.interval)*time.Second)
+	defer cancel()
+
+	errs := make([]error, len(hm.checks)+1)
+	results := make([]CheckResult, len(hm.checks)+1)
+	var wg sync.WaitGroup
+	run := func(i int, name string, check func(context.Context) error) {
+		defer wg.Done()
+		start := time.Now()
+		errs[i] = check(ctx)
+		results[i] = newCheckResult(name, errs[i], time.Since(start))
+	}
+	wg.Add(len(results))
+	go run(0, "sequencer", hm.healthCheck)
+	for i, check := range hm.checks {
+		go run(i+1, check.Name(), check.Check)
+	}
+	wg.Wait()
+
+	err := errs[0]
+	for i, check := range hm.checks {
+		checkErr := errs[i+1]
+		if checkErr != nil {
+			hm.log.Error("health check failed", "check", check.Name(), "err", checkErr)
+			if err == nil {
+				err = fmt.Errorf("%w: %s check failed: %w", ErrSequencerNotHealthy, check.Name(), checkErr)
+			}
+		}
+	}
+
+	hm.resultsLock.Lock()
+	hm.results = results
+	hm.resultsLock.Unlock()
+
+	if err == nil {
+		hm.log.Info("sequencer is healthy")
+	}
+	return err
+}
+
+func newCheckResult(name string, err error, latency time.Duration) CheckResult {
+	result := CheckResult{
+		Name:    name,
+		Healthy: err == nil,
+		Latency: latency,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
 // healthCheck checks the health of the sequencer by 3 criteria:
 // 1. unsafe head is progressing per block time
 // 2. unsafe head is not too far behind now (measured by unsafeInterval)
@@ -209,7 +281,6 @@ func (hm *SequencerHealthMonitor) healthCheck(ctx context.Context) error {
 		return ErrSequencerNotHealthy
 	}
 
-	hm.log.Info("sequencer is healthy")
 	return nil
 }
 
@@ -2,6 +2,7 @@ package health
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -47,6 +48,7 @@ func (s *HealthMonitorTestSuite) SetupMonitor(
 	now, unsafeInterval, safeInterval uint64,
 	mockRollupClient *testutils.MockRollupClient,
 	mockP2P *p2pMocks.API,
+	checks ...Check,
 ) *SequencerHealthMonitor {
 	tp := &timeProvider{now: now}
 	if mockP2P == nil {
@@ -69,6 +71,7 @@ func (s *HealthMonitorTestSuite) SetupMonitor(
 		timeProviderFn: tp.Now,
 		node:           mockRollupClient,
 		p2p:            mockP2P,
+		checks:         checks,
 	}
 	err := monitor.Start(context.Background())
 	s.NoError(err)
@@ -205,6 +208,97 @@ func (s *HealthMonitorTestSuite) TestHealthyWithUnsafeLag() {
 	s.NoError(monitor.Stop())
 }
 
+func (s *HealthMonitorTestSuite) TestUnhealthyCheckFailed() {
+	s.T().Parallel()
+	now := uint64(time.Now().Unix())
+
+	rc := &testutils.MockRollupClient{}
+	rc.ExpectSyncStatus(mockSyncStatus(now-1, 1, now-3, 0), nil)
+
+	monitor := s.SetupMonitor(now, 60, 60, rc, nil,
+		&stubCheck{name: "passing"},
+		&stubCheck{name: "failing", err: errors.New("wedged")},
+	)
+	healthUpdateCh := monitor.Subscribe()
+
+	healthy := <-healthUpdateCh
+	s.ErrorIs(healthy, ErrSequencerNotHealthy)
+	s.ErrorContains(healthy, "wedged")
+
+	results := monitor.Results()
+	s.Len(results, 3)
+	s.Equal("sequencer", results[0].Name)
+	s.True(results[0].Healthy)
+	s.Equal("passing", results[1].Name)
+	s.True(results[1].Healthy)
+	s.Equal("failing", results[2].Name)
+	s.False(results[2].Healthy)
+	s.Equal("wedged", results[2].Error)
+
+	s.NoError(monitor.Stop())
+}
+
+func (s *HealthMonitorTestSuite) TestSlowChecksBoundedByInterval() {
+	s.T().Parallel()
+	now := uint64(time.Now().Unix())
+
+	rc := &testutils.MockRollupClient{}
+	rc.ExpectSyncStatus(mockSyncStatus(now-1, 1, now-3, 0), nil)
+	mockP2P := &p2pMocks.API{}
+	mockP2P.EXPECT().PeerStats(mock.Anything).Return(&p2p.PeerStats{Connected: healthyPeerCount}, nil)
+	tp := &timeProvider{now: now}
+	monitor := &SequencerHealthMonitor{
+		log:            s.log,
+		interval:       s.interval,
+		metrics:        &metrics.NoopMetricsImpl{},
+		rollupCfg:      s.rollupCfg,
+		unsafeInterval: 60,
+		safeInterval:   60,
+		safeEnabled:    true,
+		minPeerCount:   s.minPeerCount,
+		timeProviderFn: tp.Now,
+		node:           rc,
+		p2p:            mockP2P,
+		checks: []Check{
+			&stubCheck{name: "slow1", wait: true},
+			&stubCheck{name: "slow2", wait: true},
+			&stubCheck{name: "slow3", wait: true},
+		},
+	}
+
+	start := time.Now()
+	err := monitor.runChecks(context.Background())
+	s.ErrorIs(err, ErrSequencerNotHealthy)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Less(time.Since(start), 2*time.Duration(s.interval)*time.Second, "checks should run concurrently within the interval")
+
+	results := monitor.Results()
+	s.Len(results, 4)
+	s.True(results[0].Healthy)
+	for _, result := range results[1:] {
+		s.False(result.Healthy)
+	}
+}
+
+type stubCheck struct {
+	name string
+	err  error
+	// wait makes the check block until its context is done.
+	wait bool
+}
+
+func (c *stubCheck) Name() string {
+	return c.name
+}
+
+func (c *stubCheck) Check(ctx context.Context) error {
+	if c.wait {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return c.err
+}
+
 func mockSyncStatus(unsafeTime, unsafeNum, safeTime, safeNum uint64) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		UnsafeL2: eth.L2BlockRef{
@@ -8,12 +8,25 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 var ErrNotLeader = errors.New("refusing to proxy request to non-leader sequencer")
 
+// HandoverStatus describes whether a server is fit to take over sequencing during a graceful leadership handover.
+type HandoverStatus struct {
+	// ServerID is the consensus server ID of the conductor.
+	ServerID string `json:"serverID"`
+	// Healthy is true if the sequencer is healthy.
+	Healthy bool `json:"healthy"`
+	// Drained is true if the conductor is in maintenance mode and must not become leader.
+	Drained bool `json:"drained"`
+	// UnsafeHead is the latest unsafe block of the sequencer.
+	UnsafeHead eth.BlockID `json:"unsafeHead"`
+}
+
 // API defines the interface for the op-conductor API.
 type API interface {
 	// OverrideLeader is used to override or clear override for the leader status.
@@ -36,6 +49,17 @@ type API interface {
 	Stopped(ctx context.Context) (bool, error)
 	// SequencerHealthy returns true if the sequencer is healthy.
 	SequencerHealthy(ctx context.Context) (bool, error)
+	// SequencerHealthDetails returns the per-check results of the latest sequencer health check.
+	SequencerHealthDetails(ctx context.Context) ([]health.CheckResult, error)
+	// Drain gracefully hands over leadership to the healthiest follower once it has caught up with the latest unsafe head,
+	// and puts op-conductor into maintenance mode, in which it does not sequence and gives up leadership whenever elected.
+	Drain(ctx context.Context) error
+	// Undrain brings op-conductor out of maintenance mode.
+	Undrain(ctx context.Context) error
+	// Drained returns true if op-conductor is in maintenance mode.
+	Drained(ctx context.Context) (bool, error)
+	// HandoverStatus returns whether the server is fit to take over sequencing.
+	HandoverStatus(ctx context.Context) (*HandoverStatus, error)
 
 	// Consensus related APIs
 	// Leader returns true if the server is the leader.
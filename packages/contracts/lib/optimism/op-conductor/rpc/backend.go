@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -18,6 +19,11 @@ type conductor interface {
 	Paused() bool
 	Stopped() bool
 	SequencerHealthy(ctx context.Context) bool
+	SequencerHealthDetails(ctx context.Context) []health.CheckResult
+	Drain(ctx context.Context) error
+	Undrain(ctx context.Context) error
+	Drained() bool
+	HandoverStatus(ctx context.Context) (*HandoverStatus, error)
 
 	Leader(ctx context.Context) bool
 	LeaderWithID(ctx context.Context) *consensus.ServerInfo
@@ -135,6 +141,31 @@ func (api *APIBackend) SequencerHealthy(ctx context.Context) (bool, error) {
 	return api.con.SequencerHealthy(ctx), nil
 }
 
+// SequencerHealthDetails implements API.
+func (api *APIBackend) SequencerHealthDetails(ctx context.Context) ([]health.CheckResult, error) {
+	return api.con.SequencerHealthDetails(ctx), nil
+}
+
+// Drain implements API.
+func (api *APIBackend) Drain(ctx context.Context) error {
+	return api.con.Drain(ctx)
+}
+
+// Undrain implements API.
+func (api *APIBackend) Undrain(ctx context.Context) error {
+	return api.con.Undrain(ctx)
+}
+
+// Drained implements API.
+func (api *APIBackend) Drained(_ context.Context) (bool, error) {
+	return api.con.Drained(), nil
+}
+
+// HandoverStatus implements API.
+func (api *APIBackend) HandoverStatus(ctx context.Context) (*HandoverStatus, error) {
+	return api.con.HandoverStatus(ctx)
+}
+
 // ClusterMembership implements API.
 func (api *APIBackend) ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
 	return api.con.ClusterMembership(ctx)
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -131,6 +132,37 @@ func (c *APIClient) SequencerHealthy(ctx context.Context) (bool, error) {
 	return healthy, err
 }
 
+// SequencerHealthDetails implements API.
+func (c *APIClient) SequencerHealthDetails(ctx context.Context) ([]health.CheckResult, error) {
+	var results []health.CheckResult
+	err := c.c.CallContext(ctx, &results, prefixRPC("sequencerHealthDetails"))
+	return results, err
+}
+
+// Drain implements API.
+func (c *APIClient) Drain(ctx context.Context) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("drain"))
+}
+
+// Undrain implements API.
+func (c *APIClient) Undrain(ctx context.Context) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("undrain"))
+}
+
+// Drained implements API.
+func (c *APIClient) Drained(ctx context.Context) (bool, error) {
+	var drained bool
+	err := c.c.CallContext(ctx, &drained, prefixRPC("drained"))
+	return drained, err
+}
+
+// HandoverStatus implements API.
+func (c *APIClient) HandoverStatus(ctx context.Context) (*HandoverStatus, error) {
+	var status *HandoverStatus
+	err := c.c.CallContext(ctx, &status, prefixRPC("handoverStatus"))
+	return status, err
+}
+
 // ClusterMembership implements API.
 func (c *APIClient) ClusterMembership(ctx context.Context) (*consensus.ClusterMembership, error) {
 	var clusterMembership consensus.ClusterMembership
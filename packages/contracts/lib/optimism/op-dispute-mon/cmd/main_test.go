@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/superchain-registry/superchain"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -234,6 +236,104 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestAlertRules(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, alerts.AllRules, cfg.AlertRules)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--alert-rules", alerts.RuleHonestClaimLost+","+alerts.RuleInsufficientCollateral))
+		require.Equal(t, []string{alerts.RuleHonestClaimLost, alerts.RuleInsufficientCollateral}, cfg.AlertRules)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown alert rule: bogus", addRequiredArgs("--alert-rules", "bogus"))
+	})
+}
+
+func TestAlertWebhooks(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.AlertWebhooks)
+	})
+
+	t.Run("MultiValue", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--alert-webhooks", "http://hook1", "--alert-webhooks", "http://hook2"))
+		require.Equal(t, []string{"http://hook1", "http://hook2"}, cfg.AlertWebhooks)
+	})
+}
+
+func TestAlertClockPercent(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultAlertClockPercent, cfg.AlertClockPercent)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--alert-clock-percent", "80"))
+		require.Equal(t, uint(80), cfg.AlertClockPercent)
+	})
+}
+
+func TestArchiveDir(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.ArchiveDir)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--archive-dir", "/tmp/archive"))
+		require.Equal(t, "/tmp/archive", cfg.ArchiveDir)
+	})
+}
+
+func TestRPCConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, oprpc.DefaultCLIConfig(), cfg.RPCConfig)
+	})
+
+	t.Run("Custom", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--rpc.addr", "127.0.0.1", "--rpc.port", "9000"))
+		require.Equal(t, "127.0.0.1", cfg.RPCConfig.ListenAddr)
+		require.Equal(t, 9000, cfg.RPCConfig.ListenPort)
+	})
+}
+
+func TestTreeView(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.TreeViewEnabled)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--tree-view.enabled"))
+		require.True(t, cfg.TreeViewEnabled)
+	})
+}
+
+func TestOutputSources(t *testing.T) {
+	t.Run("DefaultsToRollupRpcOnly", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.AdditionalRollupRpcs)
+		require.Empty(t, cfg.L2EthRpc)
+		require.Zero(t, cfg.OutputQuorum)
+		require.Equal(t, 1, cfg.NumOutputSources())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(
+			"--additional-rollup-rpcs", "http://rollup2,http://rollup3",
+			"--l2-eth-rpc", "http://l2",
+			"--output-quorum", "3"))
+		require.Equal(t, []string{"http://rollup2", "http://rollup3"}, cfg.AdditionalRollupRpcs)
+		require.Equal(t, "http://l2", cfg.L2EthRpc)
+		require.EqualValues(t, 3, cfg.OutputQuorum)
+		require.Equal(t, 4, cfg.NumOutputSources())
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := dryRunWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -3,10 +3,13 @@ package config
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -16,6 +19,8 @@ var (
 	ErrMissingGameFactoryAddress = errors.New("missing game factory address")
 	ErrMissingRollupRpc          = errors.New("missing rollup rpc url")
 	ErrMissingMaxConcurrency     = errors.New("missing max concurrency")
+	ErrInvalidAlertClockPercent  = errors.New("alert clock percent must be between 1 and 100")
+	ErrInvalidOutputQuorum       = errors.New("output quorum must be a majority of the output sources")
 )
 
 const (
@@ -30,6 +35,10 @@ const (
 
 	//DefaultMaxConcurrency is the default number of threads to use when fetching game data
 	DefaultMaxConcurrency = uint(5)
+
+	// DefaultAlertClockPercent is the default percentage of the chess clock that may elapse
+	// before an invalid proposal without an honest counter claim triggers an alert.
+	DefaultAlertClockPercent = uint(50)
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -45,6 +54,20 @@ type Config struct {
 	IgnoredGames    []common.Address // Games to exclude from monitoring
 	MaxConcurrency  uint             // Maximum number of threads to use when fetching game data
 
+	AdditionalRollupRpcs []string // Additional rollup node RPC URLs to check root claims against
+	L2EthRpc             string   // L2 execution client RPC URL to recompute output roots from (optional)
+	OutputQuorum         uint     // Number of output sources that must agree (0 == majority)
+
+	AlertRules        []string // Names of the alert rules to evaluate
+	AlertWebhooks     []string // URLs to post alert notifications to
+	AlertClockPercent uint     // Percentage of the chess clock that may elapse before an uncountered invalid proposal alerts
+
+	ArchiveDir string // Directory to archive resolved games in. The archive is disabled if empty.
+
+	TreeViewEnabled bool // Serve a live HTML view of the claim trees of monitored games on the RPC server
+
+	RPCConfig oprpc.CLIConfig // Serves the archive API and claim tree view when either is enabled
+
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
 }
@@ -59,11 +82,24 @@ func NewConfig(gameFactoryAddress common.Address, l1EthRpc string, rollupRpc str
 		GameWindow:      DefaultGameWindow,
 		MaxConcurrency:  DefaultMaxConcurrency,
 
+		AlertRules:        slices.Clone(alerts.AllRules),
+		AlertClockPercent: DefaultAlertClockPercent,
+
+		RPCConfig:     oprpc.DefaultCLIConfig(),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
 	}
 }
 
+// NumOutputSources returns the number of sources root claims are checked against.
+func (c Config) NumOutputSources() int {
+	n := 1 + len(c.AdditionalRollupRpcs)
+	if c.L2EthRpc != "" {
+		n++
+	}
+	return n
+}
+
 func (c Config) Check() error {
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
@@ -77,6 +113,20 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMissingMaxConcurrency
 	}
+	for _, rule := range c.AlertRules {
+		if !alerts.IsKnownRule(rule) {
+			return fmt.Errorf("%w: %v", alerts.ErrUnknownRule, rule)
+		}
+	}
+	if sources := c.NumOutputSources(); c.OutputQuorum != 0 && (c.OutputQuorum > uint(sources) || c.OutputQuorum*2 <= uint(sources)) {
+		return fmt.Errorf("%w: quorum %v, sources %v", ErrInvalidOutputQuorum, c.OutputQuorum, sources)
+	}
+	if c.AlertClockPercent == 0 || c.AlertClockPercent > 100 {
+		return ErrInvalidAlertClockPercent
+	}
+	if err := c.RPCConfig.Check(); err != nil {
+		return fmt.Errorf("rpc config: %w", err)
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return fmt.Errorf("metrics config: %w", err)
 	}
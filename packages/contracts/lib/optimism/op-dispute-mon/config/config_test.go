@@ -3,6 +3,8 @@ package config
 import (
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -45,3 +47,43 @@ func TestMaxConcurrencyRequired(t *testing.T) {
 	config.MaxConcurrency = 0
 	require.ErrorIs(t, config.Check(), ErrMissingMaxConcurrency)
 }
+
+func TestAlertRulesMustBeKnown(t *testing.T) {
+	config := validConfig()
+	config.AlertRules = []string{alerts.RuleHonestClaimLost, "bogus"}
+	require.ErrorIs(t, config.Check(), alerts.ErrUnknownRule)
+}
+
+func TestAlertClockPercent(t *testing.T) {
+	config := validConfig()
+	config.AlertClockPercent = 0
+	require.ErrorIs(t, config.Check(), ErrInvalidAlertClockPercent)
+	config.AlertClockPercent = 101
+	require.ErrorIs(t, config.Check(), ErrInvalidAlertClockPercent)
+	config.AlertClockPercent = 100
+	require.NoError(t, config.Check())
+}
+
+func TestRPCConfigMustBeValid(t *testing.T) {
+	config := validConfig()
+	config.RPCConfig.ListenPort = -1
+	require.ErrorIs(t, config.Check(), oprpc.ErrInvalidPort)
+}
+
+func TestOutputQuorumMustBeMajority(t *testing.T) {
+	config := validConfig()
+	require.NoError(t, config.Check(), "should default to majority")
+	config.OutputQuorum = 2
+	require.ErrorIs(t, config.Check(), ErrInvalidOutputQuorum)
+
+	config.AdditionalRollupRpcs = []string{"http://rollup2"}
+	config.L2EthRpc = "http://l2"
+	config.OutputQuorum = 1
+	require.ErrorIs(t, config.Check(), ErrInvalidOutputQuorum)
+	config.OutputQuorum = 2
+	require.NoError(t, config.Check())
+	config.OutputQuorum = 3
+	require.NoError(t, config.Check())
+	config.OutputQuorum = 4
+	require.ErrorIs(t, config.Check(), ErrInvalidOutputQuorum)
+}
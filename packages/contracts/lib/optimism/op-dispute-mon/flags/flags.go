@@ -2,16 +2,19 @@ package flags
 
 import (
 	"fmt"
+	"strings"
 
 	challengerFlags "github.com/ethereum-optimism/optimism/op-challenger/flags"
 	"github.com/ethereum-optimism/optimism/op-service/flags"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -36,6 +39,22 @@ var (
 		EnvVars: prefixEnvVars("ROLLUP_RPC"),
 	}
 	// Optional Flags
+	AdditionalRollupRpcsFlag = &cli.StringSliceFlag{
+		Name:    "additional-rollup-rpcs",
+		Usage:   "List of additional rollup node RPC URLs to check root claims against.",
+		EnvVars: prefixEnvVars("ADDITIONAL_ROLLUP_RPCS"),
+	}
+	L2EthRpcFlag = &cli.StringFlag{
+		Name:    "l2-eth-rpc",
+		Usage:   "L2 execution client RPC URL to recompute output roots from with eth_getProof when checking root claims.",
+		EnvVars: prefixEnvVars("L2_ETH_RPC"),
+	}
+	OutputQuorumFlag = &cli.UintFlag{
+		Name: "output-quorum",
+		Usage: "Number of output sources (rollup nodes and the L2 execution client) that must agree on whether a root claim " +
+			"is valid. Must be a majority of the sources. Defaults to a simple majority.",
+		EnvVars: prefixEnvVars("OUTPUT_QUORUM"),
+	}
 	GameFactoryAddressFlag = &cli.StringFlag{
 		Name:    "game-factory-address",
 		Usage:   "Address of the fault game factory contract.",
@@ -71,6 +90,33 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   config.DefaultMaxConcurrency,
 	}
+	AlertRulesFlag = &cli.StringSliceFlag{
+		Name:    "alert-rules",
+		Usage:   "Alert rules to evaluate. Valid options: " + strings.Join(alerts.AllRules, ", "),
+		EnvVars: prefixEnvVars("ALERT_RULES"),
+		Value:   cli.NewStringSlice(alerts.AllRules...),
+	}
+	AlertWebhooksFlag = &cli.StringSliceFlag{
+		Name:    "alert-webhooks",
+		Usage:   "List of webhook URLs to post alert notifications to as JSON.",
+		EnvVars: prefixEnvVars("ALERT_WEBHOOKS"),
+	}
+	AlertClockPercentFlag = &cli.UintFlag{
+		Name:    "alert-clock-percent",
+		Usage:   "Percentage of the chess clock that may elapse before an invalid proposal without an honest counter claim triggers an alert.",
+		EnvVars: prefixEnvVars("ALERT_CLOCK_PERCENT"),
+		Value:   config.DefaultAlertClockPercent,
+	}
+	ArchiveDirFlag = &cli.StringFlag{
+		Name:    "archive-dir",
+		Usage:   "Directory to archive the final state of resolved games in. Enables the archive API on the RPC server.",
+		EnvVars: prefixEnvVars("ARCHIVE_DIR"),
+	}
+	TreeViewEnabledFlag = &cli.BoolFlag{
+		Name:    "tree-view.enabled",
+		Usage:   "Serve a live HTML view of the claim trees of monitored games at /tree/ on the RPC server.",
+		EnvVars: prefixEnvVars("TREE_VIEW_ENABLED"),
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -81,6 +127,9 @@ var requiredFlags = []cli.Flag{
 
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
+	AdditionalRollupRpcsFlag,
+	L2EthRpcFlag,
+	OutputQuorumFlag,
 	GameFactoryAddressFlag,
 	NetworkFlag,
 	HonestActorsFlag,
@@ -88,9 +137,15 @@ var optionalFlags = []cli.Flag{
 	GameWindowFlag,
 	IgnoredGamesFlag,
 	MaxConcurrencyFlag,
+	AlertRulesFlag,
+	AlertWebhooksFlag,
+	AlertClockPercentFlag,
+	ArchiveDirFlag,
+	TreeViewEnabledFlag,
 }
 
 func init() {
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
@@ -147,6 +202,13 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
 
+	alertRules := ctx.StringSlice(AlertRulesFlag.Name)
+	for _, rule := range alertRules {
+		if !alerts.IsKnownRule(rule) {
+			return nil, fmt.Errorf("invalid %v: %w: %v", AlertRulesFlag.Name, alerts.ErrUnknownRule, rule)
+		}
+	}
+
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
 
@@ -161,6 +223,19 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		IgnoredGames:    ignoredGames,
 		MaxConcurrency:  maxConcurrency,
 
+		AdditionalRollupRpcs: ctx.StringSlice(AdditionalRollupRpcsFlag.Name),
+		L2EthRpc:             ctx.String(L2EthRpcFlag.Name),
+		OutputQuorum:         ctx.Uint(OutputQuorumFlag.Name),
+
+		AlertRules:        alertRules,
+		AlertWebhooks:     ctx.StringSlice(AlertWebhooksFlag.Name),
+		AlertClockPercent: ctx.Uint(AlertClockPercentFlag.Name),
+
+		ArchiveDir: ctx.String(ArchiveDirFlag.Name),
+
+		TreeViewEnabled: ctx.Bool(TreeViewEnabledFlag.Name),
+
+		RPCConfig:     oprpc.ReadCLIConfig(ctx),
 		MetricsConfig: metricsConfig,
 		PprofConfig:   pprofConfig,
 	}, nil
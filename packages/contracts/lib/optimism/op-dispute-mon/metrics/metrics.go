@@ -185,6 +185,13 @@ type Metricer interface {
 
 	RecordOldestGameUpdateTime(t time.Time)
 
+	RecordActiveAlerts(rule string, count int)
+
+	RecordArchivedGames(count int)
+
+	RecordOutputSourceStatus(source string, status string, count int)
+	RecordOutputSourceDisagreements(source string, count int)
+
 	caching.Metrics
 	contractMetrics.ContractMetricer
 }
@@ -229,6 +236,13 @@ type Metrics struct {
 
 	requiredCollateral  prometheus.GaugeVec
 	availableCollateral prometheus.GaugeVec
+
+	activeAlerts prometheus.GaugeVec
+
+	archivedGames prometheus.Counter
+
+	outputSources             prometheus.GaugeVec
+	outputSourceDisagreements prometheus.GaugeVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -395,6 +409,33 @@ func NewMetrics() *Metrics {
 			// An l2 block number challenge with an agreement means the challenge was invalid.
 			"root_agreement",
 		}),
+		activeAlerts: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "active_alerts",
+			Help:      "Number of alerts currently firing",
+		}, []string{
+			"rule",
+		}),
+		archivedGames: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "archived_games",
+			Help:      "Number of resolved games stored in the archive",
+		}),
+		outputSources: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "output_sources",
+			Help:      "Number of games by whether each output source agreed with the root claim",
+		}, []string{
+			"source",
+			"status",
+		}),
+		outputSourceDisagreements: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "output_source_disagreements",
+			Help:      "Number of games where the output source disagreed with the quorum of output sources",
+		}, []string{
+			"source",
+		}),
 	}
 }
 
@@ -548,6 +589,22 @@ func (m *Metrics) RecordBondCollateral(addr common.Address, required, available
 	m.availableCollateral.WithLabelValues(addr.Hex(), zeroBalanceLabel).Set(0)
 }
 
+func (m *Metrics) RecordActiveAlerts(rule string, count int) {
+	m.activeAlerts.WithLabelValues(rule).Set(float64(count))
+}
+
+func (m *Metrics) RecordArchivedGames(count int) {
+	m.archivedGames.Add(float64(count))
+}
+
+func (m *Metrics) RecordOutputSourceStatus(source string, status string, count int) {
+	m.outputSources.WithLabelValues(source, status).Set(float64(count))
+}
+
+func (m *Metrics) RecordOutputSourceDisagreements(source string, count int) {
+	m.outputSourceDisagreements.WithLabelValues(source).Set(float64(count))
+}
+
 func (m *Metrics) RecordL2Challenges(agreement bool, count int) {
 	agree := "disagree"
 	if agreement {
@@ -51,3 +51,11 @@ func (*NoopMetricsImpl) RecordFailedGames(_ int) {}
 func (*NoopMetricsImpl) RecordBondCollateral(_ common.Address, _, _ *big.Int) {}
 
 func (*NoopMetricsImpl) RecordL2Challenges(_ bool, _ int) {}
+
+func (*NoopMetricsImpl) RecordActiveAlerts(_ string, _ int) {}
+
+func (*NoopMetricsImpl) RecordArchivedGames(_ int) {}
+
+func (*NoopMetricsImpl) RecordOutputSourceStatus(_ string, _ string, _ int) {}
+
+func (*NoopMetricsImpl) RecordOutputSourceDisagreements(_ string, _ int) {}
@@ -0,0 +1,126 @@
+package alerts
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type RClock interface {
+	Now() time.Time
+}
+
+type AlertMetrics interface {
+	RecordActiveAlerts(rule string, count int)
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, alerts []Alert) error
+}
+
+// Alerter evaluates the alert rules each monitoring cycle. Notifications are only sent when an alert first fires
+// and when it stops firing, rather than every cycle the condition holds.
+type Alerter struct {
+	logger    log.Logger
+	clock     RClock
+	metrics   AlertMetrics
+	rules     []Rule
+	notifiers []*notifierState
+
+	// active tracks the currently firing alerts by key
+	active map[string]Alert
+}
+
+// notifierState tracks the alerts a Notifier was last successfully notified of, so that failed deliveries are
+// retried for that notifier only.
+type notifierState struct {
+	notifier Notifier
+	// notified tracks the firing alerts the notifier has been notified of, by key
+	notified map[string]Alert
+}
+
+// NewAlerter creates an Alerter. notifiers may be empty in which case alerts are only logged and reported as metrics.
+func NewAlerter(logger log.Logger, clock RClock, metrics AlertMetrics, notifiers []Notifier, rules ...Rule) *Alerter {
+	states := make([]*notifierState, 0, len(notifiers))
+	for _, notifier := range notifiers {
+		states = append(states, &notifierState{notifier: notifier, notified: make(map[string]Alert)})
+	}
+	return &Alerter{
+		logger:    logger,
+		clock:     clock,
+		metrics:   metrics,
+		rules:     rules,
+		notifiers: states,
+		active:    make(map[string]Alert),
+	}
+}
+
+// CheckAlerts evaluates the alert rules and notifies each notifier of the alerts that started or stopped firing since
+// it was last successfully notified. If a notifier fails, the same changes are sent to it again next cycle.
+// Alerts for games that are no longer monitored are dropped without being resolved, as the rule may still match.
+func (a *Alerter) CheckAlerts(ctx context.Context, games []*types.EnrichedGameData) {
+	now := a.clock.Now()
+	monitored := make(map[common.Address]bool, len(games))
+	for _, game := range games {
+		monitored[game.Proxy] = true
+	}
+	current := make(map[string]Alert)
+	for _, rule := range a.rules {
+		alerts := rule.Evaluate(now, games)
+		a.metrics.RecordActiveAlerts(rule.Name(), len(alerts))
+		for _, alert := range alerts {
+			alert.Status = StatusFiring
+			current[alert.key()] = alert
+		}
+	}
+	for _, alert := range alertChanges(a.active, current, monitored) {
+		if alert.Status == StatusFiring {
+			a.logger.Error("Alert firing", "rule", alert.Rule, "message", alert.Message, "key", alert.key())
+		} else {
+			a.logger.Info("Alert resolved", "rule", alert.Rule, "key", alert.key())
+		}
+	}
+	a.active = current
+
+	for i, state := range a.notifiers {
+		changes := alertChanges(state.notified, current, monitored)
+		if len(changes) > 0 {
+			if err := state.notifier.Notify(ctx, changes); err != nil {
+				a.logger.Error("Failed to send alert notifications", "notifier", i, "alerts", len(changes), "err", err)
+				continue
+			}
+		}
+		state.notified = current
+	}
+}
+
+// alertChanges returns the alerts in current that aren't in previous as firing, and the alerts in previous that aren't
+// in current as resolved. Alerts for games that are no longer monitored haven't stopped firing so aren't resolved.
+func alertChanges(previous map[string]Alert, current map[string]Alert, monitored map[common.Address]bool) []Alert {
+	var changes []Alert
+	for key, alert := range current {
+		if _, ok := previous[key]; !ok {
+			changes = append(changes, alert)
+		}
+	}
+	for key, alert := range previous {
+		if _, ok := current[key]; ok {
+			continue
+		}
+		if alert.Game != nil && !monitored[*alert.Game] {
+			continue
+		}
+		alert.Status = StatusResolved
+		changes = append(changes, alert)
+	}
+	// Sort for deterministic notifications
+	slices.SortFunc(changes, func(a, b Alert) int {
+		return strings.Compare(a.key(), b.key())
+	})
+	return changes
+}
@@ -0,0 +1,195 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlerter(t *testing.T) {
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	rule := &stubRule{}
+	notifier := &stubNotifier{}
+	m := &stubAlertMetrics{active: make(map[string]int)}
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	alerter := NewAlerter(logger, clock.NewDeterministicClock(now), m, []Notifier{notifier}, rule)
+	games := monitoredGames(game1, game2)
+
+	rule.games = []common.Address{game1}
+	alerter.CheckAlerts(context.Background(), games)
+	require.Len(t, notifier.sent, 1)
+	require.Len(t, notifier.sent[0], 1)
+	require.Equal(t, StatusFiring, notifier.sent[0][0].Status)
+	require.Equal(t, game1, *notifier.sent[0][0].Game)
+	require.Equal(t, 1, m.active["stub"])
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Alert firing")))
+
+	// Only new alerts are notified
+	rule.games = []common.Address{game1, game2}
+	alerter.CheckAlerts(context.Background(), games)
+	require.Len(t, notifier.sent, 2)
+	require.Len(t, notifier.sent[1], 1)
+	require.Equal(t, game2, *notifier.sent[1][0].Game)
+	require.Equal(t, 2, m.active["stub"])
+
+	// No notification when nothing changes
+	alerter.CheckAlerts(context.Background(), games)
+	require.Len(t, notifier.sent, 2)
+
+	// Alerts that stop firing are notified as resolved
+	rule.games = []common.Address{game2}
+	alerter.CheckAlerts(context.Background(), games)
+	require.Len(t, notifier.sent, 3)
+	require.Len(t, notifier.sent[2], 1)
+	require.Equal(t, StatusResolved, notifier.sent[2][0].Status)
+	require.Equal(t, game1, *notifier.sent[2][0].Game)
+	require.Equal(t, 1, m.active["stub"])
+
+	// Notification failures are logged and the changes retried next cycle
+	notifier.err = errors.New("boom")
+	rule.games = nil
+	alerter.CheckAlerts(context.Background(), games)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Failed to send alert notifications")))
+	require.Len(t, notifier.sent, 3)
+	require.Equal(t, 0, m.active["stub"])
+
+	notifier.err = nil
+	alerter.CheckAlerts(context.Background(), games)
+	require.Len(t, notifier.sent, 4)
+	require.Len(t, notifier.sent[3], 1)
+	require.Equal(t, StatusResolved, notifier.sent[3][0].Status)
+	require.Equal(t, game2, *notifier.sent[3][0].Game)
+
+	// Delivered changes are not notified again
+	alerter.CheckAlerts(context.Background(), games)
+	require.Len(t, notifier.sent, 4)
+}
+
+func TestAlerterRetriesFailedFiringNotifications(t *testing.T) {
+	rule := &stubRule{games: []common.Address{{0x01}}}
+	notifier := &stubNotifier{err: errors.New("boom")}
+	m := &stubAlertMetrics{active: make(map[string]int)}
+	alerter := NewAlerter(testlog.Logger(t, log.LevelInfo), clock.NewDeterministicClock(now), m, []Notifier{notifier}, rule)
+	alerter.CheckAlerts(context.Background(), nil)
+	require.Empty(t, notifier.sent)
+
+	notifier.err = nil
+	alerter.CheckAlerts(context.Background(), nil)
+	require.Len(t, notifier.sent, 1)
+	require.Equal(t, StatusFiring, notifier.sent[0][0].Status)
+}
+
+func TestAlerterTracksDeliveryPerNotifier(t *testing.T) {
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	rule := &stubRule{games: []common.Address{game1}}
+	failing := &stubNotifier{err: errors.New("boom")}
+	working := &stubNotifier{}
+	m := &stubAlertMetrics{active: make(map[string]int)}
+	alerter := NewAlerter(testlog.Logger(t, log.LevelInfo), clock.NewDeterministicClock(now), m, []Notifier{failing, working}, rule)
+	games := monitoredGames(game1, game2)
+
+	alerter.CheckAlerts(context.Background(), games)
+	require.Empty(t, failing.sent)
+	require.Len(t, working.sent, 1)
+
+	// Only the notifier that failed is sent the undelivered alert again, along with the new alert
+	failing.err = nil
+	rule.games = []common.Address{game1, game2}
+	alerter.CheckAlerts(context.Background(), games)
+	require.Len(t, failing.sent, 1)
+	require.Len(t, failing.sent[0], 2)
+	require.Len(t, working.sent, 2)
+	require.Len(t, working.sent[1], 1)
+	require.Equal(t, game2, *working.sent[1][0].Game)
+}
+
+func TestAlerterDoesNotResolveGamesLeavingWindow(t *testing.T) {
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	rule := &stubRule{games: []common.Address{game1, game2}}
+	notifier := &stubNotifier{}
+	m := &stubAlertMetrics{active: make(map[string]int)}
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	alerter := NewAlerter(logger, clock.NewDeterministicClock(now), m, []Notifier{notifier}, rule)
+	alerter.CheckAlerts(context.Background(), monitoredGames(game1, game2))
+	require.Len(t, notifier.sent, 1)
+
+	// game1 is no longer monitored so the rule stops matching it, but it hasn't resolved
+	rule.games = []common.Address{game2}
+	alerter.CheckAlerts(context.Background(), monitoredGames(game2))
+	require.Len(t, notifier.sent, 1)
+	require.Nil(t, logs.FindLog(testlog.NewMessageFilter("Alert resolved")))
+
+	// Alerts for games that are still monitored are resolved
+	rule.games = nil
+	alerter.CheckAlerts(context.Background(), monitoredGames(game2))
+	require.Len(t, notifier.sent, 2)
+	require.Len(t, notifier.sent[1], 1)
+	require.Equal(t, StatusResolved, notifier.sent[1][0].Status)
+	require.Equal(t, game2, *notifier.sent[1][0].Game)
+}
+
+func TestAlerterWithoutNotifier(t *testing.T) {
+	rule := &stubRule{games: []common.Address{{0x01}}}
+	m := &stubAlertMetrics{active: make(map[string]int)}
+	alerter := NewAlerter(testlog.Logger(t, log.LevelInfo), clock.NewDeterministicClock(now), m, nil, rule)
+	alerter.CheckAlerts(context.Background(), nil)
+	require.Equal(t, 1, m.active["stub"])
+}
+
+func monitoredGames(addrs ...common.Address) []*types.EnrichedGameData {
+	games := make([]*types.EnrichedGameData, 0, len(addrs))
+	for _, addr := range addrs {
+		games = append(games, &types.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{Proxy: addr}})
+	}
+	return games
+}
+
+type stubRule struct {
+	games []common.Address
+}
+
+func (s *stubRule) Name() string {
+	return "stub"
+}
+
+func (s *stubRule) Evaluate(_ time.Time, _ []*types.EnrichedGameData) []Alert {
+	var alerts []Alert
+	for _, game := range s.games {
+		game := game
+		alerts = append(alerts, Alert{Rule: s.Name(), Game: &game})
+	}
+	return alerts
+}
+
+type stubNotifier struct {
+	sent [][]Alert
+	err  error
+}
+
+func (s *stubNotifier) Notify(_ context.Context, alerts []Alert) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, alerts)
+	return nil
+}
+
+type stubAlertMetrics struct {
+	active map[string]int
+}
+
+func (s *stubAlertMetrics) RecordActiveAlerts(rule string, count int) {
+	s.active[rule] = count
+}
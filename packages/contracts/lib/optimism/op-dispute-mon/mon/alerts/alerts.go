@@ -0,0 +1,106 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// RuleUnchallengedInvalidProposal fires when an invalid proposal has not been countered by an honest actor
+	// after a configured percentage of the challenger's chess clock has elapsed.
+	RuleUnchallengedInvalidProposal = "unchallenged-invalid-proposal"
+	// RuleInsufficientCollateral fires when a DelayedWETH contract holds less ETH than required to pay out bonds.
+	RuleInsufficientCollateral = "insufficient-collateral"
+	// RuleUnexpectedGameResult fires when a game resolves with a different result to the expected one.
+	RuleUnexpectedGameResult = "unexpected-game-result"
+	// RuleHonestClaimLost fires when a claim made by an honest actor is resolved as countered.
+	RuleHonestClaimLost = "honest-claim-lost"
+)
+
+// AllRules lists the names of all supported alert rules.
+var AllRules = []string{
+	RuleUnchallengedInvalidProposal,
+	RuleInsufficientCollateral,
+	RuleUnexpectedGameResult,
+	RuleHonestClaimLost,
+}
+
+var ErrUnknownRule = errors.New("unknown alert rule")
+
+type Status string
+
+const (
+	StatusFiring   Status = "firing"
+	StatusResolved Status = "resolved"
+)
+
+// Alert describes a problem detected by a Rule. Optional fields are set when relevant to the rule.
+type Alert struct {
+	Rule    string `json:"rule"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+
+	Game          *common.Address `json:"game,omitempty"`
+	L2BlockNumber uint64          `json:"l2BlockNumber,omitempty"`
+	RootClaim     *common.Hash    `json:"rootClaim,omitempty"`
+	ClaimIndex    *int            `json:"claimIndex,omitempty"`
+	Claimant      *common.Address `json:"claimant,omitempty"`
+	Deadline      *time.Time      `json:"deadline,omitempty"`
+
+	// Contract is the contract the alert relates to when not a specific game (e.g. the DelayedWETH contract)
+	Contract *common.Address   `json:"contract,omitempty"`
+	Details  map[string]string `json:"details,omitempty"`
+}
+
+// key identifies the alert so it is only notified when it first fires and when it resolves.
+func (a Alert) key() string {
+	key := a.Rule
+	if a.Game != nil {
+		key += "/" + a.Game.Hex()
+	}
+	if a.ClaimIndex != nil {
+		key += "/" + strconv.Itoa(*a.ClaimIndex)
+	}
+	if a.Contract != nil {
+		key += "/" + a.Contract.Hex()
+	}
+	return key
+}
+
+// Rule evaluates the monitored games and returns an Alert for each problem found.
+type Rule interface {
+	Name() string
+	Evaluate(now time.Time, games []*types.EnrichedGameData) []Alert
+}
+
+// NewRules creates the rules with the specified names.
+// clockPercent is the percentage of the chess clock allowed to elapse before an invalid proposal must be countered.
+func NewRules(names []string, honestActors types.HonestActors, clockPercent uint) ([]Rule, error) {
+	rules := make([]Rule, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case RuleUnchallengedInvalidProposal:
+			rules = append(rules, &unchallengedInvalidProposalRule{honestActors: honestActors, clockPercent: clockPercent})
+		case RuleInsufficientCollateral:
+			rules = append(rules, &insufficientCollateralRule{})
+		case RuleUnexpectedGameResult:
+			rules = append(rules, &unexpectedGameResultRule{})
+		case RuleHonestClaimLost:
+			rules = append(rules, &honestClaimLostRule{honestActors: honestActors})
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrUnknownRule, name)
+		}
+	}
+	return rules, nil
+}
+
+// IsKnownRule returns true if name is a supported alert rule.
+func IsKnownRule(name string) bool {
+	return slices.Contains(AllRules, name)
+}
@@ -0,0 +1,155 @@
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type unchallengedInvalidProposalRule struct {
+	honestActors types.HonestActors
+	clockPercent uint
+}
+
+func (r *unchallengedInvalidProposalRule) Name() string {
+	return RuleUnchallengedInvalidProposal
+}
+
+func (r *unchallengedInvalidProposalRule) Evaluate(now time.Time, games []*types.EnrichedGameData) []Alert {
+	var alerts []Alert
+	for _, game := range games {
+		if game.Status != gameTypes.GameStatusInProgress || game.AgreeWithClaim || game.BlockNumberChallenged || len(game.Claims) == 0 {
+			continue
+		}
+		if r.hasHonestCounter(game) {
+			continue
+		}
+		root := game.Claims[0]
+		maxClock := time.Duration(game.MaxClockDuration) * time.Second
+		elapsed := now.Sub(root.Clock.Timestamp)
+		if elapsed*100 < maxClock*time.Duration(r.clockPercent) {
+			continue
+		}
+		alert := gameAlert(r.Name(), game, fmt.Sprintf("Invalid proposal not countered by an honest actor after %v of %v", elapsed.Truncate(time.Second), maxClock))
+		claimIndex := root.ContractIndex
+		alert.ClaimIndex = &claimIndex
+		deadline := root.Clock.Timestamp.Add(maxClock)
+		alert.Deadline = &deadline
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+// hasHonestCounter returns true if the root claim has been countered by an honest actor.
+// If no honest actors are configured, any counter claim is accepted.
+func (r *unchallengedInvalidProposalRule) hasHonestCounter(game *types.EnrichedGameData) bool {
+	for _, claim := range game.Claims[1:] {
+		if claim.ParentContractIndex != 0 {
+			continue
+		}
+		if len(r.honestActors) == 0 || r.honestActors.Contains(claim.Claimant) {
+			return true
+		}
+	}
+	return false
+}
+
+type insufficientCollateralRule struct{}
+
+func (r *insufficientCollateralRule) Name() string {
+	return RuleInsufficientCollateral
+}
+
+func (r *insufficientCollateralRule) Evaluate(_ time.Time, games []*types.EnrichedGameData) []Alert {
+	var alerts []Alert
+	for addr, collateral := range bonds.CalculateRequiredCollateral(games) {
+		if collateral.Actual == nil || collateral.Required.Cmp(collateral.Actual) <= 0 {
+			continue
+		}
+		contract := addr
+		alerts = append(alerts, Alert{
+			Rule:     r.Name(),
+			Message:  "DelayedWETH collateral below the amount required to pay out bonds",
+			Contract: &contract,
+			Details: map[string]string{
+				"required":  collateral.Required.String(),
+				"available": collateral.Actual.String(),
+			},
+		})
+	}
+	return alerts
+}
+
+type unexpectedGameResultRule struct{}
+
+func (r *unexpectedGameResultRule) Name() string {
+	return RuleUnexpectedGameResult
+}
+
+func (r *unexpectedGameResultRule) Evaluate(_ time.Time, games []*types.EnrichedGameData) []Alert {
+	var alerts []Alert
+	for _, game := range games {
+		if game.Status == gameTypes.GameStatusInProgress {
+			continue
+		}
+		expected := gameTypes.GameStatusDefenderWon
+		if !game.AgreeWithClaim {
+			expected = gameTypes.GameStatusChallengerWon
+		}
+		if game.Status == expected {
+			continue
+		}
+		alert := gameAlert(r.Name(), game, fmt.Sprintf("Game resolved as %v but expected %v", game.Status, expected))
+		alert.Details = map[string]string{
+			"expectedRootClaim": game.ExpectedRootClaim.Hex(),
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts
+}
+
+type honestClaimLostRule struct {
+	honestActors types.HonestActors
+}
+
+func (r *honestClaimLostRule) Name() string {
+	return RuleHonestClaimLost
+}
+
+func (r *honestClaimLostRule) Evaluate(_ time.Time, games []*types.EnrichedGameData) []Alert {
+	var alerts []Alert
+	for _, game := range games {
+		for _, claim := range game.Claims {
+			if !claim.Resolved || claim.CounteredBy == (common.Address{}) || !r.honestActors.Contains(claim.Claimant) {
+				continue
+			}
+			alert := gameAlert(r.Name(), game, "Claim resolved against honest actor")
+			claimIndex := claim.ContractIndex
+			claimant := claim.Claimant
+			alert.ClaimIndex = &claimIndex
+			alert.Claimant = &claimant
+			alert.Details = map[string]string{
+				"counteredBy": claim.CounteredBy.Hex(),
+				"bond":        claim.Bond.String(),
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+func gameAlert(rule string, game *types.EnrichedGameData, message string) Alert {
+	addr := game.Proxy
+	rootClaim := game.RootClaim
+	return Alert{
+		Rule:          rule,
+		Message:       message,
+		Game:          &addr,
+		L2BlockNumber: game.L2BlockNumber,
+		RootClaim:     &rootClaim,
+	}
+}
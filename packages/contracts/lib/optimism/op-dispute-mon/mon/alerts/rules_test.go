@@ -0,0 +1,168 @@
+package alerts
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now         = time.Unix(1_000_000, 0)
+	honestActor = common.Address{0xaa}
+	dishonest   = common.Address{0xbb}
+)
+
+func TestUnchallengedInvalidProposal(t *testing.T) {
+	rule := &unchallengedInvalidProposalRule{honestActors: types.NewHonestActors([]common.Address{honestActor}), clockPercent: 50}
+	newGame := func(elapsed time.Duration, counters ...common.Address) *types.EnrichedGameData {
+		game := &types.EnrichedGameData{
+			GameMetadata:     gameTypes.GameMetadata{Proxy: common.Address{0x01}},
+			Status:           gameTypes.GameStatusInProgress,
+			MaxClockDuration: 1000,
+			L2BlockNumber:    42,
+			Claims: []types.EnrichedClaim{
+				{Claim: faultTypes.Claim{Clock: faultTypes.Clock{Timestamp: now.Add(-elapsed)}}},
+			},
+		}
+		for i, counter := range counters {
+			game.Claims = append(game.Claims, types.EnrichedClaim{Claim: faultTypes.Claim{
+				ClaimData:     faultTypes.ClaimData{Position: faultTypes.NewPositionFromGIndex(big.NewInt(2))},
+				Claimant:      counter,
+				ContractIndex: i + 1,
+			}})
+		}
+		return game
+	}
+
+	t.Run("BeforeThreshold", func(t *testing.T) {
+		require.Empty(t, rule.Evaluate(now, []*types.EnrichedGameData{newGame(499 * time.Second)}))
+	})
+
+	t.Run("AfterThreshold", func(t *testing.T) {
+		game := newGame(500 * time.Second)
+		alerts := rule.Evaluate(now, []*types.EnrichedGameData{game})
+		require.Len(t, alerts, 1)
+		require.Equal(t, RuleUnchallengedInvalidProposal, alerts[0].Rule)
+		require.Equal(t, game.Proxy, *alerts[0].Game)
+		require.Equal(t, uint64(42), alerts[0].L2BlockNumber)
+		require.Equal(t, 0, *alerts[0].ClaimIndex)
+		require.Equal(t, now.Add(500*time.Second), *alerts[0].Deadline)
+	})
+
+	t.Run("CounteredByDishonestActor", func(t *testing.T) {
+		require.Len(t, rule.Evaluate(now, []*types.EnrichedGameData{newGame(600*time.Second, dishonest)}), 1)
+	})
+
+	t.Run("CounteredByHonestActor", func(t *testing.T) {
+		require.Empty(t, rule.Evaluate(now, []*types.EnrichedGameData{newGame(600*time.Second, dishonest, honestActor)}))
+	})
+
+	t.Run("AnyCounterWithoutHonestActors", func(t *testing.T) {
+		rule := &unchallengedInvalidProposalRule{clockPercent: 50}
+		require.Empty(t, rule.Evaluate(now, []*types.EnrichedGameData{newGame(600*time.Second, dishonest)}))
+	})
+
+	t.Run("IgnoreValidProposals", func(t *testing.T) {
+		game := newGame(600 * time.Second)
+		game.AgreeWithClaim = true
+		require.Empty(t, rule.Evaluate(now, []*types.EnrichedGameData{game}))
+	})
+
+	t.Run("IgnoreChallengedBlockNumber", func(t *testing.T) {
+		game := newGame(600 * time.Second)
+		game.BlockNumberChallenged = true
+		require.Empty(t, rule.Evaluate(now, []*types.EnrichedGameData{game}))
+	})
+
+	t.Run("IgnoreResolvedGames", func(t *testing.T) {
+		game := newGame(600 * time.Second)
+		game.Status = gameTypes.GameStatusChallengerWon
+		require.Empty(t, rule.Evaluate(now, []*types.EnrichedGameData{game}))
+	})
+}
+
+func TestInsufficientCollateral(t *testing.T) {
+	rule := &insufficientCollateralRule{}
+	weth1 := common.Address{0x1a}
+	weth2 := common.Address{0x2b}
+	games := []*types.EnrichedGameData{
+		{
+			Credits:       map[common.Address]*big.Int{honestActor: big.NewInt(2)},
+			WETHContract:  weth1,
+			ETHCollateral: big.NewInt(100),
+		},
+		{
+			Credits:       map[common.Address]*big.Int{honestActor: big.NewInt(46)},
+			WETHContract:  weth2,
+			ETHCollateral: big.NewInt(10),
+		},
+	}
+	alerts := rule.Evaluate(now, games)
+	require.Len(t, alerts, 1)
+	require.Equal(t, weth2, *alerts[0].Contract)
+	require.Equal(t, "46", alerts[0].Details["required"])
+	require.Equal(t, "10", alerts[0].Details["available"])
+}
+
+func TestUnexpectedGameResult(t *testing.T) {
+	rule := &unexpectedGameResultRule{}
+	games := []*types.EnrichedGameData{
+		{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x01}}, Status: gameTypes.GameStatusInProgress},
+		{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x02}}, Status: gameTypes.GameStatusDefenderWon, AgreeWithClaim: true},
+		{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x03}}, Status: gameTypes.GameStatusChallengerWon, AgreeWithClaim: false},
+		{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x04}}, Status: gameTypes.GameStatusChallengerWon, AgreeWithClaim: true},
+		{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x05}}, Status: gameTypes.GameStatusDefenderWon, AgreeWithClaim: false},
+	}
+	alerts := rule.Evaluate(now, games)
+	require.Len(t, alerts, 2)
+	require.Equal(t, common.Address{0x04}, *alerts[0].Game)
+	require.Equal(t, common.Address{0x05}, *alerts[1].Game)
+}
+
+func TestHonestClaimLost(t *testing.T) {
+	rule := &honestClaimLostRule{honestActors: types.NewHonestActors([]common.Address{honestActor})}
+	claim := func(idx int, claimant common.Address, counteredBy common.Address, resolved bool) types.EnrichedClaim {
+		return types.EnrichedClaim{
+			Claim: faultTypes.Claim{
+				ClaimData:     faultTypes.ClaimData{Bond: big.NewInt(int64(idx + 1))},
+				Claimant:      claimant,
+				CounteredBy:   counteredBy,
+				ContractIndex: idx,
+			},
+			Resolved: resolved,
+		}
+	}
+	game := &types.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x01}},
+		Claims: []types.EnrichedClaim{
+			claim(0, dishonest, honestActor, true),
+			claim(1, honestActor, common.Address{}, true),
+			claim(2, honestActor, dishonest, false),
+			claim(3, honestActor, dishonest, true),
+		},
+	}
+	alerts := rule.Evaluate(now, []*types.EnrichedGameData{game})
+	require.Len(t, alerts, 1)
+	require.Equal(t, 3, *alerts[0].ClaimIndex)
+	require.Equal(t, honestActor, *alerts[0].Claimant)
+	require.Equal(t, dishonest.Hex(), alerts[0].Details["counteredBy"])
+	require.Equal(t, "4", alerts[0].Details["bond"])
+}
+
+func TestNewRules(t *testing.T) {
+	rules, err := NewRules(AllRules, nil, 50)
+	require.NoError(t, err)
+	require.Len(t, rules, len(AllRules))
+	for i, rule := range rules {
+		require.Equal(t, AllRules[i], rule.Name())
+	}
+
+	_, err = NewRules([]string{"bogus"}, nil, 50)
+	require.ErrorIs(t, err, ErrUnknownRule)
+}
@@ -0,0 +1,65 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const DefaultWebhookTimeout = 10 * time.Second
+
+// WebhookPayload is the JSON body posted to webhooks.
+type WebhookPayload struct {
+	Alerts []Alert `json:"alerts"`
+}
+
+// WebhookNotifier posts alerts as JSON to a generic webhook URL.
+type WebhookNotifier struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:     url,
+		client:  http.DefaultClient,
+		timeout: DefaultWebhookTimeout,
+	}
+}
+
+// Notify posts the alerts to the webhook.
+func (n *WebhookNotifier) Notify(ctx context.Context, alerts []Alert) error {
+	body, err := json.Marshal(WebhookPayload{Alerts: alerts})
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+	if err := n.post(ctx, n.url, body); err != nil {
+		return fmt.Errorf("webhook %v: %w", n.url, err)
+	}
+	return nil
+}
+
+func (n *WebhookNotifier) post(ctx context.Context, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %v", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	game := common.Address{0x01}
+	claimIndex := 3
+	alerts := []Alert{{Rule: RuleHonestClaimLost, Status: StatusFiring, Game: &game, ClaimIndex: &claimIndex}}
+
+	t.Run("PostAlerts", func(t *testing.T) {
+		var received []WebhookPayload
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var payload WebhookPayload
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			received = append(received, payload)
+		}))
+		defer server.Close()
+
+		notifier := NewWebhookNotifier(server.URL)
+		require.NoError(t, notifier.Notify(context.Background(), alerts))
+		require.Len(t, received, 1)
+		require.Equal(t, alerts, received[0].Alerts)
+	})
+
+	t.Run("ErrorStatus", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+
+		notifier := NewWebhookNotifier(failing.URL)
+		err := notifier.Notify(context.Background(), alerts)
+		require.ErrorContains(t, err, failing.URL)
+		require.ErrorContains(t, err, "500")
+	})
+}
@@ -0,0 +1,29 @@
+package archive
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Namespace is the JSON-RPC namespace the archive API is served under.
+const Namespace = "archive"
+
+// API exposes the archive over JSON-RPC.
+type API struct {
+	store *Store
+}
+
+func NewAPI(store *Store) *API {
+	return &API{store: store}
+}
+
+// GetGame returns the archived game at the specified address.
+func (a *API) GetGame(_ context.Context, addr common.Address) (*Game, error) {
+	return a.store.Get(addr)
+}
+
+// QueryGames returns the archived games matching the filter, ordered by creation timestamp.
+func (a *API) QueryGames(ctx context.Context, filter Filter) ([]*Game, error) {
+	return a.store.Query(ctx, filter)
+}
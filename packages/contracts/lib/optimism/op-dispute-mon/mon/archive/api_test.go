@@ -0,0 +1,46 @@
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI(t *testing.T) {
+	store := openTestStore(t)
+	game1 := &Game{Address: common.Address{0x01}, Timestamp: 100, Proposer: proposer1, Outcome: OutcomeDefenderWon}
+	game2 := &Game{Address: common.Address{0x02}, Timestamp: 200, Proposer: proposer2, Challengers: []common.Address{challenger1}, Outcome: OutcomeChallengerWon}
+	require.NoError(t, store.Put(game1))
+	require.NoError(t, store.Put(game2))
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName(Namespace, NewAPI(store)))
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+	t.Cleanup(client.Close)
+
+	t.Run("GetGame", func(t *testing.T) {
+		var game Game
+		require.NoError(t, client.CallContext(context.Background(), &game, "archive_getGame", game2.Address))
+		require.Equal(t, *game2, game)
+	})
+
+	t.Run("GetUnknownGame", func(t *testing.T) {
+		var game Game
+		err := client.CallContext(context.Background(), &game, "archive_getGame", common.Address{0xff})
+		require.ErrorContains(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("QueryGames", func(t *testing.T) {
+		var games []*Game
+		outcome := OutcomeChallengerWon
+		require.NoError(t, client.CallContext(context.Background(), &games, "archive_queryGames", Filter{Outcome: &outcome}))
+		require.Equal(t, []*Game{game2}, games)
+
+		require.NoError(t, client.CallContext(context.Background(), &games, "archive_queryGames", Filter{From: 50, To: 150}))
+		require.Equal(t, []*Game{game1}, games)
+	})
+}
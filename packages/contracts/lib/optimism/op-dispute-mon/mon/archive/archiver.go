@@ -0,0 +1,89 @@
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ArchiveMetrics interface {
+	RecordArchivedGames(count int)
+}
+
+// Archiver stores the state of each game once it resolves so it remains available after the game leaves the
+// monitoring window. Bonds are claimed and withdrawn after a game resolves, so the archived game is updated
+// whenever its credits or withdrawals change while it is still being monitored.
+type Archiver struct {
+	logger  log.Logger
+	metrics ArchiveMetrics
+	store   *Store
+
+	// archived caches the last archived state of the resolved games in the monitoring window, to avoid reading from
+	// and writing to the store every update. Games that leave the monitoring window are removed.
+	archived map[common.Address][]byte
+}
+
+func NewArchiver(logger log.Logger, metrics ArchiveMetrics, store *Store) *Archiver {
+	return &Archiver{
+		logger:   logger,
+		metrics:  metrics,
+		store:    store,
+		archived: make(map[common.Address][]byte),
+	}
+}
+
+func (a *Archiver) ArchiveGames(games []*types.EnrichedGameData) {
+	count := 0
+	archived := make(map[common.Address][]byte, len(a.archived))
+	for _, data := range games {
+		if data.Status == gameTypes.GameStatusInProgress {
+			continue
+		}
+		game := NewGame(data)
+		encoded, err := json.Marshal(game)
+		if err != nil {
+			a.logger.Error("Failed to encode game", "game", data.Proxy, "err", err)
+			continue
+		}
+		prev, ok := a.archived[data.Proxy]
+		if !ok {
+			if prev, err = a.loadArchived(data.Proxy); err != nil {
+				a.logger.Error("Failed to load archived game", "game", data.Proxy, "err", err)
+				continue
+			}
+		}
+		if bytes.Equal(prev, encoded) {
+			archived[data.Proxy] = prev
+			continue
+		}
+		if err := a.store.Put(game); err != nil {
+			a.logger.Error("Failed to archive game", "game", data.Proxy, "err", err)
+			continue
+		}
+		if prev == nil {
+			a.logger.Info("Archived game", "game", data.Proxy, "status", data.Status)
+			count++
+		} else {
+			a.logger.Info("Updated archived game", "game", data.Proxy, "status", data.Status)
+		}
+		archived[data.Proxy] = encoded
+	}
+	a.archived = archived
+	a.metrics.RecordArchivedGames(count)
+}
+
+// loadArchived returns the encoded game from the store, or nil if it isn't archived.
+func (a *Archiver) loadArchived(addr common.Address) ([]byte, error) {
+	game, err := a.store.Get(addr)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return json.Marshal(game)
+}
@@ -0,0 +1,131 @@
+package archive
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveResolvedGames(t *testing.T) {
+	store := openTestStore(t)
+	m := &stubArchiveMetrics{}
+	archiver := NewArchiver(testlog.Logger(t, log.LevelInfo), m, store)
+	inProgress := &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x01}},
+		Status:       gameTypes.GameStatusInProgress,
+	}
+	resolved := resolvedGame()
+
+	archiver.ArchiveGames([]*monTypes.EnrichedGameData{inProgress, resolved})
+	require.Equal(t, 1, m.archived)
+	_, err := store.Get(inProgress.Proxy)
+	require.ErrorIs(t, err, ErrNotFound)
+	game, err := store.Get(resolved.Proxy)
+	require.NoError(t, err)
+	require.Equal(t, NewGame(resolved), game)
+
+	// Games are only archived once
+	archiver.ArchiveGames([]*monTypes.EnrichedGameData{inProgress, resolved})
+	require.Equal(t, 1, m.archived)
+
+	// Previously archived games are detected after a restart
+	archiver = NewArchiver(testlog.Logger(t, log.LevelInfo), m, store)
+	archiver.ArchiveGames([]*monTypes.EnrichedGameData{resolved})
+	require.Equal(t, 1, m.archived)
+}
+
+func TestArchiveBondFlowsAfterResolution(t *testing.T) {
+	store := openTestStore(t)
+	m := &stubArchiveMetrics{}
+	archiver := NewArchiver(testlog.Logger(t, log.LevelInfo), m, store)
+	resolved := resolvedGame()
+	archiver.ArchiveGames([]*monTypes.EnrichedGameData{resolved})
+
+	// The credit is claimed, requesting a withdrawal from DelayedWETH
+	recipient := common.Address{0xc1}
+	resolved.Credits[recipient] = big.NewInt(0)
+	resolved.WithdrawalRequests = map[common.Address]*contracts.WithdrawalRequest{
+		recipient: {Amount: big.NewInt(300), Timestamp: big.NewInt(5000)},
+	}
+	archiver.ArchiveGames([]*monTypes.EnrichedGameData{resolved})
+	require.Equal(t, 1, m.archived, "should update rather than add the game")
+	game, err := store.Get(resolved.Proxy)
+	require.NoError(t, err)
+	require.Zero(t, game.Credits[recipient].ToInt().Sign())
+	require.Equal(t, Withdrawal{Amount: (*hexutil.Big)(big.NewInt(300)), Timestamp: 5000}, game.Withdrawals[recipient])
+
+	// Games that leave the monitoring window are no longer cached
+	archiver.ArchiveGames(nil)
+	require.Empty(t, archiver.archived)
+}
+
+func TestNewGame(t *testing.T) {
+	game := NewGame(resolvedGame())
+	require.Equal(t, common.Address{0x02}, game.Address)
+	require.Equal(t, common.Address{0xa1}, game.Proposer)
+	require.Equal(t, []common.Address{{0xc1}}, game.Challengers, "should only include claimants disputing the root claim")
+	require.Equal(t, OutcomeChallengerWon, game.Outcome)
+	require.True(t, game.ExpectedOutcome)
+	require.Len(t, game.Claims, 3)
+	require.Equal(t, common.Address{0xc1}, game.Claims[0].CounteredBy)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(3)), game.Claims[1].Position)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(200)), game.Claims[1].Bond)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(300)), game.Credits[common.Address{0xc1}])
+}
+
+func TestNewGame_BlockNumberChallenger(t *testing.T) {
+	data := resolvedGame()
+	data.BlockNumberChallenged = true
+	data.BlockNumberChallenger = common.Address{0xbb}
+	game := NewGame(data)
+	require.Equal(t, []common.Address{{0xbb}, {0xc1}}, game.Challengers)
+}
+
+func resolvedGame() *monTypes.EnrichedGameData {
+	claim := func(idx int, parent int, gIndex int64, claimant common.Address, counteredBy common.Address, bond int64) monTypes.EnrichedClaim {
+		return monTypes.EnrichedClaim{
+			Claim: faultTypes.Claim{
+				ClaimData: faultTypes.ClaimData{
+					Position: faultTypes.NewPositionFromGIndex(big.NewInt(gIndex)),
+					Bond:     big.NewInt(bond),
+				},
+				Claimant:            claimant,
+				CounteredBy:         counteredBy,
+				ContractIndex:       idx,
+				ParentContractIndex: parent,
+				Clock:               faultTypes.Clock{Timestamp: time.Unix(1000, 0)},
+			},
+			Resolved: true,
+		}
+	}
+	return &monTypes.EnrichedGameData{
+		GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x02}, Timestamp: 500},
+		Status:       gameTypes.GameStatusChallengerWon,
+		Claims: []monTypes.EnrichedClaim{
+			claim(0, 0, 1, common.Address{0xa1}, common.Address{0xc1}, 100),
+			claim(1, 0, 3, common.Address{0xc1}, common.Address{}, 200),
+			claim(2, 1, 6, common.Address{0xa1}, common.Address{}, 300),
+		},
+		Credits: map[common.Address]*big.Int{
+			{0xc1}: big.NewInt(300),
+		},
+	}
+}
+
+type stubArchiveMetrics struct {
+	archived int
+}
+
+func (s *stubArchiveMetrics) RecordArchivedGames(count int) {
+	s.archived += count
+}
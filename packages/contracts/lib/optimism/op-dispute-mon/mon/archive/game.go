@@ -0,0 +1,140 @@
+package archive
+
+import (
+	"math/big"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+type Outcome string
+
+const (
+	OutcomeChallengerWon Outcome = "challenger-won"
+	OutcomeDefenderWon   Outcome = "defender-won"
+)
+
+func outcomeFromStatus(status gameTypes.GameStatus) Outcome {
+	if status == gameTypes.GameStatusChallengerWon {
+		return OutcomeChallengerWon
+	}
+	return OutcomeDefenderWon
+}
+
+// Game is the archived final state of a resolved dispute game.
+type Game struct {
+	Address   common.Address `json:"address"`
+	GameType  uint32         `json:"gameType"`
+	Index     uint64         `json:"index"`
+	Timestamp uint64         `json:"timestamp"`
+
+	L1Head        common.Hash    `json:"l1Head"`
+	L2BlockNumber uint64         `json:"l2BlockNumber"`
+	RootClaim     common.Hash    `json:"rootClaim"`
+	Proposer      common.Address `json:"proposer"`
+	// Challengers are the actors that disputed the root claim, either by posting claims at odd depths
+	// or by challenging the L2 block number.
+	Challengers []common.Address `json:"challengers"`
+
+	Outcome               Outcome        `json:"outcome"`
+	AgreeWithClaim        bool           `json:"agreeWithClaim"`
+	ExpectedRootClaim     common.Hash    `json:"expectedRootClaim"`
+	ExpectedOutcome       bool           `json:"expectedOutcome"`
+	BlockNumberChallenged bool           `json:"blockNumberChallenged"`
+	BlockNumberChallenger common.Address `json:"blockNumberChallenger"`
+	MaxClockDuration      uint64         `json:"maxClockDuration"`
+
+	Claims []Claim `json:"claims"`
+
+	// Credits records the bonds paid out by the game that are yet to be claimed, keyed by recipient.
+	Credits map[common.Address]*hexutil.Big `json:"credits"`
+	// Withdrawals records the DelayedWETH withdrawal requests of claimed credits, keyed by recipient.
+	Withdrawals  map[common.Address]Withdrawal `json:"withdrawals"`
+	WETHContract common.Address                `json:"wethContract"`
+}
+
+type Withdrawal struct {
+	Amount    *hexutil.Big `json:"amount"`
+	Timestamp uint64       `json:"timestamp"`
+}
+
+type Claim struct {
+	Index         int            `json:"index"`
+	ParentIndex   int            `json:"parentIndex"`
+	Position      *hexutil.Big   `json:"position"`
+	Value         common.Hash    `json:"value"`
+	Claimant      common.Address `json:"claimant"`
+	CounteredBy   common.Address `json:"counteredBy"`
+	Bond          *hexutil.Big   `json:"bond"`
+	Resolved      bool           `json:"resolved"`
+	ClockDuration uint64         `json:"clockDuration"`
+	ClockUpdated  uint64         `json:"clockUpdated"`
+}
+
+// NewGame converts the enriched data of a resolved game to its archived form.
+func NewGame(game *types.EnrichedGameData) *Game {
+	archived := &Game{
+		Address:               game.Proxy,
+		GameType:              game.GameType,
+		Index:                 game.Index,
+		Timestamp:             game.Timestamp,
+		L1Head:                game.L1Head,
+		L2BlockNumber:         game.L2BlockNumber,
+		RootClaim:             game.RootClaim,
+		Outcome:               outcomeFromStatus(game.Status),
+		AgreeWithClaim:        game.AgreeWithClaim,
+		ExpectedRootClaim:     game.ExpectedRootClaim,
+		ExpectedOutcome:       (game.Status == gameTypes.GameStatusDefenderWon) == game.AgreeWithClaim,
+		BlockNumberChallenged: game.BlockNumberChallenged,
+		BlockNumberChallenger: game.BlockNumberChallenger,
+		MaxClockDuration:      game.MaxClockDuration,
+		Credits:               make(map[common.Address]*hexutil.Big, len(game.Credits)),
+		Withdrawals:           make(map[common.Address]Withdrawal, len(game.WithdrawalRequests)),
+		WETHContract:          game.WETHContract,
+	}
+	challengers := make(map[common.Address]bool)
+	if game.BlockNumberChallenged {
+		challengers[game.BlockNumberChallenger] = true
+		archived.Challengers = append(archived.Challengers, game.BlockNumberChallenger)
+	}
+	for _, claim := range game.Claims {
+		if claim.IsRoot() {
+			archived.Proposer = claim.Claimant
+		} else if claim.Depth()%2 == 1 && !challengers[claim.Claimant] {
+			// Claims at odd depths dispute the root claim
+			challengers[claim.Claimant] = true
+			archived.Challengers = append(archived.Challengers, claim.Claimant)
+		}
+		archived.Claims = append(archived.Claims, Claim{
+			Index:         claim.ContractIndex,
+			ParentIndex:   claim.ParentContractIndex,
+			Position:      (*hexutil.Big)(claim.Position.ToGIndex()),
+			Value:         claim.Value,
+			Claimant:      claim.Claimant,
+			CounteredBy:   claim.CounteredBy,
+			Bond:          (*hexutil.Big)(bigOrZero(claim.Bond)),
+			Resolved:      claim.Resolved,
+			ClockDuration: uint64(claim.Clock.Duration.Seconds()),
+			ClockUpdated:  uint64(claim.Clock.Timestamp.Unix()),
+		})
+	}
+	for recipient, amount := range game.Credits {
+		archived.Credits[recipient] = (*hexutil.Big)(bigOrZero(amount))
+	}
+	for recipient, request := range game.WithdrawalRequests {
+		archived.Withdrawals[recipient] = Withdrawal{
+			Amount:    (*hexutil.Big)(bigOrZero(request.Amount)),
+			Timestamp: bigOrZero(request.Timestamp).Uint64(),
+		}
+	}
+	return archived
+}
+
+func bigOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
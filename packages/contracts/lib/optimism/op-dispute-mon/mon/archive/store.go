@@ -0,0 +1,246 @@
+package archive
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrClosed   = errors.New("archive closed")
+)
+
+const (
+	// Keys are prefixed with a constant byte to differentiate the "columns" within the database
+	keyPrefixGame byte = iota
+	keyPrefixTimestamp
+	keyPrefixProposer
+	keyPrefixChallenger
+
+	// DefaultQueryLimit is the number of games returned by a query that doesn't specify a limit
+	DefaultQueryLimit = 100
+	// MaxQueryLimit is the maximum number of games returned by a single query
+	MaxQueryLimit = 1000
+)
+
+func gameKey(addr common.Address) []byte {
+	return append([]byte{keyPrefixGame}, addr.Bytes()...)
+}
+
+// indexKey creates an index key ordered by timestamp, optionally scoped to an actor address.
+func indexKey(prefix byte, actor *common.Address, timestamp uint64, game common.Address) []byte {
+	key := []byte{prefix}
+	if actor != nil {
+		key = append(key, actor.Bytes()...)
+	}
+	key = binary.BigEndian.AppendUint64(key, timestamp)
+	return append(key, game.Bytes()...)
+}
+
+// Filter selects the games returned by a query. Unset fields match all games.
+type Filter struct {
+	Proposer   *common.Address `json:"proposer,omitempty"`
+	Challenger *common.Address `json:"challenger,omitempty"`
+	Outcome    *Outcome        `json:"outcome,omitempty"`
+	// From and To limit the game creation timestamp to the inclusive range [From, To].
+	From  uint64 `json:"from,omitempty"`
+	To    uint64 `json:"to,omitempty"`
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+func (f Filter) matches(game *Game) bool {
+	if f.Proposer != nil && game.Proposer != *f.Proposer {
+		return false
+	}
+	if f.Challenger != nil && !slices.Contains(game.Challengers, *f.Challenger) {
+		return false
+	}
+	if f.Outcome != nil && game.Outcome != *f.Outcome {
+		return false
+	}
+	return true
+}
+
+// Store is an embedded database of archived games, indexed by creation time, proposer and challengers.
+type Store struct {
+	// m prevents closing the database while reads or writes are in progress
+	m  sync.RWMutex
+	db *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+func OpenStore(path string) (*Store, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %v: %w", path, err)
+	}
+	return &Store{
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+// Put stores the game, replacing any existing entry for the same game.
+func (s *Store) Put(game *Game) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	value, err := json.Marshal(game)
+	if err != nil {
+		return fmt.Errorf("failed to encode game %v: %w", game.Address, err)
+	}
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	if existing, err := s.get(game.Address); errors.Is(err, ErrNotFound) {
+		// Nothing to replace
+	} else if err != nil {
+		return err
+	} else {
+		for _, key := range indexKeys(existing) {
+			if err := batch.Delete(key, s.writeOpts); err != nil {
+				return fmt.Errorf("failed to delete index for game %v: %w", game.Address, err)
+			}
+		}
+	}
+	if err := batch.Set(gameKey(game.Address), value, s.writeOpts); err != nil {
+		return fmt.Errorf("failed to store game %v: %w", game.Address, err)
+	}
+	for _, key := range indexKeys(game) {
+		if err := batch.Set(key, nil, s.writeOpts); err != nil {
+			return fmt.Errorf("failed to index game %v: %w", game.Address, err)
+		}
+	}
+	if err := batch.Commit(s.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit game %v: %w", game.Address, err)
+	}
+	return nil
+}
+
+func indexKeys(game *Game) [][]byte {
+	keys := [][]byte{
+		indexKey(keyPrefixTimestamp, nil, game.Timestamp, game.Address),
+		indexKey(keyPrefixProposer, &game.Proposer, game.Timestamp, game.Address),
+	}
+	for _, challenger := range game.Challengers {
+		keys = append(keys, indexKey(keyPrefixChallenger, &challenger, game.Timestamp, game.Address))
+	}
+	return keys
+}
+
+// Has returns true if the game has been archived.
+func (s *Store) Has(addr common.Address) (bool, error) {
+	if _, err := s.Get(addr); errors.Is(err, ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Get returns the archived game or ErrNotFound if the game has not been archived.
+func (s *Store) Get(addr common.Address) (*Game, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	return s.get(addr)
+}
+
+func (s *Store) get(addr common.Address) (*Game, error) {
+	value, closer, err := s.db.Get(gameKey(addr))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read game %v: %w", addr, err)
+	}
+	defer closer.Close()
+	var game Game
+	if err := json.Unmarshal(value, &game); err != nil {
+		return nil, fmt.Errorf("failed to decode game %v: %w", addr, err)
+	}
+	return &game, nil
+}
+
+// Query returns the games matching the filter, ordered by creation timestamp.
+// The most selective index available is used to find candidate games which are then checked against the filter.
+func (s *Store) Query(ctx context.Context, filter Filter) ([]*Game, error) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultQueryLimit
+	}
+	limit = min(limit, MaxQueryLimit)
+	to := filter.To
+	if to == 0 {
+		to = math.MaxUint64
+	}
+
+	prefix := keyPrefixTimestamp
+	var actor *common.Address
+	if filter.Challenger != nil {
+		prefix = keyPrefixChallenger
+		actor = filter.Challenger
+	} else if filter.Proposer != nil {
+		prefix = keyPrefixProposer
+		actor = filter.Proposer
+	}
+	// Upper bound is exclusive so extend the last possible key to include it.
+	iter, err := s.db.NewIterWithContext(ctx, &pebble.IterOptions{
+		LowerBound: indexKey(prefix, actor, filter.From, common.Address{}),
+		UpperBound: append(indexKey(prefix, actor, to, common.MaxAddress), 0),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	games := make([]*Game, 0)
+	for valid := iter.First(); valid && uint64(len(games)) < limit; valid = iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		key := iter.Key()
+		addr := common.BytesToAddress(key[len(key)-common.AddressLength:])
+		game, err := s.get(addr)
+		if err != nil {
+			return nil, err
+		}
+		if filter.matches(game) {
+			games = append(games, game)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate archive: %w", err)
+	}
+	return games, nil
+}
+
+func (s *Store) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		// Already closed
+		return nil
+	}
+	s.closed = true
+	return s.db.Close()
+}
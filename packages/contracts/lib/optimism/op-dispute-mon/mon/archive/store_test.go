@@ -0,0 +1,130 @@
+package archive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	proposer1   = common.Address{0xa1}
+	proposer2   = common.Address{0xa2}
+	challenger1 = common.Address{0xc1}
+	challenger2 = common.Address{0xc2}
+)
+
+func TestStore_GetGame(t *testing.T) {
+	store := openTestStore(t)
+	game := &Game{Address: common.Address{0x01}, Timestamp: 100, Proposer: proposer1, Outcome: OutcomeDefenderWon}
+
+	_, err := store.Get(game.Address)
+	require.ErrorIs(t, err, ErrNotFound)
+	has, err := store.Has(game.Address)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, store.Put(game))
+	actual, err := store.Get(game.Address)
+	require.NoError(t, err)
+	require.Equal(t, game, actual)
+	has, err = store.Has(game.Address)
+	require.NoError(t, err)
+	require.True(t, has)
+}
+
+func TestStore_PersistAcrossRestarts(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(dir)
+	require.NoError(t, err)
+	game := &Game{Address: common.Address{0x01}, Timestamp: 100, Proposer: proposer1}
+	require.NoError(t, store.Put(game))
+	require.NoError(t, store.Close())
+
+	store, err = OpenStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+	actual, err := store.Get(game.Address)
+	require.NoError(t, err)
+	require.Equal(t, game, actual)
+}
+
+func TestStore_Query(t *testing.T) {
+	store := openTestStore(t)
+	game1 := &Game{Address: common.Address{0x01}, Timestamp: 100, Proposer: proposer1, Outcome: OutcomeDefenderWon}
+	game2 := &Game{Address: common.Address{0x02}, Timestamp: 200, Proposer: proposer2, Challengers: []common.Address{challenger1}, Outcome: OutcomeChallengerWon}
+	game3 := &Game{Address: common.Address{0x03}, Timestamp: 300, Proposer: proposer1, Challengers: []common.Address{challenger1, challenger2}, Outcome: OutcomeDefenderWon}
+	game4 := &Game{Address: common.Address{0x04}, Timestamp: 300, Proposer: proposer2, Challengers: []common.Address{challenger2}, Outcome: OutcomeChallengerWon}
+	// Insert out of order to check results are ordered by timestamp
+	for _, game := range []*Game{game3, game1, game4, game2} {
+		require.NoError(t, store.Put(game))
+	}
+	challengerWon := OutcomeChallengerWon
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []*Game
+	}{
+		{name: "All", filter: Filter{}, expected: []*Game{game1, game2, game3, game4}},
+		{name: "From", filter: Filter{From: 200}, expected: []*Game{game2, game3, game4}},
+		{name: "To", filter: Filter{To: 200}, expected: []*Game{game1, game2}},
+		{name: "Range", filter: Filter{From: 150, To: 250}, expected: []*Game{game2}},
+		{name: "Proposer", filter: Filter{Proposer: &proposer1}, expected: []*Game{game1, game3}},
+		{name: "Challenger", filter: Filter{Challenger: &challenger1}, expected: []*Game{game2, game3}},
+		{name: "Outcome", filter: Filter{Outcome: &challengerWon}, expected: []*Game{game2, game4}},
+		{name: "ProposerAndChallenger", filter: Filter{Proposer: &proposer2, Challenger: &challenger2}, expected: []*Game{game4}},
+		{name: "ChallengerAndRange", filter: Filter{Challenger: &challenger2, From: 300, To: 300}, expected: []*Game{game3, game4}},
+		{name: "Limit", filter: Filter{Limit: 2}, expected: []*Game{game1, game2}},
+		{name: "NoMatches", filter: Filter{From: 400}, expected: []*Game{}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			games, err := store.Query(context.Background(), test.filter)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, games)
+		})
+	}
+}
+
+func TestStore_ReplaceUpdatesIndexes(t *testing.T) {
+	store := openTestStore(t)
+	game := &Game{Address: common.Address{0x01}, Timestamp: 100, Proposer: proposer1, Challengers: []common.Address{challenger1}}
+	require.NoError(t, store.Put(game))
+
+	updated := &Game{Address: game.Address, Timestamp: 100, Proposer: proposer1, Challengers: []common.Address{challenger2}}
+	require.NoError(t, store.Put(updated))
+
+	games, err := store.Query(context.Background(), Filter{Challenger: &challenger1})
+	require.NoError(t, err)
+	require.Empty(t, games)
+	games, err = store.Query(context.Background(), Filter{Challenger: &challenger2})
+	require.NoError(t, err)
+	require.Equal(t, []*Game{updated}, games)
+	games, err = store.Query(context.Background(), Filter{})
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+}
+
+func TestStore_Closed(t *testing.T) {
+	store, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+	require.NoError(t, store.Close(), "should allow closing multiple times")
+	require.ErrorIs(t, store.Put(&Game{}), ErrClosed)
+	_, err = store.Get(common.Address{})
+	require.ErrorIs(t, err, ErrClosed)
+	_, err = store.Query(context.Background(), Filter{})
+	require.ErrorIs(t, err, ErrClosed)
+}
+
+func openTestStore(t *testing.T) *Store {
+	store, err := OpenStore(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+	})
+	return store
+}
@@ -2,70 +2,140 @@ package extract
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
-
-	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
-type OutputRollupClient interface {
-	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
-	SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error)
-}
+// DefaultOutputSourceTimeout is the maximum time to wait for each output source to check a root claim.
+const DefaultOutputSourceTimeout = 30 * time.Second
 
-type OutputMetrics interface {
-	RecordOutputFetchTime(float64)
+var (
+	ErrNoQuorum            = errors.New("output sources did not reach quorum")
+	ErrOutputSourceTimeout = errors.New("output source timed out")
+)
+
+// OutputSource checks a game's root claim against the output root it considers canonical.
+type OutputSource interface {
+	// Name identifies the source in logs and metrics.
+	Name() string
+	// CheckRootClaim returns the output root the source expects at the game's L2 block number and whether the
+	// source agrees with the game's root claim. The expected root is zero if the source has no output at that block.
+	CheckRootClaim(ctx context.Context, game *monTypes.EnrichedGameData) (common.Hash, bool, error)
 }
 
 type AgreementEnricher struct {
 	log     log.Logger
-	metrics OutputMetrics
-	client  OutputRollupClient
+	quorum  int
+	timeout time.Duration
+	sources []OutputSource
 }
 
-func NewAgreementEnricher(logger log.Logger, metrics OutputMetrics, client OutputRollupClient) *AgreementEnricher {
+// NewAgreementEnricher creates an enricher that checks root claims against each of the sources.
+// At least quorum sources must agree or disagree with the root claim for the game to be enriched.
+// A quorum of 0 requires a majority of the sources. Sources that don't respond within timeout are unavailable.
+func NewAgreementEnricher(logger log.Logger, quorum uint, timeout time.Duration, sources ...OutputSource) *AgreementEnricher {
+	if quorum == 0 {
+		quorum = uint(len(sources)/2 + 1)
+	}
 	return &AgreementEnricher{
 		log:     logger,
-		metrics: metrics,
-		client:  client,
+		quorum:  int(quorum),
+		timeout: timeout,
+		sources: sources,
 	}
 }
 
-// Enrich validates the specified root claim against the output at the given block number.
+type sourceResult struct {
+	index    int
+	expected common.Hash
+	agree    bool
+	err      error
+}
+
+// Enrich validates the specified root claim against the output at the given block number from each source.
 func (o *AgreementEnricher) Enrich(ctx context.Context, block rpcblock.Block, caller GameCaller, game *monTypes.EnrichedGameData) error {
-	output, err := o.client.OutputAtBlock(ctx, game.L2BlockNumber)
-	if err != nil {
-		// string match as the error comes from the remote server so we can't use Errors.Is sadly.
-		if strings.Contains(err.Error(), "not found") {
-			// Output root doesn't exist, so we must disagree with it.
-			game.AgreeWithClaim = false
-			return nil
+	results := o.checkSources(ctx, game)
+
+	game.OutputSources = make(map[string]monTypes.OutputSourceStatus, len(o.sources))
+	agreements := 0
+	disagreements := 0
+	// Votes for each expected root from sources that disagree, in the order the root was first seen
+	var expectedRoots []common.Hash
+	votes := make(map[common.Hash]int)
+	var errs []error
+	for i, result := range results {
+		name := o.sources[i].Name()
+		switch {
+		case result.err != nil:
+			o.log.Warn("Failed to check root claim against output source", "game", game.Proxy, "source", name, "err", result.err)
+			game.OutputSources[name] = monTypes.OutputSourceUnavailable
+			errs = append(errs, fmt.Errorf("%v: %w", name, result.err))
+		case result.agree:
+			game.OutputSources[name] = monTypes.OutputSourceAgree
+			agreements++
+		default:
+			game.OutputSources[name] = monTypes.OutputSourceDisagree
+			disagreements++
+			if votes[result.expected] == 0 {
+				expectedRoots = append(expectedRoots, result.expected)
+			}
+			votes[result.expected]++
 		}
-		return fmt.Errorf("failed to get output at block: %w", err)
 	}
-	o.metrics.RecordOutputFetchTime(float64(time.Now().Unix()))
-	game.ExpectedRootClaim = common.Hash(output.OutputRoot)
-	rootMatches := game.RootClaim == game.ExpectedRootClaim
-	if !rootMatches {
+
+	if agreements >= o.quorum {
+		game.AgreeWithClaim = true
+		game.ExpectedRootClaim = game.RootClaim
+		return nil
+	}
+	if disagreements >= o.quorum {
 		game.AgreeWithClaim = false
+		game.ExpectedRootClaim = expectedRoots[0]
+		for _, root := range expectedRoots[1:] {
+			if votes[root] > votes[game.ExpectedRootClaim] {
+				game.ExpectedRootClaim = root
+			}
+		}
 		return nil
 	}
+	return fmt.Errorf("%w (%v agree, %v disagree, quorum %v): %w", ErrNoQuorum, agreements, disagreements, o.quorum, errors.Join(errs...))
+}
 
-	// If the root matches, also check that l2 block is safe at the L1 head
-	safeHead, err := o.client.SafeHeadAtL1Block(ctx, game.L1HeadNum)
-	if err != nil {
-		o.log.Warn("Unable to verify proposed block was safe", "l1HeadNum", game.L1HeadNum, "l2BlockNum", game.L2BlockNumber, "err", err)
-		// If safe head data isn't available, assume the output root was safe
-		// Avoids making the dispute mon dependent on safe head db being available
-		game.AgreeWithClaim = true
-		return nil
+// checkSources checks the root claim against each source concurrently, each with its own timeout.
+// Sources that haven't responded by the timeout are reported as failed with ErrOutputSourceTimeout,
+// even if they ignore the cancelled context.
+func (o *AgreementEnricher) checkSources(ctx context.Context, game *monTypes.EnrichedGameData) []sourceResult {
+	results := make([]sourceResult, len(o.sources))
+	for i := range results {
+		results[i] = sourceResult{index: i, err: fmt.Errorf("%w after %v", ErrOutputSourceTimeout, o.timeout)}
+	}
+	// Buffered so sources that respond after the timeout don't block.
+	// Sources check a copy of the game so a late response can't race with later updates to it.
+	resultsCh := make(chan sourceResult, len(o.sources))
+	gameCopy := *game
+	for i, source := range o.sources {
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, o.timeout)
+			defer cancel()
+			expected, agree, err := source.CheckRootClaim(ctx, &gameCopy)
+			resultsCh <- sourceResult{index: i, expected: expected, agree: agree, err: err}
+		}()
+	}
+	timeout := time.NewTimer(o.timeout)
+	defer timeout.Stop()
+	for range o.sources {
+		select {
+		case result := <-resultsCh:
+			results[result.index] = result
+		case <-timeout.C:
+			return results
+		}
 	}
-	game.AgreeWithClaim = safeHead.SafeHead.Number >= game.L2BlockNumber
-	return nil
+	return results
 }
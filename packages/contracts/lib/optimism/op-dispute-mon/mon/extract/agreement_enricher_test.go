@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -136,11 +137,103 @@ func TestDetector_CheckRootAgreement(t *testing.T) {
 	})
 }
 
+func TestAgreementEnricher_Quorum(t *testing.T) {
+	t.Parallel()
+
+	otherRoot := common.HexToHash("0x5678")
+	agree := &stubOutputSource{name: "agree", expected: mockRootClaim, agree: true}
+	disagree := &stubOutputSource{name: "disagree", expected: otherRoot}
+	notFound := &stubOutputSource{name: "notFound"}
+	unavailable := &stubOutputSource{name: "unavailable", err: errors.New("boom")}
+	unsafe := &stubOutputSource{name: "unsafe", expected: mockRootClaim}
+
+	tests := []struct {
+		name              string
+		quorum            uint
+		sources           []OutputSource
+		expectErr         bool
+		expectAgree       bool
+		expectedRootClaim common.Hash
+	}{
+		{name: "AllAgree", sources: []OutputSource{agree, agree, agree}, expectAgree: true, expectedRootClaim: mockRootClaim},
+		{name: "MajorityAgree", sources: []OutputSource{agree, disagree, agree}, expectAgree: true, expectedRootClaim: mockRootClaim},
+		{name: "MajorityDisagree", sources: []OutputSource{disagree, agree, disagree}, expectedRootClaim: otherRoot},
+		{name: "AgreeWithUnavailable", sources: []OutputSource{agree, unavailable, agree}, expectAgree: true, expectedRootClaim: mockRootClaim},
+		{name: "NoMajority", sources: []OutputSource{agree, unavailable, disagree}, expectErr: true},
+		{name: "AllUnavailable", sources: []OutputSource{unavailable, unavailable}, expectErr: true},
+		{name: "ExplicitQuorum", quorum: 3, sources: []OutputSource{agree, agree, disagree, agree}, expectAgree: true, expectedRootClaim: mockRootClaim},
+		{name: "ExplicitQuorumNotReached", quorum: 3, sources: []OutputSource{agree, agree, disagree, unavailable}, expectErr: true},
+		// Disagreeing sources may expect different roots, the most common expected root is reported
+		{name: "MostCommonExpectedRoot", sources: []OutputSource{notFound, disagree, disagree}, expectedRootClaim: otherRoot},
+		{name: "TiedExpectedRootUsesFirst", sources: []OutputSource{unsafe, disagree, agree}, expectedRootClaim: mockRootClaim},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			logger := testlog.Logger(t, log.LvlInfo)
+			enricher := NewAgreementEnricher(logger, test.quorum, time.Minute, test.sources...)
+			game := &types.EnrichedGameData{RootClaim: mockRootClaim}
+			err := enricher.Enrich(context.Background(), rpcblock.Latest, nil, game)
+			if test.expectErr {
+				require.ErrorIs(t, err, ErrNoQuorum)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expectAgree, game.AgreeWithClaim)
+			require.Equal(t, test.expectedRootClaim, game.ExpectedRootClaim)
+		})
+	}
+
+	t.Run("RecordSourceStatus", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		enricher := NewAgreementEnricher(logger, 0, time.Minute, agree, disagree, unavailable, unsafe, notFound)
+		game := &types.EnrichedGameData{RootClaim: mockRootClaim}
+		require.NoError(t, enricher.Enrich(context.Background(), rpcblock.Latest, nil, game))
+		require.Equal(t, map[string]types.OutputSourceStatus{
+			"agree":       types.OutputSourceAgree,
+			"disagree":    types.OutputSourceDisagree,
+			"unavailable": types.OutputSourceUnavailable,
+			"unsafe":      types.OutputSourceDisagree,
+			"notFound":    types.OutputSourceDisagree,
+		}, game.OutputSources)
+	})
+
+	t.Run("IncludeSourceErrors", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		enricher := NewAgreementEnricher(logger, 0, time.Minute, unavailable)
+		err := enricher.Enrich(context.Background(), rpcblock.Latest, nil, &types.EnrichedGameData{})
+		require.ErrorIs(t, err, ErrNoQuorum)
+		require.ErrorIs(t, err, unavailable.err)
+	})
+
+	t.Run("HungSourceTimesOut", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		// The hung source ignores context cancellation so the enricher must stop waiting for it
+		hung := &stubOutputSource{name: "hung", hang: make(chan struct{})}
+		defer close(hung.hang)
+		enricher := NewAgreementEnricher(logger, 0, 10*time.Millisecond, agree, hung, agree)
+		game := &types.EnrichedGameData{RootClaim: mockRootClaim}
+		require.NoError(t, enricher.Enrich(context.Background(), rpcblock.Latest, nil, game))
+		require.True(t, game.AgreeWithClaim)
+		require.Equal(t, types.OutputSourceUnavailable, game.OutputSources["hung"])
+	})
+
+	t.Run("HungSourceFailsQuorum", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		hung := &stubOutputSource{name: "hung", hang: make(chan struct{})}
+		defer close(hung.hang)
+		enricher := NewAgreementEnricher(logger, 2, 10*time.Millisecond, agree, hung)
+		err := enricher.Enrich(context.Background(), rpcblock.Latest, nil, &types.EnrichedGameData{RootClaim: mockRootClaim})
+		require.ErrorIs(t, err, ErrNoQuorum)
+		require.ErrorIs(t, err, ErrOutputSourceTimeout)
+	})
+}
+
 func setupOutputValidatorTest(t *testing.T) (*AgreementEnricher, *stubRollupClient, *stubOutputMetrics) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	client := &stubRollupClient{safeHeadNum: 99999999999}
 	metrics := &stubOutputMetrics{}
-	validator := NewAgreementEnricher(logger, metrics, client)
+	validator := NewAgreementEnricher(logger, 1, time.Minute, NewRollupOutputSource("rollup", logger, metrics, client))
 	return validator, client, metrics
 }
 
@@ -152,6 +245,25 @@ func (s *stubOutputMetrics) RecordOutputFetchTime(fetchTime float64) {
 	s.fetchTime = fetchTime
 }
 
+type stubOutputSource struct {
+	name     string
+	expected common.Hash
+	agree    bool
+	err      error
+	hang     chan struct{}
+}
+
+func (s *stubOutputSource) Name() string {
+	return s.name
+}
+
+func (s *stubOutputSource) CheckRootClaim(_ context.Context, _ *types.EnrichedGameData) (common.Hash, bool, error) {
+	if s.hang != nil {
+		<-s.hang
+	}
+	return s.expected, s.agree, s.err
+}
+
 type stubRollupClient struct {
 	blockNum    uint64
 	outputErr   error
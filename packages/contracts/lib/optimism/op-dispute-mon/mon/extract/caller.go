@@ -30,6 +30,7 @@ type GameCaller interface {
 	BondCaller
 	BalanceCaller
 	ClaimCaller
+	DepthCaller
 }
 
 type GameCallerCreator struct {
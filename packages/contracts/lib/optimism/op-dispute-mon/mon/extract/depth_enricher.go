@@ -0,0 +1,57 @@
+package extract
+
+import (
+	"context"
+	"fmt"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ Enricher = (*DepthEnricher)(nil)
+
+const depthCacheSize = 1000
+
+type DepthCaller interface {
+	GetSplitDepth(ctx context.Context) (faultTypes.Depth, error)
+	GetMaxGameDepth(ctx context.Context) (faultTypes.Depth, error)
+}
+
+type gameDepths struct {
+	split faultTypes.Depth
+	max   faultTypes.Depth
+}
+
+// DepthEnricher sets the split and max depth of games.
+// The depths are immutable so are cached to avoid fetching them again each update.
+type DepthEnricher struct {
+	cache *caching.LRUCache[common.Address, gameDepths]
+}
+
+func NewDepthEnricher() *DepthEnricher {
+	return &DepthEnricher{
+		cache: caching.NewLRUCache[common.Address, gameDepths](nil, "", depthCacheSize),
+	}
+}
+
+func (e *DepthEnricher) Enrich(ctx context.Context, _ rpcblock.Block, caller GameCaller, game *monTypes.EnrichedGameData) error {
+	depths, ok := e.cache.Get(game.Proxy)
+	if !ok {
+		splitDepth, err := caller.GetSplitDepth(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve split depth: %w", err)
+		}
+		maxDepth, err := caller.GetMaxGameDepth(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve max depth: %w", err)
+		}
+		depths = gameDepths{split: splitDepth, max: maxDepth}
+		e.cache.Add(game.Proxy, depths)
+	}
+	game.SplitDepth = depths.split
+	game.MaxDepth = depths.max
+	return nil
+}
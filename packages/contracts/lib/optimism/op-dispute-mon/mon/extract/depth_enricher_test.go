@@ -0,0 +1,52 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepthEnricher(t *testing.T) {
+	t.Run("FetchesDepths", func(t *testing.T) {
+		caller := &mockGameCaller{splitDepth: 30, maxDepth: 73}
+		enricher := NewDepthEnricher()
+		game := &types.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x01}}}
+		require.NoError(t, enricher.Enrich(context.Background(), rpcblock.Latest, caller, game))
+		require.Equal(t, faultTypes.Depth(30), game.SplitDepth)
+		require.Equal(t, faultTypes.Depth(73), game.MaxDepth)
+		require.Equal(t, 1, caller.depthCalls)
+	})
+
+	t.Run("CachesDepths", func(t *testing.T) {
+		caller := &mockGameCaller{splitDepth: 30, maxDepth: 73}
+		enricher := NewDepthEnricher()
+		game := &types.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x01}}}
+		require.NoError(t, enricher.Enrich(context.Background(), rpcblock.Latest, caller, game))
+		game = &types.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x01}}}
+		require.NoError(t, enricher.Enrich(context.Background(), rpcblock.Latest, caller, game))
+		require.Equal(t, faultTypes.Depth(30), game.SplitDepth)
+		require.Equal(t, faultTypes.Depth(73), game.MaxDepth)
+		require.Equal(t, 1, caller.depthCalls)
+
+		// Different games are fetched separately
+		game = &types.EnrichedGameData{GameMetadata: gameTypes.GameMetadata{Proxy: common.Address{0x02}}}
+		require.NoError(t, enricher.Enrich(context.Background(), rpcblock.Latest, caller, game))
+		require.Equal(t, 2, caller.depthCalls)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		caller := &mockGameCaller{depthErr: expectedErr}
+		enricher := NewDepthEnricher()
+		game := &types.EnrichedGameData{}
+		err := enricher.Enrich(context.Background(), rpcblock.Latest, caller, game)
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
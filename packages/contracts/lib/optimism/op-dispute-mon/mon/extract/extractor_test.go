@@ -292,6 +292,10 @@ type mockGameCaller struct {
 	withdrawals      []*contracts.WithdrawalRequest
 	resolvedErr      error
 	resolved         map[int]bool
+	depthCalls       int
+	depthErr         error
+	splitDepth       faultTypes.Depth
+	maxDepth         faultTypes.Depth
 }
 
 func (m *mockGameCaller) GetWithdrawals(_ context.Context, _ rpcblock.Block, _ ...common.Address) ([]*contracts.WithdrawalRequest, error) {
@@ -357,6 +361,21 @@ func (m *mockGameCaller) GetBalanceAndDelay(_ context.Context, _ rpcblock.Block)
 	return m.balance, m.delayDuration, m.balanceAddr, nil
 }
 
+func (m *mockGameCaller) GetSplitDepth(_ context.Context) (faultTypes.Depth, error) {
+	m.depthCalls++
+	if m.depthErr != nil {
+		return 0, m.depthErr
+	}
+	return m.splitDepth, nil
+}
+
+func (m *mockGameCaller) GetMaxGameDepth(_ context.Context) (faultTypes.Depth, error) {
+	if m.depthErr != nil {
+		return 0, m.depthErr
+	}
+	return m.maxDepth, nil
+}
+
 func (m *mockGameCaller) IsResolved(_ context.Context, _ rpcblock.Block, claims ...faultTypes.Claim) ([]bool, error) {
 	if m.resolvedErr != nil {
 		return nil, m.resolvedErr
@@ -0,0 +1,168 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type SafeHeadProvider interface {
+	SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error)
+}
+
+type OutputRollupClient interface {
+	SafeHeadProvider
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+type OutputMetrics interface {
+	RecordOutputFetchTime(float64)
+}
+
+// RollupOutputSource checks root claims against the output roots of a rollup node.
+type RollupOutputSource struct {
+	name    string
+	log     log.Logger
+	metrics OutputMetrics
+	client  OutputRollupClient
+}
+
+var _ OutputSource = (*RollupOutputSource)(nil)
+
+func NewRollupOutputSource(name string, logger log.Logger, metrics OutputMetrics, client OutputRollupClient) *RollupOutputSource {
+	return &RollupOutputSource{
+		name:    name,
+		log:     logger,
+		metrics: metrics,
+		client:  client,
+	}
+}
+
+func (r *RollupOutputSource) Name() string {
+	return r.name
+}
+
+// CheckRootClaim validates the root claim against the output at the game's block number. The root claim is only
+// agreed with if the block was also safe at the game's L1 head.
+func (r *RollupOutputSource) CheckRootClaim(ctx context.Context, game *monTypes.EnrichedGameData) (common.Hash, bool, error) {
+	output, err := r.client.OutputAtBlock(ctx, game.L2BlockNumber)
+	if err != nil {
+		// string match as the error comes from the remote server so we can't use Errors.Is sadly.
+		if strings.Contains(err.Error(), "not found") {
+			// Output root doesn't exist, so we must disagree with it.
+			return common.Hash{}, false, nil
+		}
+		return common.Hash{}, false, fmt.Errorf("failed to get output at block: %w", err)
+	}
+	r.metrics.RecordOutputFetchTime(float64(time.Now().Unix()))
+	expected := common.Hash(output.OutputRoot)
+	if game.RootClaim != expected {
+		return expected, false, nil
+	}
+
+	// If the root matches, also check that l2 block is safe at the L1 head
+	return expected, safeAtL1Head(ctx, r.log, r.client, game), nil
+}
+
+// safeAtL1Head returns true if the game's L2 block was safe at the game's L1 head.
+func safeAtL1Head(ctx context.Context, logger log.Logger, safeHeads SafeHeadProvider, game *monTypes.EnrichedGameData) bool {
+	safeHead, err := safeHeads.SafeHeadAtL1Block(ctx, game.L1HeadNum)
+	if err != nil {
+		logger.Warn("Unable to verify proposed block was safe", "l1HeadNum", game.L1HeadNum, "l2BlockNum", game.L2BlockNumber, "err", err)
+		// If safe head data isn't available, assume the output root was safe
+		// Avoids making the dispute mon dependent on safe head db being available
+		return true
+	}
+	return safeHead.SafeHead.Number >= game.L2BlockNumber
+}
+
+type L2ProofClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+// L2OutputSource checks root claims against output roots recomputed from the state of an L2 execution client.
+// The execution client doesn't know which blocks were safe, so the safe head at the game's L1 head is looked up
+// from a rollup node, in the same way as RollupOutputSource.
+type L2OutputSource struct {
+	name      string
+	log       log.Logger
+	client    L2ProofClient
+	safeHeads SafeHeadProvider
+}
+
+var _ OutputSource = (*L2OutputSource)(nil)
+
+func NewL2OutputSource(name string, logger log.Logger, client L2ProofClient, safeHeads SafeHeadProvider) *L2OutputSource {
+	return &L2OutputSource{
+		name:      name,
+		log:       logger,
+		client:    client,
+		safeHeads: safeHeads,
+	}
+}
+
+func (l *L2OutputSource) Name() string {
+	return l.name
+}
+
+// CheckRootClaim validates the root claim against the output recomputed at the game's block number. The root claim is
+// only agreed with if the block was also safe at the game's L1 head.
+func (l *L2OutputSource) CheckRootClaim(ctx context.Context, game *monTypes.EnrichedGameData) (common.Hash, bool, error) {
+	header, err := l.client.HeaderByNumber(ctx, new(big.Int).SetUint64(game.L2BlockNumber))
+	if errors.Is(err, ethereum.NotFound) {
+		// Block doesn't exist, so we must disagree with the output root.
+		return common.Hash{}, false, nil
+	} else if err != nil {
+		return common.Hash{}, false, fmt.Errorf("failed to get L2 block header: %w", err)
+	}
+	blockHash := header.Hash()
+	proof, err := l.client.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, blockHash.Hex())
+	if err != nil {
+		return common.Hash{}, false, fmt.Errorf("failed to get message passer proof at block %v: %w", blockHash, err)
+	}
+	if err := proof.Verify(header.Root); err != nil {
+		return common.Hash{}, false, fmt.Errorf("invalid message passer proof at block %v: %w", blockHash, err)
+	}
+	expected := common.Hash(eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(proof.StorageHash),
+		BlockHash:                blockHash,
+	}))
+	if game.RootClaim != expected {
+		return expected, false, nil
+	}
+	return expected, safeAtL1Head(ctx, l.log, l.safeHeads, game), nil
+}
+
+// ethProofClient adds eth_getProof support to an ethclient.Client.
+type ethProofClient struct {
+	*ethclient.Client
+}
+
+func NewL2ProofClient(client *ethclient.Client) L2ProofClient {
+	return &ethProofClient{client}
+}
+
+func (c *ethProofClient) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	var result *eth.AccountResult
+	if err := c.Client.Client().CallContext(ctx, &result, "eth_getProof", address, storage, blockTag); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, nil
+}
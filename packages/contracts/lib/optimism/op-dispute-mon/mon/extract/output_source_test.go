@@ -0,0 +1,172 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL2OutputSource(t *testing.T) {
+	storageRoot := common.Hash{0xaa}
+	t.Run("Agree", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, &stubRollupClient{safeHeadNum: 42})
+		game := &monTypes.EnrichedGameData{L2BlockNumber: 42, RootClaim: client.outputRoot()}
+		expected, agree, err := source.CheckRootClaim(context.Background(), game)
+		require.NoError(t, err)
+		require.True(t, agree)
+		require.Equal(t, client.outputRoot(), expected)
+		require.Equal(t, "l2", source.Name())
+	})
+
+	t.Run("Disagree", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, &stubRollupClient{safeHeadNum: 42})
+		game := &monTypes.EnrichedGameData{L2BlockNumber: 42, RootClaim: common.Hash{0xbb}}
+		expected, agree, err := source.CheckRootClaim(context.Background(), game)
+		require.NoError(t, err)
+		require.False(t, agree)
+		require.Equal(t, client.outputRoot(), expected)
+	})
+
+	t.Run("NotSafeAtL1Head", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, &stubRollupClient{safeHeadNum: 41})
+		game := &monTypes.EnrichedGameData{L2BlockNumber: 42, RootClaim: client.outputRoot()}
+		expected, agree, err := source.CheckRootClaim(context.Background(), game)
+		require.NoError(t, err)
+		require.False(t, agree)
+		require.Equal(t, client.outputRoot(), expected)
+	})
+
+	t.Run("SafeHeadUnavailable", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		safeHeads := &stubRollupClient{safeHeadErr: errors.New("boom")}
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, safeHeads)
+		game := &monTypes.EnrichedGameData{L2BlockNumber: 42, RootClaim: client.outputRoot()}
+		_, agree, err := source.CheckRootClaim(context.Background(), game)
+		require.NoError(t, err)
+		require.True(t, agree, "should assume the block was safe like the rollup source")
+	})
+
+	t.Run("BlockNotFound", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		client.headerErr = ethereum.NotFound
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, &stubRollupClient{safeHeadNum: 42})
+		game := &monTypes.EnrichedGameData{L2BlockNumber: 42, RootClaim: client.outputRoot()}
+		expected, agree, err := source.CheckRootClaim(context.Background(), game)
+		require.NoError(t, err)
+		require.False(t, agree)
+		require.Equal(t, common.Hash{}, expected)
+	})
+
+	t.Run("HeaderError", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		client.headerErr = errors.New("boom")
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, &stubRollupClient{safeHeadNum: 42})
+		_, _, err := source.CheckRootClaim(context.Background(), &monTypes.EnrichedGameData{L2BlockNumber: 42})
+		require.ErrorIs(t, err, client.headerErr)
+	})
+
+	t.Run("ProofError", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		client.proofErr = errors.New("boom")
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, &stubRollupClient{safeHeadNum: 42})
+		_, _, err := source.CheckRootClaim(context.Background(), &monTypes.EnrichedGameData{L2BlockNumber: 42})
+		require.ErrorIs(t, err, client.proofErr)
+	})
+
+	t.Run("InvalidProof", func(t *testing.T) {
+		client := newStubL2ProofClient(t, 42, storageRoot)
+		// Storage root doesn't match the proof
+		client.proof.StorageHash = common.Hash{0xcc}
+		source := NewL2OutputSource("l2", testlog.Logger(t, log.LevelInfo), client, &stubRollupClient{safeHeadNum: 42})
+		_, _, err := source.CheckRootClaim(context.Background(), &monTypes.EnrichedGameData{L2BlockNumber: 42})
+		require.ErrorContains(t, err, "invalid message passer proof")
+	})
+}
+
+type stubL2ProofClient struct {
+	header    *types.Header
+	proof     *eth.AccountResult
+	headerErr error
+	proofErr  error
+}
+
+// newStubL2ProofClient creates a client for a block whose state contains only the message passer account.
+func newStubL2ProofClient(t *testing.T, blockNum uint64, storageRoot common.Hash) *stubL2ProofClient {
+	codeHash := crypto.Keccak256Hash([]byte{0x01})
+	account, err := rlp.EncodeToBytes([]any{uint64(0), []byte{}, storageRoot, codeHash})
+	require.NoError(t, err)
+	key := crypto.Keccak256(predeploys.L2ToL1MessagePasserAddr.Bytes())
+	state := trie.NewEmpty(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	state.MustUpdate(key, account)
+
+	proofDB := memorydb.New()
+	require.NoError(t, state.Prove(key, proofDB))
+	var accountProof []hexutil.Bytes
+	it := proofDB.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		accountProof = append(accountProof, common.CopyBytes(it.Value()))
+	}
+	return &stubL2ProofClient{
+		header: &types.Header{
+			Number: new(big.Int).SetUint64(blockNum),
+			Root:   state.Hash(),
+		},
+		proof: &eth.AccountResult{
+			AccountProof: accountProof,
+			Address:      predeploys.L2ToL1MessagePasserAddr,
+			Balance:      (*hexutil.Big)(new(big.Int)),
+			CodeHash:     codeHash,
+			StorageHash:  storageRoot,
+		},
+	}
+}
+
+func (s *stubL2ProofClient) outputRoot() common.Hash {
+	return common.Hash(eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(s.header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(s.proof.StorageHash),
+		BlockHash:                s.header.Hash(),
+	}))
+}
+
+func (s *stubL2ProofClient) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if s.headerErr != nil {
+		return nil, s.headerErr
+	}
+	if number.Cmp(s.header.Number) != 0 {
+		return nil, ethereum.NotFound
+	}
+	return s.header, nil
+}
+
+func (s *stubL2ProofClient) GetProof(_ context.Context, address common.Address, _ []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	if s.proofErr != nil {
+		return nil, s.proofErr
+	}
+	if address != predeploys.L2ToL1MessagePasserAddr || blockTag != s.header.Hash().Hex() {
+		return nil, errors.New("unexpected proof request")
+	}
+	return s.proof, nil
+}
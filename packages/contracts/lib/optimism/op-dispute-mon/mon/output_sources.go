@@ -0,0 +1,53 @@
+package mon
+
+import (
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type OutputSourceMetrics interface {
+	RecordOutputSourceStatus(source string, status string, count int)
+	RecordOutputSourceDisagreements(source string, count int)
+}
+
+// OutputSourceMonitor reports how each output source assessed the root claims of monitored games and how often
+// each source disagreed with the quorum of sources, identifying sources that are lagging or faulty.
+type OutputSourceMonitor struct {
+	logger  log.Logger
+	metrics OutputSourceMetrics
+	sources []string
+}
+
+func NewOutputSourceMonitor(logger log.Logger, metrics OutputSourceMetrics, sources []string) *OutputSourceMonitor {
+	return &OutputSourceMonitor{
+		logger:  logger,
+		metrics: metrics,
+		sources: sources,
+	}
+}
+
+func (m *OutputSourceMonitor) CheckOutputSources(games []*types.EnrichedGameData) {
+	for _, source := range m.sources {
+		statusCounts := make(map[types.OutputSourceStatus]int)
+		disagreements := 0
+		for _, game := range games {
+			status, ok := game.OutputSources[source]
+			if !ok {
+				continue
+			}
+			statusCounts[status]++
+			if status != types.OutputSourceUnavailable && (status == types.OutputSourceAgree) != game.AgreeWithClaim {
+				m.logger.Debug("Output source disagrees with quorum", "source", source, "game", game.Proxy,
+					"blockNum", game.L2BlockNumber, "status", status, "agreement", game.AgreeWithClaim)
+				disagreements++
+			}
+		}
+		for _, status := range []types.OutputSourceStatus{types.OutputSourceAgree, types.OutputSourceDisagree, types.OutputSourceUnavailable} {
+			m.metrics.RecordOutputSourceStatus(source, status.String(), statusCounts[status])
+		}
+		m.metrics.RecordOutputSourceDisagreements(source, disagreements)
+		if disagreements > 0 {
+			m.logger.Warn("Output source disagrees with quorum", "source", source, "games", disagreements)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package mon
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckOutputSources(t *testing.T) {
+	games := []*types.EnrichedGameData{
+		{AgreeWithClaim: true, OutputSources: map[string]types.OutputSourceStatus{
+			"a": types.OutputSourceAgree,
+			"b": types.OutputSourceAgree,
+			"c": types.OutputSourceDisagree,
+		}},
+		{AgreeWithClaim: false, OutputSources: map[string]types.OutputSourceStatus{
+			"a": types.OutputSourceDisagree,
+			"b": types.OutputSourceUnavailable,
+			"c": types.OutputSourceDisagree,
+		}},
+		{AgreeWithClaim: true, OutputSources: map[string]types.OutputSourceStatus{
+			"a": types.OutputSourceAgree,
+			"b": types.OutputSourceAgree,
+			"c": types.OutputSourceUnavailable,
+		}},
+		// Enriched before the output source was configured
+		{AgreeWithClaim: true},
+	}
+	metrics := &stubOutputSourceMetrics{
+		statuses:      make(map[string]map[string]int),
+		disagreements: make(map[string]int),
+	}
+	logger, logs := testlog.CaptureLogger(t, log.LvlInfo)
+	monitor := NewOutputSourceMonitor(logger, metrics, []string{"a", "b", "c", "d"})
+	monitor.CheckOutputSources(games)
+
+	require.Equal(t, map[string]map[string]int{
+		"a": {"agree": 2, "disagree": 1, "unavailable": 0},
+		"b": {"agree": 2, "disagree": 0, "unavailable": 1},
+		"c": {"agree": 0, "disagree": 2, "unavailable": 1},
+		"d": {"agree": 0, "disagree": 0, "unavailable": 0},
+	}, metrics.statuses)
+	require.Equal(t, map[string]int{"a": 0, "b": 0, "c": 1, "d": 0}, metrics.disagreements)
+
+	l := logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("Output source disagrees with quorum"))
+	require.NotNil(t, l)
+	require.Equal(t, "c", l.AttrValue("source"))
+	require.EqualValues(t, 1, l.AttrValue("games"))
+}
+
+type stubOutputSourceMetrics struct {
+	statuses      map[string]map[string]int
+	disagreements map[string]int
+}
+
+func (s *stubOutputSourceMetrics) RecordOutputSourceStatus(source string, status string, count int) {
+	if s.statuses[source] == nil {
+		s.statuses[source] = make(map[string]int)
+	}
+	s.statuses[source][status] = count
+}
+
+func (s *stubOutputSourceMetrics) RecordOutputSourceDisagreements(source string, count int) {
+	s.disagreements[source] = count
+}
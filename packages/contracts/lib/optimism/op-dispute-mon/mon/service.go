@@ -5,13 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"strings"
 	"sync/atomic"
 
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/alerts"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/archive"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/bonds"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/treeview"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
@@ -24,10 +30,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 )
 
+// treeViewPath is the path the claim tree view is served at on the RPC server
+const treeViewPath = "/tree/"
+
 type Service struct {
 	logger       log.Logger
 	metrics      metrics.Metricer
@@ -38,19 +48,26 @@ type Service struct {
 
 	cl clock.Clock
 
-	extractor    *extract.Extractor
-	forecast     *Forecast
-	bonds        *bonds.Bonds
-	game         *extract.GameCallerCreator
-	resolutions  *ResolutionMonitor
-	claims       *ClaimMonitor
-	withdrawals  *WithdrawalMonitor
-	rollupClient *sources.RollupClient
+	extractor     *extract.Extractor
+	forecast      *Forecast
+	bonds         *bonds.Bonds
+	game          *extract.GameCallerCreator
+	resolutions   *ResolutionMonitor
+	claims        *ClaimMonitor
+	withdrawals   *WithdrawalMonitor
+	alerter       *alerts.Alerter
+	archive       *archive.Store
+	archiver      *archive.Archiver
+	rollupClients []*sources.RollupClient
+	l2Client      *ethclient.Client
+	outputSources []extract.OutputSource
 
 	l1Client *ethclient.Client
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	rpcServer    *oprpc.Server
+	treeView     *treeview.Viewer
 
 	stopped atomic.Bool
 }
@@ -84,8 +101,8 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initFactoryContract(cfg); err != nil {
 		return fmt.Errorf("failed to create factory contract bindings: %w", err)
 	}
-	if err := s.initOutputRollupClient(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init rollup client: %w", err)
+	if err := s.initOutputSources(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init output sources: %w", err)
 	}
 
 	s.initClaimMonitor(cfg)
@@ -98,6 +115,16 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 
 	s.initForecast(cfg)
 	s.initBonds()
+	if err := s.initAlerter(cfg); err != nil {
+		return fmt.Errorf("failed to init alerts: %w", err)
+	}
+	if err := s.initArchive(cfg); err != nil {
+		return fmt.Errorf("failed to init archive: %w", err)
+	}
+	s.initTreeView(cfg)
+	if err := s.initRPCServer(cfg); err != nil {
+		return fmt.Errorf("failed to init RPC server: %w", err)
+	}
 
 	s.initMonitor(ctx, cfg) // Monitor must be initialized last
 
@@ -132,12 +159,13 @@ func (s *Service) initExtractor(cfg *config.Config) {
 		cfg.IgnoredGames,
 		cfg.MaxConcurrency,
 		extract.NewClaimEnricher(),
+		extract.NewDepthEnricher(),
 		extract.NewRecipientEnricher(), // Must be called before WithdrawalsEnricher and BondEnricher
 		extract.NewWithdrawalsEnricher(),
 		extract.NewBondEnricher(),
 		extract.NewBalanceEnricher(),
 		extract.NewL1HeadBlockNumEnricher(s.l1Client),
-		extract.NewAgreementEnricher(s.logger, s.metrics, s.rollupClient),
+		extract.NewAgreementEnricher(s.logger, cfg.OutputQuorum, extract.DefaultOutputSourceTimeout, s.outputSources...),
 	)
 }
 
@@ -149,12 +177,82 @@ func (s *Service) initBonds() {
 	s.bonds = bonds.NewBonds(s.logger, s.metrics, s.cl)
 }
 
-func (s *Service) initOutputRollupClient(ctx context.Context, cfg *config.Config) error {
-	outputRollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.RollupRpc)
+func (s *Service) initAlerter(cfg *config.Config) error {
+	rules, err := alerts.NewRules(cfg.AlertRules, s.honestActors, cfg.AlertClockPercent)
 	if err != nil {
-		return fmt.Errorf("failed to dial rollup client: %w", err)
+		return err
+	}
+	notifiers := make([]alerts.Notifier, 0, len(cfg.AlertWebhooks))
+	for _, url := range cfg.AlertWebhooks {
+		notifiers = append(notifiers, alerts.NewWebhookNotifier(url))
+	}
+	s.alerter = alerts.NewAlerter(s.logger, s.cl, s.metrics, notifiers, rules...)
+	return nil
+}
+
+func (s *Service) initArchive(cfg *config.Config) error {
+	if cfg.ArchiveDir == "" {
+		return nil
+	}
+	store, err := archive.OpenStore(cfg.ArchiveDir)
+	if err != nil {
+		return err
+	}
+	s.archive = store
+	s.archiver = archive.NewArchiver(s.logger, s.metrics, store)
+	return nil
+}
+
+func (s *Service) initTreeView(cfg *config.Config) {
+	if !cfg.TreeViewEnabled {
+		return
+	}
+	s.treeView = treeview.NewViewer(s.logger, s.cl, cfg.HonestActors, cfg.MonitorInterval)
+}
+
+// initRPCServer starts the RPC server if the archive API or claim tree view is enabled.
+func (s *Service) initRPCServer(cfg *config.Config) error {
+	if s.archive == nil && s.treeView == nil {
+		return nil
+	}
+	opts := []oprpc.ServerOption{oprpc.WithLogger(s.logger)}
+	if s.treeView != nil {
+		opts = append(opts, oprpc.WithHandler(treeViewPath, http.StripPrefix(strings.TrimSuffix(treeViewPath, "/"), s.treeView.Handler())))
+	}
+	server := oprpc.NewServer(cfg.RPCConfig.ListenAddr, cfg.RPCConfig.ListenPort, version.SimpleWithMeta, opts...)
+	if s.archive != nil {
+		server.AddAPI(rpc.API{
+			Namespace: archive.Namespace,
+			Service:   archive.NewAPI(s.archive),
+		})
+	}
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("failed to start RPC server: %w", err)
+	}
+	s.logger.Info("Started RPC server", "endpoint", server.Endpoint(), "archive", s.archive != nil, "treeView", s.treeView != nil)
+	s.rpcServer = server
+	return nil
+}
+
+func (s *Service) initOutputSources(ctx context.Context, cfg *config.Config) error {
+	for i, rpcUrl := range append([]string{cfg.RollupRpc}, cfg.AdditionalRollupRpcs...) {
+		rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, rpcUrl)
+		if err != nil {
+			return fmt.Errorf("failed to dial rollup client: %w", err)
+		}
+		s.rollupClients = append(s.rollupClients, rollupClient)
+		name := fmt.Sprintf("rollup-%d", i)
+		s.outputSources = append(s.outputSources, extract.NewRollupOutputSource(name, s.logger.New("source", name), s.metrics, rollupClient))
+	}
+	if cfg.L2EthRpc != "" {
+		l2Client, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.L2EthRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial L2 execution client: %w", err)
+		}
+		s.l2Client = l2Client
+		// The primary rollup node provides the safe head the execution client doesn't know about.
+		s.outputSources = append(s.outputSources, extract.NewL2OutputSource("l2-el", s.logger.New("source", "l2-el"), extract.NewL2ProofClient(l2Client), s.rollupClients[0]))
 	}
-	s.rollupClient = outputRollupClient
 	return nil
 }
 
@@ -218,17 +316,35 @@ func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
 		return block.Hash(), nil
 	}
 	l2ChallengesMonitor := NewL2ChallengesMonitor(s.logger, s.metrics)
+	sourceNames := make([]string, len(s.outputSources))
+	for i, source := range s.outputSources {
+		sourceNames[i] = source.Name()
+	}
+	outputSourceMonitor := NewOutputSourceMonitor(s.logger, s.metrics, sourceNames)
 	updateTimeMonitor := NewUpdateTimeMonitor(s.cl, s.metrics)
-	s.monitor = newGameMonitor(ctx, s.logger, s.cl, s.metrics, cfg.MonitorInterval, cfg.GameWindow, blockHashFetcher,
-		s.l1Client.BlockNumber,
-		s.extractor.Extract,
-		s.forecast.Forecast,
+	monitors := []Monitor{
 		s.bonds.CheckBonds,
 		s.resolutions.CheckResolutions,
 		s.claims.CheckClaims,
 		s.withdrawals.CheckWithdrawals,
 		l2ChallengesMonitor.CheckL2Challenges,
-		updateTimeMonitor.CheckUpdateTimes)
+		outputSourceMonitor.CheckOutputSources,
+		updateTimeMonitor.CheckUpdateTimes,
+		func(games []*types.EnrichedGameData) {
+			s.alerter.CheckAlerts(ctx, games)
+		},
+	}
+	if s.archiver != nil {
+		monitors = append(monitors, s.archiver.ArchiveGames)
+	}
+	if s.treeView != nil {
+		monitors = append(monitors, s.treeView.UpdateGames)
+	}
+	s.monitor = newGameMonitor(ctx, s.logger, s.cl, s.metrics, cfg.MonitorInterval, cfg.GameWindow, blockHashFetcher,
+		s.l1Client.BlockNumber,
+		s.extractor.Extract,
+		s.forecast.Forecast,
+		monitors...)
 }
 
 func (s *Service) Start(ctx context.Context) error {
@@ -247,6 +363,16 @@ func (s *Service) Stop(ctx context.Context) error {
 	s.logger.Info("Stopping dispute mon service")
 
 	var result error
+	if s.rpcServer != nil {
+		if err := s.rpcServer.Stop(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close RPC server: %w", err))
+		}
+	}
+	if s.archive != nil {
+		if err := s.archive.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close archive: %w", err))
+		}
+	}
 	if s.pprofService != nil {
 		if err := s.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
@@ -257,6 +383,12 @@ func (s *Service) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
 		}
 	}
+	for _, rollupClient := range s.rollupClients {
+		rollupClient.Close()
+	}
+	if s.l2Client != nil {
+		s.l2Client.Close()
+	}
 	s.stopped.Store(true)
 	s.logger.Info("stopped dispute mon service", "err", result)
 	return result
@@ -0,0 +1,69 @@
+package treeview
+
+import "html/template"
+
+const pageStyle = `<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+code { font-family: monospace; }
+</style>`
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+{{if .Refresh}}<meta http-equiv="refresh" content="{{.Refresh}}">{{end}}
+<title>Dispute Games</title>
+` + pageStyle + `
+</head>
+<body>
+<h1>Dispute Games</h1>
+<table>
+<tr><th>Game</th><th>Created</th><th>L2 Block</th><th>Status</th><th>Claims</th><th>Root Claim</th></tr>
+{{range .Games}}
+<tr>
+<td><a href="games/{{.Address.Hex}}"><code>{{.Address.Hex}}</code></a></td>
+<td>{{.Created}}</td>
+<td>{{.L2BlockNumber}}</td>
+<td>{{.Status}}</td>
+<td>{{.Claims}}</td>
+<td>{{if .AgreeWithClaim}}Valid{{else}}Invalid{{end}}</td>
+</tr>
+{{else}}
+<tr><td colspan="6">No games monitored</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
+
+var gameTemplate = template.Must(template.New("game").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+{{if .Refresh}}<meta http-equiv="refresh" content="{{.Refresh}}">{{end}}
+<title>Game {{.Tree.Game.Hex}}</title>
+` + pageStyle + `
+<script type="module">
+import mermaid from "https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.esm.min.mjs";
+mermaid.initialize({ startOnLoad: true, maxTextSize: 1000000, flowchart: { useMaxWidth: false } });
+</script>
+</head>
+<body>
+<p><a href="../">All games</a></p>
+<h1>Game <code>{{.Tree.Game.Hex}}</code></h1>
+<p>
+Status: {{.Tree.Status}} &bull; L2 Block: {{.Tree.L2BlockNumber}} &bull; Root Claim: <code>{{.Tree.RootClaim.Hex}}</code>
+&bull; Split Depth: {{.Tree.SplitDepth}} &bull; Max Depth: {{.Tree.MaxDepth}} &bull; Claims: {{len .Tree.Nodes}}
+{{with .Tree.L2BlockNumberChallenger}}&bull; L2 block number challenged by <code>{{.Hex}}</code>{{end}}
+</p>
+<p>Download: {{range .Formats}}<a href="?format={{.}}">{{.}}</a> {{end}}</p>
+<p>Green claims are agreed with by the honest actor, red claims are disagreed with and grey claims are unknown.
+Countered claims have a dashed outline.</p>
+<pre class="mermaid">
+{{.Mermaid}}
+</pre>
+</body>
+</html>
+`))
@@ -0,0 +1,204 @@
+package treeview
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claimtree"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrGameNotFound = errors.New("game not found")
+
+type RClock interface {
+	Now() time.Time
+}
+
+// Viewer serves a live HTML view of the claim trees of the monitored games.
+// Trees can also be downloaded as JSON, Graphviz DOT or Mermaid.
+type Viewer struct {
+	logger       log.Logger
+	clock        RClock
+	honestActors []common.Address
+	refresh      time.Duration
+
+	m     sync.RWMutex
+	games []*types.EnrichedGameData
+}
+
+// NewViewer creates a Viewer. Pages automatically reload at the refresh interval.
+func NewViewer(logger log.Logger, clock RClock, honestActors []common.Address, refresh time.Duration) *Viewer {
+	return &Viewer{
+		logger:       logger,
+		clock:        clock,
+		honestActors: honestActors,
+		refresh:      refresh,
+	}
+}
+
+// UpdateGames records the latest state of the monitored games.
+func (v *Viewer) UpdateGames(games []*types.EnrichedGameData) {
+	sorted := slices.Clone(games)
+	// Show the newest games first
+	slices.SortFunc(sorted, func(a, b *types.EnrichedGameData) int {
+		if a.Timestamp != b.Timestamp {
+			return cmp.Compare(b.Timestamp, a.Timestamp)
+		}
+		return bytes.Compare(a.Proxy[:], b.Proxy[:])
+	})
+	v.m.Lock()
+	defer v.m.Unlock()
+	v.games = sorted
+}
+
+// Tree builds the claim tree of the specified game from the latest monitored state.
+func (v *Viewer) Tree(addr common.Address) (*claimtree.Tree, error) {
+	v.m.RLock()
+	defer v.m.RUnlock()
+	idx := slices.IndexFunc(v.games, func(game *types.EnrichedGameData) bool {
+		return game.Proxy == addr
+	})
+	if idx < 0 {
+		return nil, ErrGameNotFound
+	}
+	return BuildTree(v.clock.Now(), v.games[idx], v.honestActors)
+}
+
+// BuildTree creates the claim tree for a monitored game.
+func BuildTree(now time.Time, game *types.EnrichedGameData, honestActors []common.Address) (*claimtree.Tree, error) {
+	claims := make([]faultTypes.Claim, len(game.Claims))
+	resolved := make([]bool, len(game.Claims))
+	for i, claim := range game.Claims {
+		claims[i] = claim.Claim
+		resolved[i] = claim.Resolved
+	}
+	agree := game.AgreeWithClaim
+	return claimtree.Build(now, claimtree.Game{
+		Address:                 game.Proxy,
+		Status:                  game.Status,
+		L2BlockNumber:           game.L2BlockNumber,
+		RootClaim:               game.RootClaim,
+		SplitDepth:              game.SplitDepth,
+		MaxDepth:                game.MaxDepth,
+		MaxClockDuration:        time.Duration(game.MaxClockDuration) * time.Second,
+		L2BlockNumberChallenged: game.BlockNumberChallenged,
+		L2BlockNumberChallenger: game.BlockNumberChallenger,
+		HonestActors:            honestActors,
+		AgreeWithRootClaim:      &agree,
+	}, claims, resolved)
+}
+
+// Handler returns the HTTP handler serving the view.
+//
+//	GET /                          - lists the monitored games
+//	GET /games/{address}           - shows the claim tree of a game
+//	GET /games/{address}?format=   - downloads the claim tree as json, dot or mermaid
+func (v *Viewer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", v.serveIndex)
+	mux.HandleFunc("GET /games/{address}", v.serveGame)
+	return mux
+}
+
+type indexGame struct {
+	Address        common.Address
+	L2BlockNumber  uint64
+	Status         string
+	Claims         int
+	AgreeWithClaim bool
+	Created        string
+}
+
+func (v *Viewer) serveIndex(w http.ResponseWriter, _ *http.Request) {
+	v.m.RLock()
+	games := make([]indexGame, 0, len(v.games))
+	for _, game := range v.games {
+		games = append(games, indexGame{
+			Address:        game.Proxy,
+			L2BlockNumber:  game.L2BlockNumber,
+			Status:         game.Status.String(),
+			Claims:         len(game.Claims),
+			AgreeWithClaim: game.AgreeWithClaim,
+			Created:        time.Unix(int64(game.Timestamp), 0).UTC().Format(time.DateTime),
+		})
+	}
+	v.m.RUnlock()
+	v.render(w, indexTemplate, map[string]any{
+		"Refresh": int(v.refresh.Seconds()),
+		"Games":   games,
+	})
+}
+
+func (v *Viewer) serveGame(w http.ResponseWriter, r *http.Request) {
+	addrStr := r.PathValue("address")
+	if !common.IsHexAddress(addrStr) {
+		http.Error(w, "invalid game address", http.StatusBadRequest)
+		return
+	}
+	tree, err := v.Tree(common.HexToAddress(addrStr))
+	if errors.Is(err, ErrGameNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		v.logger.Error("Failed to build claim tree", "game", addrStr, "err", err)
+		http.Error(w, "failed to build claim tree", http.StatusInternalServerError)
+		return
+	}
+
+	if formatStr := r.URL.Query().Get("format"); formatStr != "" {
+		format, err := claimtree.ParseFormat(formatStr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var out bytes.Buffer
+		if err := claimtree.Write(&out, format, tree); err != nil {
+			v.logger.Error("Failed to export claim tree", "game", addrStr, "format", format, "err", err)
+			http.Error(w, "failed to export claim tree", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentTypes[format])
+		_, _ = w.Write(out.Bytes())
+		return
+	}
+
+	var mermaid bytes.Buffer
+	if err := claimtree.WriteMermaid(&mermaid, tree); err != nil {
+		v.logger.Error("Failed to render claim tree", "game", addrStr, "err", err)
+		http.Error(w, "failed to render claim tree", http.StatusInternalServerError)
+		return
+	}
+	v.render(w, gameTemplate, map[string]any{
+		"Refresh": int(v.refresh.Seconds()),
+		"Tree":    tree,
+		"Formats": claimtree.Formats,
+		"Mermaid": mermaid.String(),
+	})
+}
+
+var contentTypes = map[claimtree.Format]string{
+	claimtree.FormatJSON:    "application/json",
+	claimtree.FormatDOT:     "text/vnd.graphviz",
+	claimtree.FormatMermaid: "text/plain; charset=utf-8",
+}
+
+func (v *Viewer) render(w http.ResponseWriter, tmpl *template.Template, data any) {
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		v.logger.Error("Failed to render page", "template", tmpl.Name(), "err", err)
+		http.Error(w, fmt.Sprintf("failed to render %v", tmpl.Name()), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(out.Bytes())
+}
@@ -0,0 +1,144 @@
+package treeview
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claimtree"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	honestActor = common.Address{0xbb}
+	olderGame   = common.Address{0x01}
+	newerGame   = common.Address{0x02}
+)
+
+func TestBuildTree(t *testing.T) {
+	now := time.Unix(2000, 0)
+	game := testGame(newerGame, 200)
+	tree, err := BuildTree(now, game, []common.Address{honestActor})
+	require.NoError(t, err)
+	require.Equal(t, newerGame, tree.Game)
+	require.Equal(t, uint64(2), tree.SplitDepth)
+	require.Equal(t, uint64(4), tree.MaxDepth)
+	require.Len(t, tree.Nodes, 2)
+	require.False(t, *tree.Nodes[0].HonestAgrees, "should use agreement with root claim")
+	require.True(t, *tree.Nodes[1].HonestAgrees)
+	require.True(t, tree.Nodes[1].Resolved)
+	require.Equal(t, uint64(3600-500), tree.Nodes[0].ClockRemaining)
+}
+
+func TestViewer(t *testing.T) {
+	viewer := NewViewer(testlog.Logger(t, log.LevelInfo), clock.NewDeterministicClock(time.Unix(2000, 0)), []common.Address{honestActor}, 30*time.Second)
+	viewer.UpdateGames([]*types.EnrichedGameData{testGame(olderGame, 100), testGame(newerGame, 200)})
+	server := httptest.NewServer(viewer.Handler())
+	t.Cleanup(server.Close)
+
+	t.Run("Index", func(t *testing.T) {
+		status, contentType, body := get(t, server.URL+"/")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "text/html; charset=utf-8", contentType)
+		require.Contains(t, body, `<meta http-equiv="refresh" content="30">`)
+		newerIdx := indexOf(t, body, `<a href="games/`+newerGame.Hex()+`">`)
+		olderIdx := indexOf(t, body, `<a href="games/`+olderGame.Hex()+`">`)
+		require.Less(t, newerIdx, olderIdx, "should list newest games first")
+	})
+
+	t.Run("Game", func(t *testing.T) {
+		status, contentType, body := get(t, server.URL+"/games/"+newerGame.Hex())
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "text/html; charset=utf-8", contentType)
+		require.Contains(t, body, `<pre class="mermaid">`)
+		require.Contains(t, body, "c0 --&gt;|attack| c1")
+		require.Contains(t, body, `<a href="?format=dot">dot</a>`)
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		status, contentType, body := get(t, server.URL+"/games/"+newerGame.Hex()+"?format=json")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "application/json", contentType)
+		var tree claimtree.Tree
+		require.NoError(t, json.Unmarshal([]byte(body), &tree))
+		require.Equal(t, newerGame, tree.Game)
+
+		status, contentType, body = get(t, server.URL+"/games/"+newerGame.Hex()+"?format=dot")
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, "text/vnd.graphviz", contentType)
+		require.Contains(t, body, "c0 -> c1")
+	})
+
+	t.Run("UnknownFormat", func(t *testing.T) {
+		status, _, _ := get(t, server.URL+"/games/"+newerGame.Hex()+"?format=svg")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		status, _, _ := get(t, server.URL+"/games/foo")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("UnknownGame", func(t *testing.T) {
+		status, _, _ := get(t, server.URL+"/games/"+common.Address{0xff}.Hex())
+		require.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("RemovedGame", func(t *testing.T) {
+		viewer.UpdateGames([]*types.EnrichedGameData{testGame(newerGame, 200)})
+		status, _, _ := get(t, server.URL+"/games/"+olderGame.Hex())
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func get(t *testing.T, url string) (int, string, string) {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+}
+
+func indexOf(t *testing.T, body string, s string) int {
+	idx := strings.Index(body, s)
+	require.NotEqual(t, -1, idx, "missing %v", s)
+	return idx
+}
+
+func testGame(addr common.Address, timestamp uint64) *types.EnrichedGameData {
+	root := faultTypes.Claim{
+		ClaimData: faultTypes.ClaimData{Position: faultTypes.RootPosition, Bond: big.NewInt(10)},
+		Claimant:  common.Address{0xaa},
+		Clock:     faultTypes.Clock{Timestamp: time.Unix(1500, 0)},
+	}
+	counter := faultTypes.Claim{
+		ClaimData:     faultTypes.ClaimData{Position: root.Position.Attack(), Bond: big.NewInt(20)},
+		Claimant:      honestActor,
+		Clock:         faultTypes.Clock{Timestamp: time.Unix(1600, 0)},
+		ContractIndex: 1,
+	}
+	return &types.EnrichedGameData{
+		GameMetadata:     gameTypes.GameMetadata{Proxy: addr, Timestamp: timestamp},
+		Status:           gameTypes.GameStatusInProgress,
+		MaxClockDuration: 3600,
+		SplitDepth:       2,
+		MaxDepth:         4,
+		Claims: []types.EnrichedClaim{
+			{Claim: root},
+			{Claim: counter, Resolved: true},
+		},
+	}
+}
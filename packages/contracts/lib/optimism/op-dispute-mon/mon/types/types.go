@@ -10,6 +10,28 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// OutputSourceStatus is the result of checking a root claim against a single output source.
+type OutputSourceStatus uint8
+
+const (
+	OutputSourceAgree OutputSourceStatus = iota
+	OutputSourceDisagree
+	OutputSourceUnavailable
+)
+
+func (s OutputSourceStatus) String() string {
+	switch s {
+	case OutputSourceAgree:
+		return "agree"
+	case OutputSourceDisagree:
+		return "disagree"
+	case OutputSourceUnavailable:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
 // EnrichedClaim extends the faultTypes.Claim with additional context.
 type EnrichedClaim struct {
 	faultTypes.Claim
@@ -25,6 +47,8 @@ type EnrichedGameData struct {
 	RootClaim             common.Hash
 	Status                types.GameStatus
 	MaxClockDuration      uint64
+	SplitDepth            faultTypes.Depth
+	MaxDepth              faultTypes.Depth
 	BlockNumberChallenged bool
 	BlockNumberChallenger common.Address
 	Claims                []EnrichedClaim
@@ -32,6 +56,9 @@ type EnrichedGameData struct {
 	AgreeWithClaim    bool
 	ExpectedRootClaim common.Hash
 
+	// OutputSources records the result of checking the root claim against each output source, keyed by source name.
+	OutputSources map[string]OutputSourceStatus
+
 	// Recipients maps addresses to true if they are a bond recipient in the game.
 	Recipients map[common.Address]bool
 
@@ -0,0 +1,227 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// Case is a single conformance check, run against a new instance of the server.
+type Case struct {
+	Name        string
+	Description string
+	// Malformed cases send invalid requests that the server must reject by not responding.
+	// The server is allowed to exit with an error, but must not crash.
+	Malformed bool
+	Run       func(c *Conn, f *Fixture) error
+}
+
+// Cases are the conformance cases every pre-image server must pass.
+var Cases = []Case{
+	{
+		Name:        "local-key",
+		Description: "Serves local key pre-images",
+		Run:         expectEntriesOfType(preimage.LocalKeyType),
+	},
+	{
+		Name:        "keccak256-key",
+		Description: "Serves keccak256 key pre-images",
+		Run:         expectEntriesOfType(preimage.Keccak256KeyType),
+	},
+	{
+		Name:        "sha256-key",
+		Description: "Serves sha256 key pre-images",
+		Run:         expectEntriesOfType(preimage.Sha256KeyType),
+	},
+	{
+		Name:        "blob-key",
+		Description: "Serves blob key pre-images",
+		Run:         expectEntriesOfType(preimage.BlobKeyType),
+	},
+	{
+		Name:        "precompile-key",
+		Description: "Serves precompile key pre-images",
+		Run:         expectEntriesOfType(preimage.PrecompileKeyType),
+	},
+	{
+		Name:        "zero-length-preimage",
+		Description: "Serves a zero-length pre-image with only the length prefix",
+		Run:         expectEntries("zero-length", "keccak256-0"),
+	},
+	{
+		Name:        "large-preimage",
+		Description: "Serves a pre-image much larger than the pipe buffer",
+		Run:         expectEntries("large", "keccak256-0"),
+	},
+	{
+		Name:        "repeated-requests",
+		Description: "Serves the same pre-image many times",
+		Run: func(c *Conn, f *Fixture) error {
+			for i := 0; i < 100; i++ {
+				if err := expectEntries("keccak256-0", "local-1")(c, f); err != nil {
+					return fmt.Errorf("request %d: %w", i, err)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "hint-ack",
+		Description: "Acknowledges hints with a single byte",
+		Run: func(c *Conn, f *Fixture) error {
+			for _, hint := range []string{"l1-block-header 0x1234", "l2-state-node 0x5678", "unknown-hint-type"} {
+				if err := c.Hint([]byte(hint)); err != nil {
+					return fmt.Errorf("hint %q: %w", hint, err)
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Name:        "zero-length-hint",
+		Description: "Acknowledges a zero-length hint",
+		Run: func(c *Conn, f *Fixture) error {
+			return c.Hint(nil)
+		},
+	},
+	{
+		Name:        "large-hint",
+		Description: "Acknowledges a hint much larger than the pipe buffer",
+		Run: func(c *Conn, f *Fixture) error {
+			return c.Hint([]byte("large-hint " + strings.Repeat("ab", 512*1024)))
+		},
+	},
+	{
+		Name:        "hint-routing",
+		Description: "Serves pre-images after hints for each key type, with the channels used independently",
+		Run: func(c *Conn, f *Fixture) error {
+			for _, entry := range f.Preimages {
+				if err := c.Hint([]byte(fmt.Sprintf("conformance-%s %s", entry.Name, entry.Key))); err != nil {
+					return fmt.Errorf("hint for %s: %w", entry.Name, err)
+				}
+				if err := expectEntries(entry.Name)(c, f); err != nil {
+					return err
+				}
+			}
+			// Multiple hints before a request and multiple requests before a hint
+			for i := 0; i < 10; i++ {
+				if err := c.Hint([]byte(fmt.Sprintf("conformance-batch %d", i))); err != nil {
+					return fmt.Errorf("batched hint %d: %w", i, err)
+				}
+			}
+			return expectEntries("local-1", "sha256-0", "blob-0", "precompile-0")(c, f)
+		},
+	},
+	{
+		Name:        "unknown-key",
+		Description: "Rejects a request for a pre-image that is not available",
+		Malformed:   true,
+		Run: func(c *Conn, f *Fixture) error {
+			return expectRejected(c, preimage.Keccak256Key(preimage.Keccak256([]byte("not in fixture"))).PreimageKey())
+		},
+	},
+	{
+		Name:        "zero-key-type",
+		Description: "Rejects a request with the illegal zero key type",
+		Malformed:   true,
+		Run: func(c *Conn, f *Fixture) error {
+			return expectRejected(c, withKeyType(f.Preimages[0].Key, 0))
+		},
+	},
+	{
+		Name:        "unknown-key-type",
+		Description: "Rejects a request with an undefined key type",
+		Malformed:   true,
+		Run: func(c *Conn, f *Fixture) error {
+			return expectRejected(c, withKeyType(f.Preimages[0].Key, 0xff))
+		},
+	},
+	{
+		Name:        "truncated-key",
+		Description: "Exits cleanly when the pre-image channel is closed part way through a key",
+		Malformed:   true,
+		Run: func(c *Conn, f *Fixture) error {
+			return c.WritePreimageRequest(f.Preimages[0].Key[:16])
+		},
+	},
+	{
+		Name:        "truncated-hint",
+		Description: "Exits cleanly when the hint channel is closed part way through a hint",
+		Malformed:   true,
+		Run: func(c *Conn, f *Fixture) error {
+			data := binary.BigEndian.AppendUint32(nil, 100)
+			return c.WriteHintRequest(append(data, "truncated"...))
+		},
+	},
+}
+
+// FindCase returns the case with the given name.
+func FindCase(name string) (Case, bool) {
+	for _, c := range Cases {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Case{}, false
+}
+
+func expectEntriesOfType(keyType preimage.KeyType) func(c *Conn, f *Fixture) error {
+	return func(c *Conn, f *Fixture) error {
+		entries := f.EntriesOfType(keyType)
+		if len(entries) == 0 {
+			return fmt.Errorf("fixture has no pre-images of key type %d", keyType)
+		}
+		for _, entry := range entries {
+			if err := expectEntry(c, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func expectEntries(names ...string) func(c *Conn, f *Fixture) error {
+	return func(c *Conn, f *Fixture) error {
+		for _, name := range names {
+			entry, ok := f.Entry(name)
+			if !ok {
+				return fmt.Errorf("fixture has no pre-image named %v", name)
+			}
+			if err := expectEntry(c, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func expectEntry(c *Conn, entry Entry) error {
+	value, err := c.Get(entry.Key)
+	if err != nil {
+		return fmt.Errorf("failed to get %v (%v): %w", entry.Name, entry.Key, err)
+	}
+	if !bytes.Equal(value, entry.Value) {
+		return fmt.Errorf("incorrect pre-image for %v (%v): expected %d bytes, got %d bytes", entry.Name, entry.Key, len(entry.Value), len(value))
+	}
+	return nil
+}
+
+func expectRejected(c *Conn, key common.Hash) error {
+	value, err := c.Get(key)
+	if IsRejected(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return fmt.Errorf("expected request for %v to be rejected but got %d byte response", key, len(value))
+}
+
+func withKeyType(key common.Hash, keyType byte) common.Hash {
+	key[0] = keyType
+	return key
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-preimage/conformance"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+)
+
+// fixturePlaceholder is replaced with the path of the fixture in the server command arguments.
+const fixturePlaceholder = "{fixture}"
+
+var (
+	FixtureFlag = &cli.PathFlag{
+		Name:  "fixture",
+		Usage: "Path of the fixture of pre-images the server serves. Generated from --seed if not set.",
+	}
+	SeedFlag = &cli.Int64Flag{
+		Name:  "seed",
+		Usage: "Seed used to generate the fixture, and the first fuzzing iteration",
+		Value: 1,
+	}
+	TimeoutFlag = &cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "Maximum time to wait for each response, and for the server to exit",
+		Value: 10 * time.Second,
+	}
+	CaseFlag = &cli.StringSliceFlag{
+		Name:  "case",
+		Usage: "Name of a conformance case to run. May be repeated. Runs all cases if not set.",
+	}
+	VerboseFlag = &cli.BoolFlag{
+		Name:  "verbose",
+		Usage: "Show the output of the server",
+	}
+	IterationsFlag = &cli.IntFlag{
+		Name:  "iterations",
+		Usage: "Number of request sequences to fuzz",
+		Value: 100,
+	}
+	MaxOpsFlag = &cli.IntFlag{
+		Name:  "max-ops",
+		Usage: "Maximum number of requests in each fuzzed sequence",
+		Value: 50,
+	}
+	OutFlag = &cli.PathFlag{
+		Name:     "out",
+		Usage:    "Path to write the fixture to",
+		Required: true,
+	}
+)
+
+var RunCommand = &cli.Command{
+	Name:      "run",
+	Usage:     "Run the conformance cases against a pre-image server",
+	ArgsUsage: "-- <server> [args...]",
+	Description: "Runs each conformance case against a new instance of the server. " +
+		"The server is started with the hint channel on file descriptors 3 (read) and 4 (write), and the pre-image " +
+		"channel on file descriptors 5 (read) and 6 (write). " + fixturePlaceholder + " in the server arguments is " +
+		"replaced with the path of the fixture of pre-images the server must serve.",
+	Flags:  []cli.Flag{FixtureFlag, SeedFlag, TimeoutFlag, CaseFlag, VerboseFlag},
+	Action: Run,
+}
+
+var FuzzCommand = &cli.Command{
+	Name:        "fuzz",
+	Usage:       "Fuzz a pre-image server, comparing its responses to the reference server",
+	ArgsUsage:   "-- <server> [args...]",
+	Description: "Sends random sequences of requests to the server and the reference server, reporting crashes and disagreements. " + fixturePlaceholder + " in the server arguments is replaced with the path of the fixture.",
+	Flags:       []cli.Flag{FixtureFlag, SeedFlag, TimeoutFlag, IterationsFlag, MaxOpsFlag, VerboseFlag},
+	Action:      Fuzz,
+}
+
+var ServeCommand = &cli.Command{
+	Name:   "serve",
+	Usage:  "Run the reference pre-image server, serving pre-images from a fixture",
+	Flags:  []cli.Flag{FixtureFlag},
+	Action: Serve,
+}
+
+var FixtureCommand = &cli.Command{
+	Name:   "fixture",
+	Usage:  "Generate a fixture of pre-images",
+	Flags:  []cli.Flag{SeedFlag, OutFlag},
+	Action: Fixture,
+}
+
+func Run(ctx *cli.Context) error {
+	f, server, cleanup, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	cases := conformance.Cases
+	if names := ctx.StringSlice(CaseFlag.Name); len(names) > 0 {
+		cases = nil
+		for _, name := range names {
+			c, ok := conformance.FindCase(name)
+			if !ok {
+				return fmt.Errorf("unknown case: %v", name)
+			}
+			cases = append(cases, c)
+		}
+	}
+	failed := 0
+	for _, result := range conformance.Run(server, f, cases, ctx.Duration(TimeoutFlag.Name)) {
+		if result.Err != nil {
+			failed++
+			_, _ = fmt.Fprintf(ctx.App.Writer, "FAIL %v: %v\n", result.Case.Name, result.Err)
+		} else {
+			_, _ = fmt.Fprintf(ctx.App.Writer, "PASS %v\n", result.Case.Name)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d cases failed", failed, len(cases))
+	}
+	return nil
+}
+
+func Fuzz(ctx *cli.Context) error {
+	f, server, cleanup, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	cfg := conformance.FuzzConfig{
+		Seed:       ctx.Int64(SeedFlag.Name),
+		Iterations: ctx.Int(IterationsFlag.Name),
+		MaxOps:     ctx.Int(MaxOpsFlag.Name),
+		Timeout:    ctx.Duration(TimeoutFlag.Name),
+	}
+	findings, err := conformance.Fuzz(ctx.Context, server, &conformance.ReferenceServer{Fixture: f}, f, cfg)
+	for _, finding := range findings {
+		_, _ = fmt.Fprint(ctx.App.Writer, finding)
+	}
+	if err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("%d of %d iterations failed", len(findings), cfg.Iterations)
+	}
+	_, _ = fmt.Fprintf(ctx.App.Writer, "%d iterations passed\n", cfg.Iterations)
+	return nil
+}
+
+func Serve(ctx *cli.Context) error {
+	if !ctx.IsSet(FixtureFlag.Name) {
+		return fmt.Errorf("flag %v is required", FixtureFlag.Name)
+	}
+	f, err := conformance.ReadFixture(ctx.Path(FixtureFlag.Name))
+	if err != nil {
+		return err
+	}
+	return conformance.Serve(preimage.ClientHinterChannel(), preimage.ClientPreimageChannel(), f.Get, func(hint string) error { return nil })
+}
+
+func Fixture(ctx *cli.Context) error {
+	return conformance.WriteFixture(ctx.Path(OutFlag.Name), conformance.NewFixture(rand.New(rand.NewSource(ctx.Int64(SeedFlag.Name)))))
+}
+
+// setup loads or generates the fixture, and creates the server under test from the command arguments.
+// The returned cleanup function removes the fixture if it was generated.
+func setup(ctx *cli.Context) (*conformance.Fixture, conformance.Server, func(), error) {
+	if ctx.NArg() == 0 {
+		return nil, nil, nil, errors.New("no server command specified")
+	}
+	path := ctx.Path(FixtureFlag.Name)
+	cleanup := func() {}
+	var f *conformance.Fixture
+	if path != "" {
+		var err error
+		f, err = conformance.ReadFixture(path)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	} else {
+		f = conformance.NewFixture(rand.New(rand.NewSource(ctx.Int64(SeedFlag.Name))))
+		dir, err := os.MkdirTemp("", "preimage-conformance")
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to create fixture dir: %w", err)
+		}
+		cleanup = func() { _ = os.RemoveAll(dir) }
+		path = filepath.Join(dir, "fixture.json")
+		if err := conformance.WriteFixture(path, f); err != nil {
+			cleanup()
+			return nil, nil, nil, err
+		}
+	}
+
+	args := ctx.Args().Tail()
+	for i, arg := range args {
+		args[i] = strings.ReplaceAll(arg, fixturePlaceholder, path)
+	}
+	var output io.Writer
+	if ctx.Bool(VerboseFlag.Name) {
+		output = ctx.App.ErrWriter
+	}
+	return f, &conformance.ProcessServer{Name: ctx.Args().First(), Args: args, Stdout: output, Stderr: output}, cleanup, nil
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "preimage-conformance"
+	app.Usage = "Pre-image oracle protocol conformance suite"
+	app.Description = "Checks that a pre-image server implements the pre-image oracle and hint protocol identically to the reference server"
+	app.Commands = []*cli.Command{
+		RunCommand,
+		FuzzCommand,
+		ServeCommand,
+		FixtureCommand,
+	}
+	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
+	err := app.RunContext(ctx, os.Args)
+	if err != nil {
+		if errors.Is(err, ctx.Err()) {
+			_, _ = fmt.Fprintf(os.Stderr, "command interrupted")
+			os.Exit(130)
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "error: %v", err)
+			os.Exit(1)
+		}
+	}
+}
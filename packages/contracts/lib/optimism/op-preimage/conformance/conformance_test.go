@@ -0,0 +1,157 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const testTimeout = 5 * time.Second
+
+// The test binary doubles as a pre-image server process when this environment variable is set to a server mode.
+const serverModeEnv = "OP_PREIMAGE_CONFORMANCE_TEST_SERVER"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(serverModeEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "reference":
+		f, err := ReadFixture(os.Args[len(os.Args)-1])
+		if err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if err := Serve(preimage.ClientHinterChannel(), preimage.ClientPreimageChannel(), f.Get, func(string) error { return nil }); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	case "panic":
+		panic("boom")
+	}
+}
+
+func TestReferenceServerConforms(t *testing.T) {
+	f := NewFixture(rand.New(rand.NewSource(1)))
+	for _, result := range Run(&ReferenceServer{Fixture: f}, f, Cases, testTimeout) {
+		require.NoError(t, result.Err, result.Case.Name)
+	}
+}
+
+func TestProcessServer(t *testing.T) {
+	f := NewFixture(rand.New(rand.NewSource(1)))
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, WriteFixture(path, f))
+
+	t.Run("Conforms", func(t *testing.T) {
+		t.Setenv(serverModeEnv, "reference")
+		server := &ProcessServer{Name: os.Args[0], Args: []string{path}, Stderr: os.Stderr}
+		for _, result := range Run(server, f, Cases, testTimeout) {
+			require.NoError(t, result.Err, result.Case.Name)
+		}
+	})
+
+	t.Run("Crashed", func(t *testing.T) {
+		t.Setenv(serverModeEnv, "panic")
+		server := &ProcessServer{Name: os.Args[0]}
+		results := Run(server, f, Cases[:1], testTimeout)
+		require.ErrorIs(t, results[0].Err, ErrCrashed)
+	})
+}
+
+func TestDetectsNonConformingServer(t *testing.T) {
+	f := NewFixture(rand.New(rand.NewSource(1)))
+	lenient := &testServer{
+		// Responds with an empty pre-image instead of rejecting unknown keys
+		getter: func(key [32]byte) ([]byte, error) {
+			value, err := f.Get(key)
+			if errors.Is(err, ErrNotFound) {
+				return []byte{}, nil
+			}
+			return value, err
+		},
+	}
+	results := Run(lenient, f, Cases, testTimeout)
+	failed := make(map[string]bool)
+	for _, result := range results {
+		failed[result.Case.Name] = result.Err != nil
+	}
+	require.True(t, failed["unknown-key"])
+	require.True(t, failed["zero-key-type"])
+	require.True(t, failed["unknown-key-type"])
+	require.False(t, failed["local-key"])
+	require.False(t, failed["truncated-key"])
+}
+
+func TestFuzz(t *testing.T) {
+	f := NewFixture(rand.New(rand.NewSource(1)))
+	reference := &ReferenceServer{Fixture: f}
+	cfg := FuzzConfig{Seed: 1, Iterations: 20, MaxOps: 20, Timeout: testTimeout}
+
+	t.Run("Agrees", func(t *testing.T) {
+		findings, err := Fuzz(context.Background(), &ReferenceServer{Fixture: f}, reference, f, cfg)
+		require.NoError(t, err)
+		require.Empty(t, findings)
+	})
+
+	t.Run("Disagrees", func(t *testing.T) {
+		// Serves incorrect blob pre-images
+		target := &testServer{
+			getter: func(key [32]byte) ([]byte, error) {
+				if key[0] == byte(preimage.BlobKeyType) {
+					return []byte{1, 2, 3}, nil
+				}
+				return f.Get(key)
+			},
+		}
+		findings, err := Fuzz(context.Background(), target, reference, f, cfg)
+		require.NoError(t, err)
+		require.NotEmpty(t, findings)
+		for _, finding := range findings {
+			// Findings can be reproduced from their seed
+			ops := GenerateOps(rand.New(rand.NewSource(finding.Seed)), f, cfg.MaxOps)
+			require.Equal(t, ops[:len(finding.Ops)], finding.Ops)
+		}
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := Fuzz(ctx, reference, reference, f, cfg)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestFixtureRoundTrip(t *testing.T) {
+	f := NewFixture(rand.New(rand.NewSource(1)))
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, WriteFixture(path, f))
+	actual, err := ReadFixture(path)
+	require.NoError(t, err)
+	require.Equal(t, f, actual)
+
+	for _, entry := range f.Preimages {
+		if entry.Key[0] == byte(preimage.Keccak256KeyType) || entry.Key[0] == byte(preimage.Sha256KeyType) {
+			_, err := preimage.WithVerification(f.Get)(entry.Key)
+			require.NoError(t, err, entry.Name)
+		}
+	}
+}
+
+// testServer serves pre-images in-process from a custom getter.
+type testServer struct {
+	getter preimage.PreimageGetter
+}
+
+func (s *testServer) Start(timeout time.Duration) (*Conn, error) {
+	return startInProcess(timeout, s.getter)
+}
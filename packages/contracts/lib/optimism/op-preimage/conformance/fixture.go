@@ -0,0 +1,136 @@
+package conformance
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// LargePreimageSize is the size of the large pre-image in generated fixtures.
+// It is much larger than the buffer of a pipe so the server must handle partial writes.
+const LargePreimageSize = 4 * 1024 * 1024
+
+var ErrNotFound = errors.New("pre-image not found")
+
+// Entry is a pre-image served by the server under test.
+type Entry struct {
+	// Name identifies the entry for the conformance cases that request it.
+	Name  string        `json:"name"`
+	Key   common.Hash   `json:"key"`
+	Value hexutil.Bytes `json:"value"`
+}
+
+// Fixture is the set of pre-images the server under test must serve.
+type Fixture struct {
+	Preimages []Entry `json:"preimages"`
+}
+
+// NewFixture generates a fixture with pre-images of every key type, including zero-length and large pre-images.
+// The fixture is deterministic for a given rng seed.
+func NewFixture(rng *rand.Rand) *Fixture {
+	randomBytes := func(n int) []byte {
+		out := make([]byte, n)
+		_, _ = rng.Read(out)
+		return out
+	}
+	randomKey := func(keyType preimage.KeyType) common.Hash {
+		key := common.BytesToHash(randomBytes(32))
+		key[0] = byte(keyType)
+		return key
+	}
+	keccak := func(value []byte) common.Hash {
+		return preimage.Keccak256Key(preimage.Keccak256(value)).PreimageKey()
+	}
+
+	f := &Fixture{}
+	add := func(name string, key common.Hash, value []byte) {
+		f.Preimages = append(f.Preimages, Entry{Name: name, Key: key, Value: value})
+	}
+	for i := uint64(1); i <= 7; i++ {
+		add(fmt.Sprintf("local-%d", i), preimage.LocalIndexKey(i).PreimageKey(), randomBytes(32))
+	}
+	for i := 0; i < 4; i++ {
+		value := randomBytes(rng.Intn(1000) + 1)
+		add(fmt.Sprintf("keccak256-%d", i), keccak(value), value)
+	}
+	for i := 0; i < 4; i++ {
+		value := randomBytes(rng.Intn(1000) + 1)
+		add(fmt.Sprintf("sha256-%d", i), preimage.Sha256Key(sha256.Sum256(value)).PreimageKey(), value)
+	}
+	for i := 0; i < 4; i++ {
+		// Blob pre-images are a single field element
+		add(fmt.Sprintf("blob-%d", i), randomKey(preimage.BlobKeyType), randomBytes(32))
+	}
+	for i := 0; i < 4; i++ {
+		// Precompile results are prefixed with a status byte
+		add(fmt.Sprintf("precompile-%d", i), randomKey(preimage.PrecompileKeyType), append([]byte{1}, randomBytes(32)...))
+	}
+	add("zero-length", keccak(nil), []byte{})
+	large := randomBytes(LargePreimageSize)
+	add("large", keccak(large), large)
+	return f
+}
+
+// Get implements preimage.PreimageGetter, returning ErrNotFound for keys not in the fixture.
+func (f *Fixture) Get(key [32]byte) ([]byte, error) {
+	for _, entry := range f.Preimages {
+		if entry.Key == key {
+			return entry.Value, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %v", ErrNotFound, common.Hash(key))
+}
+
+// Entry returns the entry with the given name.
+func (f *Fixture) Entry(name string) (Entry, bool) {
+	for _, entry := range f.Preimages {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// EntriesOfType returns the entries with keys of the given type.
+func (f *Fixture) EntriesOfType(keyType preimage.KeyType) []Entry {
+	var out []Entry
+	for _, entry := range f.Preimages {
+		if entry.Key[0] == byte(keyType) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// WriteFixture writes the fixture as JSON to path.
+func WriteFixture(path string, f *Fixture) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// ReadFixture reads a fixture written by WriteFixture.
+func ReadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture: %w", err)
+	}
+	return &f, nil
+}
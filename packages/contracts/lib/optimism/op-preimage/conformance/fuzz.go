@@ -0,0 +1,220 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+type OpKind int
+
+const (
+	// OpGet requests a pre-image.
+	OpGet OpKind = iota
+	// OpHint sends a hint.
+	OpHint
+	// OpTruncatedGet writes part of a pre-image key, then closes the channels.
+	OpTruncatedGet
+	// OpTruncatedHint writes part of a hint, then closes the channels.
+	OpTruncatedHint
+)
+
+// Op is a single request in a fuzzed sequence.
+type Op struct {
+	Kind OpKind
+	Key  common.Hash
+	Data []byte
+}
+
+func (o Op) String() string {
+	switch o.Kind {
+	case OpGet:
+		return fmt.Sprintf("get %v", o.Key)
+	case OpHint:
+		return fmt.Sprintf("hint %v", hexutil.Bytes(o.Data))
+	case OpTruncatedGet:
+		return fmt.Sprintf("truncated-get %v", hexutil.Bytes(o.Data))
+	case OpTruncatedHint:
+		return fmt.Sprintf("truncated-hint %v", hexutil.Bytes(o.Data))
+	default:
+		return fmt.Sprintf("unknown(%d)", o.Kind)
+	}
+}
+
+// outcome is the observed response to an Op.
+type outcome struct {
+	value    []byte
+	rejected bool
+	err      error
+}
+
+func (o outcome) String() string {
+	switch {
+	case o.err != nil:
+		return "error: " + o.err.Error()
+	case o.rejected:
+		return "rejected"
+	default:
+		return fmt.Sprintf("%d bytes", len(o.value))
+	}
+}
+
+func (o outcome) equal(other outcome) bool {
+	return o.err == nil && other.err == nil && o.rejected == other.rejected && bytes.Equal(o.value, other.value)
+}
+
+// FuzzConfig configures a fuzzing run.
+type FuzzConfig struct {
+	// Seed is the seed of the first iteration. Each iteration i uses Seed+i, so it can be reproduced alone.
+	Seed       int64
+	Iterations int
+	// MaxOps is the maximum number of requests in each iteration.
+	MaxOps int
+	// Timeout applies to each request, and waiting for the server to exit.
+	Timeout time.Duration
+}
+
+// Finding is a sequence of requests that crashed the target server, or where its responses disagreed with the
+// reference server.
+type Finding struct {
+	Seed   int64
+	Ops    []Op
+	Reason string
+}
+
+func (f Finding) String() string {
+	var out strings.Builder
+	_, _ = fmt.Fprintf(&out, "seed %d: %s\n", f.Seed, f.Reason)
+	for i, op := range f.Ops {
+		_, _ = fmt.Fprintf(&out, "  %d: %v\n", i, op)
+	}
+	return out.String()
+}
+
+// Fuzz sends random sequences of requests to the target and reference servers, returning the sequences that crashed
+// the target or where the responses differed.
+func Fuzz(ctx context.Context, target Server, reference Server, f *Fixture, cfg FuzzConfig) ([]Finding, error) {
+	var findings []Finding
+	for i := 0; i < cfg.Iterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return findings, err
+		}
+		seed := cfg.Seed + int64(i)
+		ops := GenerateOps(rand.New(rand.NewSource(seed)), f, cfg.MaxOps)
+		expected, _, err := execute(reference, ops, cfg.Timeout)
+		if err != nil {
+			return findings, fmt.Errorf("reference server failed with seed %d: %w", seed, err)
+		}
+		actual, exitErr, err := execute(target, ops, cfg.Timeout)
+		if err != nil {
+			return findings, fmt.Errorf("target server failed with seed %d: %w", seed, err)
+		}
+		if reason := compare(expected, actual, exitErr); reason != "" {
+			findings = append(findings, Finding{Seed: seed, Ops: ops[:min(len(ops), max(len(expected), len(actual)))], Reason: reason})
+		}
+	}
+	return findings, nil
+}
+
+// GenerateOps generates a random sequence of up to maxOps requests.
+// Most requests are for pre-images in the fixture, with some unknown keys, invalid key types and malformed requests.
+func GenerateOps(rng *rand.Rand, f *Fixture, maxOps int) []Op {
+	count := rng.Intn(max(maxOps, 1)) + 1
+	ops := make([]Op, 0, count)
+	randomBytes := func(n int) []byte {
+		out := make([]byte, n)
+		_, _ = rng.Read(out)
+		return out
+	}
+	for i := 0; i < count; i++ {
+		switch r := rng.Intn(100); {
+		case r < 55:
+			entry := f.Preimages[rng.Intn(len(f.Preimages))]
+			ops = append(ops, Op{Kind: OpGet, Key: entry.Key})
+		case r < 65:
+			// Unknown key of a valid type
+			keyTypes := []preimage.KeyType{preimage.LocalKeyType, preimage.Keccak256KeyType, preimage.Sha256KeyType, preimage.BlobKeyType, preimage.PrecompileKeyType}
+			ops = append(ops, Op{Kind: OpGet, Key: withKeyType(common.BytesToHash(randomBytes(32)), byte(keyTypes[rng.Intn(len(keyTypes))]))})
+		case r < 70:
+			// Key with an invalid or reserved type
+			keyTypes := []byte{0, byte(preimage.GlobalGenericKeyType), byte(preimage.PrecompileKeyType) + 1, 0xff}
+			ops = append(ops, Op{Kind: OpGet, Key: withKeyType(common.BytesToHash(randomBytes(32)), keyTypes[rng.Intn(len(keyTypes))])})
+		case r < 96:
+			ops = append(ops, Op{Kind: OpHint, Data: randomBytes(rng.Intn(2000))})
+		case r < 98:
+			return append(ops, Op{Kind: OpTruncatedGet, Data: randomBytes(rng.Intn(31) + 1)})
+		default:
+			// A length prefix longer than the data that follows it
+			data := randomBytes(rng.Intn(100))
+			prefix := []byte{0, 0, 1, 0}
+			return append(ops, Op{Kind: OpTruncatedHint, Data: append(prefix, data...)})
+		}
+	}
+	return ops
+}
+
+// execute runs ops against a new instance of server until a request is rejected, as the server is expected to stop
+// serving requests after rejecting one. It returns the outcome of each op that was run, and the exit error of the
+// server.
+func execute(server Server, ops []Op, timeout time.Duration) ([]outcome, error, error) {
+	conn, err := server.Start(timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	var outcomes []outcome
+	for _, op := range ops {
+		var o outcome
+		switch op.Kind {
+		case OpGet:
+			o.value, o.err = conn.Get(op.Key)
+		case OpHint:
+			o.err = conn.Hint(op.Data)
+		case OpTruncatedGet:
+			o.err = conn.WritePreimageRequest(op.Data)
+			o.rejected = true
+		case OpTruncatedHint:
+			o.err = conn.WriteHintRequest(op.Data)
+			o.rejected = true
+		}
+		if IsRejected(o.err) {
+			o.rejected = true
+			o.err = nil
+		}
+		outcomes = append(outcomes, o)
+		if o.rejected || o.err != nil {
+			break
+		}
+	}
+	return outcomes, conn.Close(), nil
+}
+
+// compare returns the reason the actual outcomes differ from the expected outcomes, or the empty string if they match.
+func compare(expected []outcome, actual []outcome, exitErr error) string {
+	if errors.Is(exitErr, ErrCrashed) || errors.Is(exitErr, ErrNoExit) {
+		return exitErr.Error()
+	}
+	for i := 0; i < max(len(expected), len(actual)); i++ {
+		if i >= len(expected) {
+			return fmt.Sprintf("op %d: expected server to stop but got %v", i, actual[i])
+		}
+		if i >= len(actual) {
+			return fmt.Sprintf("op %d: expected %v but server stopped", i, expected[i])
+		}
+		if !expected[i].equal(actual[i]) {
+			return fmt.Sprintf("op %d: expected %v but got %v", i, expected[i], actual[i])
+		}
+	}
+	if len(expected) > 0 && !expected[len(expected)-1].rejected && exitErr != nil {
+		return fmt.Sprintf("server exited with error: %v", exitErr)
+	}
+	return ""
+}
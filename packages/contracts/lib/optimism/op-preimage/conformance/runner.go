@@ -0,0 +1,39 @@
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Result is the outcome of running a Case.
+type Result struct {
+	Case Case
+	Err  error
+}
+
+// Run runs each case against a new instance of the server.
+// The timeout applies to each request, and waiting for the server to exit.
+func Run(server Server, f *Fixture, cases []Case, timeout time.Duration) []Result {
+	results := make([]Result, 0, len(cases))
+	for _, c := range cases {
+		results = append(results, Result{Case: c, Err: runCase(server, f, c, timeout)})
+	}
+	return results
+}
+
+func runCase(server Server, f *Fixture, c Case, timeout time.Duration) error {
+	conn, err := server.Start(timeout)
+	if err != nil {
+		return err
+	}
+	runErr := c.Run(conn, f)
+	exitErr := conn.Close()
+	if errors.Is(exitErr, ErrCrashed) || errors.Is(exitErr, ErrNoExit) {
+		return errors.Join(runErr, exitErr)
+	}
+	if exitErr != nil && !c.Malformed {
+		return errors.Join(runErr, fmt.Errorf("server exited with error: %w", exitErr))
+	}
+	return runErr
+}
@@ -0,0 +1,305 @@
+package conformance
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+var (
+	// ErrNoResponse is returned when the server doesn't respond to a request before the timeout.
+	ErrNoResponse = errors.New("no response from server")
+	// ErrClosed is returned when the server closes the channel without responding to a request.
+	ErrClosed = errors.New("server closed channel")
+	// ErrCrashed is returned when the server process crashes, rather than exiting with an error.
+	ErrCrashed = errors.New("server crashed")
+	// ErrNoExit is returned when the server doesn't exit after its channels are closed.
+	ErrNoExit = errors.New("server did not exit after channels were closed")
+)
+
+// panicExitCodes are the exit codes used by the Go (2) and Rust (101) runtimes when a program panics.
+var panicExitCodes = []int{2, 101}
+
+// Server is a pre-image server under test.
+type Server interface {
+	// Start starts a new instance of the server, connected to the returned Conn.
+	// The timeout applies to each request sent over the Conn.
+	Start(timeout time.Duration) (*Conn, error)
+}
+
+// Serve serves hints and pre-image requests until both channels are closed, or a request fails.
+// Hints and pre-images are served concurrently, as the channels are independent.
+func Serve(hintChannel io.ReadWriter, preimageChannel io.ReadWriter, getter preimage.PreimageGetter, hinter preimage.HintHandler) error {
+	errs := make(chan error, 2)
+	go func() {
+		server := preimage.NewOracleServer(preimageChannel)
+		for {
+			if err := server.NextPreimageRequest(getter); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	go func() {
+		reader := preimage.NewHintReader(hintChannel)
+		for {
+			if err := reader.NextHint(hinter); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	for i := 0; i < 2; i++ {
+		if err := <-errs; !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReferenceServer runs the reference OracleServer and HintReader in-process, serving pre-images from a Fixture.
+type ReferenceServer struct {
+	Fixture *Fixture
+}
+
+var _ Server = (*ReferenceServer)(nil)
+
+func (s *ReferenceServer) Start(timeout time.Duration) (*Conn, error) {
+	return startInProcess(timeout, s.Fixture.Get)
+}
+
+// InProcessServer runs a pre-image server implemented in Go in-process, connected to the Conn by pipes.
+// This allows servers that embed the pre-image server in a larger program, such as the op-program host,
+// to be checked without building a binary.
+type InProcessServer struct {
+	// Serve serves hints and pre-image requests on the channels until both are closed by the client.
+	Serve func(hintChannel preimage.FileChannel, preimageChannel preimage.FileChannel) error
+}
+
+var _ Server = (*InProcessServer)(nil)
+
+func (s *InProcessServer) Start(timeout time.Duration) (*Conn, error) {
+	pClient, pServer, err := preimage.CreateBidirectionalChannel()
+	if err != nil {
+		return nil, err
+	}
+	hClient, hServer, err := preimage.CreateBidirectionalChannel()
+	if err != nil {
+		return nil, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		err := s.Serve(hServer, pServer)
+		// Close the server side of the channels so the client sees the server has stopped
+		_ = hServer.Close()
+		_ = pServer.Close()
+		done <- err
+	}()
+	return newConn(hClient, pClient, timeout, func() error {
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(timeout):
+			return ErrNoExit
+		}
+	}), nil
+}
+
+// startInProcess serves pre-images from getter using the reference OracleServer and HintReader.
+func startInProcess(timeout time.Duration, getter preimage.PreimageGetter) (*Conn, error) {
+	server := &InProcessServer{
+		Serve: func(hintChannel preimage.FileChannel, preimageChannel preimage.FileChannel) error {
+			return Serve(hintChannel, preimageChannel, getter, func(hint string) error { return nil })
+		},
+	}
+	return server.Start(timeout)
+}
+
+// ProcessServer runs a pre-image server binary in server mode.
+// The hint channel is passed to the process as file descriptors 3 (read) and 4 (write),
+// and the pre-image channel as file descriptors 5 (read) and 6 (write).
+type ProcessServer struct {
+	Name   string
+	Args   []string
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+var _ Server = (*ProcessServer)(nil)
+
+func (s *ProcessServer) Start(timeout time.Duration) (*Conn, error) {
+	pClient, pServer, err := preimage.CreateBidirectionalChannel()
+	if err != nil {
+		return nil, err
+	}
+	hClient, hServer, err := preimage.CreateBidirectionalChannel()
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(s.Name, s.Args...) // nosemgrep
+	cmd.Stdout = s.Stdout
+	cmd.Stderr = s.Stderr
+	cmd.ExtraFiles = []*os.File{
+		hServer.Reader(),
+		hServer.Writer(),
+		pServer.Reader(),
+		pServer.Writer(),
+	}
+	if err := cmd.Start(); err != nil {
+		_ = hClient.Close()
+		_ = pClient.Close()
+		return nil, fmt.Errorf("failed to start server: %w", err)
+	}
+	// Close our copy of the server side of the channels so the client sees when the process exits
+	_ = hServer.Close()
+	_ = pServer.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	return newConn(hClient, pClient, timeout, func() error {
+		select {
+		case err := <-done:
+			return classifyExit(err)
+		case <-time.After(timeout):
+			_ = cmd.Process.Kill()
+			<-done
+			return ErrNoExit
+		}
+	}), nil
+}
+
+// classifyExit wraps the error from a process exiting due to a signal or runtime panic in ErrCrashed.
+func classifyExit(err error) error {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return err
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return fmt.Errorf("%w: killed by signal %v", ErrCrashed, status.Signal())
+	}
+	for _, code := range panicExitCodes {
+		if exitErr.ExitCode() == code {
+			return fmt.Errorf("%w: exit code %d", ErrCrashed, code)
+		}
+	}
+	return err
+}
+
+// Conn is the client side of a connection to a Server.
+// Unlike preimage.OracleClient and preimage.HintWriter, errors are returned rather than causing a panic,
+// and requests fail with ErrNoResponse if the server doesn't respond within the timeout.
+type Conn struct {
+	hints     preimage.FileChannel
+	preimages preimage.FileChannel
+	timeout   time.Duration
+	wait      func() error
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+func newConn(hints preimage.FileChannel, preimages preimage.FileChannel, timeout time.Duration, wait func() error) *Conn {
+	return &Conn{hints: hints, preimages: preimages, timeout: timeout, wait: wait}
+}
+
+// Get requests the pre-image for key.
+func (c *Conn) Get(key [32]byte) ([]byte, error) {
+	if err := c.write(c.preimages, key[:]); err != nil {
+		return nil, fmt.Errorf("failed to write key: %w", err)
+	}
+	var lengthBytes [8]byte
+	if err := c.read(c.preimages, lengthBytes[:]); err != nil {
+		return nil, fmt.Errorf("failed to read length prefix: %w", err)
+	}
+	length := binary.BigEndian.Uint64(lengthBytes[:])
+	if length > 2*LargePreimageSize {
+		return nil, fmt.Errorf("pre-image length %d is too large", length)
+	}
+	value := make([]byte, length)
+	if err := c.read(c.preimages, value); err != nil {
+		return nil, fmt.Errorf("failed to read pre-image (length %d): %w", length, err)
+	}
+	return value, nil
+}
+
+// Hint sends a hint and waits for it to be acknowledged.
+func (c *Conn) Hint(hint []byte) error {
+	data := binary.BigEndian.AppendUint32(nil, uint32(len(hint)))
+	data = append(data, hint...)
+	if err := c.write(c.hints, data); err != nil {
+		return fmt.Errorf("failed to write hint: %w", err)
+	}
+	var ack [1]byte
+	if err := c.read(c.hints, ack[:]); err != nil {
+		return fmt.Errorf("failed to read hint ack: %w", err)
+	}
+	return nil
+}
+
+// WritePreimageRequest writes raw data to the pre-image channel, without reading a response.
+func (c *Conn) WritePreimageRequest(data []byte) error {
+	return c.write(c.preimages, data)
+}
+
+// WriteHintRequest writes raw data to the hint channel, without reading a response.
+func (c *Conn) WriteHintRequest(data []byte) error {
+	return c.write(c.hints, data)
+}
+
+// Close closes the client side of the channels and waits for the server to exit.
+// An error is returned if the server exited with an error, and wraps ErrCrashed if it crashed.
+func (c *Conn) Close() error {
+	c.closeOnce.Do(func() {
+		// Closing the writers signals the end of requests to the server
+		_ = c.hints.Writer().Close()
+		_ = c.preimages.Writer().Close()
+		c.closeErr = c.wait()
+		_ = c.hints.Reader().Close()
+		_ = c.preimages.Reader().Close()
+	})
+	return c.closeErr
+}
+
+func (c *Conn) write(ch preimage.FileChannel, data []byte) error {
+	if err := ch.Writer().SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+		return err
+	}
+	_, err := ch.Write(data)
+	return classifyIOErr(err)
+}
+
+func (c *Conn) read(ch preimage.FileChannel, data []byte) error {
+	if err := ch.Reader().SetReadDeadline(time.Now().Add(c.timeout)); err != nil {
+		return err
+	}
+	_, err := io.ReadFull(ch, data)
+	return classifyIOErr(err)
+}
+
+func classifyIOErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, os.ErrDeadlineExceeded):
+		return fmt.Errorf("%w: %w", ErrNoResponse, err)
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.EPIPE):
+		return fmt.Errorf("%w: %w", ErrClosed, err)
+	default:
+		return err
+	}
+}
+
+// IsRejected returns true if err indicates the server did not respond to a request.
+func IsRejected(err error) bool {
+	return errors.Is(err, ErrNoResponse) || errors.Is(err, ErrClosed)
+}
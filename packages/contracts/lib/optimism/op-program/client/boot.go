@@ -22,11 +22,19 @@ const (
 	// These local keys are only used for custom chains
 	L2ChainConfigLocalIndex
 	RollupConfigLocalIndex
+
+	// This local key is only used for range proofs
+	L2OutputIntervalLocalIndex
 )
 
-// CustomChainIDIndicator is used to detect when the program should load custom chain configuration
+// CustomChainIDIndicator is used to detect when the program should load custom boot info: the chain configuration.
 const CustomChainIDIndicator = uint64(math.MaxUint64)
 
+// CustomRangeChainIDIndicator is used to detect when the program should load custom boot info for a range proof:
+// the chain configuration and the output interval. It is separate from CustomChainIDIndicator so that the local keys
+// requested for custom chains that prove a single output are unchanged.
+const CustomRangeChainIDIndicator = uint64(math.MaxUint64 - 1)
+
 type BootInfo struct {
 	L1Head             common.Hash
 	L2OutputRoot       common.Hash
@@ -36,6 +44,10 @@ type BootInfo struct {
 
 	L2ChainConfig *params.ChainConfig
 	RollupConfig  *rollup.Config
+
+	// L2OutputInterval is the number of L2 blocks between the output roots proven by a range proof,
+	// or 0 to prove the single output at L2ClaimBlockNumber.
+	L2OutputInterval uint64
 }
 
 type oracleClient interface {
@@ -59,7 +71,8 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 
 	var l2ChainConfig *params.ChainConfig
 	var rollupConfig *rollup.Config
-	if l2ChainID == CustomChainIDIndicator {
+	var l2OutputInterval uint64
+	if l2ChainID == CustomChainIDIndicator || l2ChainID == CustomRangeChainIDIndicator {
 		l2ChainConfig = new(params.ChainConfig)
 		err := json.Unmarshal(br.r.Get(L2ChainConfigLocalIndex), &l2ChainConfig)
 		if err != nil {
@@ -70,6 +83,11 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		if err != nil {
 			panic("failed to bootstrap rollup config")
 		}
+		// The output interval is only requested for range proofs, so fault dispute games that prove a single
+		// output never request it.
+		if l2ChainID == CustomRangeChainIDIndicator {
+			l2OutputInterval = binary.BigEndian.Uint64(br.r.Get(L2OutputIntervalLocalIndex))
+		}
 	} else {
 		var err error
 		rollupConfig, err = chainconfig.RollupConfigByChainID(l2ChainID)
@@ -90,5 +108,6 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		L2ChainID:          l2ChainID,
 		L2ChainConfig:      l2ChainConfig,
 		RollupConfig:       rollupConfig,
+		L2OutputInterval:   l2OutputInterval,
 	}
 }
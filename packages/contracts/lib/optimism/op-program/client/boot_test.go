@@ -39,6 +39,23 @@ func TestBootstrapClient_CustomChain(t *testing.T) {
 		L2ChainConfig:      chainconfig.OPSepoliaChainConfig(),
 		RollupConfig:       chaincfg.OPSepolia(),
 	}
+	// The output interval must not be requested
+	mockOracle := &mockBoostrapOracle{bootInfo, true}
+	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
+	require.EqualValues(t, bootInfo, readBootInfo)
+}
+
+func TestBootstrapClient_CustomRange(t *testing.T) {
+	bootInfo := &BootInfo{
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1,
+		L2ChainID:          CustomRangeChainIDIndicator,
+		L2ChainConfig:      chainconfig.OPSepoliaChainConfig(),
+		RollupConfig:       chaincfg.OPSepolia(),
+		L2OutputInterval:   100,
+	}
 	mockOracle := &mockBoostrapOracle{bootInfo, true}
 	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
 	require.EqualValues(t, bootInfo, readBootInfo)
@@ -86,6 +103,11 @@ func (o *mockBoostrapOracle) Get(key preimage.Key) []byte {
 		}
 		b, _ := json.Marshal(o.b.RollupConfig)
 		return b
+	case L2OutputIntervalLocalIndex.PreimageKey():
+		if o.b.L2ChainID != CustomRangeChainIDIndicator {
+			panic(fmt.Sprintf("unexpected oracle request for preimage key %x", key.PreimageKey()))
+		}
+		return binary.BigEndian.AppendUint64(nil, o.b.L2OutputInterval)
 	default:
 		panic("unknown key")
 	}
@@ -0,0 +1,129 @@
+package claim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrInvalidOutputInterval = errors.New("output interval must be greater than 0")
+
+// RangeBlockNumbers returns the L2 block numbers of the outputs proven by a range proof from agreedBlockNum to
+// l2ClaimBlockNum: every interval blocks after the agreed block, and the claim block.
+// If the claim block is not after the agreed block, only the claim block is proven.
+func RangeBlockNumbers(agreedBlockNum uint64, l2ClaimBlockNum uint64, interval uint64) ([]uint64, error) {
+	if interval == 0 {
+		return nil, ErrInvalidOutputInterval
+	}
+	var blocks []uint64
+	for n := agreedBlockNum + interval; n < l2ClaimBlockNum && n > agreedBlockNum; n += interval {
+		blocks = append(blocks, n)
+	}
+	return append(blocks, l2ClaimBlockNum), nil
+}
+
+// OutputRootsCommitment returns the root of the binary merkle tree of the output roots, with each node the keccak256
+// hash of its children. The tree is padded with zero hashes to a power of two leaves.
+// The commitment to a single output root is the output root itself.
+func OutputRootsCommitment(outputRoots []eth.Bytes32) common.Hash {
+	if len(outputRoots) == 0 {
+		return common.Hash{}
+	}
+	layer := make([]common.Hash, len(outputRoots))
+	for i, root := range outputRoots {
+		layer[i] = common.Hash(root)
+	}
+	for len(layer) > 1 {
+		layer = nextLayer(layer)
+	}
+	return layer[0]
+}
+
+// OutputRootProof returns the sibling hashes proving the output root at index against OutputRootsCommitment,
+// starting from the leaf.
+func OutputRootProof(outputRoots []eth.Bytes32, index int) ([]common.Hash, error) {
+	if index < 0 || index >= len(outputRoots) {
+		return nil, fmt.Errorf("index %d out of range for %d output roots", index, len(outputRoots))
+	}
+	layer := make([]common.Hash, len(outputRoots))
+	for i, root := range outputRoots {
+		layer[i] = common.Hash(root)
+	}
+	var proof []common.Hash
+	for len(layer) > 1 {
+		sibling := index ^ 1
+		if sibling < len(layer) {
+			proof = append(proof, layer[sibling])
+		} else {
+			proof = append(proof, common.Hash{})
+		}
+		layer = nextLayer(layer)
+		index /= 2
+	}
+	return proof, nil
+}
+
+// VerifyOutputRootProof checks that outputRoot is at index in the output roots committed to by commitment.
+func VerifyOutputRootProof(commitment common.Hash, outputRoot eth.Bytes32, index int, proof []common.Hash) bool {
+	if index < 0 || index >= 1<<len(proof) {
+		return false
+	}
+	node := common.Hash(outputRoot)
+	for _, sibling := range proof {
+		if index%2 == 0 {
+			node = crypto.Keccak256Hash(node[:], sibling[:])
+		} else {
+			node = crypto.Keccak256Hash(sibling[:], node[:])
+		}
+		index /= 2
+	}
+	return node == commitment
+}
+
+// nextLayer hashes each pair of nodes, padding odd layers with a zero hash.
+func nextLayer(layer []common.Hash) []common.Hash {
+	next := make([]common.Hash, (len(layer)+1)/2)
+	for i := range next {
+		left := layer[2*i]
+		var right common.Hash
+		if 2*i+1 < len(layer) {
+			right = layer[2*i+1]
+		}
+		next[i] = crypto.Keccak256Hash(left[:], right[:])
+	}
+	return next
+}
+
+// ValidateClaimRange validates that claimedCommitment is the OutputRootsCommitment of the output roots of each block
+// in RangeBlockNumbers. As with ValidateClaim, blocks after the safe head use the output root of the safe head.
+// The computed output roots are returned even if the claim is invalid.
+func ValidateClaimRange(log log.Logger, agreedBlockNum uint64, l2ClaimBlockNum uint64, interval uint64, claimedCommitment eth.Bytes32, src L2Source) ([]eth.Bytes32, error) {
+	blocks, err := RangeBlockNumbers(agreedBlockNum, l2ClaimBlockNum, interval)
+	if err != nil {
+		return nil, err
+	}
+	l2Head, err := src.L2BlockRefByLabel(context.Background(), eth.Safe)
+	if err != nil {
+		return nil, fmt.Errorf("cannot retrieve safe head: %w", err)
+	}
+	outputRoots := make([]eth.Bytes32, len(blocks))
+	for i, blockNum := range blocks {
+		outputRoots[i], err = src.L2OutputRoot(min(blockNum, l2Head.Number))
+		if err != nil {
+			return nil, fmt.Errorf("calculate L2 output root of block %d: %w", blockNum, err)
+		}
+		log.Info("Computed output root", "index", i, "block", blockNum, "output", outputRoots[i])
+	}
+	commitment := OutputRootsCommitment(outputRoots)
+	log.Info("Validating range claim", "head", l2Head, "outputs", len(outputRoots), "commitment", commitment, "claim", claimedCommitment)
+	if common.Hash(claimedCommitment) != commitment {
+		return outputRoots, fmt.Errorf("%w: claim: %v actual: %v", ErrClaimNotValid, claimedCommitment, commitment)
+	}
+	return outputRoots, nil
+}
@@ -0,0 +1,197 @@
+package claim
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// rangeL2 returns an output root for each block number up to the safe head.
+type rangeL2 struct {
+	safeHead      uint64
+	outputRootErr error
+
+	requestedOutputRoots []uint64
+}
+
+func (m *rangeL2) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	if label != eth.Safe {
+		panic("unexpected usage")
+	}
+	return eth.L2BlockRef{Number: m.safeHead}, nil
+}
+
+func (m *rangeL2) L2OutputRoot(u uint64) (eth.Bytes32, error) {
+	m.requestedOutputRoots = append(m.requestedOutputRoots, u)
+	if m.outputRootErr != nil {
+		return eth.Bytes32{}, m.outputRootErr
+	}
+	if u > m.safeHead {
+		panic("output root requested after safe head")
+	}
+	return outputRootAt(u), nil
+}
+
+var _ L2Source = (*rangeL2)(nil)
+
+func outputRootAt(blockNum uint64) eth.Bytes32 {
+	return eth.Bytes32(crypto.Keccak256Hash(binary.BigEndian.AppendUint64(nil, blockNum)))
+}
+
+func outputRootsAt(blockNums ...uint64) []eth.Bytes32 {
+	roots := make([]eth.Bytes32, len(blockNums))
+	for i, n := range blockNums {
+		roots[i] = outputRootAt(n)
+	}
+	return roots
+}
+
+func TestRangeBlockNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		agreed   uint64
+		claim    uint64
+		interval uint64
+		expected []uint64
+	}{
+		{"SingleBlock", 10, 11, 1, []uint64{11}},
+		{"EveryBlock", 10, 13, 1, []uint64{11, 12, 13}},
+		{"ExactMultiple", 10, 40, 10, []uint64{20, 30, 40}},
+		{"PartialLastInterval", 10, 35, 10, []uint64{20, 30, 35}},
+		{"IntervalLongerThanRange", 10, 15, 100, []uint64{15}},
+		{"ClaimAtAgreed", 10, 10, 5, []uint64{10}},
+		{"ClaimBeforeAgreed", 10, 5, 5, []uint64{5}},
+		{"Overflow", 10, ^uint64(0), ^uint64(0) - 5, []uint64{^uint64(0)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := RangeBlockNumbers(test.agreed, test.claim, test.interval)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("ZeroInterval", func(t *testing.T) {
+		_, err := RangeBlockNumbers(10, 20, 0)
+		require.ErrorIs(t, err, ErrInvalidOutputInterval)
+	})
+}
+
+func TestOutputRootsCommitment(t *testing.T) {
+	roots := outputRootsAt(1, 2, 3)
+	hash := func(a, b common.Hash) common.Hash {
+		return crypto.Keccak256Hash(a[:], b[:])
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		require.Equal(t, common.Hash{}, OutputRootsCommitment(nil))
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		require.Equal(t, common.Hash(roots[0]), OutputRootsCommitment(roots[:1]))
+	})
+
+	t.Run("Pair", func(t *testing.T) {
+		expected := hash(common.Hash(roots[0]), common.Hash(roots[1]))
+		require.Equal(t, expected, OutputRootsCommitment(roots[:2]))
+	})
+
+	t.Run("PaddedWithZeroHashes", func(t *testing.T) {
+		expected := hash(
+			hash(common.Hash(roots[0]), common.Hash(roots[1])),
+			hash(common.Hash(roots[2]), common.Hash{}))
+		require.Equal(t, expected, OutputRootsCommitment(roots))
+	})
+}
+
+func TestOutputRootProof(t *testing.T) {
+	for _, count := range []int{1, 2, 3, 4, 5, 8, 13} {
+		blocks := make([]uint64, count)
+		for i := range blocks {
+			blocks[i] = uint64(i + 1)
+		}
+		roots := outputRootsAt(blocks...)
+		commitment := OutputRootsCommitment(roots)
+		for i, root := range roots {
+			proof, err := OutputRootProof(roots, i)
+			require.NoError(t, err)
+			require.Truef(t, VerifyOutputRootProof(commitment, root, i, proof), "count %d index %d", count, i)
+			require.Falsef(t, VerifyOutputRootProof(commitment, eth.Bytes32{0xaa}, i, proof), "count %d index %d", count, i)
+			if count > 1 {
+				require.Falsef(t, VerifyOutputRootProof(commitment, root, i^1, proof), "count %d index %d", count, i)
+			}
+		}
+	}
+
+	t.Run("IndexOutOfRange", func(t *testing.T) {
+		roots := outputRootsAt(1, 2)
+		_, err := OutputRootProof(roots, 2)
+		require.Error(t, err)
+		_, err = OutputRootProof(roots, -1)
+		require.Error(t, err)
+		require.False(t, VerifyOutputRootProof(OutputRootsCommitment(roots), roots[0], 2, []common.Hash{common.Hash(roots[1])}))
+	})
+}
+
+func TestValidateClaimRange(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		l2 := &rangeL2{safeHead: 100}
+		expected := outputRootsAt(20, 30, 35)
+		logger := testlog.Logger(t, log.LevelError)
+		roots, err := ValidateClaimRange(logger, 10, 35, 10, eth.Bytes32(OutputRootsCommitment(expected)), l2)
+		require.NoError(t, err)
+		require.Equal(t, expected, roots)
+		require.Equal(t, []uint64{20, 30, 35}, l2.requestedOutputRoots)
+	})
+
+	t.Run("Valid-PriorToSafeHead", func(t *testing.T) {
+		l2 := &rangeL2{safeHead: 25}
+		expected := outputRootsAt(20, 25, 25)
+		logger := testlog.Logger(t, log.LevelError)
+		roots, err := ValidateClaimRange(logger, 10, 35, 10, eth.Bytes32(OutputRootsCommitment(expected)), l2)
+		require.NoError(t, err)
+		require.Equal(t, expected, roots)
+		require.Equal(t, []uint64{20, 25, 25}, l2.requestedOutputRoots)
+	})
+
+	t.Run("SingleOutputMatchesValidateClaim", func(t *testing.T) {
+		l2 := &rangeL2{safeHead: 100}
+		logger := testlog.Logger(t, log.LevelError)
+		_, err := ValidateClaimRange(logger, 10, 15, 100, outputRootAt(15), l2)
+		require.NoError(t, err)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		l2 := &rangeL2{safeHead: 100}
+		expected := outputRootsAt(20, 30, 35)
+		claimed := outputRootsAt(20, 31, 35)
+		logger := testlog.Logger(t, log.LevelError)
+		roots, err := ValidateClaimRange(logger, 10, 35, 10, eth.Bytes32(OutputRootsCommitment(claimed)), l2)
+		require.ErrorIs(t, err, ErrClaimNotValid)
+		require.Equal(t, expected, roots)
+	})
+
+	t.Run("ZeroInterval", func(t *testing.T) {
+		l2 := &rangeL2{safeHead: 100}
+		logger := testlog.Logger(t, log.LevelError)
+		_, err := ValidateClaimRange(logger, 10, 35, 0, eth.Bytes32{}, l2)
+		require.ErrorIs(t, err, ErrInvalidOutputInterval)
+	})
+
+	t.Run("Error-output-root", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		l2 := &rangeL2{safeHead: 100, outputRootErr: expectedErr}
+		logger := testlog.Logger(t, log.LevelError)
+		_, err := ValidateClaimRange(logger, 10, 35, 10, eth.Bytes32{}, l2)
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
@@ -74,3 +74,7 @@ func (o *CachingOracle) OutputByRoot(root common.Hash) eth.Output {
 	o.outputs.Add(root, output)
 	return output
 }
+
+func (o *CachingOracle) HintBlockExecution(blockNumber uint64) {
+	o.oracle.HintBlockExecution(blockNumber)
+}
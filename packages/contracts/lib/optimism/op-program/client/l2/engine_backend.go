@@ -20,6 +20,9 @@ import (
 	"github.com/ethereum/go-ethereum/triedb"
 )
 
+// BlockObserver is notified of each block executed by the OracleBackedL2Chain.
+type BlockObserver func(block *types.Block)
+
 type OracleBackedL2Chain struct {
 	log        log.Logger
 	oracle     Oracle
@@ -38,13 +41,15 @@ type OracleBackedL2Chain struct {
 	// Inserted blocks
 	blocks map[common.Hash]*types.Block
 	db     ethdb.KeyValueStore
+
+	observer BlockObserver
 }
 
 // Must implement CachingEngineBackend, not just EngineBackend to ensure that blocks are stored when they are created
 // and don't need to be re-executed when sent back via execution_newPayload.
 var _ engineapi.CachingEngineBackend = (*OracleBackedL2Chain)(nil)
 
-func NewOracleBackedL2Chain(logger log.Logger, oracle Oracle, precompileOracle engineapi.PrecompileOracle, chainCfg *params.ChainConfig, l2OutputRoot common.Hash) (*OracleBackedL2Chain, error) {
+func NewOracleBackedL2Chain(logger log.Logger, oracle Oracle, precompileOracle engineapi.PrecompileOracle, precompiles *engineapi.PrecompileRegistry, chainCfg *params.ChainConfig, l2OutputRoot common.Hash) (*OracleBackedL2Chain, error) {
 	output := oracle.OutputByRoot(l2OutputRoot)
 	outputV0, ok := output.(*eth.OutputV0)
 	if !ok {
@@ -71,7 +76,7 @@ func NewOracleBackedL2Chain(logger log.Logger, oracle Oracle, precompileOracle e
 		blocks:     make(map[common.Hash]*types.Block),
 		db:         NewOracleBackedDB(oracle),
 		vmCfg: vm.Config{
-			PrecompileOverrides: engineapi.CreatePrecompileOverrides(precompileOracle),
+			PrecompileOverrides: precompiles.CreatePrecompileOverrides(precompileOracle),
 		},
 	}, nil
 }
@@ -182,6 +187,11 @@ func (o *OracleBackedL2Chain) StateAt(root common.Hash) (*state.StateDB, error)
 	return stateDB, nil
 }
 
+// HintBlockExecution implements engineapi.BlockExecutionHinter.
+func (o *OracleBackedL2Chain) HintBlockExecution(parent *types.Header) {
+	o.oracle.HintBlockExecution(parent.Number.Uint64() + 1)
+}
+
 func (o *OracleBackedL2Chain) InsertBlockWithoutSetHead(block *types.Block, makeWitness bool) (*stateless.Witness, error) {
 	processor, err := engineapi.NewBlockProcessorFromHeader(o, block.Header())
 	if err != nil {
@@ -213,9 +223,17 @@ func (o *OracleBackedL2Chain) AssembleAndInsertBlockWithoutSetHead(processor *en
 		return nil, fmt.Errorf("commit block: %w", err)
 	}
 	o.blocks[block.Hash()] = block
+	if o.observer != nil {
+		o.observer(block)
+	}
 	return block, nil
 }
 
+// SetBlockObserver sets the observer notified after each block is executed.
+func (o *OracleBackedL2Chain) SetBlockObserver(observer BlockObserver) {
+	o.observer = observer
+}
+
 func (o *OracleBackedL2Chain) SetCanonical(head *types.Block) (common.Hash, error) {
 	oldHead := o.head
 	o.head = head.Header()
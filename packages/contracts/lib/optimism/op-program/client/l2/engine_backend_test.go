@@ -97,6 +97,20 @@ func TestAppendToChain(t *testing.T) {
 	require.Equal(t, newBlock.Header(), chain.GetHeaderByNumber(uint64(4)), "get canonical header for new head")
 }
 
+func TestBlockObserver(t *testing.T) {
+	blocks, chain := setupOracleBackedChainWithLowerHead(t, 4, 2)
+	var observed []common.Hash
+	chain.SetBlockObserver(func(block *types.Block) {
+		observed = append(observed, block.Hash())
+	})
+
+	_, err := chain.InsertBlockWithoutSetHead(blocks[3], false)
+	require.NoError(t, err)
+	_, err = chain.InsertBlockWithoutSetHead(blocks[4], false)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{blocks[3].Hash(), blocks[4].Hash()}, observed)
+}
+
 func TestSetFinalized(t *testing.T) {
 	blocks, chain := setupOracleBackedChainWithLowerHead(t, 5, 0)
 	for _, block := range blocks[1:] {
@@ -259,7 +273,7 @@ func TestPrecompileOracle(t *testing.T) {
 			precompileOracle.Results = map[common.Hash]l2test.PrecompileResult{
 				crypto.Keccak256Hash(arg): {Result: test.result, Ok: true},
 			}
-			chain, err := NewOracleBackedL2Chain(logger, oracle, precompileOracle, chainCfg, common.Hash(eth.OutputRoot(&stubOutput)))
+			chain, err := NewOracleBackedL2Chain(logger, oracle, precompileOracle, engineapi.NewDefaultPrecompileRegistry(), chainCfg, common.Hash(eth.OutputRoot(&stubOutput)))
 			require.NoError(t, err)
 
 			newBlock := createBlock(t, chain, WithInput(test.input), WithTargetAddress(test.target))
@@ -288,7 +302,7 @@ func setupOracleBackedChainWithLowerHead(t *testing.T, blockCount int, headBlock
 	head := blocks[headBlockNumber].Hash()
 	stubOutput := eth.OutputV0{BlockHash: head}
 	precompileOracle := l2test.NewStubPrecompileOracle(t)
-	chain, err := NewOracleBackedL2Chain(logger, oracle, precompileOracle, chainCfg, common.Hash(eth.OutputRoot(&stubOutput)))
+	chain, err := NewOracleBackedL2Chain(logger, oracle, precompileOracle, engineapi.NewDefaultPrecompileRegistry(), chainCfg, common.Hash(eth.OutputRoot(&stubOutput)))
 	require.NoError(t, err)
 	return blocks, chain
 }
@@ -30,6 +30,12 @@ type BlockDataProvider interface {
 	consensus.ChainHeaderReader
 }
 
+// BlockExecutionHinter is optionally implemented by a BlockDataProvider to be notified before a block is executed,
+// so that the state required to execute it can be fetched in advance.
+type BlockExecutionHinter interface {
+	HintBlockExecution(parent *types.Header)
+}
+
 type BlockProcessor struct {
 	header       *types.Header
 	state        *state.StateDB
@@ -73,6 +79,9 @@ func NewBlockProcessorFromHeader(provider BlockDataProvider, h *types.Header) (*
 	if header.Time <= parentHeader.Time {
 		return nil, errors.New("invalid timestamp")
 	}
+	if hinter, ok := provider.(BlockExecutionHinter); ok {
+		hinter.HintBlockExecution(parentHeader)
+	}
 	statedb, err := provider.StateAt(parentHeader.Root)
 	if err != nil {
 		return nil, fmt.Errorf("get parent state: %w", err)
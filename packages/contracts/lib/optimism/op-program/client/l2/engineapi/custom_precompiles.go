@@ -0,0 +1,10 @@
+package engineapi
+
+// customPrecompiles are the additional precompiles accelerated by this build of op-program.
+// Both the client program and the host use NewDefaultPrecompileRegistry unless a registry is configured explicitly,
+// so precompiles added here are accelerated by the client and served by the host without any further configuration.
+//
+// Chains with additional precompiles that L1 also provides can build a custom op-program by adding them here, for
+// example P256VerifyPrecompile. The client program changes, so the absolute prestate must be rebuilt and the host
+// must be built from the same source.
+var customPrecompiles = []AcceleratedPrecompile{}
@@ -0,0 +1,190 @@
+package engineapi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	ErrDuplicatePrecompile = errors.New("duplicate accelerated precompile")
+	ErrInvalidPrecompile   = errors.New("invalid accelerated precompile")
+	errPrecompileOracle    = errors.New("precompile oracle reported failure")
+)
+
+var p256VerifyPrecompileAddress = common.BytesToAddress([]byte{0x01, 0x00})
+
+// InputValidator checks the input to an accelerated precompile before the oracle is called.
+// If the input is invalid, it returns false along with the result and error the precompile must return without
+// calling the oracle, matching the behavior of the original precompile.
+type InputValidator func(input []byte) (ok bool, result []byte, err error)
+
+// AcceleratedPrecompile is a precompile that the client executes by requesting the result from the pre-image oracle,
+// rather than performing the computation in the fault proof VM.
+//
+// The onchain PreimageOracle executes the precompile on L1 to provide the result, so it must be available at the same
+// address on L1, with identical behavior to the L2 precompile for every input accepted by ValidateInput.
+type AcceleratedPrecompile struct {
+	Address common.Address
+	Name    string
+	// Implementation computes the result in the host. It must match the L1 precompile.
+	Implementation vm.PrecompiledContract
+	// RequiredGas computes the gas used by the precompile. Uses the gas of the L2 precompile if nil.
+	RequiredGas func(input []byte) uint64
+	// ValidateInput checks the input before it is sent to the oracle. All inputs are sent to the oracle if nil.
+	ValidateInput InputValidator
+	// override creates a custom override of the original precompile, for built-in precompiles that require
+	// fork specific behavior. A generic override using RequiredGas and ValidateInput is used if nil.
+	override func(rules params.Rules, orig vm.PrecompiledContract, oracle PrecompileOracle) vm.PrecompiledContract
+}
+
+// PrecompileRegistry is the set of precompiles accelerated by the pre-image oracle.
+// The client and host must use registries with the same precompiles.
+type PrecompileRegistry struct {
+	precompiles map[common.Address]AcceleratedPrecompile
+}
+
+// NewPrecompileRegistry creates a registry with the built-in accelerated precompiles and the additional precompiles.
+func NewPrecompileRegistry(additional ...AcceleratedPrecompile) (*PrecompileRegistry, error) {
+	r := &PrecompileRegistry{precompiles: make(map[common.Address]AcceleratedPrecompile)}
+	for _, p := range append(builtinPrecompiles(), additional...) {
+		if err := r.Register(p); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// NewDefaultPrecompileRegistry creates a registry with the built-in accelerated precompiles and the custom
+// precompiles of this build. It is used by both the client program and the host so they always match.
+func NewDefaultPrecompileRegistry() *PrecompileRegistry {
+	r, err := NewPrecompileRegistry(customPrecompiles...)
+	if err != nil {
+		panic(fmt.Errorf("invalid default precompiles: %w", err))
+	}
+	return r
+}
+
+// Register adds an accelerated precompile to the registry.
+func (r *PrecompileRegistry) Register(p AcceleratedPrecompile) error {
+	if p.Address == (common.Address{}) || p.Implementation == nil {
+		return fmt.Errorf("%w: %v requires an address and implementation", ErrInvalidPrecompile, p.Name)
+	}
+	if existing, ok := r.precompiles[p.Address]; ok {
+		return fmt.Errorf("%w: %v and %v at %v", ErrDuplicatePrecompile, existing.Name, p.Name, p.Address)
+	}
+	r.precompiles[p.Address] = p
+	return nil
+}
+
+// Get returns the accelerated precompile at address.
+func (r *PrecompileRegistry) Get(address common.Address) (AcceleratedPrecompile, bool) {
+	p, ok := r.precompiles[address]
+	return p, ok
+}
+
+// Addresses returns the addresses of the accelerated precompiles in ascending order.
+func (r *PrecompileRegistry) Addresses() []common.Address {
+	addrs := make([]common.Address, 0, len(r.precompiles))
+	for addr := range r.precompiles {
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, func(a, b common.Address) int { return a.Cmp(b) })
+	return addrs
+}
+
+// CreatePrecompileOverrides overrides the accelerated precompiles to retrieve their results from precompileOracle.
+func (r *PrecompileRegistry) CreatePrecompileOverrides(precompileOracle PrecompileOracle) vm.PrecompileOverrides {
+	return func(rules params.Rules, orig vm.PrecompiledContract, address common.Address) vm.PrecompiledContract {
+		if orig == nil { // Only override existing contracts. Never introduce a precompile that is not there.
+			return nil
+		}
+		// NOTE: Ignoring chain rules for now. We assume that precompile behavior won't change for the foreseeable future
+		p, ok := r.precompiles[address]
+		if !ok {
+			return orig
+		}
+		if p.override != nil {
+			return p.override(rules, orig, precompileOracle)
+		}
+		return &acceleratedPrecompileOracle{Orig: orig, Oracle: precompileOracle, Precompile: p}
+	}
+}
+
+func builtinPrecompiles() []AcceleratedPrecompile {
+	return []AcceleratedPrecompile{
+		{
+			Address:        ecrecoverPrecompileAddress,
+			Name:           "ecrecover",
+			Implementation: vm.PrecompiledContractsCancun[ecrecoverPrecompileAddress],
+			override: func(rules params.Rules, orig vm.PrecompiledContract, oracle PrecompileOracle) vm.PrecompiledContract {
+				return &ecrecoverOracle{Orig: orig, Oracle: oracle}
+			},
+		},
+		{
+			Address:        bn256PairingPrecompileAddress,
+			Name:           "bn256Pairing",
+			Implementation: vm.PrecompiledContractsCancun[bn256PairingPrecompileAddress],
+			override: func(rules params.Rules, orig vm.PrecompiledContract, oracle PrecompileOracle) vm.PrecompiledContract {
+				precompile := bn256PairingOracle{Orig: orig, Oracle: oracle}
+				if rules.IsOptimismGranite {
+					return &bn256PairingOracleGranite{precompile}
+				}
+				return &precompile
+			},
+		},
+		{
+			Address:        kzgPointEvaluationPrecompileAddress,
+			Name:           "kzgPointEvaluation",
+			Implementation: vm.PrecompiledContractsCancun[kzgPointEvaluationPrecompileAddress],
+			override: func(rules params.Rules, orig vm.PrecompiledContract, oracle PrecompileOracle) vm.PrecompiledContract {
+				return &kzgPointEvaluationOracle{Orig: orig, Oracle: oracle}
+			},
+		},
+	}
+}
+
+// P256VerifyPrecompile accelerates the RIP-7212 secp256r1 signature verification precompile.
+// It is not accelerated by default because it is only usable when L1 supports P256VERIFY at the same address.
+var P256VerifyPrecompile = AcceleratedPrecompile{
+	Address:        p256VerifyPrecompileAddress,
+	Name:           "p256Verify",
+	Implementation: vm.PrecompiledContractsFjord[p256VerifyPrecompileAddress],
+	ValidateInput: func(input []byte) (bool, []byte, error) {
+		const p256VerifyInputLength = 160
+		// Invalid input lengths return an empty result rather than failing
+		return len(input) == p256VerifyInputLength, nil, nil
+	},
+}
+
+// acceleratedPrecompileOracle implements a generic AcceleratedPrecompile, using the pre-image oracle to compute the
+// result of valid inputs.
+type acceleratedPrecompileOracle struct {
+	Orig       vm.PrecompiledContract
+	Oracle     PrecompileOracle
+	Precompile AcceleratedPrecompile
+}
+
+func (c *acceleratedPrecompileOracle) RequiredGas(input []byte) uint64 {
+	if c.Precompile.RequiredGas != nil {
+		return c.Precompile.RequiredGas(input)
+	}
+	return c.Orig.RequiredGas(input)
+}
+
+func (c *acceleratedPrecompileOracle) Run(input []byte) ([]byte, error) {
+	if c.Precompile.ValidateInput != nil {
+		if ok, result, err := c.Precompile.ValidateInput(input); !ok {
+			return result, err
+		}
+	}
+	result, ok := c.Oracle.Precompile(c.Precompile.Address, input, c.RequiredGas(input))
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", errPrecompileOracle, c.Precompile.Name)
+	}
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package engineapi
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrecompileRegistry(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		registry := NewDefaultPrecompileRegistry()
+		require.Equal(t, []common.Address{ecrecoverPrecompileAddress, bn256PairingPrecompileAddress, kzgPointEvaluationPrecompileAddress}, registry.Addresses())
+		_, ok := registry.Get(p256VerifyPrecompileAddress)
+		require.False(t, ok)
+	})
+
+	t.Run("DefaultIncludesCustomPrecompiles", func(t *testing.T) {
+		orig := customPrecompiles
+		t.Cleanup(func() { customPrecompiles = orig })
+		customPrecompiles = []AcceleratedPrecompile{P256VerifyPrecompile}
+		registry := NewDefaultPrecompileRegistry()
+		_, ok := registry.Get(p256VerifyPrecompileAddress)
+		require.True(t, ok)
+	})
+
+	t.Run("Additional", func(t *testing.T) {
+		registry, err := NewPrecompileRegistry(P256VerifyPrecompile)
+		require.NoError(t, err)
+		require.Contains(t, registry.Addresses(), p256VerifyPrecompileAddress)
+		precompile, ok := registry.Get(p256VerifyPrecompileAddress)
+		require.True(t, ok)
+		require.Equal(t, "p256Verify", precompile.Name)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		_, err := NewPrecompileRegistry(AcceleratedPrecompile{Address: ecrecoverPrecompileAddress, Name: "custom", Implementation: &stubPrecompile{}})
+		require.ErrorIs(t, err, ErrDuplicatePrecompile)
+
+		registry, err := NewPrecompileRegistry(P256VerifyPrecompile)
+		require.NoError(t, err)
+		require.ErrorIs(t, registry.Register(P256VerifyPrecompile), ErrDuplicatePrecompile)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		registry := NewDefaultPrecompileRegistry()
+		require.ErrorIs(t, registry.Register(AcceleratedPrecompile{Name: "noAddress", Implementation: &stubPrecompile{}}), ErrInvalidPrecompile)
+		require.ErrorIs(t, registry.Register(AcceleratedPrecompile{Address: common.Address{0xaa}, Name: "noImplementation"}), ErrInvalidPrecompile)
+	})
+}
+
+func TestCustomPrecompile(t *testing.T) {
+	validInput := make([]byte, 160)
+	setup := func(t *testing.T, precompile AcceleratedPrecompile) (*stubPrecompileOracle, func(orig *stubPrecompile) vm.PrecompiledContract) {
+		registry, err := NewPrecompileRegistry(precompile)
+		require.NoError(t, err)
+		oracle := &stubPrecompileOracle{}
+		overrides := registry.CreatePrecompileOverrides(oracle)
+		return oracle, func(orig *stubPrecompile) vm.PrecompiledContract {
+			return overrides(params.Rules{}, orig, precompile.Address)
+		}
+	}
+
+	t.Run("Overridden", func(t *testing.T) {
+		registry, err := NewPrecompileRegistry(P256VerifyPrecompile)
+		require.NoError(t, err)
+		overrides := registry.CreatePrecompileOverrides(&stubPrecompileOracle{})
+		orig := &stubPrecompile{}
+		require.IsType(t, &acceleratedPrecompileOracle{}, overrides(params.Rules{}, orig, p256VerifyPrecompileAddress))
+		require.Nil(t, overrides(params.Rules{}, nil, p256VerifyPrecompileAddress), "should not add new pre-compiles")
+
+		// Not overridden unless registered
+		overrides = CreatePrecompileOverrides(&stubPrecompileOracle{})
+		require.Same(t, orig, overrides(params.Rules{}, orig, p256VerifyPrecompileAddress))
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		oracle, override := setup(t, P256VerifyPrecompile)
+		precompile := override(&stubPrecompile{})
+		require.Equal(t, stubRequiredGas, precompile.RequiredGas(validInput))
+		result, err := precompile.Run(validInput)
+		require.NoError(t, err)
+		require.Equal(t, defaultOracleResult, result)
+		require.Equal(t, p256VerifyPrecompileAddress, oracle.calledAddr)
+		require.Equal(t, validInput, oracle.calledInput)
+		require.Equal(t, stubRequiredGas, oracle.calledRequiredGas)
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		oracle, override := setup(t, P256VerifyPrecompile)
+		result, err := override(&stubPrecompile{}).Run(validInput[1:])
+		require.NoError(t, err)
+		require.Nil(t, result)
+		require.Nil(t, oracle.calledInput, "should not call oracle")
+	})
+
+	t.Run("OracleFailure", func(t *testing.T) {
+		oracle, override := setup(t, P256VerifyPrecompile)
+		oracle.failureResponse = true
+		_, err := override(&stubPrecompile{}).Run(validInput)
+		require.ErrorIs(t, err, errPrecompileOracle)
+	})
+
+	t.Run("CustomRequiredGas", func(t *testing.T) {
+		custom := AcceleratedPrecompile{
+			Address:        common.Address{0xaa},
+			Name:           "custom",
+			Implementation: &stubPrecompile{},
+			RequiredGas:    func(input []byte) uint64 { return uint64(len(input)) * 10 },
+		}
+		oracle, override := setup(t, custom)
+		precompile := override(&stubPrecompile{})
+		require.Equal(t, uint64(30), precompile.RequiredGas([]byte{1, 2, 3}))
+		_, err := precompile.Run([]byte{1, 2, 3})
+		require.NoError(t, err)
+		require.Equal(t, uint64(30), oracle.calledRequiredGas)
+	})
+}
@@ -49,27 +49,9 @@ type PrecompileOracle interface {
 	Precompile(address common.Address, input []byte, requiredGas uint64) ([]byte, bool)
 }
 
+// CreatePrecompileOverrides overrides the built-in accelerated precompiles to retrieve their results from precompileOracle.
 func CreatePrecompileOverrides(precompileOracle PrecompileOracle) vm.PrecompileOverrides {
-	return func(rules params.Rules, orig vm.PrecompiledContract, address common.Address) vm.PrecompiledContract {
-		if orig == nil { // Only override existing contracts. Never introduce a precompile that is not there.
-			return nil
-		}
-		// NOTE: Ignoring chain rules for now. We assume that precompile behavior won't change for the foreseeable future
-		switch address {
-		case ecrecoverPrecompileAddress:
-			return &ecrecoverOracle{Orig: orig, Oracle: precompileOracle}
-		case bn256PairingPrecompileAddress:
-			precompile := bn256PairingOracle{Orig: orig, Oracle: precompileOracle}
-			if rules.IsOptimismGranite {
-				return &bn256PairingOracleGranite{precompile}
-			}
-			return &precompile
-		case kzgPointEvaluationPrecompileAddress:
-			return &kzgPointEvaluationOracle{Orig: orig, Oracle: precompileOracle}
-		default:
-			return orig
-		}
-	}
+	return NewDefaultPrecompileRegistry().CreatePrecompileOverrides(precompileOracle)
 }
 
 var (
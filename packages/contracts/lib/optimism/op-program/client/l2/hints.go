@@ -1,7 +1,10 @@
 package l2
 
 import (
+	"encoding/binary"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
@@ -12,6 +15,8 @@ const (
 	HintL2Code         = "l2-code"
 	HintL2StateNode    = "l2-state-node"
 	HintL2Output       = "l2-output"
+
+	HintL2ExecutionWitness = "l2-execution-witness"
 )
 
 type BlockHeaderHint common.Hash
@@ -53,3 +58,12 @@ var _ preimage.Hint = L2OutputHint{}
 func (l L2OutputHint) Hint() string {
 	return HintL2Output + " " + (common.Hash)(l).String()
 }
+
+// ExecutionWitnessHint hints that the state required to execute the block with the given number will be requested.
+type ExecutionWitnessHint uint64
+
+var _ preimage.Hint = ExecutionWitnessHint(0)
+
+func (l ExecutionWitnessHint) Hint() string {
+	return HintL2ExecutionWitness + " " + hexutil.Encode(binary.BigEndian.AppendUint64(nil, uint64(l)))
+}
@@ -34,6 +34,10 @@ type Oracle interface {
 	BlockByHash(blockHash common.Hash) *types.Block
 
 	OutputByRoot(root common.Hash) eth.Output
+
+	// HintBlockExecution hints that the block with the given number is about to be executed, allowing the host to
+	// fetch all the state it requires at once. The state is still retrieved with NodeByHash and CodeByHash.
+	HintBlockExecution(blockNumber uint64)
 }
 
 // PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
@@ -93,6 +97,10 @@ func (p *PreimageOracle) CodeByHash(codeHash common.Hash) []byte {
 	return p.oracle.Get(preimage.Keccak256Key(codeHash))
 }
 
+func (p *PreimageOracle) HintBlockExecution(blockNumber uint64) {
+	p.hint.Hint(ExecutionWitnessHint(blockNumber))
+}
+
 func (p *PreimageOracle) OutputByRoot(l2OutputRoot common.Hash) eth.Output {
 	p.hint.Hint(L2OutputHint(l2OutputRoot))
 	data := p.oracle.Get(preimage.Keccak256Key(l2OutputRoot))
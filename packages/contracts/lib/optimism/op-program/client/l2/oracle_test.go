@@ -140,3 +140,10 @@ func TestPreimageOracleOutputByRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestPreimageOracleHintBlockExecution(t *testing.T) {
+	po, hints, _ := mockPreimageOracle(t)
+	hints.On("hint", "l2-execution-witness 0x000000000000002a").Once().Return()
+	po.HintBlockExecution(42)
+	hints.AssertExpectations(t)
+}
@@ -69,6 +69,8 @@ func (o StubBlockOracle) OutputByRoot(root common.Hash) eth.Output {
 	return output
 }
 
+func (o StubBlockOracle) HintBlockExecution(blockNumber uint64) {}
+
 // KvStateOracle loads data from a source ethdb.KeyValueStore
 type KvStateOracle struct {
 	t      *testing.T
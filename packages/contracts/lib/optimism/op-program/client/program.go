@@ -12,19 +12,58 @@ import (
 	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 )
 
+type programConfig struct {
+	precompiles         *engineapi.PrecompileRegistry
+	blockObserver       l2.BlockObserver
+	outputRootsObserver OutputRootsObserver
+}
+
+// OutputRootsObserver is notified of the output roots computed by a range proof, so that a game type can bisect over
+// them. It is called even if the claim is invalid.
+type OutputRootsObserver func(outputRoots []eth.Bytes32) error
+
+// Option configures the client program.
+type Option func(cfg *programConfig)
+
+// WithPrecompileRegistry sets the precompiles accelerated by the pre-image oracle.
+// The host must serve the same precompiles. The default registry of this build is used if precompiles is nil.
+func WithPrecompileRegistry(precompiles *engineapi.PrecompileRegistry) Option {
+	return func(cfg *programConfig) {
+		if precompiles != nil {
+			cfg.precompiles = precompiles
+		}
+	}
+}
+
+// WithBlockObserver sets an observer notified after each L2 block is executed.
+func WithBlockObserver(observer l2.BlockObserver) Option {
+	return func(cfg *programConfig) {
+		cfg.blockObserver = observer
+	}
+}
+
+// WithOutputRootsObserver sets an observer notified of the output roots computed by a range proof.
+// It isn't called when proving a single output.
+func WithOutputRootsObserver(observer OutputRootsObserver) Option {
+	return func(cfg *programConfig) {
+		cfg.outputRootsObserver = observer
+	}
+}
+
 // Main executes the client program in a detached context and exits the current process.
 // The client runtime environment must be preset before calling this function.
-func Main(logger log.Logger) {
+func Main(logger log.Logger, opts ...Option) {
 	log.Info("Starting fault proof program client")
 	preimageOracle := preimage.ClientPreimageChannel()
 	preimageHinter := preimage.ClientHinterChannel()
-	if err := RunProgram(logger, preimageOracle, preimageHinter); errors.Is(err, claim.ErrClaimNotValid) {
+	if err := RunProgram(logger, preimageOracle, preimageHinter, opts...); errors.Is(err, claim.ErrClaimNotValid) {
 		log.Error("Claim is invalid", "err", err)
 		os.Exit(1)
 	} else if err != nil {
@@ -37,14 +76,22 @@ func Main(logger log.Logger) {
 }
 
 // RunProgram executes the Program, while attached to an IO based pre-image oracle, to be served by a host.
-func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter) error {
+func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter, opts ...Option) error {
+	cfg := &programConfig{precompiles: engineapi.NewDefaultPrecompileRegistry()}
+	for _, opt := range opts {
+		opt(cfg)
+	}
 	pClient := preimage.NewOracleClient(preimageOracle)
 	hClient := preimage.NewHintWriter(preimageHinter)
 	l1PreimageOracle := l1.NewCachingOracle(l1.NewPreimageOracle(pClient, hClient))
 	l2PreimageOracle := l2.NewCachingOracle(l2.NewPreimageOracle(pClient, hClient))
 
-	bootInfo := NewBootstrapClient(pClient).BootInfo()
+	bootClient := NewBootstrapClient(pClient)
+	bootInfo := bootClient.BootInfo()
 	logger.Info("Program Bootstrapped", "bootInfo", bootInfo)
+	if bootInfo.L2OutputInterval != 0 {
+		logger.Info("Proving output range", "interval", bootInfo.L2OutputInterval)
+	}
 	return runDerivation(
 		logger,
 		bootInfo.RollupConfig,
@@ -53,19 +100,24 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 		bootInfo.L2OutputRoot,
 		bootInfo.L2Claim,
 		bootInfo.L2ClaimBlockNumber,
+		bootInfo.L2OutputInterval,
 		l1PreimageOracle,
 		l2PreimageOracle,
+		cfg,
 	)
 }
 
 // runDerivation executes the L2 state transition, given a minimal interface to retrieve data.
-func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
+// If outputInterval is not 0, the claim is validated as a range of outputs from the agreed output.
+func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, outputInterval uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle, programCfg *programConfig) error {
 	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
 	l1BlobsSource := l1.NewBlobFetcher(logger, l1Oracle)
-	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l1Oracle /* kzg oracle */, l2Cfg, l2OutputRoot)
+	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l1Oracle /* kzg oracle */, programCfg.precompiles, l2Cfg, l2OutputRoot)
 	if err != nil {
 		return fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
 	}
+	engineBackend.SetBlockObserver(programCfg.blockObserver)
+	agreedBlockNum := engineBackend.CurrentHeader().Number.Uint64()
 	l2Source := l2.NewOracleEngine(cfg, logger, engineBackend)
 
 	logger.Info("Starting derivation")
@@ -73,5 +125,14 @@ func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainCon
 	if err := d.RunComplete(); err != nil {
 		return fmt.Errorf("failed to run program to completion: %w", err)
 	}
-	return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+	if outputInterval == 0 {
+		return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+	}
+	outputRoots, err := claim.ValidateClaimRange(logger, agreedBlockNum, l2ClaimBlockNum, outputInterval, eth.Bytes32(l2Claim), l2Source)
+	if outputRoots != nil && programCfg.outputRootsObserver != nil {
+		if observerErr := programCfg.outputRootsObserver(outputRoots); observerErr != nil {
+			return errors.Join(err, fmt.Errorf("failed to observe output roots: %w", observerErr))
+		}
+	}
+	return err
 }
@@ -0,0 +1,252 @@
+package bundle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+const bundleVersion uint8 = 1
+
+var bundleMagic = [8]byte{'O', 'P', 'B', 'U', 'N', 'D', 'L', 'E'}
+
+var (
+	ErrInvalidBundle      = errors.New("invalid bundle")
+	ErrUnsupportedVersion = errors.New("unsupported bundle version")
+	ErrChecksumMismatch   = errors.New("bundle checksum mismatch")
+	ErrInputsMismatch     = errors.New("bundle was recorded with different program inputs")
+)
+
+// Entry is a pre-image requested by the client program.
+type Entry struct {
+	Key   common.Hash
+	Value []byte
+}
+
+// Bundle contains the hints and pre-images used by a client program run, in the order they were first requested.
+// It contains everything required to replay the run without fetching data from L1 or L2 nodes.
+//
+// The binary format, which is always gzip compressed, is:
+//
+//	magic     [8]byte "OPBUNDLE"
+//	version   uint8
+//	hints     uint32 count, followed by uint32 length prefixed hints
+//	preimages uint32 count, followed by a 32 byte key and uint32 length prefixed value for each pre-image
+//	checksum  [32]byte keccak256 hash of all preceding data
+type Bundle struct {
+	Hints     []string
+	Preimages []Entry
+}
+
+func (b *Bundle) Serialize(out io.Writer) error {
+	hasher := crypto.NewKeccakState()
+	bout := serialize.NewBinaryWriter(io.MultiWriter(out, hasher))
+	if err := bout.WriteUInt(bundleMagic); err != nil {
+		return err
+	}
+	if err := bout.WriteUInt(bundleVersion); err != nil {
+		return err
+	}
+	if err := bout.WriteUInt(uint32(len(b.Hints))); err != nil {
+		return err
+	}
+	for _, hint := range b.Hints {
+		if err := bout.WriteBytes([]byte(hint)); err != nil {
+			return err
+		}
+	}
+	if err := bout.WriteUInt(uint32(len(b.Preimages))); err != nil {
+		return err
+	}
+	for _, entry := range b.Preimages {
+		if err := bout.WriteHash(entry.Key); err != nil {
+			return err
+		}
+		if err := bout.WriteBytes(entry.Value); err != nil {
+			return err
+		}
+	}
+	_, err := out.Write(hasher.Sum(nil))
+	return err
+}
+
+// Deserialize reads a bundle written by Serialize, verifying the checksum and that each pre-image matches its key.
+// Memory is only allocated as data is read, so a corrupt count or length can't cause a huge allocation.
+func (b *Bundle) Deserialize(in io.Reader) error {
+	hasher := crypto.NewKeccakState()
+	teed := io.TeeReader(in, hasher)
+	bin := serialize.NewBinaryReader(teed)
+	var magic [8]byte
+	if err := bin.ReadUInt(&magic); err != nil {
+		return err
+	}
+	if magic != bundleMagic {
+		return fmt.Errorf("%w: incorrect magic bytes", ErrInvalidBundle)
+	}
+	var version uint8
+	if err := bin.ReadUInt(&version); err != nil {
+		return err
+	}
+	if version != bundleVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+	var hintCount uint32
+	if err := bin.ReadUInt(&hintCount); err != nil {
+		return err
+	}
+	b.Hints = nil
+	for i := uint32(0); i < hintCount; i++ {
+		hint, err := readBytes(bin, teed)
+		if err != nil {
+			return err
+		}
+		b.Hints = append(b.Hints, string(hint))
+	}
+	var preimageCount uint32
+	if err := bin.ReadUInt(&preimageCount); err != nil {
+		return err
+	}
+	b.Preimages = nil
+	for i := uint32(0); i < preimageCount; i++ {
+		var entry Entry
+		if err := bin.ReadHash(&entry.Key); err != nil {
+			return err
+		}
+		value, err := readBytes(bin, teed)
+		if err != nil {
+			return err
+		}
+		entry.Value = value
+		b.Preimages = append(b.Preimages, entry)
+	}
+	expected := hasher.Sum(nil)
+	var checksum [32]byte
+	if _, err := io.ReadFull(in, checksum[:]); err != nil {
+		return fmt.Errorf("failed to read checksum: %w", err)
+	}
+	if !bytes.Equal(expected, checksum[:]) {
+		return ErrChecksumMismatch
+	}
+	return b.Verify()
+}
+
+// readBytes reads a uint32 length prefixed value like serialize.BinaryReader.ReadBytes, but grows the value as data
+// is read from in rather than allocating the full length up front.
+func readBytes(bin *serialize.BinaryReader, in io.Reader) ([]byte, error) {
+	var size uint32
+	if err := bin.ReadUInt(&size); err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	data, err := io.ReadAll(io.LimitReader(in, int64(size)))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != int(size) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
+// Verify checks that each keccak256 and sha256 pre-image matches its key and that no key is duplicated.
+// Other pre-images can't be verified individually so are only checked by the client program when used.
+func (b *Bundle) Verify() error {
+	values := make(map[common.Hash][]byte, len(b.Preimages))
+	for _, entry := range b.Preimages {
+		if _, ok := values[entry.Key]; ok {
+			return fmt.Errorf("%w: duplicate pre-image %v", ErrInvalidBundle, entry.Key)
+		}
+		values[entry.Key] = entry.Value
+	}
+	verified := preimage.WithVerification(func(key [32]byte) ([]byte, error) {
+		return values[key], nil
+	})
+	for _, entry := range b.Preimages {
+		if _, err := verified(entry.Key); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+		}
+	}
+	return nil
+}
+
+// CheckLocalInputs verifies that the local pre-images in the bundle match those provided by local,
+// which ensures the bundle is replayed with the same program inputs it was recorded with.
+func (b *Bundle) CheckLocalInputs(local kvstore.PreimageSource) error {
+	for _, entry := range b.Preimages {
+		if entry.Key[0] != byte(preimage.LocalKeyType) {
+			continue
+		}
+		value, err := local(entry.Key)
+		if err != nil {
+			return fmt.Errorf("failed to get local pre-image %v: %w", entry.Key, err)
+		}
+		if !bytes.Equal(value, entry.Value) {
+			return fmt.Errorf("%w: local pre-image %v is %x but bundle has %x", ErrInputsMismatch, entry.Key, value, entry.Value)
+		}
+	}
+	return nil
+}
+
+// KV returns an in-memory key-value store containing the global pre-images of the bundle.
+// Local pre-images are excluded as they are always provided from the program inputs.
+func (b *Bundle) KV() (*kvstore.MemKV, error) {
+	kv := kvstore.NewMemKV()
+	for _, entry := range b.Preimages {
+		if entry.Key[0] == byte(preimage.LocalKeyType) {
+			continue
+		}
+		if err := kv.Put(entry.Key, entry.Value); err != nil {
+			return nil, err
+		}
+	}
+	return kv, nil
+}
+
+// Write atomically writes the bundle to path, gzip compressed.
+func Write(path string, b *Bundle) error {
+	out, err := ioutil.NewAtomicWriter(path, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle file %v: %w", path, err)
+	}
+	defer out.Abort()
+	gz := gzip.NewWriter(out)
+	if err := b.Serialize(gz); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to compress bundle: %w", err)
+	}
+	return out.Close()
+}
+
+// Read reads and verifies the bundle at path.
+func Read(path string) (*Bundle, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle %v: %w", path, err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	defer gz.Close()
+	var b Bundle
+	if err := b.Deserialize(gz); err != nil {
+		return nil, fmt.Errorf("failed to read bundle %v: %w", path, err)
+	}
+	return &b, nil
+}
@@ -0,0 +1,184 @@
+package bundle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+)
+
+func testBundle() *Bundle {
+	keccakData := []byte("keccak preimage")
+	sha256Data := []byte("sha256 preimage")
+	return &Bundle{
+		Hints: []string{"l1-block-header 0x1234", "l2-block-header 0x5678"},
+		Preimages: []Entry{
+			{Key: preimage.LocalIndexKey(1).PreimageKey(), Value: common.Hash{0xaa}.Bytes()},
+			{Key: preimage.Keccak256Key(crypto.Keccak256Hash(keccakData)).PreimageKey(), Value: keccakData},
+			{Key: preimage.Sha256Key(sha256.Sum256(sha256Data)).PreimageKey(), Value: sha256Data},
+			{Key: preimage.BlobKey(common.Hash{0xbb}).PreimageKey(), Value: []byte{}},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Run("Serialize", func(t *testing.T) {
+		expected := testBundle()
+		var buf bytes.Buffer
+		require.NoError(t, expected.Serialize(&buf))
+		var actual Bundle
+		require.NoError(t, actual.Deserialize(&buf))
+		require.Equal(t, expected.Hints, actual.Hints)
+		require.Len(t, actual.Preimages, len(expected.Preimages))
+		for i, entry := range expected.Preimages {
+			require.Equal(t, entry.Key, actual.Preimages[i].Key)
+			require.Equal(t, common.Bytes2Hex(entry.Value), common.Bytes2Hex(actual.Preimages[i].Value))
+		}
+	})
+
+	t.Run("File", func(t *testing.T) {
+		expected := testBundle()
+		path := filepath.Join(t.TempDir(), "bundle.bin")
+		require.NoError(t, Write(path, expected))
+		// Bundles are always compressed, regardless of the file extension
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		_, err = gzip.NewReader(f)
+		require.NoError(t, err)
+
+		actual, err := Read(path)
+		require.NoError(t, err)
+		require.Equal(t, expected.Hints, actual.Hints)
+		require.Len(t, actual.Preimages, len(expected.Preimages))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bundle.bin.gz")
+		require.NoError(t, Write(path, &Bundle{}))
+		actual, err := Read(path)
+		require.NoError(t, err)
+		require.Empty(t, actual.Hints)
+		require.Empty(t, actual.Preimages)
+	})
+}
+
+func TestDeserializeInvalid(t *testing.T) {
+	serialized := func(t *testing.T, b *Bundle) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, b.Serialize(&buf))
+		return buf.Bytes()
+	}
+
+	t.Run("Magic", func(t *testing.T) {
+		data := serialized(t, testBundle())
+		data[0] = 'X'
+		var b Bundle
+		require.ErrorIs(t, b.Deserialize(bytes.NewReader(data)), ErrInvalidBundle)
+	})
+
+	t.Run("Version", func(t *testing.T) {
+		data := serialized(t, testBundle())
+		data[len(bundleMagic)] = bundleVersion + 1
+		var b Bundle
+		require.ErrorIs(t, b.Deserialize(bytes.NewReader(data)), ErrUnsupportedVersion)
+	})
+
+	t.Run("Checksum", func(t *testing.T) {
+		data := serialized(t, testBundle())
+		// Modify the first byte of the first hint, after the magic, version, hint count and hint length
+		data[len(bundleMagic)+1+4+4] ^= 0xff
+		var b Bundle
+		require.ErrorIs(t, b.Deserialize(bytes.NewReader(data)), ErrChecksumMismatch)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		data := serialized(t, testBundle())
+		var b Bundle
+		require.Error(t, b.Deserialize(bytes.NewReader(data[:len(data)-10])))
+	})
+
+	t.Run("HugeHintCount", func(t *testing.T) {
+		data := serialized(t, &Bundle{})
+		binary.BigEndian.PutUint32(data[len(bundleMagic)+1:], math.MaxUint32)
+		var b Bundle
+		require.Error(t, b.Deserialize(bytes.NewReader(data)))
+	})
+
+	t.Run("HugePreimageCount", func(t *testing.T) {
+		data := serialized(t, &Bundle{})
+		binary.BigEndian.PutUint32(data[len(bundleMagic)+1+4:], math.MaxUint32)
+		var b Bundle
+		require.Error(t, b.Deserialize(bytes.NewReader(data)))
+	})
+
+	t.Run("HugePreimageLength", func(t *testing.T) {
+		data := serialized(t, &Bundle{Preimages: []Entry{{Key: preimage.LocalIndexKey(1).PreimageKey(), Value: []byte{0x01}}}})
+		binary.BigEndian.PutUint32(data[len(bundleMagic)+1+4+4+32:], math.MaxUint32)
+		var b Bundle
+		require.Error(t, b.Deserialize(bytes.NewReader(data)))
+	})
+
+	t.Run("IncorrectPreimage", func(t *testing.T) {
+		bundle := testBundle()
+		bundle.Preimages[1].Value = []byte("wrong")
+		var b Bundle
+		err := b.Deserialize(bytes.NewReader(serialized(t, bundle)))
+		require.ErrorIs(t, err, ErrInvalidBundle)
+		require.ErrorIs(t, err, preimage.ErrIncorrectData)
+	})
+
+	t.Run("DuplicatePreimage", func(t *testing.T) {
+		bundle := testBundle()
+		bundle.Preimages = append(bundle.Preimages, bundle.Preimages[1])
+		var b Bundle
+		require.ErrorIs(t, b.Deserialize(bytes.NewReader(serialized(t, bundle))), ErrInvalidBundle)
+	})
+}
+
+func TestCheckLocalInputs(t *testing.T) {
+	bundle := testBundle()
+	local := func(key common.Hash) ([]byte, error) {
+		if key == preimage.LocalIndexKey(1).PreimageKey() {
+			return common.Hash{0xaa}.Bytes(), nil
+		}
+		return nil, kvstore.ErrNotFound
+	}
+	require.NoError(t, bundle.CheckLocalInputs(local))
+
+	differentInputs := func(key common.Hash) ([]byte, error) {
+		return common.Hash{0xcc}.Bytes(), nil
+	}
+	require.ErrorIs(t, bundle.CheckLocalInputs(differentInputs), ErrInputsMismatch)
+
+	missingInputs := func(key common.Hash) ([]byte, error) {
+		return nil, kvstore.ErrNotFound
+	}
+	require.ErrorIs(t, bundle.CheckLocalInputs(missingInputs), kvstore.ErrNotFound)
+}
+
+func TestKV(t *testing.T) {
+	bundle := testBundle()
+	kv, err := bundle.KV()
+	require.NoError(t, err)
+	for _, entry := range bundle.Preimages {
+		value, err := kv.Get(entry.Key)
+		if entry.Key[0] == byte(preimage.LocalKeyType) {
+			require.ErrorIs(t, err, kvstore.ErrNotFound, "local pre-images are provided by the program inputs")
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, common.Bytes2Hex(entry.Value), common.Bytes2Hex(value))
+	}
+}
@@ -0,0 +1,77 @@
+package bundle
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// Recorder records the hints and pre-image keys used by a client program so they can be exported as a Bundle.
+// Only the keys are recorded, the values are read from the pre-image source when the bundle is created.
+// Recorder is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	hints     []string
+	seenHints map[string]struct{}
+	keys      []common.Hash
+	seenKeys  map[common.Hash]struct{}
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		seenHints: make(map[string]struct{}),
+		seenKeys:  make(map[common.Hash]struct{}),
+	}
+}
+
+// Hinter wraps hinter to record each distinct hint in the order it was first received.
+func (r *Recorder) Hinter(hinter preimage.HintHandler) preimage.HintHandler {
+	return func(hint string) error {
+		r.mu.Lock()
+		if _, ok := r.seenHints[hint]; !ok {
+			r.seenHints[hint] = struct{}{}
+			r.hints = append(r.hints, hint)
+		}
+		r.mu.Unlock()
+		return hinter(hint)
+	}
+}
+
+// Getter wraps getter to record the key of each pre-image successfully retrieved,
+// in the order it was first requested.
+func (r *Recorder) Getter(getter preimage.PreimageGetter) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		value, err := getter(key)
+		if err != nil {
+			return nil, err
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if _, ok := r.seenKeys[key]; !ok {
+			r.seenKeys[key] = struct{}{}
+			r.keys = append(r.keys, key)
+		}
+		return value, nil
+	}
+}
+
+// Bundle creates a bundle of the recorded hints and pre-images, reading the pre-image values from source.
+func (r *Recorder) Bundle(source preimage.PreimageGetter) (*Bundle, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := &Bundle{
+		Hints:     append([]string(nil), r.hints...),
+		Preimages: make([]Entry, 0, len(r.keys)),
+	}
+	for _, key := range r.keys {
+		value, err := source(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pre-image %v: %w", key, err)
+		}
+		b.Preimages = append(b.Preimages, Entry{Key: key, Value: value})
+	}
+	return b, nil
+}
@@ -0,0 +1,51 @@
+package bundle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+)
+
+func TestRecorder(t *testing.T) {
+	kv := kvstore.NewMemKV()
+	keyA := common.Hash{0x02, 0xaa}
+	keyB := common.Hash{0x02, 0xbb}
+	keyMissing := common.Hash{0x02, 0xcc}
+	require.NoError(t, kv.Put(keyA, []byte("a")))
+	require.NoError(t, kv.Put(keyB, []byte("b")))
+
+	recorder := NewRecorder()
+	getter := recorder.Getter(func(key [32]byte) ([]byte, error) { return kv.Get(key) })
+	hintErr := errors.New("boom")
+	hinter := recorder.Hinter(func(hint string) error {
+		if hint == "fail" {
+			return hintErr
+		}
+		return nil
+	})
+
+	require.NoError(t, hinter("hint-b"))
+	require.NoError(t, hinter("hint-a"))
+	require.NoError(t, hinter("hint-b"))
+	require.ErrorIs(t, hinter("fail"), hintErr)
+
+	for _, key := range []common.Hash{keyB, keyA, keyB} {
+		_, err := getter(key)
+		require.NoError(t, err)
+	}
+	_, err := getter(keyMissing)
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+
+	b, err := recorder.Bundle(func(key [32]byte) ([]byte, error) { return kv.Get(key) })
+	require.NoError(t, err)
+	require.Equal(t, []string{"hint-b", "hint-a", "fail"}, b.Hints, "should record distinct hints in order")
+	require.Equal(t, []Entry{{Key: keyB, Value: []byte("b")}, {Key: keyA, Value: []byte("a")}}, b.Preimages,
+		"should record distinct available pre-images in order")
+
+	_, err = recorder.Bundle(func(key [32]byte) ([]byte, error) { return nil, kvstore.ErrNotFound })
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -302,6 +303,34 @@ func TestL2BlockNumber(t *testing.T) {
 	})
 }
 
+func TestL2OutputInterval(t *testing.T) {
+	t.Run("DefaultZero", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.L2OutputInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l2.output-interval", "100"))
+		require.EqualValues(t, 100, cfg.L2OutputInterval)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"something\" for flag -l2.output-interval", addRequiredArgs("--l2.output-interval", "something"))
+	})
+}
+
+func TestL2OutputRoots(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.L2OutputRoots)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l2.output-interval", "100", "--l2.output-roots", "/tmp/outputs.json"))
+		require.Equal(t, "/tmp/outputs.json", cfg.L2OutputRoots)
+	})
+}
+
 func TestExec(t *testing.T) {
 	t.Run("DefaultEmpty", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -336,6 +365,69 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestRPCRateLimit(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.L1RPCRateLimit)
+		require.Zero(t, cfg.L2RPCRateLimit)
+	})
+	t.Run("L1", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l1.rpc-rate-limit", "2.5"))
+		require.Equal(t, 2.5, cfg.L1RPCRateLimit)
+	})
+	t.Run("L2", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l2.rpc-rate-limit", "10"))
+		require.Equal(t, 10.0, cfg.L2RPCRateLimit)
+	})
+}
+
+func TestPrefetchConcurrency(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, flags.DefaultPrefetchConcurrency, cfg.PrefetchConcurrency)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.concurrency", "8"))
+		require.Equal(t, 8, cfg.PrefetchConcurrency)
+	})
+}
+
+func TestBundle(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.Bundle)
+		require.Equal(t, "", cfg.RecordBundle)
+	})
+	t.Run("Record", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--bundle.record", "/tmp/bundle.bin.gz"))
+		require.Equal(t, "/tmp/bundle.bin.gz", cfg.RecordBundle)
+	})
+	t.Run("Replay", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--bundle", "/tmp/bundle.bin.gz"))
+		require.Equal(t, "/tmp/bundle.bin.gz", cfg.Bundle)
+	})
+}
+
+func TestEstimate(t *testing.T) {
+	t.Run("DefaultDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.Estimate)
+		require.Equal(t, "", cfg.EstimateModel)
+		require.Equal(t, "", cfg.EstimateReport)
+		require.Zero(t, cfg.EstimateMaxSteps)
+		require.Zero(t, cfg.EstimateMaxMemory)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--estimate", "--estimate.model", "/tmp/model.json",
+			"--estimate.report", "/tmp/report.json", "--estimate.max-steps", "1000", "--estimate.max-memory", "2000"))
+		require.True(t, cfg.Estimate)
+		require.Equal(t, "/tmp/model.json", cfg.EstimateModel)
+		require.Equal(t, "/tmp/report.json", cfg.EstimateReport)
+		require.EqualValues(t, 1000, cfg.EstimateMaxSteps)
+		require.EqualValues(t, 2000, cfg.EstimateMaxMemory)
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -23,17 +24,25 @@ import (
 )
 
 var (
-	ErrMissingRollupConfig = errors.New("missing rollup config")
-	ErrMissingL2Genesis    = errors.New("missing l2 genesis")
-	ErrInvalidL1Head       = errors.New("invalid l1 head")
-	ErrInvalidL2Head       = errors.New("invalid l2 head")
-	ErrInvalidL2OutputRoot = errors.New("invalid l2 output root")
-	ErrL1AndL2Inconsistent = errors.New("l1 and l2 options must be specified together or both omitted")
-	ErrInvalidL2Claim      = errors.New("invalid l2 claim")
-	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
-	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
-	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
-	ErrInvalidDataFormat   = errors.New("invalid data format")
+	ErrMissingRollupConfig  = errors.New("missing rollup config")
+	ErrMissingL2Genesis     = errors.New("missing l2 genesis")
+	ErrInvalidL1Head        = errors.New("invalid l1 head")
+	ErrInvalidL2Head        = errors.New("invalid l2 head")
+	ErrInvalidL2OutputRoot  = errors.New("invalid l2 output root")
+	ErrL1AndL2Inconsistent  = errors.New("l1 and l2 options must be specified together or both omitted")
+	ErrInvalidL2Claim       = errors.New("invalid l2 claim")
+	ErrInvalidL2ClaimBlock  = errors.New("invalid l2 claim block number")
+	ErrDataDirRequired      = errors.New("datadir must be specified when in non-fetching mode")
+	ErrNoExecInServerMode   = errors.New("exec command must not be set when in server mode")
+	ErrInvalidDataFormat    = errors.New("invalid data format")
+	ErrBundleWithFetching   = errors.New("bundle must not be replayed when fetching is enabled")
+	ErrBundleWithDataDir    = errors.New("bundle must not be replayed with a datadir")
+	ErrEstimateNotNative    = errors.New("estimate requires the client program to run in-process")
+	ErrMissingEstimateModel = errors.New("estimate requires a calibrated model")
+	ErrInvalidConcurrency   = errors.New("prefetch concurrency must be at least 1")
+	ErrInvalidRateLimit     = errors.New("rpc rate limit must not be negative")
+	ErrOutputRootsNoRange   = errors.New("output roots can only be written for range proofs")
+	ErrOutputRootsNotNative = errors.New("output roots require the client program to run in-process")
 )
 
 type Config struct {
@@ -51,6 +60,8 @@ type Config struct {
 	L1BeaconURL string
 	L1TrustRPC  bool
 	L1RPCKind   sources.RPCProviderKind
+	// L1RPCRateLimit is the maximum number of L1 RPC requests per second, or 0 for no limit
+	L1RPCRateLimit float64
 
 	// L2Head is the l2 block hash contained in the L2 Output referenced by the L2OutputRoot
 	L2Head common.Hash
@@ -61,11 +72,22 @@ type Config struct {
 	L2URL string
 	// L2ExperimentalURL is the URL of the L2 node (non hash db archival node, for example, reth archival node) to fetch L2 data from
 	L2ExperimentalURL string
+	// L2RPCRateLimit is the maximum number of requests per second to each L2 RPC, or 0 for no limit
+	L2RPCRateLimit float64
+	// PrefetchConcurrency is the maximum number of hints fetched in the background at once,
+	// such as execution witnesses from L2ExperimentalURL
+	PrefetchConcurrency int
 	// L2Claim is the claimed L2 output root to verify
 	L2Claim common.Hash
 	// L2ClaimBlockNumber is the block number the claimed L2 output root is from
 	// Must be above 0 and to be a valid claim needs to be above the L2Head block.
 	L2ClaimBlockNumber uint64
+	// L2OutputInterval is the number of L2 blocks between the output roots proven by a range proof.
+	// If 0, the claim is a single output root.
+	L2OutputInterval uint64
+	// L2OutputRoots is the path to write the output roots computed by a range proof to, so that a game type can bisect
+	// over them. Requires the client program to run in-process.
+	L2OutputRoots string
 	// L2ChainConfig is the op-geth chain config for the L2 execution engine
 	L2ChainConfig *params.ChainConfig
 	// ExecCmd specifies the client program to execute in a separate process.
@@ -78,6 +100,26 @@ type Config struct {
 
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
+
+	// RecordBundle is the path to write a bundle of the hints and pre-images used by the client program to.
+	// The bundle is written when the pre-image server stops, including when the client program fails.
+	RecordBundle string
+	// Bundle is the path of a bundle to replay. All pre-images are served from the bundle, without fetching.
+	Bundle string
+
+	// Estimate runs the client program natively and estimates the steps and memory it requires in cannon.
+	Estimate bool
+	// EstimateModel is the path of the calibrated estimation model. Required if Estimate is set.
+	EstimateModel string
+	// EstimateReport is the path to write the estimate report to.
+	EstimateReport string
+	// EstimateMaxSteps and EstimateMaxMemory fail the program if the estimate exceeds them, unless 0.
+	EstimateMaxSteps  uint64
+	EstimateMaxMemory uint64
+
+	// Precompiles are the precompiles accelerated by the pre-image oracle, or nil for the default registry of this build.
+	// Must match the registry used by the client program.
+	Precompiles *engineapi.PrecompileRegistry
 }
 
 func (c *Config) Check() error {
@@ -105,15 +147,39 @@ func (c *Config) Check() error {
 	if (c.L1URL != "") != (c.L2URL != "") {
 		return ErrL1AndL2Inconsistent
 	}
-	if !c.FetchingEnabled() && c.DataDir == "" {
+	if !c.FetchingEnabled() && c.DataDir == "" && c.Bundle == "" {
 		return ErrDataDirRequired
 	}
+	if c.Bundle != "" && c.FetchingEnabled() {
+		return ErrBundleWithFetching
+	}
+	if c.Bundle != "" && c.DataDir != "" {
+		return ErrBundleWithDataDir
+	}
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.Estimate && (c.ServerMode || c.ExecCmd != "") {
+		return ErrEstimateNotNative
+	}
+	if c.L2OutputRoots != "" && c.L2OutputInterval == 0 {
+		return ErrOutputRootsNoRange
+	}
+	if c.L2OutputRoots != "" && (c.ServerMode || c.ExecCmd != "") {
+		return ErrOutputRootsNotNative
+	}
+	if c.Estimate && c.EstimateModel == "" {
+		return ErrMissingEstimateModel
+	}
 	if c.DataDir != "" && !slices.Contains(types.SupportedDataFormats, c.DataFormat) {
 		return ErrInvalidDataFormat
 	}
+	if c.PrefetchConcurrency < 1 {
+		return ErrInvalidConcurrency
+	}
+	if c.L1RPCRateLimit < 0 || c.L2RPCRateLimit < 0 {
+		return ErrInvalidRateLimit
+	}
 	return nil
 }
 
@@ -144,6 +210,7 @@ func NewConfig(
 		L1RPCKind:           sources.RPCKindStandard,
 		IsCustomChainConfig: isCustomConfig,
 		DataFormat:          types.DataFormatDirectory,
+		PrefetchConcurrency: flags.DefaultPrefetchConcurrency,
 	}
 }
 
@@ -228,14 +295,26 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		L2OutputRoot:        l2OutputRoot,
 		L2Claim:             l2Claim,
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
+		L2OutputInterval:    ctx.Uint64(flags.L2OutputInterval.Name),
+		L2OutputRoots:       ctx.String(flags.L2OutputRoots.Name),
 		L1Head:              l1Head,
 		L1URL:               ctx.String(flags.L1NodeAddr.Name),
 		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
 		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		L1RPCRateLimit:      ctx.Float64(flags.L1RPCRateLimit.Name),
+		L2RPCRateLimit:      ctx.Float64(flags.L2RPCRateLimit.Name),
+		PrefetchConcurrency: ctx.Int(flags.PrefetchConcurrency.Name),
 		ExecCmd:             ctx.String(flags.Exec.Name),
 		ServerMode:          ctx.Bool(flags.Server.Name),
 		IsCustomChainConfig: isCustomConfig,
+		RecordBundle:        ctx.String(flags.RecordBundle.Name),
+		Bundle:              ctx.String(flags.Bundle.Name),
+		Estimate:            ctx.Bool(flags.Estimate.Name),
+		EstimateModel:       ctx.String(flags.EstimateModel.Name),
+		EstimateReport:      ctx.String(flags.EstimateReport.Name),
+		EstimateMaxSteps:    ctx.Uint64(flags.EstimateMaxSteps.Name),
+		EstimateMaxMemory:   ctx.Uint64(flags.EstimateMaxMemory.Name),
 	}, nil
 }
 
@@ -155,6 +155,134 @@ func TestRequireDataDirInNonFetchingMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrDataDirRequired)
 }
 
+func TestEstimate(t *testing.T) {
+	t.Run("Native", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Estimate = true
+		cfg.EstimateModel = "/tmp/model.json"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("RequireModel", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Estimate = true
+		require.ErrorIs(t, cfg.Check(), ErrMissingEstimateModel)
+	})
+
+	t.Run("RejectServerMode", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Estimate = true
+		cfg.EstimateModel = "/tmp/model.json"
+		cfg.ServerMode = true
+		require.ErrorIs(t, cfg.Check(), ErrEstimateNotNative)
+	})
+
+	t.Run("RejectExec", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Estimate = true
+		cfg.EstimateModel = "/tmp/model.json"
+		cfg.ExecCmd = "./op-program-client"
+		require.ErrorIs(t, cfg.Check(), ErrEstimateNotNative)
+	})
+}
+
+func TestL2OutputRoots(t *testing.T) {
+	t.Run("Range", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2OutputInterval = 100
+		cfg.L2OutputRoots = "/tmp/outputs.json"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("RejectSingleOutput", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2OutputRoots = "/tmp/outputs.json"
+		require.ErrorIs(t, cfg.Check(), ErrOutputRootsNoRange)
+	})
+
+	t.Run("RejectServerMode", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2OutputInterval = 100
+		cfg.L2OutputRoots = "/tmp/outputs.json"
+		cfg.ServerMode = true
+		require.ErrorIs(t, cfg.Check(), ErrOutputRootsNotNative)
+	})
+
+	t.Run("RejectExec", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2OutputInterval = 100
+		cfg.L2OutputRoots = "/tmp/outputs.json"
+		cfg.ExecCmd = "./op-program-client"
+		require.ErrorIs(t, cfg.Check(), ErrOutputRootsNotNative)
+	})
+}
+
+func TestBundle(t *testing.T) {
+	t.Run("ReplaceDataDir", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDir = ""
+		cfg.Bundle = "/tmp/bundle.bin.gz"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("RejectDataDir", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.Bundle = "/tmp/bundle.bin.gz"
+		require.ErrorIs(t, cfg.Check(), ErrBundleWithDataDir)
+	})
+
+	t.Run("RejectFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataDir = ""
+		cfg.Bundle = "/tmp/bundle.bin.gz"
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L1BeaconURL = "https://example.com:5678"
+		cfg.L2URL = "https://example.com:91011"
+		require.ErrorIs(t, cfg.Check(), ErrBundleWithFetching)
+	})
+
+	t.Run("RecordWithDataDir", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.RecordBundle = "/tmp/bundle.bin.gz"
+		require.NoError(t, cfg.Check())
+	})
+}
+
+func TestPrefetchConcurrency(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.PrefetchConcurrency = 1
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.PrefetchConcurrency = 0
+		require.ErrorIs(t, cfg.Check(), ErrInvalidConcurrency)
+	})
+}
+
+func TestRPCRateLimit(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1RPCRateLimit = 10
+		cfg.L2RPCRateLimit = 0.5
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("NegativeL1", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1RPCRateLimit = -1
+		require.ErrorIs(t, cfg.Check(), ErrInvalidRateLimit)
+	})
+
+	t.Run("NegativeL2", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L2RPCRateLimit = -1
+		require.ErrorIs(t, cfg.Check(), ErrInvalidRateLimit)
+	})
+}
+
 func TestRejectExecAndServerMode(t *testing.T) {
 	cfg := validConfig()
 	cfg.ServerMode = true
@@ -0,0 +1,40 @@
+package host
+
+import (
+	"fmt"
+	"io"
+
+	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/estimate"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// runEstimate runs the client program in-process, estimating the cannon steps and memory of each L2 block.
+// The estimate is reported even if the client program fails.
+func runEstimate(logger log.Logger, cfg *config.Config, preimageRW io.ReadWriter, hintRW io.ReadWriter) error {
+	model, err := estimate.LoadModel(cfg.EstimateModel)
+	if err != nil {
+		return err
+	}
+	recorder := estimate.NewRecorder()
+	programErr := cl.RunProgram(logger, recorder.PreimageChannel(preimageRW), recorder.HintChannel(hintRW),
+		append(programOptions(cfg), cl.WithBlockObserver(recorder.BlockExecuted))...)
+
+	report := estimate.NewReport(model, recorder)
+	for _, block := range report.Blocks {
+		logger.Info("Estimated block", "number", block.Number, "steps", block.Steps, "memory", block.Memory,
+			"gas", block.Features.Gas, "txs", block.Features.Transactions, "preimages", block.Features.PreimageRequests)
+	}
+	logger.Info("Estimated program", "blocks", len(report.Blocks), "steps", report.Steps, "memory", report.Memory,
+		"maxBlockSteps", report.MaxBlockSteps)
+	if err := jsonutil.WriteJSON(report, ioutil.ToStdOutOrFileOrNoop(cfg.EstimateReport, 0o644)); err != nil {
+		return fmt.Errorf("failed to write estimate report: %w", err)
+	}
+	if programErr != nil {
+		return programErr
+	}
+	return report.CheckLimits(cfg.EstimateMaxSteps, cfg.EstimateMaxMemory)
+}
@@ -0,0 +1,227 @@
+package estimate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var ErrInsufficientSamples = errors.New("insufficient calibration samples")
+
+// ridge regularizes the fit so that features which don't vary between samples don't make it unsolvable.
+const ridge = 1e-9
+
+// Sample is the features of a native run of the program, and the steps and memory used by the same run in cannon.
+type Sample struct {
+	Features Features `json:"features"`
+	Steps    uint64   `json:"steps"`
+	Memory   uint64   `json:"memory"`
+}
+
+// cannonDebugInfo is the subset of the debug info written by cannon run --debug-info that is used for calibration.
+type cannonDebugInfo struct {
+	Steps      hexutil.Uint64 `json:"steps"`
+	MemoryUsed hexutil.Uint64 `json:"memory_used"`
+}
+
+// LoadSample creates a Sample from the report of a native run and the debug info of a cannon run of the same inputs.
+func LoadSample(reportPath string, debugInfoPath string) (Sample, error) {
+	report, err := jsonutil.LoadJSON[Report](reportPath)
+	if err != nil {
+		return Sample{}, fmt.Errorf("failed to load report %v: %w", reportPath, err)
+	}
+	info, err := jsonutil.LoadJSON[cannonDebugInfo](debugInfoPath)
+	if err != nil {
+		return Sample{}, fmt.Errorf("failed to load cannon debug info %v: %w", debugInfoPath, err)
+	}
+	if info.Steps == 0 {
+		return Sample{}, fmt.Errorf("cannon debug info %v has no steps", debugInfoPath)
+	}
+	return Sample{Features: report.Total, Steps: uint64(info.Steps), Memory: uint64(info.MemoryUsed)}, nil
+}
+
+// Residual is the error of a calibrated model's estimates of the samples it was fit to.
+type Residual struct {
+	// RMS is the root mean square difference between the estimates and the measured values.
+	RMS float64 `json:"rms"`
+	// MaxRelative is the largest difference between an estimate and the measured value, as a fraction of the
+	// measured value.
+	MaxRelative float64 `json:"maxRelative"`
+}
+
+// Residuals are the residuals of the steps and memory estimates of a calibrated model.
+type Residuals struct {
+	Steps  Residual `json:"steps"`
+	Memory Residual `json:"memory"`
+}
+
+// Calibrate fits a model to samples using non-negative least squares, returning the residuals of the fit.
+// At least one sample is required for each feature plus the base cost. The residuals are measured on the same
+// samples so are only meaningful when there are many more samples than that.
+// Samples should cover a range of block counts and chain activity, such as empty blocks and blocks at the gas limit.
+func Calibrate(samples []Sample) (Model, Residuals, error) {
+	minSamples := len(Features{}.values()) + 1
+	if len(samples) < minSamples {
+		return Model{}, Residuals{}, fmt.Errorf("%w: need at least %d, got %d", ErrInsufficientSamples, minSamples, len(samples))
+	}
+	xs := make([][]float64, len(samples))
+	steps := make([]float64, len(samples))
+	memory := make([]float64, len(samples))
+	for i, s := range samples {
+		xs[i] = s.Features.values()
+		steps[i] = float64(s.Steps)
+		memory[i] = float64(s.Memory)
+	}
+	stepsBase, stepsCoefficients, err := fitNonNegative(xs, steps)
+	if err != nil {
+		return Model{}, Residuals{}, fmt.Errorf("failed to fit steps: %w", err)
+	}
+	memoryBase, memoryCoefficients, err := fitNonNegative(xs, memory)
+	if err != nil {
+		return Model{}, Residuals{}, fmt.Errorf("failed to fit memory: %w", err)
+	}
+	model := Model{
+		Steps:  coefficientsFromValues(stepsBase, stepsCoefficients),
+		Memory: coefficientsFromValues(memoryBase, memoryCoefficients),
+	}
+	residuals := Residuals{
+		Steps:  residual(model.Steps, samples, func(s Sample) uint64 { return s.Steps }),
+		Memory: residual(model.Memory, samples, func(s Sample) uint64 { return s.Memory }),
+	}
+	return model, residuals, nil
+}
+
+// residual computes the residual of the estimates of c compared to the measured values of samples.
+func residual(c Coefficients, samples []Sample, measured func(Sample) uint64) Residual {
+	var r Residual
+	var sumSquares float64
+	for _, s := range samples {
+		actual := float64(measured(s))
+		diff := math.Abs(float64(c.Total(s.Features)) - actual)
+		sumSquares += diff * diff
+		if actual > 0 {
+			r.MaxRelative = max(r.MaxRelative, diff/actual)
+		}
+	}
+	r.RMS = math.Sqrt(sumSquares / float64(len(samples)))
+	return r
+}
+
+// fitNonNegative fits y = base + sum(coefficients[j] * x[j]) with all coefficients not negative.
+// Negative coefficients are removed from the fit one at a time, most negative first, until none remain.
+func fitNonNegative(xs [][]float64, ys []float64) (float64, []float64, error) {
+	features := len(xs[0])
+	// Column 0 is the base, followed by each feature
+	active := make([]bool, features+1)
+	for j := range active {
+		active[j] = true
+	}
+	for {
+		solution, err := fitLeastSquares(xs, ys, active)
+		if err != nil {
+			return 0, nil, err
+		}
+		mostNegative := -1
+		for j, v := range solution {
+			if v < 0 && (mostNegative < 0 || v < solution[mostNegative]) {
+				mostNegative = j
+			}
+		}
+		if mostNegative < 0 {
+			return solution[0], solution[1:], nil
+		}
+		active[mostNegative] = false
+	}
+}
+
+// fitLeastSquares solves the ridge regularized least squares fit of the active columns.
+// Inactive columns, and columns that are zero in every sample, have a coefficient of 0.
+func fitLeastSquares(xs [][]float64, ys []float64, active []bool) ([]float64, error) {
+	column := func(i, j int) float64 {
+		if j == 0 {
+			return 1
+		}
+		return xs[i][j-1]
+	}
+	// Scale each column to a maximum of 1 so that features of very different magnitudes are fit accurately
+	var cols []int
+	var scales []float64
+	for j := range active {
+		if !active[j] {
+			continue
+		}
+		var scale float64
+		for i := range xs {
+			scale = max(scale, math.Abs(column(i, j)))
+		}
+		if scale == 0 {
+			continue
+		}
+		cols = append(cols, j)
+		scales = append(scales, scale)
+	}
+	solution := make([]float64, len(active))
+	if len(cols) == 0 {
+		return solution, nil
+	}
+
+	// Normal equations: (A^T A + ridge I) c = A^T y
+	n := len(cols)
+	m := make([][]float64, n)
+	for a := range m {
+		m[a] = make([]float64, n+1)
+		for b := 0; b < n; b++ {
+			for i := range xs {
+				m[a][b] += column(i, cols[a]) / scales[a] * column(i, cols[b]) / scales[b]
+			}
+		}
+		m[a][a] += ridge
+		for i := range xs {
+			m[a][n] += column(i, cols[a]) / scales[a] * ys[i]
+		}
+	}
+	c, err := solve(m)
+	if err != nil {
+		return nil, err
+	}
+	for a, j := range cols {
+		solution[j] = c[a] / scales[a]
+	}
+	return solution, nil
+}
+
+// solve solves the augmented matrix m using Gaussian elimination with partial pivoting.
+func solve(m [][]float64) ([]float64, error) {
+	n := len(m)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if m[pivot][col] == 0 {
+			return nil, errors.New("singular matrix")
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		for row := col + 1; row < n; row++ {
+			factor := m[row][col] / m[col][col]
+			for k := col; k <= n; k++ {
+				m[row][k] -= factor * m[col][k]
+			}
+		}
+	}
+	result := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := m[row][n]
+		for k := row + 1; k < n; k++ {
+			sum -= m[row][k] * result[k]
+		}
+		result[row] = sum / m[row][row]
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package estimate
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func TestCalibrate(t *testing.T) {
+	randomSamples := func(model Model, count int) []Sample {
+		rng := rand.New(rand.NewSource(1))
+		samples := make([]Sample, count)
+		for i := range samples {
+			blocks := uint64(rng.Intn(100) + 1)
+			f := Features{
+				Blocks:           blocks,
+				Gas:              blocks * uint64(rng.Intn(30_000_000)),
+				Transactions:     blocks * uint64(rng.Intn(200)),
+				PreimageRequests: blocks * uint64(rng.Intn(10_000)),
+				PreimageBytes:    blocks * uint64(rng.Intn(5_000_000)),
+				Hints:            blocks * uint64(rng.Intn(1000)),
+			}
+			samples[i] = Sample{Features: f, Steps: model.Steps.Total(f), Memory: model.Memory.Total(f)}
+		}
+		return samples
+	}
+	requireClose := func(t *testing.T, expected Coefficients, actual Coefficients) {
+		const tolerance = 0.001
+		require.InDelta(t, expected.Base, actual.Base, tolerance*expected.Base+1)
+		for i, v := range expected.values() {
+			require.InDelta(t, v, actual.values()[i], tolerance*v+tolerance, "coefficient %d", i)
+		}
+	}
+
+	t.Run("RecoversModel", func(t *testing.T) {
+		model, residuals, err := Calibrate(randomSamples(testModel, 20))
+		require.NoError(t, err)
+		requireClose(t, testModel.Steps, model.Steps)
+		requireClose(t, testModel.Memory, model.Memory)
+		require.Less(t, residuals.Steps.MaxRelative, 0.001)
+		require.Less(t, residuals.Memory.MaxRelative, 0.001)
+	})
+
+	t.Run("ReportsResidual", func(t *testing.T) {
+		samples := randomSamples(testModel, 20)
+		// Steps that the model can't explain
+		for i := range samples {
+			if i%2 == 0 {
+				samples[i].Steps += 100_000_000
+			}
+		}
+		_, residuals, err := Calibrate(samples)
+		require.NoError(t, err)
+		require.Greater(t, residuals.Steps.RMS, 10_000_000.0)
+		require.Greater(t, residuals.Steps.MaxRelative, 0.001)
+		require.Less(t, residuals.Memory.MaxRelative, 0.001)
+	})
+
+	t.Run("NonNegative", func(t *testing.T) {
+		samples := randomSamples(testModel, 20)
+		// Steps decrease with hints, which would give a negative coefficient in an unconstrained fit
+		for i := range samples {
+			samples[i].Steps -= samples[i].Features.Hints * 3000
+		}
+		model, _, err := Calibrate(samples)
+		require.NoError(t, err)
+		require.NoError(t, model.Check())
+		require.Zero(t, model.Steps.Hint)
+	})
+
+	t.Run("InsufficientSamples", func(t *testing.T) {
+		// One sample is required for each feature plus the base cost
+		_, _, err := Calibrate(randomSamples(testModel, 6))
+		require.ErrorIs(t, err, ErrInsufficientSamples)
+		_, _, err = Calibrate(randomSamples(testModel, 7))
+		require.NoError(t, err)
+	})
+}
+
+func TestLoadSample(t *testing.T) {
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "report.json")
+	features := Features{Blocks: 3, Gas: 100, PreimageRequests: 20}
+	require.NoError(t, jsonutil.WriteJSON(&Report{Model: testModel, Total: features}, ioutil.ToAtomicFile(reportPath, 0o644)))
+
+	t.Run("Valid", func(t *testing.T) {
+		debugInfoPath := filepath.Join(dir, "debug-info.json")
+		require.NoError(t, os.WriteFile(debugInfoPath, []byte(`{"steps":"0x3e8","pages":3,"memory_used":"0x800","num_preimage_requests":20,"total_preimage_size":100}`), 0o644))
+		sample, err := LoadSample(reportPath, debugInfoPath)
+		require.NoError(t, err)
+		require.Equal(t, Sample{Features: features, Steps: 1000, Memory: 2048}, sample)
+	})
+
+	t.Run("NoSteps", func(t *testing.T) {
+		// Written by a version of cannon that doesn't report steps
+		debugInfoPath := filepath.Join(dir, "old-debug-info.json")
+		require.NoError(t, os.WriteFile(debugInfoPath, []byte(`{"pages":3,"memory_used":"0x800"}`), 0o644))
+		_, err := LoadSample(reportPath, debugInfoPath)
+		require.ErrorContains(t, err, "has no steps")
+	})
+}
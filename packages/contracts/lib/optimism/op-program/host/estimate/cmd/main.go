@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/estimate"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	ReportFlag = &cli.StringSliceFlag{
+		Name:     "report",
+		Usage:    "Path of an estimate report written by op-program --estimate.report. May be repeated.",
+		Required: true,
+	}
+	DebugInfoFlag = &cli.StringSliceFlag{
+		Name:     "debug-info",
+		Usage:    "Path of the debug info written by cannon run --debug-info for the same inputs as the report at the same position.",
+		Required: true,
+	}
+	OutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "Path to write the calibrated model to. Writes to stdout if set to -.",
+		Value: "-",
+	}
+)
+
+func Calibrate(ctx *cli.Context) error {
+	reports := ctx.StringSlice(ReportFlag.Name)
+	debugInfos := ctx.StringSlice(DebugInfoFlag.Name)
+	if len(reports) != len(debugInfos) {
+		return fmt.Errorf("got %d reports but %d debug infos", len(reports), len(debugInfos))
+	}
+	samples := make([]estimate.Sample, 0, len(reports))
+	for i := range reports {
+		sample, err := estimate.LoadSample(reports[i], debugInfos[i])
+		if err != nil {
+			return err
+		}
+		samples = append(samples, sample)
+	}
+	model, residuals, err := estimate.Calibrate(samples)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(ctx.App.ErrWriter, "steps residual: RMS %.0f, max %.2f%%\nmemory residual: RMS %.0f bytes, max %.2f%%\n",
+		residuals.Steps.RMS, residuals.Steps.MaxRelative*100, residuals.Memory.RMS, residuals.Memory.MaxRelative*100)
+	return jsonutil.WriteJSON(model, ioutil.ToStdOutOrFileOrNoop(ctx.String(OutFlag.Name), 0o644))
+}
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "op-program-calibrate"
+	app.Usage = "Calibrate the op-program step and memory estimation model"
+	app.Description = "Fits the model used by op-program --estimate to the steps and memory used by cannon runs of the same inputs"
+	app.Flags = []cli.Flag{ReportFlag, DebugInfoFlag, OutFlag}
+	app.Action = Calibrate
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
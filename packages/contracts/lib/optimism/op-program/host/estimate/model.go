@@ -0,0 +1,127 @@
+package estimate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var ErrInvalidModel = errors.New("invalid estimation model")
+
+// Features are the measurements of native client execution used to estimate the cost of running it in cannon.
+type Features struct {
+	Blocks           uint64 `json:"blocks"`
+	Gas              uint64 `json:"gas"`
+	Transactions     uint64 `json:"transactions"`
+	PreimageRequests uint64 `json:"preimageRequests"`
+	PreimageBytes    uint64 `json:"preimageBytes"`
+	Hints            uint64 `json:"hints"`
+}
+
+// Add returns the sum of f and other.
+func (f Features) Add(other Features) Features {
+	return Features{
+		Blocks:           f.Blocks + other.Blocks,
+		Gas:              f.Gas + other.Gas,
+		Transactions:     f.Transactions + other.Transactions,
+		PreimageRequests: f.PreimageRequests + other.PreimageRequests,
+		PreimageBytes:    f.PreimageBytes + other.PreimageBytes,
+		Hints:            f.Hints + other.Hints,
+	}
+}
+
+func (f Features) values() []float64 {
+	return []float64{
+		float64(f.Blocks),
+		float64(f.Gas),
+		float64(f.Transactions),
+		float64(f.PreimageRequests),
+		float64(f.PreimageBytes),
+		float64(f.Hints),
+	}
+}
+
+// Coefficients are the cost of each feature.
+// Base is the fixed cost of running the program, such as initializing the Go runtime and bootstrapping.
+type Coefficients struct {
+	Base            float64 `json:"base"`
+	Block           float64 `json:"block"`
+	Gas             float64 `json:"gas"`
+	Transaction     float64 `json:"transaction"`
+	PreimageRequest float64 `json:"preimageRequest"`
+	PreimageByte    float64 `json:"preimageByte"`
+	Hint            float64 `json:"hint"`
+}
+
+func (c Coefficients) values() []float64 {
+	return []float64{c.Block, c.Gas, c.Transaction, c.PreimageRequest, c.PreimageByte, c.Hint}
+}
+
+func coefficientsFromValues(base float64, v []float64) Coefficients {
+	return Coefficients{
+		Base:            base,
+		Block:           v[0],
+		Gas:             v[1],
+		Transaction:     v[2],
+		PreimageRequest: v[3],
+		PreimageByte:    v[4],
+		Hint:            v[5],
+	}
+}
+
+// Cost returns the cost of features, excluding the base cost.
+func (c Coefficients) Cost(f Features) uint64 {
+	var total float64
+	for i, v := range f.values() {
+		total += c.values()[i] * v
+	}
+	return uint64(math.Round(max(total, 0)))
+}
+
+// Total returns the cost of features, including the base cost.
+func (c Coefficients) Total(f Features) uint64 {
+	return uint64(math.Round(max(c.Base, 0))) + c.Cost(f)
+}
+
+func (c Coefficients) check() error {
+	for _, v := range append(c.values(), c.Base) {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 {
+			return fmt.Errorf("%w: coefficients must be finite and not negative", ErrInvalidModel)
+		}
+	}
+	return nil
+}
+
+// Model estimates the number of cannon steps and the memory used by the program from the features of native
+// execution. Steps are additive, so the steps of each block are the cost of its features. Cannon never releases
+// memory, so the memory high-water mark after a block is the total cost of the features up to and including it.
+// The coefficients depend on the client program version and VM, so there is no default model. Models are created by
+// Calibrate from cannon runs of the same inputs.
+type Model struct {
+	Steps  Coefficients `json:"steps"`
+	Memory Coefficients `json:"memory"`
+}
+
+func (m Model) Check() error {
+	if err := m.Steps.check(); err != nil {
+		return fmt.Errorf("steps: %w", err)
+	}
+	if err := m.Memory.check(); err != nil {
+		return fmt.Errorf("memory: %w", err)
+	}
+	return nil
+}
+
+// LoadModel reads a model from a JSON file.
+func LoadModel(path string) (Model, error) {
+	m, err := jsonutil.LoadJSON[Model](path)
+	if err != nil {
+		return Model{}, fmt.Errorf("failed to load model: %w", err)
+	}
+	if err := m.Check(); err != nil {
+		return Model{}, err
+	}
+	return *m, nil
+}
@@ -0,0 +1,73 @@
+package estimate
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testModel is an arbitrary valid model with coefficients of realistic magnitudes.
+var testModel = Model{
+	Steps: Coefficients{
+		Base:            400_000_000,
+		Block:           20_000_000,
+		Gas:             50,
+		Transaction:     200_000,
+		PreimageRequest: 30_000,
+		PreimageByte:    150,
+		Hint:            2_000,
+	},
+	Memory: Coefficients{
+		Base:            64 * 1024 * 1024,
+		Block:           1024 * 1024,
+		PreimageRequest: 256,
+		PreimageByte:    2,
+	},
+}
+
+func TestCoefficients(t *testing.T) {
+	c := Coefficients{Base: 10.4, Block: 1.5, Gas: 0.25, Transaction: 3, PreimageRequest: 2, PreimageByte: 0.5, Hint: 7}
+	f := Features{Blocks: 2, Gas: 4, Transactions: 1, PreimageRequests: 3, PreimageBytes: 10, Hints: 1}
+	require.EqualValues(t, 3+1+3+6+5+7, c.Cost(f))
+	require.EqualValues(t, 10+25, c.Total(f))
+}
+
+func TestLoadModel(t *testing.T) {
+	dir := t.TempDir()
+	write := func(t *testing.T, m Model) string {
+		path := filepath.Join(dir, t.Name()+".json")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		return path
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		m, err := LoadModel(write(t, testModel))
+		require.NoError(t, err)
+		require.Equal(t, testModel, m)
+	})
+
+	t.Run("Negative", func(t *testing.T) {
+		m := testModel
+		m.Memory.Gas = -1
+		_, err := LoadModel(write(t, m))
+		require.ErrorIs(t, err, ErrInvalidModel)
+	})
+
+	t.Run("Infinite", func(t *testing.T) {
+		m := testModel
+		m.Steps.Hint = math.Inf(1)
+		require.ErrorIs(t, m.Check(), ErrInvalidModel)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := LoadModel(filepath.Join(dir, "missing.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
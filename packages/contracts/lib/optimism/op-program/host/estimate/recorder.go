@@ -0,0 +1,110 @@
+package estimate
+
+import (
+	"io"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// preimageKeyLength and preimageLengthPrefix are the sizes of the request and the response prefix of the pre-image
+// oracle protocol.
+const (
+	preimageKeyLength    = 32
+	preimageLengthPrefix = 8
+)
+
+// BlockFeatures are the features of executing a single L2 block.
+type BlockFeatures struct {
+	Number   uint64   `json:"number"`
+	Features Features `json:"features"`
+}
+
+// Recorder measures the features of a client program running natively.
+// The client must communicate with the host through the channels returned by PreimageChannel and HintChannel, and
+// notify the recorder of each block executed by calling BlockExecuted.
+//
+// The pre-image requests and hints sent since the previous block are attributed to the next block executed, so each
+// block includes the derivation work required to produce it. The first block also includes bootstrapping the program.
+// The requests after the last block, such as validating the claim, are not attributed to any block.
+//
+// A Recorder is not safe for concurrent use, matching the single-threaded client.
+type Recorder struct {
+	blocks  []BlockFeatures
+	current Features
+
+	keyBytes      uint64
+	preimageBytes uint64
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// PreimageChannel wraps the client side of the pre-image channel to count requests.
+func (r *Recorder) PreimageChannel(rw io.ReadWriter) io.ReadWriter {
+	return &countingReadWriter{rw: rw, onRead: r.preimageRead, onWrite: r.preimageWrite}
+}
+
+// HintChannel wraps the client side of the hint channel to count hints.
+func (r *Recorder) HintChannel(rw io.ReadWriter) io.ReadWriter {
+	// Each hint is acknowledged with a single byte
+	return &countingReadWriter{rw: rw, onRead: func(n int) { r.current.Hints += uint64(n) }, onWrite: func(int) {}}
+}
+
+func (r *Recorder) preimageWrite(n int) {
+	r.keyBytes += uint64(n)
+	r.current.PreimageRequests += r.keyBytes / preimageKeyLength
+	r.keyBytes %= preimageKeyLength
+}
+
+func (r *Recorder) preimageRead(n int) {
+	r.preimageBytes += uint64(n)
+}
+
+// flush attributes the bytes read since the last flush to the current features, excluding the length prefixes.
+func (r *Recorder) flush() {
+	prefixes := r.current.PreimageRequests * preimageLengthPrefix
+	if r.preimageBytes >= prefixes {
+		r.current.PreimageBytes = r.preimageBytes - prefixes
+	}
+}
+
+// BlockExecuted records the features of block, including all requests since the previous block.
+func (r *Recorder) BlockExecuted(block *types.Block) {
+	r.flush()
+	r.current.Blocks = 1
+	r.current.Gas = block.GasUsed()
+	r.current.Transactions = uint64(len(block.Transactions()))
+	r.blocks = append(r.blocks, BlockFeatures{Number: block.NumberU64(), Features: r.current})
+	r.current = Features{}
+	r.preimageBytes = 0
+}
+
+// Blocks returns the features of each block executed, in the order they were executed.
+func (r *Recorder) Blocks() []BlockFeatures {
+	return r.blocks
+}
+
+// Unattributed returns the features since the last block executed.
+func (r *Recorder) Unattributed() Features {
+	r.flush()
+	return r.current
+}
+
+type countingReadWriter struct {
+	rw      io.ReadWriter
+	onRead  func(n int)
+	onWrite func(n int)
+}
+
+func (c *countingReadWriter) Read(p []byte) (int, error) {
+	n, err := c.rw.Read(p)
+	c.onRead(n)
+	return n, err
+}
+
+func (c *countingReadWriter) Write(p []byte) (int, error) {
+	n, err := c.rw.Write(p)
+	c.onWrite(n)
+	return n, err
+}
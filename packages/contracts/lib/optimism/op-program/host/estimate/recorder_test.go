@@ -0,0 +1,135 @@
+package estimate
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func TestRecorder(t *testing.T) {
+	preimages := map[common.Hash][]byte{
+		{0x02, 0x01}: []byte("first"),
+		{0x02, 0x02}: make([]byte, 1000),
+		{0x02, 0x03}: {},
+	}
+	pClientRW, pHostRW, err := preimage.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	hClientRW, hHostRW, err := preimage.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	serverDone := make(chan struct{})
+	go func() {
+		defer close(serverDone)
+		server := preimage.NewOracleServer(pHostRW)
+		getter := func(key [32]byte) ([]byte, error) { return preimages[key], nil }
+		for {
+			if err := server.NextPreimageRequest(getter); err != nil {
+				return
+			}
+		}
+	}()
+	hinterDone := make(chan struct{})
+	go func() {
+		defer close(hinterDone)
+		reader := preimage.NewHintReader(hHostRW)
+		for {
+			if err := reader.NextHint(func(string) error { return nil }); err != nil {
+				return
+			}
+		}
+	}()
+	t.Cleanup(func() {
+		require.NoError(t, pClientRW.Close())
+		require.NoError(t, hClientRW.Close())
+		<-serverDone
+		<-hinterDone
+	})
+
+	r := NewRecorder()
+	oracle := preimage.NewOracleClient(r.PreimageChannel(pClientRW))
+	hinter := preimage.NewHintWriter(r.HintChannel(hClientRW))
+	get := func(key common.Hash) []byte {
+		return oracle.Get(rawKey(key))
+	}
+
+	hinter.Hint(rawHint("hint 1"))
+	require.Equal(t, []byte("first"), get(common.Hash{0x02, 0x01}))
+	require.Len(t, get(common.Hash{0x02, 0x02}), 1000)
+	r.BlockExecuted(newBlock(10, 21000, 2))
+
+	hinter.Hint(rawHint("hint 2"))
+	hinter.Hint(rawHint("hint 3"))
+	require.Empty(t, get(common.Hash{0x02, 0x03}))
+	r.BlockExecuted(newBlock(11, 0, 0))
+
+	require.Equal(t, "first", string(get(common.Hash{0x02, 0x01})))
+
+	require.Equal(t, []BlockFeatures{
+		{Number: 10, Features: Features{Blocks: 1, Gas: 21000, Transactions: 2, PreimageRequests: 2, PreimageBytes: 1005, Hints: 1}},
+		{Number: 11, Features: Features{Blocks: 1, PreimageRequests: 1, Hints: 2}},
+	}, r.Blocks())
+	require.Equal(t, Features{PreimageRequests: 1, PreimageBytes: 5}, r.Unattributed())
+}
+
+func TestCountingReadWriter(t *testing.T) {
+	t.Run("PartialKeyWrites", func(t *testing.T) {
+		r := NewRecorder()
+		rw := r.PreimageChannel(&stubReadWriter{})
+		_, err := rw.Write(make([]byte, 20))
+		require.NoError(t, err)
+		require.Zero(t, r.Unattributed().PreimageRequests)
+		_, err = rw.Write(make([]byte, 44))
+		require.NoError(t, err)
+		require.EqualValues(t, 2, r.Unattributed().PreimageRequests)
+	})
+
+	t.Run("CountsOnlyTransferredBytes", func(t *testing.T) {
+		r := NewRecorder()
+		rw := r.HintChannel(&stubReadWriter{err: io.EOF})
+		_, err := rw.Read(make([]byte, 1))
+		require.ErrorIs(t, err, io.EOF)
+		require.Zero(t, r.Unattributed().Hints)
+	})
+}
+
+func newBlock(number int64, gasUsed uint64, txs int) *types.Block {
+	header := &types.Header{Number: big.NewInt(number), GasUsed: gasUsed}
+	body := &types.Body{}
+	for i := 0; i < txs; i++ {
+		body.Transactions = append(body.Transactions, types.NewTx(&types.LegacyTx{Nonce: uint64(i)}))
+	}
+	return types.NewBlockWithHeader(header).WithBody(*body)
+}
+
+type rawKey common.Hash
+
+func (k rawKey) PreimageKey() [32]byte {
+	return k
+}
+
+type rawHint string
+
+func (h rawHint) Hint() string {
+	return string(h)
+}
+
+// stubReadWriter accepts all writes, and returns err from reads.
+type stubReadWriter struct {
+	err error
+}
+
+func (s *stubReadWriter) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	return len(p), nil
+}
+
+func (s *stubReadWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
@@ -0,0 +1,66 @@
+package estimate
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrExceedsLimits = errors.New("estimate exceeds limits")
+
+// BlockEstimate is the estimated cost of executing a single L2 block in cannon.
+type BlockEstimate struct {
+	Number   uint64   `json:"number"`
+	Features Features `json:"features"`
+	Steps    uint64   `json:"steps"`
+	// Memory is the estimated memory high-water mark after executing the block.
+	Memory uint64 `json:"memory"`
+}
+
+// Report is the estimated cost of running the program in cannon.
+type Report struct {
+	Model  Model           `json:"model"`
+	Blocks []BlockEstimate `json:"blocks"`
+	// Unattributed are the features after the last block executed.
+	Unattributed Features `json:"unattributed"`
+	// Total are the features of the entire program, used to calibrate the model.
+	Total         Features `json:"total"`
+	Steps         uint64   `json:"steps"`
+	Memory        uint64   `json:"memory"`
+	MaxBlockSteps uint64   `json:"maxBlockSteps"`
+}
+
+// NewReport estimates the cost of each block recorded by r.
+func NewReport(model Model, r *Recorder) *Report {
+	report := &Report{
+		Model:        model,
+		Unattributed: r.Unattributed(),
+	}
+	var cumulative Features
+	for _, block := range r.Blocks() {
+		cumulative = cumulative.Add(block.Features)
+		estimate := BlockEstimate{
+			Number:   block.Number,
+			Features: block.Features,
+			Steps:    model.Steps.Cost(block.Features),
+			Memory:   model.Memory.Total(cumulative),
+		}
+		report.MaxBlockSteps = max(report.MaxBlockSteps, estimate.Steps)
+		report.Blocks = append(report.Blocks, estimate)
+	}
+	report.Total = cumulative.Add(report.Unattributed)
+	report.Steps = model.Steps.Total(report.Total)
+	report.Memory = model.Memory.Total(report.Total)
+	return report
+}
+
+// CheckLimits returns an error if the estimated steps or memory exceed the limits.
+// A limit of 0 is not checked.
+func (r *Report) CheckLimits(maxSteps uint64, maxMemory uint64) error {
+	if maxSteps != 0 && r.Steps > maxSteps {
+		return fmt.Errorf("%w: estimated %d steps, limit %d", ErrExceedsLimits, r.Steps, maxSteps)
+	}
+	if maxMemory != 0 && r.Memory > maxMemory {
+		return fmt.Errorf("%w: estimated %d bytes of memory, limit %d", ErrExceedsLimits, r.Memory, maxMemory)
+	}
+	return nil
+}
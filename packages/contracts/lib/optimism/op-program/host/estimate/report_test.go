@@ -0,0 +1,41 @@
+package estimate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	model := Model{
+		Steps:  Coefficients{Base: 1000, Block: 100, Gas: 2, PreimageRequest: 10},
+		Memory: Coefficients{Base: 500, PreimageByte: 3},
+	}
+	r := &Recorder{
+		blocks: []BlockFeatures{
+			{Number: 5, Features: Features{Blocks: 1, Gas: 50, PreimageRequests: 2, PreimageBytes: 10}},
+			{Number: 6, Features: Features{Blocks: 1, Gas: 0, PreimageRequests: 1, PreimageBytes: 20}},
+		},
+		current: Features{PreimageRequests: 1},
+		// Includes the length prefix of the unattributed request
+		preimageBytes: 8 + 4,
+	}
+	report := NewReport(model, r)
+	require.Equal(t, []BlockEstimate{
+		{Number: 5, Features: r.blocks[0].Features, Steps: 100 + 100 + 20, Memory: 500 + 30},
+		{Number: 6, Features: r.blocks[1].Features, Steps: 100 + 10, Memory: 500 + 90},
+	}, report.Blocks)
+	require.Equal(t, Features{PreimageRequests: 1, PreimageBytes: 4}, report.Unattributed)
+	require.Equal(t, Features{Blocks: 2, Gas: 50, PreimageRequests: 4, PreimageBytes: 34}, report.Total)
+	require.EqualValues(t, 1000+220+110+10, report.Steps)
+	require.EqualValues(t, 500+34*3, report.Memory)
+	require.EqualValues(t, 220, report.MaxBlockSteps)
+}
+
+func TestCheckLimits(t *testing.T) {
+	report := &Report{Steps: 1000, Memory: 2000}
+	require.NoError(t, report.CheckLimits(0, 0))
+	require.NoError(t, report.CheckLimits(1000, 2000))
+	require.ErrorIs(t, report.CheckLimits(999, 0), ErrExceedsLimits)
+	require.ErrorIs(t, report.CheckLimits(0, 1999), ErrExceedsLimits)
+}
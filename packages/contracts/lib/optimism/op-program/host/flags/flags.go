@@ -16,6 +16,9 @@ import (
 
 const EnvVarPrefix = "OP_PROGRAM"
 
+// DefaultPrefetchConcurrency is the default maximum number of hints fetched in the background at once.
+const DefaultPrefetchConcurrency = 4
+
 func prefixEnvVars(name string) []string {
 	return service.PrefixEnvVar(EnvVarPrefix, name)
 }
@@ -52,6 +55,11 @@ var (
 		Usage:   "Address of L2 JSON-RPC endpoint to use for experimental features (debug_executionWitness)",
 		EnvVars: prefixEnvVars("L2_RPC_EXPERIMENTAL_RPC"),
 	}
+	L2RPCRateLimit = &cli.Float64Flag{
+		Name:    "l2.rpc-rate-limit",
+		Usage:   "Optional self-imposed rate-limit on requests to each L2 JSON-RPC endpoint, specified in requests / second. Disabled if set to 0.",
+		EnvVars: prefixEnvVars("L2_RPC_RATE_LIMIT"),
+	}
 	L1Head = &cli.StringFlag{
 		Name:    "l1.head",
 		Usage:   "Hash of the L1 head block. Derivation stops after this block is processed.",
@@ -77,6 +85,16 @@ var (
 		Usage:   "Number of the L2 block that the claim is from",
 		EnvVars: prefixEnvVars("L2_BLOCK_NUM"),
 	}
+	L2OutputInterval = &cli.Uint64Flag{
+		Name:    "l2.output-interval",
+		Usage:   "Number of L2 blocks between the output roots proven by a range proof client. Only used by range proofs.",
+		EnvVars: prefixEnvVars("L2_OUTPUT_INTERVAL"),
+	}
+	L2OutputRoots = &cli.StringFlag{
+		Name:    "l2.output-roots",
+		Usage:   "Path to write the JSON output roots computed by a range proof to. Requires --l2.output-interval and the client to run in-process.",
+		EnvVars: prefixEnvVars("L2_OUTPUT_ROOTS"),
+	}
 	L2GenesisPath = &cli.StringFlag{
 		Name:    "l2.genesis",
 		Usage:   "Path to the op-geth genesis file",
@@ -97,6 +115,17 @@ var (
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
 		EnvVars: prefixEnvVars("L1_TRUST_RPC"),
 	}
+	L1RPCRateLimit = &cli.Float64Flag{
+		Name:    "l1.rpc-rate-limit",
+		Usage:   "Optional self-imposed rate-limit on L1 JSON-RPC requests, specified in requests / second. Disabled if set to 0.",
+		EnvVars: prefixEnvVars("L1_RPC_RATE_LIMIT"),
+	}
+	PrefetchConcurrency = &cli.IntFlag{
+		Name:    "prefetch.concurrency",
+		Usage:   "Maximum number of hints to fetch in the background at once, such as L2 execution witnesses from the experimental L2 endpoint.",
+		EnvVars: prefixEnvVars("PREFETCH_CONCURRENCY"),
+		Value:   DefaultPrefetchConcurrency,
+	}
 	L1RPCProviderKind = &cli.GenericFlag{
 		Name: "l1.rpckind",
 		Usage: "The kind of RPC provider, used to inform optimal transactions receipts fetching, and thus reduce costs. Valid options: " +
@@ -117,6 +146,41 @@ var (
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	RecordBundle = &cli.StringFlag{
+		Name:    "bundle.record",
+		Usage:   "Path to write a compressed bundle of the hints and preimages used by the client program to, which can be replayed offline with --bundle.",
+		EnvVars: prefixEnvVars("BUNDLE_RECORD"),
+	}
+	Bundle = &cli.StringFlag{
+		Name:    "bundle",
+		Usage:   "Path of a bundle written by --bundle.record to serve preimages from. No L1 or L2 data is fetched.",
+		EnvVars: prefixEnvVars("BUNDLE"),
+	}
+	Estimate = &cli.BoolFlag{
+		Name:    "estimate",
+		Usage:   "Run the client program natively and estimate the cannon steps and memory of each L2 block. Requires the client to run in-process.",
+		EnvVars: prefixEnvVars("ESTIMATE"),
+	}
+	EstimateModel = &cli.StringFlag{
+		Name:    "estimate.model",
+		Usage:   "Path of a JSON model calibrated against cannon to estimate with. Required with --estimate.",
+		EnvVars: prefixEnvVars("ESTIMATE_MODEL"),
+	}
+	EstimateReport = &cli.StringFlag{
+		Name:    "estimate.report",
+		Usage:   "Path to write the JSON estimate report to.",
+		EnvVars: prefixEnvVars("ESTIMATE_REPORT"),
+	}
+	EstimateMaxSteps = &cli.Uint64Flag{
+		Name:    "estimate.max-steps",
+		Usage:   "Fail if the estimated cannon steps exceed this limit. 0 disables the check.",
+		EnvVars: prefixEnvVars("ESTIMATE_MAX_STEPS"),
+	}
+	EstimateMaxMemory = &cli.Uint64Flag{
+		Name:    "estimate.max-memory",
+		Usage:   "Fail if the estimated cannon memory high-water mark exceeds this number of bytes. 0 disables the check.",
+		EnvVars: prefixEnvVars("ESTIMATE_MAX_MEMORY"),
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
@@ -137,13 +201,25 @@ var programFlags = []cli.Flag{
 	DataFormat,
 	L2NodeAddr,
 	L2NodeExperimentalAddr,
+	L2RPCRateLimit,
 	L2GenesisPath,
+	L2OutputInterval,
+	L2OutputRoots,
 	L1NodeAddr,
 	L1BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
+	L1RPCRateLimit,
+	PrefetchConcurrency,
 	Exec,
 	Server,
+	RecordBundle,
+	Bundle,
+	Estimate,
+	EstimateModel,
+	EstimateReport,
+	EstimateMaxSteps,
+	EstimateMaxMemory,
 }
 
 func init() {
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	"github.com/ethereum-optimism/optimism/op-program/host/bundle"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
@@ -19,6 +20,9 @@ import (
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -27,6 +31,8 @@ import (
 type Prefetcher interface {
 	Hint(hint string) error
 	GetPreimage(ctx context.Context, key common.Hash) ([]byte, error)
+	// Close stops any background fetching. The key-value store must not be modified after it returns.
+	Close() error
 }
 type PrefetcherCreator func(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (Prefetcher, error)
 
@@ -132,8 +138,26 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 		}
 		logger.Debug("Client program completed successfully")
 		return nil
+	} else if cfg.Estimate {
+		return runEstimate(logger, cfg, pClientRW, hClientRW)
 	} else {
-		return cl.RunProgram(logger, pClientRW, hClientRW)
+		return cl.RunProgram(logger, pClientRW, hClientRW, programOptions(cfg)...)
+	}
+}
+
+// programOptions returns the options for a client program run in-process.
+func programOptions(cfg *config.Config) []cl.Option {
+	opts := []cl.Option{cl.WithPrecompileRegistry(cfg.Precompiles)}
+	if cfg.L2OutputRoots != "" {
+		opts = append(opts, cl.WithOutputRootsObserver(writeOutputRoots(cfg.L2OutputRoots)))
+	}
+	return opts
+}
+
+// writeOutputRoots returns an observer that writes the output roots computed by a range proof to path as JSON.
+func writeOutputRoots(path string) cl.OutputRootsObserver {
+	return func(outputRoots []eth.Bytes32) error {
+		return jsonutil.WriteJSON(outputRoots, ioutil.ToStdOutOrFileOrNoop(path, 0o644))
 	}
 }
 
@@ -141,13 +165,17 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 // This method will block until both the hinter and preimage handlers complete.
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
-func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel, prefetcherCreator PrefetcherCreator) error {
+func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel, prefetcherCreator PrefetcherCreator) (result error) {
 	var serverDone chan error
 	var hinterDone chan error
 	logger.Info("Starting preimage server")
 	var kv kvstore.KV
+	var prefetch Prefetcher
+	var recorder *bundle.Recorder
+	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 
 	// Close the preimage/hint channels, and then kv store once the server and hinter have exited.
+	// The bundle is written after the server has exited so it contains every pre-image used.
 	defer func() {
 		preimageChannel.Close()
 		hintChannel.Close()
@@ -159,13 +187,36 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		if prefetch != nil {
+			_ = prefetch.Close()
+		}
 
+		if recorder != nil && kv != nil {
+			source := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, kv.Get)
+			if err := writeBundle(logger, cfg.RecordBundle, recorder, source.Get); err != nil && result == nil {
+				result = err
+			}
+		}
 		if kv != nil {
 			kv.Close()
 		}
 	}()
 
-	if cfg.DataDir == "" {
+	if cfg.Bundle != "" {
+		logger.Info("Loading bundle", "path", cfg.Bundle)
+		b, err := bundle.Read(cfg.Bundle)
+		if err != nil {
+			return err
+		}
+		if err := b.CheckLocalInputs(localPreimageSource.Get); err != nil {
+			return err
+		}
+		store, err := b.KV()
+		if err != nil {
+			return fmt.Errorf("failed to load bundle: %w", err)
+		}
+		kv = store
+	} else if cfg.DataDir == "" {
 		logger.Info("Using in-memory storage")
 		kv = kvstore.NewMemKV()
 	} else {
@@ -183,10 +234,11 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		getPreimage kvstore.PreimageSource
 		hinter      preimage.HintHandler
 	)
-	prefetch, err := prefetcherCreator(ctx, logger, kv, cfg)
+	p, err := prefetcherCreator(ctx, logger, kv, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create prefetcher: %w", err)
 	}
+	prefetch = p
 	if prefetch != nil {
 		getPreimage = func(key common.Hash) ([]byte, error) { return prefetch.GetPreimage(ctx, key) }
 		hinter = prefetch.Hint
@@ -199,9 +251,14 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		}
 	}
 
-	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := preimage.WithVerification(splitter.Get)
+	if cfg.RecordBundle != "" {
+		logger.Info("Recording bundle", "path", cfg.RecordBundle)
+		recorder = bundle.NewRecorder()
+		preimageGetter = recorder.Getter(preimageGetter)
+		hinter = recorder.Hinter(hinter)
+	}
 
 	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
 	hinterDone = routeHints(logger, hintChannel, hinter)
@@ -220,12 +277,28 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	}
 }
 
+func writeBundle(logger log.Logger, path string, recorder *bundle.Recorder, source preimage.PreimageGetter) error {
+	b, err := recorder.Bundle(source)
+	if err != nil {
+		return fmt.Errorf("failed to create bundle: %w", err)
+	}
+	if err := bundle.Write(path, b); err != nil {
+		return err
+	}
+	logger.Info("Wrote bundle", "path", path, "hints", len(b.Hints), "preimages", len(b.Preimages))
+	return nil
+}
+
 func makeDefaultPrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (Prefetcher, error) {
 	if !cfg.FetchingEnabled() {
 		return nil, nil
 	}
 	logger.Info("Connecting to L1 node", "l1", cfg.L1URL)
-	l1RPC, err := client.NewRPC(ctx, logger, cfg.L1URL, client.WithDialAttempts(10))
+	l1Opts := []client.RPCOption{client.WithDialAttempts(10)}
+	if cfg.L1RPCRateLimit > 0 {
+		l1Opts = append(l1Opts, client.WithRateLimit(cfg.L1RPCRateLimit, rateLimitBurst(cfg.L1RPCRateLimit)))
+	}
+	l1RPC, err := client.NewRPC(ctx, logger, cfg.L1URL, l1Opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup L1 RPC: %w", err)
 	}
@@ -246,7 +319,13 @@ func makeDefaultPrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV
 		return nil, fmt.Errorf("failed to create L2 source: %w", err)
 	}
 
-	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2Client, kv), nil
+	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2Client, kv, cfg.Precompiles, cfg.PrefetchConcurrency, cfg.L2ClaimBlockNumber), nil
+}
+
+// rateLimitBurst returns the burst allowed for an RPC rate limited to rateLimit requests per second,
+// allowing up to a second of requests at once.
+func rateLimitBurst(rateLimit float64) int {
+	return max(int(rateLimit), 1)
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
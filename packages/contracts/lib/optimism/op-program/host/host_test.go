@@ -2,20 +2,37 @@ package host
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
 	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-preimage/conformance"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
+	"github.com/ethereum-optimism/optimism/op-program/host/bundle"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,6 +71,251 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestRecordAndReplayBundle(t *testing.T) {
+	l1Head := common.Hash{0x11}
+	data := []byte("preimage data")
+	dataKey := preimage.Keccak256Key(crypto.Keccak256Hash(data))
+	newConfig := func() *config.Config {
+		cfg := config.NewConfig(chaincfg.OPSepolia(), chainconfig.OPSepoliaChainConfig(), l1Head, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		cfg.ServerMode = true
+		return cfg
+	}
+	logger := testlog.Logger(t, log.LevelTrace)
+
+	// runClient starts a pre-image server for cfg and requests the L1 head and data pre-images.
+	runClient := func(t *testing.T, cfg *config.Config) error {
+		preimageServer, preimageClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		hintServer, hintClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		result := make(chan error)
+		go func() {
+			result <- PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer, makeDefaultPrefetcher)
+		}()
+
+		pClient := preimage.NewOracleClient(preimageClient)
+		hClient := preimage.NewHintWriter(hintClient)
+		hClient.Hint(testHint("data"))
+		require.Equal(t, l1Head.Bytes(), pClient.Get(client.L1HeadLocalIndex))
+		require.Equal(t, data, pClient.Get(dataKey))
+		require.NoError(t, preimageClient.Close())
+		require.NoError(t, hintClient.Close())
+		return waitFor(result)
+	}
+
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, "bundle.bin.gz")
+	recordCfg := newConfig()
+	recordCfg.DataDir = filepath.Join(dir, "data")
+	recordCfg.RecordBundle = bundlePath
+	require.NoError(t, os.MkdirAll(recordCfg.DataDir, 0o755))
+	kv, err := kvstore.NewDiskKV(logger, recordCfg.DataDir, recordCfg.DataFormat)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(dataKey.PreimageKey(), data))
+	require.NoError(t, kv.Put(common.Hash{0x02, 0xff}, []byte("unused")))
+	require.NoError(t, kv.Close())
+	require.NoError(t, runClient(t, recordCfg))
+
+	recorded, err := bundle.Read(bundlePath)
+	require.NoError(t, err)
+	require.Equal(t, []string{"data"}, recorded.Hints)
+	require.Len(t, recorded.Preimages, 2, "should only contain the pre-images used")
+
+	t.Run("Replay", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.Bundle = bundlePath
+		require.NoError(t, cfg.Check())
+		require.NoError(t, runClient(t, cfg))
+	})
+
+	t.Run("RejectDifferentInputs", func(t *testing.T) {
+		cfg := newConfig()
+		cfg.L1Head = common.Hash{0x99}
+		cfg.Bundle = bundlePath
+		preimageServer, preimageClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		defer preimageClient.Close()
+		hintServer, hintClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		defer hintClient.Close()
+		err = PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer, makeDefaultPrefetcher)
+		require.ErrorIs(t, err, bundle.ErrInputsMismatch)
+	})
+}
+
+func TestPreimageServerConforms(t *testing.T) {
+	cfg := config.NewConfig(chaincfg.OPSepolia(), chainconfig.OPSepoliaChainConfig(), common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.ServerMode = true
+	cfg.Bundle = filepath.Join(t.TempDir(), "bundle.bin.gz")
+	f := conformanceFixture(t, cfg)
+	b := &bundle.Bundle{}
+	for _, entry := range f.Preimages {
+		b.Preimages = append(b.Preimages, bundle.Entry{Key: entry.Key, Value: entry.Value})
+	}
+	require.NoError(t, bundle.Write(cfg.Bundle, b))
+	require.NoError(t, cfg.Check())
+
+	logger := testlog.Logger(t, log.LevelInfo)
+	server := &conformance.InProcessServer{
+		Serve: func(hintChannel preimage.FileChannel, preimageChannel preimage.FileChannel) error {
+			return PreimageServer(context.Background(), logger, cfg, preimageChannel, hintChannel, makeDefaultPrefetcher)
+		},
+	}
+	for _, result := range conformance.Run(server, f, conformance.Cases, 10*time.Second) {
+		require.NoError(t, result.Err, result.Case.Name)
+	}
+
+	findings, err := conformance.Fuzz(context.Background(), server, &conformance.ReferenceServer{Fixture: f}, f, conformance.FuzzConfig{
+		Seed:       1,
+		Iterations: 10,
+		MaxOps:     20,
+		Timeout:    10 * time.Second,
+	})
+	require.NoError(t, err)
+	require.Empty(t, findings)
+}
+
+func TestPrecompileRegistryMismatch(t *testing.T) {
+	addr := engineapi.P256VerifyPrecompile.Address
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	hash := crypto.Keccak256([]byte("message"))
+	r, s, err := ecdsa.Sign(crand.Reader, key, hash)
+	require.NoError(t, err)
+	input := slices.Concat(hash, common.LeftPadBytes(r.Bytes(), 32), common.LeftPadBytes(s.Bytes(), 32),
+		common.LeftPadBytes(key.X.Bytes(), 32), common.LeftPadBytes(key.Y.Bytes(), 32))
+	clientRegistry, err := engineapi.NewPrecompileRegistry(engineapi.P256VerifyPrecompile)
+	require.NoError(t, err)
+
+	// runPrecompile executes the P256 precompile with the client registry, served by a host using hostRegistry.
+	runPrecompile := func(t *testing.T, hostRegistry *engineapi.PrecompileRegistry) ([]byte, error) {
+		cfg := config.NewConfig(chaincfg.OPSepolia(), chainconfig.OPSepoliaChainConfig(), common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		cfg.ServerMode = true
+		cfg.Precompiles = hostRegistry
+		logger := testlog.Logger(t, log.LevelInfo)
+		preimageServer, preimageClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		defer preimageClient.Close()
+		hintServer, hintClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		defer hintClient.Close()
+		result := make(chan error)
+		go func() {
+			result <- PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer,
+				func(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (Prefetcher, error) {
+					// Precompile results are computed by the host so no sources are required
+					return prefetcher.NewPrefetcher(logger, nil, nil, nil, kv, cfg.Precompiles, 1, 0), nil
+				})
+		}()
+
+		oracle := l1.NewPreimageOracle(preimage.NewOracleClient(preimageClient), preimage.NewHintWriter(hintClient))
+		precompile := clientRegistry.CreatePrecompileOverrides(oracle)(params.Rules{}, vm.PrecompiledContractsFjord[addr], addr)
+		output, runErr := func() (output []byte, err error) {
+			defer func() {
+				// The client panics if the host stops serving pre-images
+				if r := recover(); r != nil {
+					err = fmt.Errorf("client failed: %v", r)
+				}
+			}()
+			return precompile.Run(input)
+		}()
+		require.NoError(t, preimageClient.Close())
+		require.NoError(t, hintClient.Close())
+		return output, errors.Join(runErr, waitFor(result))
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		output, err := runPrecompile(t, clientRegistry)
+		require.NoError(t, err)
+		require.Equal(t, common.LeftPadBytes([]byte{1}, 32), output)
+	})
+
+	t.Run("NotRegisteredInHost", func(t *testing.T) {
+		_, err := runPrecompile(t, engineapi.NewDefaultPrecompileRegistry())
+		require.ErrorContains(t, err, "unsupported precompile address")
+	})
+}
+
+func TestRangeProofDetectedFromBootInfo(t *testing.T) {
+	// runClient runs the client program against a host for cfg until it requests pre-images that aren't available.
+	runClient := func(t *testing.T, cfg *config.Config) *testlog.CapturingHandler {
+		cfg.ServerMode = true
+		preimageServer, preimageClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		hintServer, hintClient, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		result := make(chan error)
+		go func() {
+			result <- PreimageServer(context.Background(), testlog.Logger(t, log.LevelInfo), cfg, preimageServer, hintServer, makeDefaultPrefetcher)
+		}()
+
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		func() {
+			defer func() {
+				// The client panics when the host stops serving pre-images, as there's no data to derive from
+				_ = recover()
+			}()
+			_ = client.RunProgram(logger, preimageClient, hintClient)
+		}()
+		require.NoError(t, preimageClient.Close())
+		require.NoError(t, hintClient.Close())
+		require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
+		require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Program Bootstrapped")))
+		return logs
+	}
+
+	t.Run("Range", func(t *testing.T) {
+		cfg := config.NewConfig(chaincfg.OPSepolia(), chainconfig.OPSepoliaChainConfig(), common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		cfg.L2OutputInterval = 100
+		logs := runClient(t, cfg)
+		record := logs.FindLog(testlog.NewMessageFilter("Proving output range"))
+		require.NotNil(t, record)
+		require.Equal(t, uint64(100), record.AttrValue("interval"))
+	})
+
+	t.Run("SingleOutput", func(t *testing.T) {
+		cfg := config.NewConfig(chaincfg.OPSepolia(), chainconfig.OPSepoliaChainConfig(), common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+		logs := runClient(t, cfg)
+		require.Nil(t, logs.FindLog(testlog.NewMessageFilter("Proving output range")))
+	})
+}
+
+func TestWriteOutputRoots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outputs.json")
+	outputRoots := []eth.Bytes32{{0x01}, {0x02}, {0x03}}
+	require.NoError(t, writeOutputRoots(path)(outputRoots))
+	actual, err := jsonutil.LoadJSON[[]eth.Bytes32](path)
+	require.NoError(t, err)
+	require.Equal(t, outputRoots, *actual)
+}
+
+// conformanceFixture generates a conformance fixture with the local pre-images provided by cfg,
+// as the host always serves local pre-images from its config rather than a bundle.
+func conformanceFixture(t *testing.T, cfg *config.Config) *conformance.Fixture {
+	f := conformance.NewFixture(rand.New(rand.NewSource(1)))
+	local := kvstore.NewLocalPreimageSource(cfg)
+	preimages := make([]conformance.Entry, 0, len(f.Preimages))
+	for _, entry := range f.Preimages {
+		if entry.Key[0] == byte(preimage.LocalKeyType) {
+			value, err := local.Get(entry.Key)
+			if errors.Is(err, kvstore.ErrNotFound) {
+				continue
+			}
+			require.NoError(t, err)
+			entry.Value = value
+		}
+		preimages = append(preimages, entry)
+	}
+	f.Preimages = preimages
+	return f
+}
+
+type testHint string
+
+func (h testHint) Hint() string {
+	return string(h)
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
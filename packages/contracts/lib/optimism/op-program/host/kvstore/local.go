@@ -25,6 +25,7 @@ var (
 	l2ChainIDKey          = client.L2ChainIDLocalIndex.PreimageKey()
 	l2ChainConfigKey      = client.L2ChainConfigLocalIndex.PreimageKey()
 	rollupKey             = client.RollupConfigLocalIndex.PreimageKey()
+	l2OutputIntervalKey   = client.L2OutputIntervalLocalIndex.PreimageKey()
 )
 
 func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
@@ -39,25 +40,40 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		return binary.BigEndian.AppendUint64(nil, s.config.L2ClaimBlockNumber), nil
 	case l2ChainIDKey:
 		// The CustomChainIDIndicator informs the client to rely on the L2ChainConfigKey to
-		// read the chain config. Otherwise, it'll attempt to read a non-existent hardcoded chain config
+		// read the chain config. Otherwise, it'll attempt to read a non-existent hardcoded chain config.
+		// The CustomRangeChainIDIndicator also informs the client to read the output interval.
 		var chainID uint64
-		if s.config.IsCustomChainConfig {
+		if s.config.L2OutputInterval != 0 {
+			chainID = client.CustomRangeChainIDIndicator
+		} else if s.config.IsCustomChainConfig {
 			chainID = client.CustomChainIDIndicator
 		} else {
 			chainID = s.config.L2ChainConfig.ChainID.Uint64()
 		}
 		return binary.BigEndian.AppendUint64(nil, chainID), nil
 	case l2ChainConfigKey:
-		if !s.config.IsCustomChainConfig {
+		if !s.customBootInfo() {
 			return nil, ErrNotFound
 		}
 		return json.Marshal(s.config.L2ChainConfig)
 	case rollupKey:
-		if !s.config.IsCustomChainConfig {
+		if !s.customBootInfo() {
 			return nil, ErrNotFound
 		}
 		return json.Marshal(s.config.Rollup)
+	case l2OutputIntervalKey:
+		if s.config.L2OutputInterval == 0 {
+			return nil, ErrNotFound
+		}
+		return binary.BigEndian.AppendUint64(nil, s.config.L2OutputInterval), nil
 	default:
 		return nil, ErrNotFound
 	}
 }
+
+// customBootInfo returns true if the chain configuration is provided as local pre-images.
+// Range proofs always use custom boot info, as the output interval isn't part of the standard boot info provided
+// by fault dispute games.
+func (s *LocalPreimageSource) customBootInfo() bool {
+	return s.config.IsCustomChainConfig || s.config.L2OutputInterval != 0
+}
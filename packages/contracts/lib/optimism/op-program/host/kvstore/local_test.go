@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
@@ -33,8 +34,9 @@ func TestLocalPreimageSource(t *testing.T) {
 		{"L2Claim", l2ClaimKey, cfg.L2Claim.Bytes()},
 		{"L2ClaimBlockNumber", l2ClaimBlockNumberKey, binary.BigEndian.AppendUint64(nil, cfg.L2ClaimBlockNumber)},
 		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, cfg.L2ChainConfig.ChainID.Uint64())},
-		{"Rollup", rollupKey, nil},             // Only available for custom chain configs
-		{"ChainConfig", l2ChainConfigKey, nil}, // Only available for custom chain configs
+		{"Rollup", rollupKey, nil},                     // Only available for custom chain configs
+		{"ChainConfig", l2ChainConfigKey, nil},         // Only available for custom chain configs
+		{"L2OutputInterval", l2OutputIntervalKey, nil}, // Only available for range proofs
 		{"Unknown", preimage.LocalIndexKey(1000).PreimageKey(), nil},
 	}
 	for _, test := range tests {
@@ -67,6 +69,35 @@ func TestGetCustomChainConfigPreimages(t *testing.T) {
 	actualChainConfig, err := source.Get(l2ChainConfigKey)
 	require.NoError(t, err)
 	require.Equal(t, asJson(t, cfg.L2ChainConfig), actualChainConfig)
+	actualChainID, err := source.Get(l2ChainIDKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, client.CustomChainIDIndicator), actualChainID)
+	// Custom chains that prove a single output don't provide the output interval
+	_, err = source.Get(l2OutputIntervalKey)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGetL2OutputIntervalPreimage(t *testing.T) {
+	cfg := &config.Config{
+		Rollup:           chaincfg.OPSepolia(),
+		L2ChainConfig:    params.SepoliaChainConfig,
+		L2OutputInterval: 100,
+	}
+	source := NewLocalPreimageSource(cfg)
+	actual, err := source.Get(l2OutputIntervalKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, 100), actual)
+
+	// The interval is only read with custom boot info, so the configs are provided even for known chains
+	actualChainID, err := source.Get(l2ChainIDKey)
+	require.NoError(t, err)
+	require.Equal(t, binary.BigEndian.AppendUint64(nil, client.CustomRangeChainIDIndicator), actualChainID)
+	actualRollup, err := source.Get(rollupKey)
+	require.NoError(t, err)
+	require.Equal(t, asJson(t, cfg.Rollup), actualRollup)
+	actualChainConfig, err := source.Get(l2ChainConfigKey)
+	require.NoError(t, err)
+	require.Equal(t, asJson(t, cfg.L2ChainConfig), actualChainConfig)
 }
 
 func asJson(t *testing.T, v any) []byte {
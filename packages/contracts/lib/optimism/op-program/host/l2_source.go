@@ -46,7 +46,7 @@ func NewL2Source(ctx context.Context, logger log.Logger, config *config.Config)
 	logger.Info("Connecting to canonical L2 source", "url", config.L2URL)
 
 	// eth_getProof calls are expensive and takes time, so we use a longer timeout
-	canonicalL2RPC, err := client.NewRPC(ctx, logger, config.L2URL, client.WithDialAttempts(10), client.WithCallTimeout(5*time.Minute))
+	canonicalL2RPC, err := client.NewRPC(ctx, logger, config.L2URL, l2RPCOptions(config)...)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +66,7 @@ func NewL2Source(ctx context.Context, logger log.Logger, config *config.Config)
 
 	logger.Info("Connecting to experimental L2 source", "url", config.L2ExperimentalURL)
 	// debug_executionWitness calls are expensive and takes time, so we use a longer timeout
-	experimentalRPC, err := client.NewRPC(ctx, logger, config.L2ExperimentalURL, client.WithDialAttempts(10), client.WithCallTimeout(5*time.Minute))
+	experimentalRPC, err := client.NewRPC(ctx, logger, config.L2ExperimentalURL, l2RPCOptions(config)...)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +81,15 @@ func NewL2Source(ctx context.Context, logger log.Logger, config *config.Config)
 	return source, nil
 }
 
+// l2RPCOptions returns the options for L2 RPCs. Each RPC is rate limited independently.
+func l2RPCOptions(config *config.Config) []client.RPCOption {
+	opts := []client.RPCOption{client.WithDialAttempts(10), client.WithCallTimeout(5 * time.Minute)}
+	if config.L2RPCRateLimit > 0 {
+		opts = append(opts, client.WithRateLimit(config.L2RPCRateLimit, rateLimitBurst(config.L2RPCRateLimit)))
+	}
+	return opts
+}
+
 func (l *L2Source) ExperimentalEnabled() bool {
 	return l.experimentalClient != nil
 }
@@ -5,19 +5,19 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"slices"
 	"strings"
+	"sync"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -33,12 +33,6 @@ var (
 	ErrExperimentalPrefetchDisabled = errors.New("experimental prefetch disabled")
 )
 
-var acceleratedPrecompiles = []common.Address{
-	common.BytesToAddress([]byte{0x1}),  // ecrecover
-	common.BytesToAddress([]byte{0x8}),  // bn256Pairing
-	common.BytesToAddress([]byte{0x0a}), // KZG Point Evaluation
-}
-
 type L1Source interface {
 	InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error)
 	InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error)
@@ -55,6 +49,7 @@ type L2Source interface {
 	NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 	CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
 	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
+	ExecutionWitness(ctx context.Context, blockNum uint64) (*eth.ExecutionWitness, error)
 }
 
 type Prefetcher struct {
@@ -62,34 +57,162 @@ type Prefetcher struct {
 	l1Fetcher     L1Source
 	l1BlobFetcher L1BlobSource
 	l2Fetcher     L2Source
-	lastHint      string
 	kvStore       kvstore.KV
+	precompiles   *engineapi.PrecompileRegistry
+
+	mu       sync.Mutex
+	lastHint string
+	// executingHint is the execution witness hint of the block the client is currently executing
+	executingHint string
+	// maxWitnessBlock is the last block number that execution witnesses are fetched ahead for
+	maxWitnessBlock uint64
+	// inflight maps the hints being fetched in the background to a channel that is closed when the fetch completes
+	inflight map[string]chan struct{}
+	// fetched contains the hints successfully fetched in the background, which don't need to be fetched again
+	fetched map[string]struct{}
+	// witnessDisabled is set when the L2 source doesn't support execution witnesses so they are no longer requested
+	witnessDisabled bool
+	// sem limits the number of hints fetched in the background concurrently
+	sem    chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+// NewPrefetcher creates a Prefetcher which fetches up to maxConcurrency hints in the background at once.
+// When the execution witness of a block is hinted, the witnesses of the following blocks up to maxWitnessBlock are
+// fetched ahead so that up to maxConcurrency witnesses are fetched while the client executes earlier blocks.
+// Only the precompiles in the registry are served, or the default registry of this build if precompiles is nil.
+// Close must be called to stop any background fetches.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV, precompiles *engineapi.PrecompileRegistry, maxConcurrency int, maxWitnessBlock uint64) *Prefetcher {
+	if precompiles == nil {
+		precompiles = engineapi.NewDefaultPrecompileRegistry()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Prefetcher{
-		logger:        logger,
-		l1Fetcher:     NewRetryingL1Source(logger, l1Fetcher),
-		l1BlobFetcher: NewRetryingL1BlobSource(logger, l1BlobFetcher),
-		l2Fetcher:     NewRetryingL2Source(logger, l2Fetcher),
-		kvStore:       kvStore,
+		logger:          logger,
+		l1Fetcher:       NewRetryingL1Source(logger, l1Fetcher),
+		l1BlobFetcher:   NewRetryingL1BlobSource(logger, l1BlobFetcher),
+		l2Fetcher:       NewRetryingL2Source(logger, l2Fetcher),
+		kvStore:         kvStore,
+		precompiles:     precompiles,
+		maxWitnessBlock: maxWitnessBlock,
+		inflight:        make(map[string]chan struct{}),
+		fetched:         make(map[string]struct{}),
+		sem:             make(chan struct{}, max(maxConcurrency, 1)),
+		ctx:             ctx,
+		cancel:          cancel,
 	}
 }
 
 func (p *Prefetcher) Hint(hint string) error {
 	p.logger.Trace("Received hint", "hint", hint)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	hintType, _, _ := strings.Cut(hint, " ")
+	if hintType == l2.HintL2ExecutionWitness {
+		p.hintExecutionWitness(hint)
+		return nil
+	}
 	p.lastHint = hint
 	return nil
 }
 
+// hintExecutionWitness starts fetching the execution witness of the hinted block in the background, along with the
+// witnesses of the following blocks so the next blocks' state is ready by the time the client executes them.
+// Must be called with p.mu held.
+func (p *Prefetcher) hintExecutionWitness(hint string) {
+	p.executingHint = hint
+	p.startBackgroundFetch(hint)
+	_, hintBytes, err := parseHint(hint)
+	if err != nil || len(hintBytes) != 8 {
+		// Invalid hints are reported when fetched
+		return
+	}
+	blockNum := binary.BigEndian.Uint64(hintBytes)
+	for i := uint64(1); i < uint64(cap(p.sem)) && blockNum+i <= p.maxWitnessBlock; i++ {
+		p.startBackgroundFetch(l2.ExecutionWitnessHint(blockNum + i).Hint())
+	}
+}
+
+// startBackgroundFetch starts fetching hint in the background unless it is already being or has been fetched.
+// Errors are only logged as the pre-images it provides are also available from other hints.
+// Must be called with p.mu held.
+func (p *Prefetcher) startBackgroundFetch(hint string) {
+	if _, ok := p.inflight[hint]; ok || p.witnessDisabled {
+		return
+	}
+	if _, ok := p.fetched[hint]; ok {
+		return
+	}
+	done := make(chan struct{})
+	p.inflight[hint] = done
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		var err error
+		select {
+		case p.sem <- struct{}{}:
+			err = p.prefetch(p.ctx, hint)
+			<-p.sem
+		case <-p.ctx.Done():
+			err = p.ctx.Err()
+		}
+		p.mu.Lock()
+		delete(p.inflight, hint)
+		if errors.Is(err, ErrExperimentalPrefetchDisabled) {
+			p.logger.Info("Execution witnesses not available, fetching state individually")
+			p.witnessDisabled = true
+		} else if err != nil {
+			p.logger.Warn("Background prefetch failed", "hint", hint, "err", err)
+		} else {
+			p.fetched[hint] = struct{}{}
+		}
+		p.mu.Unlock()
+		close(done)
+	}()
+}
+
+// waitForExecutingWitness waits until the execution witness of the block being executed has been fetched.
+// Witnesses fetched ahead for later blocks are not waited for.
+func (p *Prefetcher) waitForExecutingWitness(ctx context.Context) error {
+	p.mu.Lock()
+	done, ok := p.inflight[p.executingHint]
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops any hints being fetched in the background and waits for them to exit.
+func (p *Prefetcher) Close() error {
+	p.cancel()
+	p.wg.Wait()
+	return nil
+}
+
 func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte, error) {
 	p.logger.Trace("Pre-image requested", "key", key)
 	pre, err := p.kvStore.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		// The pre-image may be provided by the witness being fetched in the background,
+		// which is faster than fetching it individually.
+		if err := p.waitForExecutingWitness(ctx); err != nil {
+			return nil, err
+		}
+		pre, err = p.kvStore.Get(key)
+	}
 	// Use a loop to keep retrying the prefetch as long as the key is not found
 	// This handles the case where the prefetch downloads a preimage, but it is then deleted unexpectedly
 	// before we get to read it.
-	for errors.Is(err, kvstore.ErrNotFound) && p.lastHint != "" {
-		hint := p.lastHint
+	for errors.Is(err, kvstore.ErrNotFound) && p.getLastHint() != "" {
+		hint := p.getLastHint()
 		if err := p.prefetch(ctx, hint); err != nil {
 			return nil, fmt.Errorf("prefetch failed: %w", err)
 		}
@@ -101,6 +224,12 @@ func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte,
 	return pre, err
 }
 
+func (p *Prefetcher) getLastHint() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lastHint
+}
+
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	hintType, hintBytes, err := parseHint(hint)
 	if err != nil {
@@ -189,16 +318,15 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		}
 		precompileAddress := common.BytesToAddress(hintBytes[:20])
 		// For extra safety, avoid accelerating unexpected precompiles
-		if !slices.Contains(acceleratedPrecompiles, precompileAddress) {
+		precompile, ok := p.precompiles.Get(precompileAddress)
+		if !ok {
 			return fmt.Errorf("unsupported precompile address: %s", precompileAddress)
 		}
-		// NOTE: We use the precompiled contracts from Cancun because it's the only set that contains the addresses of all accelerated precompiles
-		// We assume the precompile Run function behavior does not change across EVM upgrades.
+		// NOTE: We assume the precompile Run function behavior does not change across EVM upgrades.
 		// As such, we must not rely on upgrade-specific behavior such as precompile.RequiredGas.
-		precompile := getPrecompiledContract(precompileAddress)
 
 		// KZG Point Evaluation precompile also verifies its input
-		result, err := precompile.Run(hintBytes[20:])
+		result, err := precompile.Implementation.Run(hintBytes[20:])
 		if err == nil {
 			result = append(precompileSuccess[:], result...)
 		} else {
@@ -219,16 +347,15 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		// The requiredGas is only used by the L1 PreimageOracle to enforce complete precompile execution.
 
 		// For extra safety, avoid accelerating unexpected precompiles
-		if !slices.Contains(acceleratedPrecompiles, precompileAddress) {
+		precompile, ok := p.precompiles.Get(precompileAddress)
+		if !ok {
 			return fmt.Errorf("unsupported precompile address: %s", precompileAddress)
 		}
-		// NOTE: We use the precompiled contracts from Cancun because it's the only set that contains the addresses of all accelerated precompiles
-		// We assume the precompile Run function behavior does not change across EVM upgrades.
+		// NOTE: We assume the precompile Run function behavior does not change across EVM upgrades.
 		// As such, we must not rely on upgrade-specific behavior such as precompile.RequiredGas.
-		precompile := getPrecompiledContract(precompileAddress)
 
 		// KZG Point Evaluation precompile also verifies its input
-		result, err := precompile.Run(hintBytes[28:])
+		result, err := precompile.Implementation.Run(hintBytes[28:])
 		if err == nil {
 			result = append(precompileSuccess[:], result...)
 		} else {
@@ -288,10 +415,35 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 			return fmt.Errorf("failed to fetch L2 output root %s: %w", hash, err)
 		}
 		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), output.Marshal())
+	case l2.HintL2ExecutionWitness:
+		if len(hintBytes) != 8 {
+			return fmt.Errorf("invalid L2 execution witness hint: %x", hint)
+		}
+		blockNum := binary.BigEndian.Uint64(hintBytes)
+		witness, err := p.l2Fetcher.ExecutionWitness(ctx, blockNum)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L2 execution witness for block %d: %w", blockNum, err)
+		}
+		return p.storeExecutionWitness(witness)
 	}
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
 
+// storeExecutionWitness stores the state trie nodes and contract codes in witness.
+// Each is stored by its hash, so the keys in the witness don't need to be trusted.
+func (p *Prefetcher) storeExecutionWitness(witness *eth.ExecutionWitness) error {
+	for _, values := range []map[string]hexutil.Bytes{witness.State, witness.Codes} {
+		for _, value := range values {
+			key := preimage.Keccak256Key(crypto.Keccak256Hash(value)).PreimageKey()
+			if err := p.kvStore.Put(key, value); err != nil {
+				return fmt.Errorf("failed to store witness data: %w", err)
+			}
+		}
+	}
+	p.logger.Debug("Stored execution witness", "nodes", len(witness.State), "codes", len(witness.Codes))
+	return nil
+}
+
 func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
 	opaqueReceipts, err := eth.EncodeReceipts(receipts)
 	if err != nil {
@@ -332,7 +484,3 @@ func parseHint(hint string) (string, []byte, error) {
 	}
 	return hintType, hintBytes, nil
 }
-
-func getPrecompiledContract(address common.Address) vm.PrecompiledContract {
-	return vm.PrecompiledContractsCancun[address]
-}
@@ -2,23 +2,30 @@ package prefetcher
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	crand "crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -402,9 +409,44 @@ func TestUnsupportedPrecompile(t *testing.T) {
 	oracle.Precompile(common.HexToAddress("0xdead"), nil)
 }
 
+func TestFetchCustomPrecompileResult(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	require.NoError(t, err)
+	hash := crypto.Keccak256([]byte("message"))
+	r, s, err := ecdsa.Sign(crand.Reader, key, hash)
+	require.NoError(t, err)
+	input := slices.Concat(hash, common.LeftPadBytes(r.Bytes(), 32), common.LeftPadBytes(s.Bytes(), 32),
+		common.LeftPadBytes(key.X.Bytes(), 32), common.LeftPadBytes(key.Y.Bytes(), 32))
+	addr := engineapi.P256VerifyPrecompile.Address
+	requiredGas := uint64(3450)
+
+	t.Run("Registered", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		registry, err := engineapi.NewPrecompileRegistry(engineapi.P256VerifyPrecompile)
+		require.NoError(t, err)
+		prefetcher.precompiles = registry
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+
+		result, ok := oracle.Precompile(addr, input, requiredGas)
+		require.True(t, ok)
+		require.Equal(t, common.LeftPadBytes([]byte{1}, 32), result)
+	})
+
+	t.Run("NotRegistered", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		hint := l1.PrecompileHintV2(slices.Concat(addr.Bytes(), binary.BigEndian.AppendUint64(nil, requiredGas), input))
+		require.NoError(t, prefetcher.Hint(hint.Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.PrecompileKey(crypto.Keccak256Hash(hint)).PreimageKey())
+		require.ErrorContains(t, err, "unsupported precompile address")
+	})
+}
+
 func TestRestrictedPrecompileContracts(t *testing.T) {
-	for _, addr := range acceleratedPrecompiles {
-		require.NotNil(t, getPrecompiledContract(addr))
+	registry := engineapi.NewDefaultPrecompileRegistry()
+	for _, addr := range registry.Addresses() {
+		precompile, ok := registry.Get(addr)
+		require.True(t, ok)
+		require.NotNil(t, precompile.Implementation)
 	}
 }
 
@@ -512,6 +554,131 @@ func TestFetchL2Code(t *testing.T) {
 	})
 }
 
+func TestFetchL2ExecutionWitness(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	node := testutils.RandomData(rng, 30)
+	code := testutils.RandomData(rng, 30)
+	nodeHash := crypto.Keccak256Hash(node)
+	codeHash := crypto.Keccak256Hash(code)
+	witness := &eth.ExecutionWitness{
+		// Keys in the witness are ignored, values are always stored by their hash
+		State: map[string]hexutil.Bytes{"a": node},
+		Codes: map[string]hexutil.Bytes{"b": code},
+	}
+	blockNum := uint64(42)
+	hint := l2.ExecutionWitnessHint(blockNum).Hint()
+
+	t.Run("StoresNodesAndCodes", func(t *testing.T) {
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+		l2Cl.ExpectExecutionWitness(blockNum, witness, nil)
+		defer l2Cl.Mock.AssertExpectations(t)
+
+		require.NoError(t, prefetcher.Hint(hint))
+		// Duplicate hints are not fetched again
+		require.NoError(t, prefetcher.Hint(hint))
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.EqualValues(t, node, oracle.NodeByHash(nodeHash))
+		require.EqualValues(t, code, oracle.CodeByHash(codeHash))
+
+		require.NoError(t, prefetcher.Hint(hint))
+		require.NoError(t, prefetcher.Close())
+	})
+
+	t.Run("FallbackWhenDisabled", func(t *testing.T) {
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+		l2Cl.ExpectExecutionWitness(blockNum, (*eth.ExecutionWitness)(nil), ErrExperimentalPrefetchDisabled)
+		l2Cl.ExpectNodeByHash(nodeHash, node, nil)
+		defer l2Cl.Mock.AssertExpectations(t)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		require.NoError(t, prefetcher.Hint(hint))
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.EqualValues(t, node, oracle.NodeByHash(nodeHash))
+
+		// Witnesses are not requested again once the source reports they are unavailable
+		require.NoError(t, prefetcher.Hint(l2.ExecutionWitnessHint(blockNum+1).Hint()))
+		require.NoError(t, prefetcher.Close())
+	})
+
+	t.Run("FallbackWhenFailed", func(t *testing.T) {
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+		l2Cl.ExpectExecutionWitness(blockNum, (*eth.ExecutionWitness)(nil), ErrExperimentalPrefetchFailed)
+		l2Cl.ExpectCodeByHash(codeHash, code, nil)
+		defer l2Cl.Mock.AssertExpectations(t)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		require.NoError(t, prefetcher.Hint(hint))
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.EqualValues(t, code, oracle.CodeByHash(codeHash))
+		require.NoError(t, prefetcher.Close())
+	})
+
+	t.Run("FetchesFollowingBlocksConcurrently", func(t *testing.T) {
+		_, l1Source, l1BlobSource, l2Cl, kv := createPrefetcher(t)
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Source, l1BlobSource, l2Cl, kv, engineapi.NewDefaultPrecompileRegistry(), 3, blockNum+10)
+		started := make(chan uint64, 3)
+		release := make(chan struct{})
+		for i := uint64(0); i < 3; i++ {
+			l2Cl.Mock.On("ExecutionWitness", blockNum+i).Once().Run(func(args mock.Arguments) {
+				started <- args.Get(0).(uint64)
+				<-release
+			}).Return(witness, new(error))
+		}
+		defer l2Cl.Mock.AssertExpectations(t)
+
+		require.NoError(t, prefetcher.Hint(hint))
+		// All three witnesses must be in flight at once for any of them to complete
+		var inflight []uint64
+		for i := 0; i < 3; i++ {
+			inflight = append(inflight, <-started)
+		}
+		slices.Sort(inflight)
+		require.Equal(t, []uint64{blockNum, blockNum + 1, blockNum + 2}, inflight)
+		close(release)
+
+		oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		require.EqualValues(t, node, oracle.NodeByHash(nodeHash))
+		require.NoError(t, prefetcher.Close())
+	})
+
+	t.Run("DoesNotFetchAheadPastMaxWitnessBlock", func(t *testing.T) {
+		_, l1Source, l1BlobSource, l2Cl, kv := createPrefetcher(t)
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelDebug), l1Source, l1BlobSource, l2Cl, kv, engineapi.NewDefaultPrecompileRegistry(), 3, blockNum+1)
+		l2Cl.ExpectExecutionWitness(blockNum, witness, nil)
+		l2Cl.ExpectExecutionWitness(blockNum+1, witness, nil)
+		defer l2Cl.Mock.AssertExpectations(t)
+
+		require.NoError(t, prefetcher.Hint(hint))
+		prefetcher.wg.Wait()
+		require.NoError(t, prefetcher.Close())
+	})
+
+	t.Run("InvalidHint", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		err := prefetcher.prefetch(context.Background(), l2.HintL2ExecutionWitness+" 0x1234")
+		require.ErrorContains(t, err, "invalid L2 execution witness hint")
+	})
+
+	t.Run("CloseCancelsPendingFetches", func(t *testing.T) {
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+		started := make(chan struct{})
+		l2Cl.Mock.On("ExecutionWitness", blockNum).Once().Run(func(args mock.Arguments) {
+			close(started)
+		}).Return((*eth.ExecutionWitness)(nil), new(error))
+		// Occupy the only concurrency slot so the second hint is still pending when closed
+		prefetcher.sem <- struct{}{}
+		require.NoError(t, prefetcher.Hint(hint))
+		require.NoError(t, prefetcher.Close())
+		<-prefetcher.sem
+		select {
+		case <-started:
+			t.Fatal("should not have fetched witness after close")
+		default:
+		}
+	})
+}
+
 func TestBadHints(t *testing.T) {
 	prefetcher, _, _, _, kv := createPrefetcher(t)
 	hash := common.Hash{0xad}
@@ -569,7 +736,7 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	_, l1Source, l1BlobSource, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, kv, engineapi.NewDefaultPrecompileRegistry(), 1, 0)
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -610,6 +777,15 @@ func (m *l2Client) ExpectOutputByRoot(root common.Hash, output eth.Output, err e
 	m.Mock.On("OutputByRoot", root).Once().Return(output, &err)
 }
 
+func (m *l2Client) ExecutionWitness(ctx context.Context, blockNum uint64) (*eth.ExecutionWitness, error) {
+	out := m.Mock.MethodCalled("ExecutionWitness", blockNum)
+	return out[0].(*eth.ExecutionWitness), *out[1].(*error)
+}
+
+func (m *l2Client) ExpectExecutionWitness(blockNum uint64, witness *eth.ExecutionWitness, err error) {
+	m.Mock.On("ExecutionWitness", blockNum).Once().Return(witness, &err)
+}
+
 func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *testutils.MockBlobsFetcher, *l2Client, kvstore.KV) {
 	logger := testlog.Logger(t, log.LevelDebug)
 	kv := kvstore.NewMemKV()
@@ -621,7 +797,7 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *test
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, kv, engineapi.NewDefaultPrecompileRegistry(), 1, 0)
 	return prefetcher, l1Source, l1BlobSource, l2Source, kv
 }
 
@@ -323,6 +323,15 @@ func TestRetryingL2Source(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, output, actualOutput)
 	})
+
+	t.Run("ExecutionWitness Not Retried", func(t *testing.T) {
+		source, mock := createL2Source(t)
+		defer mock.AssertExpectations(t)
+		mock.ExpectExecutionWitness(1, nil, ErrExperimentalPrefetchFailed)
+
+		_, err := source.ExecutionWitness(ctx, 1)
+		require.ErrorIs(t, err, ErrExperimentalPrefetchFailed)
+	})
 }
 
 func createL2Source(t *testing.T) (*RetryingL2Source, *MockL2Source) {
@@ -358,6 +367,11 @@ func (m *MockL2Source) OutputByRoot(ctx context.Context, root common.Hash) (eth.
 	return out[0].(eth.Output), *out[1].(*error)
 }
 
+func (m *MockL2Source) ExecutionWitness(ctx context.Context, blockNum uint64) (*eth.ExecutionWitness, error) {
+	out := m.Mock.MethodCalled("ExecutionWitness", blockNum)
+	return out[0].(*eth.ExecutionWitness), *out[1].(*error)
+}
+
 func (m *MockL2Source) ExpectInfoAndTxsByHash(blockHash common.Hash, info eth.BlockInfo, txs types.Transactions, err error) {
 	m.Mock.On("InfoAndTxsByHash", blockHash).Once().Return(info, txs, &err)
 }
@@ -374,4 +388,8 @@ func (m *MockL2Source) ExpectOutputByRoot(root common.Hash, output eth.Output, e
 	m.Mock.On("OutputByRoot", root).Once().Return(output, &err)
 }
 
+func (m *MockL2Source) ExpectExecutionWitness(blockNum uint64, witness *eth.ExecutionWitness, err error) {
+	m.Mock.On("ExecutionWitness", blockNum).Once().Return(witness, &err)
+}
+
 var _ L2Source = (*MockL2Source)(nil)
@@ -79,6 +79,40 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	MaxPendingProposalsFlag = &cli.Uint64Flag{
+		Name: "max-pending-proposals",
+		Usage: "The maximum number of L2OutputOracle proposals to pipeline when the proposer is behind by " +
+			"more than one submission interval. 0 or 1 proposes one output at a time.",
+		Value:   1,
+		EnvVars: prefixEnvVars("MAX_PENDING_PROPOSALS"),
+	}
+	CatchUpGasLimitFlag = &cli.Uint64Flag{
+		Name: "catch-up-gas-limit",
+		Usage: "The gas limit of all but the first of a batch of pipelined L2OutputOracle proposals, which can't be " +
+			"estimated as the L1 state doesn't include the preceding proposals yet.",
+		Value:   250_000,
+		EnvVars: prefixEnvVars("CATCH_UP_GAS_LIMIT"),
+	}
+	VerifyRollupRpcsFlag = &cli.StringSliceFlag{
+		Name: "verify-rollup-rpcs",
+		Usage: "HTTP provider URLs of additional rollup nodes to verify outputs against before proposing. " +
+			"Outputs are only proposed if enough output roots match, see --verify-quorum.",
+		EnvVars: prefixEnvVars("VERIFY_ROLLUP_RPCS"),
+	}
+	VerifyQuorumFlag = &cli.UintFlag{
+		Name: "verify-quorum",
+		Usage: "Number of matching output roots required to propose an output, counting the rollup node the " +
+			"output is fetched from and the --verify-rollup-rpcs nodes. 0 requires a majority of them to match, " +
+			"otherwise it must be at least 2.",
+		Value:   0,
+		EnvVars: prefixEnvVars("VERIFY_QUORUM"),
+	}
+	VerifyL2EthRpcFlag = &cli.StringFlag{
+		Name: "verify-l2-eth-rpc",
+		Usage: "HTTP provider URL of an L2 execution client. If set, output roots are recomputed from its state " +
+			"and must match before proposing.",
+		EnvVars: prefixEnvVars("VERIFY_L2_ETH_RPC"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -98,6 +132,11 @@ var optionalFlags = []cli.Flag{
 	DisputeGameTypeFlag,
 	ActiveSequencerCheckDurationFlag,
 	WaitNodeSyncFlag,
+	MaxPendingProposalsFlag,
+	CatchUpGasLimitFlag,
+	VerifyRollupRpcsFlag,
+	VerifyQuorumFlag,
+	VerifyL2EthRpcFlag,
 }
 
 func init() {
@@ -34,6 +34,8 @@ type Metricer interface {
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+
+	RecordOutputDisagreement(source string)
 }
 
 type Metrics struct {
@@ -47,6 +49,8 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	outputDisagreements *prometheus.CounterVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -81,6 +85,13 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		outputDisagreements: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_disagreements_total",
+			Help:      "Number of output roots from a verification source that disagreed with the output to propose",
+		}, []string{
+			"source",
+		}),
 	}
 }
 
@@ -106,6 +117,9 @@ func (m *Metrics) RecordUp() {
 
 const (
 	BlockProposed = "proposed"
+
+	// OutputSourceL2 is the verification source label of output roots recomputed from the L2 execution client.
+	OutputSourceL2 = "l2_el"
 )
 
 // RecordL2BlocksProposed should be called when new L2 block is proposed
@@ -113,6 +127,11 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordOutputDisagreement should be called when a verification source disagrees with the output to propose
+func (m *Metrics) RecordOutputDisagreement(source string) {
+	m.outputDisagreements.WithLabelValues(source).Inc()
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
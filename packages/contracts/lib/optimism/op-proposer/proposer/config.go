@@ -2,6 +2,7 @@ package proposer
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/urfave/cli/v2"
@@ -61,6 +62,22 @@ type CLIConfig struct {
 
 	// Whether to wait for the sequencer to sync to a recent block at startup.
 	WaitNodeSync bool
+
+	// MaxPendingProposals is the maximum number of L2OutputOracle proposals to pipeline
+	// when catching up on more than one submission interval.
+	MaxPendingProposals uint64
+
+	// CatchUpGasLimit is the gas limit of all but the first of a batch of pipelined L2OutputOracle proposals.
+	CatchUpGasLimit uint64
+
+	// VerifyRollupRpcs are the HTTP provider URLs of additional rollup nodes to verify outputs against.
+	VerifyRollupRpcs []string
+
+	// VerifyQuorum is the number of matching output roots required to propose, 0 requires a majority to match.
+	VerifyQuorum uint
+
+	// VerifyL2EthRpc is the HTTP provider URL of an L2 execution client to recompute output roots with.
+	VerifyL2EthRpc string
 }
 
 func (c *CLIConfig) Check() error {
@@ -89,6 +106,19 @@ func (c *CLIConfig) Check() error {
 	if c.ProposalInterval != 0 && c.DGFAddress == "" {
 		return errors.New("the `ProposalInterval` was provided but the `DisputeGameFactory` address was not set")
 	}
+	if c.MaxPendingProposals > 1 && c.L2OOAddress == "" {
+		return errors.New("the `MaxPendingProposals` was set but the `L2OutputOracle` address was not set")
+	}
+	if c.MaxPendingProposals > 1 && c.CatchUpGasLimit == 0 {
+		return errors.New("the `MaxPendingProposals` was set but the `CatchUpGasLimit` was not set")
+	}
+	// The output being proposed counts towards the quorum, so a quorum of 1 would propose it unverified.
+	if len(c.VerifyRollupRpcs) > 0 && c.VerifyQuorum == 1 {
+		return errors.New("the `VerifyQuorum` must be at least 2 to verify outputs against the `VerifyRollupRpcs`")
+	}
+	if c.VerifyQuorum > uint(len(c.VerifyRollupRpcs))+1 {
+		return fmt.Errorf("the `VerifyQuorum` of %d exceeds the %d available output sources", c.VerifyQuorum, len(c.VerifyRollupRpcs)+1)
+	}
 
 	return nil
 }
@@ -113,5 +143,10 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		DisputeGameType:              uint32(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
 		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
+		MaxPendingProposals:          ctx.Uint64(flags.MaxPendingProposalsFlag.Name),
+		CatchUpGasLimit:              ctx.Uint64(flags.CatchUpGasLimitFlag.Name),
+		VerifyRollupRpcs:             ctx.StringSlice(flags.VerifyRollupRpcsFlag.Name),
+		VerifyQuorum:                 ctx.Uint(flags.VerifyQuorumFlag.Name),
+		VerifyL2EthRpc:               ctx.String(flags.VerifyL2EthRpcFlag.Name),
 	}
 }
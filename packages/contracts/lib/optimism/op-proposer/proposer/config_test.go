@@ -0,0 +1,62 @@
+package proposer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer"
+	"github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+func validProposerConfig() proposer.CLIConfig {
+	return proposer.CLIConfig{
+		L1EthRpc:      "fake",
+		RollupRpc:     "fake",
+		L2OOAddress:   "0x1234",
+		PollInterval:  time.Second,
+		TxMgrConfig:   txmgr.NewCLIConfig("fake", txmgr.DefaultBatcherFlagValues),
+		RPCConfig:     rpc.DefaultCLIConfig(),
+		LogConfig:     log.DefaultCLIConfig(),
+		MetricsConfig: metrics.DefaultCLIConfig(),
+		PprofConfig:   oppprof.DefaultCLIConfig(),
+	}
+}
+
+func TestValidProposerConfig(t *testing.T) {
+	cfg := validProposerConfig()
+	require.NoError(t, cfg.Check(), "valid config should pass the check function")
+}
+
+func TestProposerConfigVerifyQuorum(t *testing.T) {
+	tests := []struct {
+		name      string
+		rpcs      []string
+		quorum    uint
+		errString string
+	}{
+		{name: "NoVerification", quorum: 1},
+		{name: "Majority", rpcs: []string{"a", "b"}, quorum: 0},
+		{name: "AllSources", rpcs: []string{"a", "b"}, quorum: 3},
+		{name: "PrimaryOnly", rpcs: []string{"a", "b"}, quorum: 1, errString: "must be at least 2"},
+		{name: "ExceedsSources", rpcs: []string{"a", "b"}, quorum: 4, errString: "exceeds the 3 available output sources"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validProposerConfig()
+			cfg.VerifyRollupRpcs = test.rpcs
+			cfg.VerifyQuorum = test.quorum
+			err := cfg.Check()
+			if test.errString == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.errString)
+			}
+		})
+	}
+}
@@ -42,6 +42,7 @@ type L1Client interface {
 type L2OOContract interface {
 	Version(*bind.CallOpts) (string, error)
 	NextBlockNumber(*bind.CallOpts) (*big.Int, error)
+	SubmissionInterval(*bind.CallOpts) (*big.Int, error)
 }
 
 type DGFContract interface {
@@ -55,6 +56,11 @@ type RollupClient interface {
 	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
 }
 
+// OutputVerifier verifies an output fetched for proposal before it is proposed.
+type OutputVerifier interface {
+	VerifyOutput(ctx context.Context, output *eth.OutputResponse) error
+}
+
 type DriverSetup struct {
 	Log         log.Logger
 	Metr        metrics.Metricer
@@ -65,6 +71,9 @@ type DriverSetup struct {
 
 	// RollupProvider's RollupClient() is used to retrieve output roots from
 	RollupProvider dial.RollupProvider
+
+	// OutputVerifier, if set, verifies every output before it is proposed.
+	OutputVerifier OutputVerifier
 }
 
 // L2OutputSubmitter is responsible for proposing outputs
@@ -250,16 +259,83 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (*eth.OutputRes
 		return nil, false, fmt.Errorf("fetching output: %w", err)
 	}
 
-	// Always propose if it's part of the Finalized L2 chain. Or if allowed, if it's part of the safe L2 chain.
+	if !l.isReadyForProposal(output) {
+		return output, false, nil
+	}
+	if err := l.verifyOutput(ctx, output); err != nil {
+		return nil, false, err
+	}
+	return output, true, nil
+}
+
+// FetchL2OOCatchUpOutputs gets up to maxOutputs consecutive output proposals for the L2OO,
+// starting at the earliest next block number that should be proposed and advancing by the
+// L2OO submission interval. It is used to catch up when the proposer has fallen behind by
+// more than one submission interval.
+// The returned outputs are all ready for proposal and ordered by L2 block number.
+// The passed context is expected to be a lifecycle context. A network timeout
+// context will be derived from it.
+func (l *L2OutputSubmitter) FetchL2OOCatchUpOutputs(ctx context.Context, maxOutputs uint64) ([]*eth.OutputResponse, error) {
+	if l.l2ooContract == nil {
+		return nil, fmt.Errorf("L2OutputOracle contract not set, cannot fetch next output info")
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	callOpts := &bind.CallOpts{
+		From:    l.Txmgr.From(),
+		Context: cCtx,
+	}
+	nextCheckpointBlockBig, err := l.l2ooContract.NextBlockNumber(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("querying next block number: %w", err)
+	}
+	submissionIntervalBig, err := l.l2ooContract.SubmissionInterval(callOpts)
+	if err != nil {
+		return nil, fmt.Errorf("querying submission interval: %w", err)
+	}
+	submissionInterval := submissionIntervalBig.Uint64()
+	if submissionInterval == 0 {
+		return nil, errors.New("L2OutputOracle submission interval is zero")
+	}
+	// Fetch the current L2 heads
+	currentBlockNumber, err := l.FetchCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var outputs []*eth.OutputResponse
+	for block := nextCheckpointBlockBig.Uint64(); block <= currentBlockNumber && uint64(len(outputs)) < maxOutputs; block += submissionInterval {
+		output, err := l.FetchOutput(ctx, block)
+		if err != nil {
+			return nil, fmt.Errorf("fetching output: %w", err)
+		}
+		if !l.isReadyForProposal(output) {
+			break
+		}
+		if err := l.verifyOutput(ctx, output); err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	if len(outputs) == 0 {
+		l.Log.Debug("Proposer submission interval has not elapsed", "currentBlockNumber", currentBlockNumber, "nextBlockNumber", nextCheckpointBlockBig)
+	}
+	return outputs, nil
+}
+
+// isReadyForProposal returns whether the output may be proposed.
+// Always propose if it's part of the Finalized L2 chain. Or if allowed, if it's part of the safe L2 chain.
+func (l *L2OutputSubmitter) isReadyForProposal(output *eth.OutputResponse) bool {
 	if output.BlockRef.Number > output.Status.FinalizedL2.Number && (!l.Cfg.AllowNonFinalized || output.BlockRef.Number > output.Status.SafeL2.Number) {
 		l.Log.Debug("Not proposing yet, L2 block is not ready for proposal",
 			"l2_proposal", output.BlockRef,
 			"l2_safe", output.Status.SafeL2,
 			"l2_finalized", output.Status.FinalizedL2,
 			"allow_non_finalized", l.Cfg.AllowNonFinalized)
-		return output, false, nil
+		return false
 	}
-	return output, true, nil
+	return true
 }
 
 // FetchDGFOutput queries the DGF for the latest game and infers whether it is time to make another proposal
@@ -295,6 +371,9 @@ func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResp
 	if err != nil {
 		return nil, false, fmt.Errorf("could not fetch output at current block number %d: %w", currentBlockNumber, err)
 	}
+	if err := l.verifyOutput(ctx, output); err != nil {
+		return nil, false, err
+	}
 
 	return output, true, nil
 }
@@ -338,6 +417,19 @@ func (l *L2OutputSubmitter) FetchOutput(ctx context.Context, block uint64) (*eth
 	return output, nil
 }
 
+// verifyOutput verifies the output with the OutputVerifier, if any.
+// Outputs are only verified once they are ready for proposal,
+// as rollup nodes may legitimately disagree on unsafe blocks.
+func (l *L2OutputSubmitter) verifyOutput(ctx context.Context, output *eth.OutputResponse) error {
+	if l.OutputVerifier == nil {
+		return nil
+	}
+	if err := l.OutputVerifier.VerifyOutput(ctx, output); err != nil {
+		return fmt.Errorf("verifying output at block %d: %w", output.BlockRef.Number, err)
+	}
+	return nil
+}
+
 // ProposeL2OutputTxData creates the transaction data for the ProposeL2Output function
 func (l *L2OutputSubmitter) ProposeL2OutputTxData(output *eth.OutputResponse) ([]byte, error) {
 	return proposeL2OutputTxData(l.l2ooABI, output)
@@ -388,10 +480,10 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 }
 
 // sendTransaction creates & sends transactions through the underlying transaction manager.
-func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
+func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) (*types.Receipt, error) {
 	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	l.Log.Info("Proposing output root", "output", output.OutputRoot, "block", output.BlockRef)
@@ -399,16 +491,16 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	if l.Cfg.DisputeGameFactoryAddr != nil {
 		candidate, err := l.ProposeL2OutputDGFTxCandidate(ctx, output)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		receipt, err = l.Txmgr.Send(ctx, candidate)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		data, err := l.ProposeL2OutputTxData(output)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
 			TxData:   data,
@@ -416,19 +508,24 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 			GasLimit: 0,
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
+	l.logReceipt(output, receipt)
+	return receipt, nil
+}
+
+func (l *L2OutputSubmitter) logReceipt(output *eth.OutputResponse, receipt *types.Receipt) {
 	if receipt.Status == types.ReceiptStatusFailed {
-		l.Log.Error("Proposer tx successfully published but reverted", "tx_hash", receipt.TxHash)
+		l.Log.Error("Proposer tx successfully published but reverted", "tx_hash", receipt.TxHash, "block", output.BlockRef)
 	} else {
 		l.Log.Info("Proposer tx successfully published",
 			"tx_hash", receipt.TxHash,
+			"block", output.BlockRef,
 			"l1blocknum", output.Status.CurrentL1.Number,
 			"l1blockhash", output.Status.CurrentL1.Hash)
 	}
-	return nil
 }
 
 // loop is responsible for creating & submitting the next outputs
@@ -452,6 +549,23 @@ func (l *L2OutputSubmitter) loop() {
 			// A note on retrying: the outer ticker already runs on a short
 			// poll interval, which has a default value of 6 seconds. So no
 			// retry logic is needed around output fetching here.
+			if l.dgfContract == nil && l.Cfg.MaxPendingProposals > 1 {
+				outputs, err := l.FetchL2OOCatchUpOutputs(ctx, l.Cfg.MaxPendingProposals)
+				if err != nil {
+					l.Log.Warn("Error getting outputs", "err", err)
+					continue
+				}
+				switch len(outputs) {
+				case 0:
+					// debug logging already in FetchL2OOCatchUpOutputs
+				case 1:
+					l.proposeOutput(ctx, outputs[0])
+				default:
+					l.proposeOutputs(ctx, outputs)
+				}
+				continue
+			}
+
 			var output *eth.OutputResponse
 			var shouldPropose bool
 			var err error
@@ -497,7 +611,7 @@ func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.Outpu
 	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
-	if err := l.sendTransaction(cCtx, output); err != nil {
+	if _, err := l.sendTransaction(cCtx, output); err != nil {
 		l.Log.Error("Failed to send proposal transaction",
 			"err", err,
 			"l1blocknum", output.Status.CurrentL1.Number,
@@ -507,3 +621,128 @@ func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.Outpu
 	}
 	l.Metr.RecordL2BlocksProposed(output.BlockRef)
 }
+
+// proposeOutputs pipelines the proposals of several consecutive L2OO outputs through a tx queue,
+// bounded by the MaxPendingProposals config. The outputs must be ordered by L2 block number:
+// the queue assigns nonces in the order the proposals are queued, so the L2OO receives them in sequence.
+// Queueing stops as soon as a proposal fails, since all later proposals would revert.
+func (l *L2OutputSubmitter) proposeOutputs(ctx context.Context, outputs []*eth.OutputResponse) {
+	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	last := outputs[len(outputs)-1]
+	if err := l.waitForL1Head(cCtx, last.Status.HeadL1.Number+1); err != nil {
+		l.Log.Error("Failed to wait for L1 head before proposing outputs", "err", err, "l1head", last.Status.HeadL1.Number)
+		return
+	}
+
+	l.Log.Info("Catching up on output proposals", "count", len(outputs), "first", outputs[0].BlockRef, "last", last.BlockRef)
+	queue := txmgr.NewQueue[*eth.OutputResponse](cCtx, l.Txmgr, l.Cfg.MaxPendingProposals)
+	receiptCh := make(chan txmgr.TxReceipt[*eth.OutputResponse], len(outputs))
+	failed := false
+	handleReceipt := func(r txmgr.TxReceipt[*eth.OutputResponse]) {
+		if r.Err != nil {
+			l.Log.Error("Failed to send proposal transaction",
+				"err", r.Err,
+				"block", r.ID.BlockRef,
+				"l1blocknum", r.ID.Status.CurrentL1.Number,
+				"l1blockhash", r.ID.Status.CurrentL1.Hash)
+			failed = true
+			return
+		}
+		l.logReceipt(r.ID, r.Receipt)
+		if r.Receipt.Status == types.ReceiptStatusFailed {
+			failed = true
+			return
+		}
+		l.Metr.RecordL2BlocksProposed(r.ID.BlockRef)
+	}
+
+	for i, output := range outputs {
+		// Handle receipts that are already available, to stop queueing after a failure.
+	drain:
+		for {
+			select {
+			case r := <-receiptCh:
+				handleReceipt(r)
+			default:
+				break drain
+			}
+		}
+		if failed {
+			break
+		}
+
+		data, err := l.ProposeL2OutputTxData(output)
+		if err != nil {
+			l.Log.Error("Failed to create proposal tx data", "err", err, "block", output.BlockRef)
+			break
+		}
+		candidate := txmgr.TxCandidate{
+			TxData: data,
+			To:     l.Cfg.L2OutputOracleAddr,
+		}
+		if i > 0 {
+			candidate.GasLimit = l.Cfg.CatchUpGasLimit
+		}
+		l.Log.Info("Proposing output root", "output", output.OutputRoot, "block", output.BlockRef)
+		queue.SendOrdered(output, candidate, receiptCh)
+	}
+
+	_ = queue.Wait() // errors are handled per receipt
+	close(receiptCh)
+	for r := range receiptCh {
+		handleReceipt(r)
+	}
+}
+
+// ProposeOutputAtBlock proposes the output at the given L2 block on demand, outside of the
+// regular proposal schedule. The block must be ready for proposal, like any scheduled output.
+// The L2OO only accepts the next checkpoint block, so any other block is rejected when proposing to an L2OO.
+func (l *L2OutputSubmitter) ProposeOutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	l.mutex.Lock()
+	running := l.running
+	l.mutex.Unlock()
+	if !running {
+		return nil, ErrProposerNotRunning
+	}
+
+	if l.l2ooContract != nil {
+		cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+		defer cancel()
+		nextCheckpointBlock, err := l.l2ooContract.NextBlockNumber(&bind.CallOpts{
+			From:    l.Txmgr.From(),
+			Context: cCtx,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("querying next block number: %w", err)
+		}
+		if nextCheckpointBlock.Uint64() != blockNum {
+			return nil, fmt.Errorf("L2OutputOracle only accepts the next checkpoint block %d, requested %d", nextCheckpointBlock, blockNum)
+		}
+	}
+
+	output, err := l.FetchOutput(ctx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if !l.isReadyForProposal(output) {
+		return nil, fmt.Errorf("L2 block %d is not ready for proposal, finalized: %d, safe: %d",
+			blockNum, output.Status.FinalizedL2.Number, output.Status.SafeL2.Number)
+	}
+	if err := l.verifyOutput(ctx, output); err != nil {
+		return nil, err
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	receipt, err := l.sendTransaction(cCtx, output)
+	if err != nil {
+		return nil, fmt.Errorf("sending proposal transaction: %w", err)
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return nil, fmt.Errorf("proposal transaction %s reverted", receipt.TxHash)
+	}
+	l.Metr.RecordL2BlocksProposed(output.BlockRef)
+	return output, nil
+}
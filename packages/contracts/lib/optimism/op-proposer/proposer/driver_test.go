@@ -38,6 +38,11 @@ func (m *MockL2OOContract) NextBlockNumber(opts *bind.CallOpts) (*big.Int, error
 	return args.Get(0).(*big.Int), args.Error(1)
 }
 
+func (m *MockL2OOContract) SubmissionInterval(opts *bind.CallOpts) (*big.Int, error) {
+	args := m.Called(opts)
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
 type StubDGFContract struct {
 	hasProposedCount int
 }
@@ -179,3 +184,98 @@ func TestL2OutputSubmitter_OutputRetry(t *testing.T) {
 		})
 	}
 }
+
+// setupL2OO creates an L2OO output submitter without any mock expectations set.
+func setupL2OO(t *testing.T, cfg ProposerConfig) (*L2OutputSubmitter, *mockRollupEndpointProvider, *MockL2OOContract, *txmgrmocks.TxManager, *testlog.CapturingHandler) {
+	ep := newEndpointProvider()
+	l2OutputOracleAddr := common.HexToAddress("0x3F8A862E63E759a77DA22d384027D21BF096bA9E")
+	cfg.L2OutputOracleAddr = &l2OutputOracleAddr
+	txMgr := txmgrmocks.NewTxManager(t)
+	l2ooContract := new(MockL2OOContract)
+	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	lgr, logs := testlog.CaptureLogger(t, log.LevelDebug)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	ps := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:            lgr,
+			Metr:           metrics.NoopMetrics,
+			Cfg:            cfg,
+			Txmgr:          txMgr,
+			RollupProvider: ep,
+		},
+		done:         make(chan struct{}),
+		l2ooABI:      parsed,
+		l2ooContract: l2ooContract,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+	return ps, ep, l2ooContract, txMgr, logs
+}
+
+func TestL2OutputSubmitter_CatchUp(t *testing.T) {
+	ps, ep, l2ooContract, txMgr, logs := setupL2OO(t, ProposerConfig{
+		PollInterval:        time.Microsecond,
+		NetworkTimeout:      time.Second,
+		MaxPendingProposals: 3,
+		CatchUpGasLimit:     250_000,
+	})
+	ctx := context.Background()
+
+	status := &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 45}}
+	ep.rollupClient.On("SyncStatus").Return(status, nil).Once()
+	for _, num := range []uint64{10, 20, 30} {
+		ep.rollupClient.ExpectOutputAtBlock(num, &eth.OutputResponse{
+			Version:  supportedL2OutputVersion,
+			BlockRef: eth.L2BlockRef{Number: num},
+			Status:   status,
+		}, nil).Once()
+	}
+	l2ooContract.On("NextBlockNumber", mock.AnythingOfType("*bind.CallOpts")).Return(big.NewInt(10), nil).Once()
+	l2ooContract.On("SubmissionInterval", mock.AnythingOfType("*bind.CallOpts")).Return(big.NewInt(10), nil).Once()
+	txMgr.On("From").Return(common.Address{0xab})
+
+	outputs, err := ps.FetchL2OOCatchUpOutputs(ctx, ps.Cfg.MaxPendingProposals)
+	require.NoError(t, err)
+	require.Len(t, outputs, 3)
+	for i, output := range outputs {
+		require.Equal(t, uint64(10*(i+1)), output.BlockRef.Number)
+	}
+
+	var gasLimits []uint64
+	txMgr.On("BlockNumber", mock.Anything).Return(uint64(100), nil).Once()
+	txMgr.On("SendAsync", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			gasLimits = append(gasLimits, args.Get(1).(txmgr.TxCandidate).GasLimit)
+			args.Get(2).(chan txmgr.SendResponse) <- txmgr.SendResponse{
+				Receipt: &types.Receipt{Status: types.ReceiptStatusSuccessful},
+			}
+		}).Times(3)
+
+	ps.proposeOutputs(ctx, outputs)
+
+	// only the first proposal is estimated, the others are based on L1 state that doesn't include their predecessors
+	require.Equal(t, []uint64{0, 250_000, 250_000}, gasLimits)
+	require.Len(t, logs.FindLogs(testlog.NewMessageFilter("Proposer tx successfully published")), 3)
+	ep.rollupClient.AssertExpectations(t)
+	l2ooContract.AssertExpectations(t)
+}
+
+func TestL2OutputSubmitter_ProposeOutputAtBlock(t *testing.T) {
+	t.Run("NotRunning", func(t *testing.T) {
+		ps, _, _, _, _ := setupL2OO(t, ProposerConfig{NetworkTimeout: time.Second})
+		_, err := ps.ProposeOutputAtBlock(context.Background(), 42)
+		require.ErrorIs(t, err, ErrProposerNotRunning)
+	})
+
+	t.Run("L2OONotNextCheckpoint", func(t *testing.T) {
+		ps, _, l2ooContract, txMgr, _ := setupL2OO(t, ProposerConfig{NetworkTimeout: time.Second})
+		ps.running = true
+		txMgr.On("From").Return(common.Address{0xab})
+		l2ooContract.On("NextBlockNumber", mock.AnythingOfType("*bind.CallOpts")).Return(big.NewInt(40), nil).Once()
+		_, err := ps.ProposeOutputAtBlock(context.Background(), 42)
+		require.ErrorContains(t, err, "only accepts the next checkpoint block 40")
+		l2ooContract.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,145 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+var ErrOutputNotVerified = errors.New("output not verified")
+
+// OutputSource is a rollup node that can provide outputs to verify a proposal against.
+type OutputSource interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// L2StateClient is an L2 execution client, used to recompute output roots locally.
+type L2StateClient interface {
+	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+// MultiSourceOutputVerifier verifies outputs against a quorum of rollup nodes,
+// and optionally against an output root recomputed from L2 execution client state.
+type MultiSourceOutputVerifier struct {
+	log            log.Logger
+	metr           metrics.Metricer
+	sources        []OutputSource
+	quorum         int
+	l2             L2StateClient
+	networkTimeout time.Duration
+}
+
+// NewMultiSourceOutputVerifier creates a verifier that requires quorum output roots, out of the
+// output being verified and the outputs of the given sources, to match before an output is proposed.
+// A quorum of 0 requires a majority of them to agree. The L2 state client is optional, if set the output root
+// is also recomputed from the L2 execution client state and must match.
+// Each request to a source times out after networkTimeout, and the source then counts as unavailable.
+func NewMultiSourceOutputVerifier(log log.Logger, metr metrics.Metricer, sources []OutputSource, quorum int, l2 L2StateClient, networkTimeout time.Duration) *MultiSourceOutputVerifier {
+	if quorum == 0 {
+		quorum = (len(sources)+1)/2 + 1
+	}
+	return &MultiSourceOutputVerifier{
+		log:            log,
+		metr:           metr,
+		sources:        sources,
+		quorum:         quorum,
+		l2:             l2,
+		networkTimeout: networkTimeout,
+	}
+}
+
+// VerifyOutput returns an error wrapping ErrOutputNotVerified if the output must not be proposed.
+func (v *MultiSourceOutputVerifier) VerifyOutput(ctx context.Context, output *eth.OutputResponse) error {
+	blockNum := output.BlockRef.Number
+	roots := make([]eth.Bytes32, len(v.sources))
+	errs := make([]error, len(v.sources))
+	var wg sync.WaitGroup
+	for i, source := range v.sources {
+		wg.Add(1)
+		go func(i int, source OutputSource) {
+			defer wg.Done()
+			cCtx, cancel := context.WithTimeout(ctx, v.networkTimeout)
+			defer cancel()
+			sourceOutput, err := source.OutputAtBlock(cCtx, blockNum)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if sourceOutput.BlockRef.Number != blockNum {
+				errs[i] = fmt.Errorf("output block number %d mismatches requested %d", sourceOutput.BlockRef.Number, blockNum)
+				return
+			}
+			roots[i] = sourceOutput.OutputRoot
+		}(i, source)
+	}
+	wg.Wait()
+
+	// The output being verified counts towards the quorum.
+	matches := 1
+	for i, root := range roots {
+		source := fmt.Sprintf("rollup_%d", i)
+		if errs[i] != nil {
+			v.log.Warn("Failed to fetch output from verification rollup node", "source", source, "block", blockNum, "err", errs[i])
+			continue
+		}
+		if root != output.OutputRoot {
+			v.log.Error("Verification rollup node disagrees on output root",
+				"source", source, "block", blockNum, "expected", output.OutputRoot, "actual", root)
+			v.metr.RecordOutputDisagreement(source)
+			continue
+		}
+		matches++
+	}
+	if matches < v.quorum {
+		return fmt.Errorf("%w: %d of %d output roots at block %d match, quorum is %d",
+			ErrOutputNotVerified, matches, len(v.sources)+1, blockNum, v.quorum)
+	}
+
+	if v.l2 != nil {
+		root, err := v.computeOutputRoot(ctx, output.BlockRef.Hash)
+		if err != nil {
+			return fmt.Errorf("%w: failed to recompute output root at block %d: %w", ErrOutputNotVerified, blockNum, err)
+		}
+		if root != output.OutputRoot {
+			v.log.Error("Recomputed output root disagrees with rollup node",
+				"block", blockNum, "expected", output.OutputRoot, "actual", root)
+			v.metr.RecordOutputDisagreement(metrics.OutputSourceL2)
+			return fmt.Errorf("%w: recomputed output root %s mismatches %s at block %d",
+				ErrOutputNotVerified, root, output.OutputRoot, blockNum)
+		}
+	}
+	return nil
+}
+
+// computeOutputRoot computes the output root of the given L2 block from the execution client state.
+func (v *MultiSourceOutputVerifier) computeOutputRoot(ctx context.Context, blockHash common.Hash) (eth.Bytes32, error) {
+	ctx, cancel := context.WithTimeout(ctx, v.networkTimeout)
+	defer cancel()
+	head, err := v.l2.InfoByHash(ctx, blockHash)
+	if err != nil {
+		return eth.Bytes32{}, fmt.Errorf("failed to get L2 block by hash: %w", err)
+	}
+	proof, err := v.l2.GetProof(ctx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, blockHash.String())
+	if err != nil {
+		return eth.Bytes32{}, fmt.Errorf("failed to get contract proof at block %s: %w", blockHash, err)
+	}
+	// make sure that the proof (including storage hash) that we retrieved is correct by verifying it against the state-root
+	if err := proof.Verify(head.Root()); err != nil {
+		return eth.Bytes32{}, fmt.Errorf("invalid withdrawal root hash, state root was %s: %w", head.Root(), err)
+	}
+	return eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(head.Root()),
+		MessagePasserStorageRoot: eth.Bytes32(proof.StorageHash),
+		BlockHash:                blockHash,
+	}), nil
+}
@@ -0,0 +1,141 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubOutputSource struct {
+	root eth.Bytes32
+	err  error
+	hang bool
+}
+
+func (s *stubOutputSource) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if s.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &eth.OutputResponse{
+		OutputRoot: s.root,
+		BlockRef:   eth.L2BlockRef{Number: blockNum},
+	}, nil
+}
+
+type disagreementMetrics struct {
+	metrics.Metricer
+	sources []string
+}
+
+func (m *disagreementMetrics) RecordOutputDisagreement(source string) {
+	m.sources = append(m.sources, source)
+}
+
+func TestMultiSourceOutputVerifier(t *testing.T) {
+	good := eth.Bytes32{0x01}
+	bad := eth.Bytes32{0x02}
+	output := &eth.OutputResponse{
+		OutputRoot: good,
+		BlockRef:   eth.L2BlockRef{Number: 42, Hash: common.Hash{0xaa}},
+	}
+
+	tests := []struct {
+		name          string
+		sources       []OutputSource
+		quorum        int
+		expectErr     bool
+		disagreements []string
+	}{
+		{
+			name:    "AllAgree",
+			sources: []OutputSource{&stubOutputSource{root: good}, &stubOutputSource{root: good}},
+		},
+		{
+			name:          "SingleDisagreementWithinDefaultQuorum",
+			sources:       []OutputSource{&stubOutputSource{root: good}, &stubOutputSource{root: bad}},
+			disagreements: []string{"rollup_1"},
+		},
+		{
+			name:          "MajorityDisagreementFailsDefaultQuorum",
+			sources:       []OutputSource{&stubOutputSource{root: bad}, &stubOutputSource{root: good}, &stubOutputSource{root: bad}},
+			expectErr:     true,
+			disagreements: []string{"rollup_0", "rollup_2"},
+		},
+		{
+			name:          "SingleDisagreementFailsQuorumOfAll",
+			sources:       []OutputSource{&stubOutputSource{root: good}, &stubOutputSource{root: bad}},
+			quorum:        3,
+			expectErr:     true,
+			disagreements: []string{"rollup_1"},
+		},
+		{
+			name:    "HungSourceTimesOut",
+			sources: []OutputSource{&stubOutputSource{hang: true}, &stubOutputSource{root: good}},
+		},
+		{
+			name:      "HungSourceFailsQuorumOfAll",
+			sources:   []OutputSource{&stubOutputSource{hang: true}, &stubOutputSource{root: good}},
+			quorum:    3,
+			expectErr: true,
+		},
+		{
+			name:          "SingleDisagreementWithinQuorum",
+			sources:       []OutputSource{&stubOutputSource{root: bad}, &stubOutputSource{root: good}},
+			quorum:        2,
+			disagreements: []string{"rollup_0"},
+		},
+		{
+			name:      "UnavailableSourceFailsQuorum",
+			sources:   []OutputSource{&stubOutputSource{err: errors.New("boom")}, &stubOutputSource{root: good}},
+			quorum:    3,
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := &disagreementMetrics{Metricer: metrics.NoopMetrics}
+			v := NewMultiSourceOutputVerifier(testlog.Logger(t, log.LevelCrit), m, test.sources, test.quorum, nil, 100*time.Millisecond)
+			err := v.VerifyOutput(context.Background(), output)
+			if test.expectErr {
+				require.ErrorIs(t, err, ErrOutputNotVerified)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, test.disagreements, m.sources)
+		})
+	}
+}
+
+type stubL2StateClient struct{}
+
+func (s *stubL2StateClient) InfoByHash(_ context.Context, _ common.Hash) (eth.BlockInfo, error) {
+	return nil, errors.New("block not found")
+}
+
+func (s *stubL2StateClient) GetProof(_ context.Context, _ common.Address, _ []common.Hash, _ string) (*eth.AccountResult, error) {
+	panic("not implemented")
+}
+
+func TestMultiSourceOutputVerifier_L2Unavailable(t *testing.T) {
+	output := &eth.OutputResponse{
+		OutputRoot: eth.Bytes32{0x01},
+		BlockRef:   eth.L2BlockRef{Number: 42, Hash: common.Hash{0xaa}},
+	}
+	v := NewMultiSourceOutputVerifier(testlog.Logger(t, log.LevelCrit), metrics.NoopMetrics, nil, 0, &stubL2StateClient{}, time.Second)
+	err := v.VerifyOutput(context.Background(), output)
+	require.ErrorIs(t, err, ErrOutputNotVerified)
+	require.ErrorContains(t, err, "block not found")
+}
@@ -3,9 +3,11 @@ package rpc
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +15,7 @@ import (
 type ProposerDriver interface {
 	StartL2OutputSubmitting() error
 	StopL2OutputSubmitting() error
+	ProposeOutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
 }
 
 type adminAPI struct {
@@ -41,3 +44,8 @@ func (a *adminAPI) StartProposer(_ context.Context) error {
 func (a *adminAPI) StopProposer(ctx context.Context) error {
 	return a.b.StopL2OutputSubmitting()
 }
+
+// ProposeOutputAtBlock proposes the output at the given L2 block on demand, e.g. to anchor a withdrawal.
+func (a *adminAPI) ProposeOutputAtBlock(ctx context.Context, blockNum hexutil.Uint64) (*eth.OutputResponse, error) {
+	return a.b.ProposeOutputAtBlock(ctx, uint64(blockNum))
+}
@@ -13,11 +13,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
@@ -46,6 +48,15 @@ type ProposerConfig struct {
 	// This option is not necessary when higher proposal latency is acceptable and L1 is healthy.
 	AllowNonFinalized bool
 
+	// MaxPendingProposals is the maximum number of L2OO proposals that are pipelined at once
+	// when the proposer is behind by more than one submission interval. 0 or 1 disables catching up in parallel.
+	MaxPendingProposals uint64
+
+	// CatchUpGasLimit is the gas limit used for all but the first of a batch of pipelined L2OO proposals.
+	// Gas estimation is done against the latest L1 state, in which the preceding proposals of the batch
+	// are not yet included, so estimating later proposals would revert.
+	CatchUpGasLimit uint64
+
 	WaitNodeSync bool
 }
 
@@ -59,6 +70,11 @@ type ProposerService struct {
 	L1Client       *ethclient.Client
 	RollupProvider dial.RollupProvider
 
+	// VerifyRollupClients and VerifyL2Client are optional sources to verify outputs against before proposing.
+	VerifyRollupClients []*sources.RollupClient
+	VerifyL2Client      *sources.EthClient
+	OutputVerifier      OutputVerifier
+
 	driver *L2OutputSubmitter
 
 	Version string
@@ -93,6 +109,8 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	ps.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	ps.AllowNonFinalized = cfg.AllowNonFinalized
 	ps.WaitNodeSync = cfg.WaitNodeSync
+	ps.MaxPendingProposals = cfg.MaxPendingProposals
+	ps.CatchUpGasLimit = cfg.CatchUpGasLimit
 
 	ps.initL2ooAddress(cfg)
 	ps.initDGF(cfg)
@@ -140,6 +158,49 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 		return fmt.Errorf("failed to build L2 endpoint provider: %w", err)
 	}
 	ps.RollupProvider = rollupProvider
+
+	for _, url := range cfg.VerifyRollupRpcs {
+		rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, ps.Log, url)
+		if err != nil {
+			return fmt.Errorf("failed to dial verification rollup RPC: %w", err)
+		}
+		ps.VerifyRollupClients = append(ps.VerifyRollupClients, rollupClient)
+	}
+	if cfg.VerifyL2EthRpc != "" {
+		rpcClient, err := client.NewRPC(ctx, ps.Log, cfg.VerifyL2EthRpc, client.WithDialAttempts(10))
+		if err != nil {
+			return fmt.Errorf("failed to dial verification L2 RPC: %w", err)
+		}
+		l2Client, err := sources.NewEthClient(rpcClient, ps.Log, nil, &sources.EthClientConfig{
+			MaxRequestsPerBatch:   10,
+			MaxConcurrentRequests: 10,
+			ReceiptsCacheSize:     10,
+			TransactionsCacheSize: 10,
+			HeadersCacheSize:      10,
+			PayloadsCacheSize:     10,
+			TrustRPC:              false,
+			MustBePostMerge:       true,
+			RPCProviderKind:       sources.RPCKindStandard,
+			MethodResetDuration:   time.Minute,
+		})
+		if err != nil {
+			rpcClient.Close()
+			return fmt.Errorf("failed to create verification L2 client: %w", err)
+		}
+		ps.VerifyL2Client = l2Client
+	}
+	if len(ps.VerifyRollupClients) > 0 || ps.VerifyL2Client != nil {
+		outputSources := make([]OutputSource, 0, len(ps.VerifyRollupClients))
+		for _, rollupClient := range ps.VerifyRollupClients {
+			outputSources = append(outputSources, rollupClient)
+		}
+		var l2Client L2StateClient
+		if ps.VerifyL2Client != nil {
+			l2Client = ps.VerifyL2Client
+		}
+		ps.OutputVerifier = NewMultiSourceOutputVerifier(ps.Log, ps.Metrics, outputSources, int(cfg.VerifyQuorum), l2Client, ps.NetworkTimeout)
+		ps.Log.Info("Output verification enabled", "rollupNodes", len(ps.VerifyRollupClients), "quorum", cfg.VerifyQuorum, "l2", ps.VerifyL2Client != nil)
+	}
 	return nil
 }
 
@@ -233,6 +294,7 @@ func (ps *ProposerService) initDriver() error {
 		L1Client:       ps.L1Client,
 		Multicaller:    batching.NewMultiCaller(ps.L1Client.Client(), batching.DefaultBatchSize),
 		RollupProvider: ps.RollupProvider,
+		OutputVerifier: ps.OutputVerifier,
 	})
 	if err != nil {
 		return err
@@ -330,6 +392,14 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 		ps.RollupProvider.Close()
 	}
 
+	for _, rollupClient := range ps.VerifyRollupClients {
+		rollupClient.Close()
+	}
+
+	if ps.VerifyL2Client != nil {
+		ps.VerifyL2Client.Close()
+	}
+
 	if result == nil {
 		ps.stopped.Store(true)
 		ps.Log.Info("L2Output Submitter stopped")
@@ -37,6 +37,7 @@ type Server struct {
 	log            log.Logger
 	tls            *ServerTLSConfig
 	middlewares    []Middleware
+	handlers       map[string]http.Handler
 }
 
 type ServerTLSConfig struct {
@@ -110,6 +111,17 @@ func WithTLSConfig(tls *ServerTLSConfig) ServerOption {
 	}
 }
 
+// WithHandler serves an additional http.Handler alongside the RPC endpoint.
+// The path is registered with the server's http.ServeMux, so a path ending in a slash also matches everything below it.
+func WithHandler(path string, hdlr http.Handler) ServerOption {
+	return func(b *Server) {
+		if b.handlers == nil {
+			b.handlers = make(map[string]http.Handler)
+		}
+		b.handlers[path] = hdlr
+	}
+}
+
 // WithMiddleware adds an http.Handler to the rpc server handler stack
 // The added middleware is invoked directly before the RPC callback
 func WithMiddleware(middleware func(http.Handler) (hdlr http.Handler)) ServerOption {
@@ -173,6 +185,9 @@ func (b *Server) Start() error {
 	mux := http.NewServeMux()
 	mux.Handle(b.rpcPath, nodeHdlr)
 	mux.Handle(b.healthzPath, b.healthzHandler)
+	for path, hdlr := range b.handlers {
+		mux.Handle(path, hdlr)
+	}
 
 	// http middleware
 	var handler http.Handler = mux
@@ -80,6 +80,35 @@ func (q *Queue[T]) TrySend(id T, candidate TxCandidate, receiptCh chan TxReceipt
 	})
 }
 
+// SendOrdered will wait until the number of pending txs is below the max pending,
+// and then send the next tx, like Send.
+//
+// Unlike Send, the tx is crafted (nonce assignment and gas estimation) before this
+// method returns, using the TxManager's SendAsync. Txs queued by consecutive calls
+// from a single goroutine are therefore assigned increasing nonces in call order,
+// which callers can rely on when the txs must be included in sequence.
+//
+// Waiting for the receipt is non-blocking, with the receipt returned on the
+// provided receipt channel. If the channel is unbuffered, the goroutine is
+// blocked from completing until the channel is read from.
+func (q *Queue[T]) SendOrdered(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) {
+	group, ctx := q.groupContext()
+	crafted := make(chan struct{})
+	group.Go(func() error {
+		responseCh := make(chan SendResponse, 1)
+		q.txMgr.SendAsync(ctx, candidate, responseCh)
+		close(crafted)
+		response := <-responseCh
+		receiptCh <- TxReceipt[T]{
+			ID:      id,
+			Receipt: response.Receipt,
+			Err:     response.Err,
+		}
+		return response.Err
+	})
+	<-crafted
+}
+
 func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) error {
 	receipt, err := q.txMgr.Send(ctx, candidate)
 	receiptCh <- TxReceipt[T]{
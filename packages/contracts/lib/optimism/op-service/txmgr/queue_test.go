@@ -235,3 +235,50 @@ func TestQueue_Send(t *testing.T) {
 		})
 	}
 }
+
+func TestQueue_SendOrdered(t *testing.T) {
+	conf := configWithNumConfs(1)
+	conf.ReceiptQueryInterval = 1 * time.Second // simulate a network send
+	backend := newMockBackendWithNonce(newGasPricer(3))
+	mgr := &SimpleTxManager{
+		chainID: conf.ChainID,
+		name:    "TEST",
+		cfg:     conf,
+		backend: backend,
+		l:       testlog.Logger(t, log.LevelCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+
+	// track the nonce assigned to each call index
+	var (
+		nonces  = make(map[int]uint64)
+		nonceMu sync.Mutex
+	)
+	backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		nonceMu.Lock()
+		nonces[int(tx.Data()[0])] = tx.Nonce()
+		nonceMu.Unlock()
+		txHash := tx.Hash()
+		backend.mine(&txHash, tx.GasFeeCap(), nil)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	queue := NewQueue[int](ctx, mgr, 2)
+
+	const numTxs = 5
+	receiptCh := make(chan TxReceipt[int], numTxs)
+	for i := 0; i < numTxs; i++ {
+		queue.SendOrdered(i, TxCandidate{
+			TxData: []byte{byte(i)},
+			To:     &common.Address{},
+		}, receiptCh)
+	}
+	require.NoError(t, queue.Wait())
+	for i := 0; i < numTxs; i++ {
+		r := <-receiptCh
+		require.NoError(t, r.Err)
+		require.Equal(t, uint64(r.ID), nonces[r.ID], "nonce must follow call order")
+	}
+}